@@ -15,6 +15,33 @@ const (
 	AchievementStatusDeleted   = "deleted"
 )
 
+// Aksi achievement yang dikembalikan GET /v1/achievements/{id}/actions; namanya mengikuti endpoint mutasi.
+const (
+	AchievementActionSubmit          = "submit"
+	AchievementActionSoftDelete      = "soft_delete"
+	AchievementActionVerify          = "verify"
+	AchievementActionReject          = "reject"
+	AchievementActionAdminSoftDelete = "admin_soft_delete"
+	AchievementActionHardDelete      = "hard_delete"
+	AchievementActionReassign        = "reassign"
+	AchievementActionReopen          = "reopen"
+)
+
+// AchievementTypeDefinition aturan per achievement_type.
+type AchievementTypeDefinition struct {
+	// RequiresAttachment: submit ditolak jika belum ada attachment (bukti), saat strict mode aktif.
+	RequiresAttachment bool `json:"requires_attachment"`
+}
+
+// AllowedAchievementTypes daftar achievement_type yang diterima sistem beserta aturannya.
+var AllowedAchievementTypes = map[string]AchievementTypeDefinition{
+	"competition":   {RequiresAttachment: true},
+	"publication":   {},
+	"organization":  {},
+	"certification": {RequiresAttachment: true},
+	"academic":      {},
+}
+
 type AchievementReference struct {
 	ID                 uuid.UUID  `db:"id" json:"id"`
 	StudentID          uuid.UUID  `db:"student_id" json:"student_id"`
@@ -26,13 +53,17 @@ type AchievementReference struct {
 	RejectionNote      *string    `db:"rejection_note" json:"rejection_note"`
 	CreatedAt          time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt          time.Time  `db:"updated_at" json:"updated_at"`
+
+	// DaysPending & Overdue dihitung di service, hanya terisi untuk status submitted.
+	DaysPending *int  `db:"-" json:"days_pending,omitempty"`
+	Overdue     *bool `db:"-" json:"overdue,omitempty"`
 }
 
 // MongoDB Achievement Document
 type Achievement struct {
 	ID              bson.ObjectID          `bson:"_id,omitempty" json:"id,omitempty"`
 	StudentID       string                 `bson:"studentId" json:"student_id"`
-	AchievementType string                 `bson:"achievementType" json:"achievement_type"` // academic, competition, organization, publication, certification, other
+	AchievementType string                 `bson:"achievementType" json:"achievement_type"` // lihat AllowedAchievementTypes
 	Title           string                 `bson:"title" json:"title"`
 	Description     string                 `bson:"description" json:"description"`
 	Details         map[string]interface{} `bson:"details" json:"details"`
@@ -50,8 +81,14 @@ type Attachment struct {
 	UploadedAt time.Time `bson:"uploadedAt" json:"uploaded_at"`
 }
 
+// RejectedAttachment file upload yang dilewati pada create dengan strict=false beserta alasannya.
+type RejectedAttachment struct {
+	FileName string `json:"file_name"`
+	Reason   string `json:"reason"`
+}
+
 type CreateAchievementRequest struct {
-	AchievementType string                 `json:"achievement_type" validate:"required,oneof=academic competition organization publication certification other"`
+	AchievementType string                 `json:"achievement_type" validate:"required,oneof=academic competition organization publication certification"`
 	Title           string                 `json:"title" validate:"required"`
 	Description     string                 `json:"description" validate:"required"`
 	Details         map[string]interface{} `json:"details" swaggertype:"object" validate:"required"`
@@ -60,6 +97,21 @@ type CreateAchievementRequest struct {
 	Points          *float64               `json:"points"`
 }
 
+// AchievementReferenceFilter filter opsional daftar reference. Rentang verified_at hanya diterapkan
+// jika VerifiedFrom dan VerifiedTo sama-sama diisi; reference yang belum diverifikasi tidak ikut.
+type AchievementReferenceFilter struct {
+	VerifiedFrom *time.Time
+	VerifiedTo   *time.Time
+}
+
+type ReassignAchievementRequest struct {
+	StudentID string `json:"student_id" validate:"required" example:"uuid-student"`
+}
+
+type ReferenceStatusesRequest struct {
+	MongoIDs []string `json:"mongo_ids" validate:"required"`
+}
+
 type SubmitAchievementRequest struct {
 	// Empty, hanya trigger submit
 }
@@ -72,6 +124,23 @@ type VerifyAchievementRequest struct {
 type UpdateAchievementStatusRequest struct {
 	Status        string  `json:"status" validate:"required,oneof=verified rejected" example:"verified/rejected"`
 	RejectionNote *string `json:"rejection_note" example:"string"`
+	// Points opsional: saat status verified, points achievement diganti nilai ini (tidak boleh negatif).
+	// Diabaikan jika status rejected.
+	Points *float64 `json:"points,omitempty" example:"50"`
+}
+
+// BulkReviewAchievementRequest review beberapa reference sekaligus dengan status yang sama.
+type BulkReviewAchievementRequest struct {
+	IDs           []string `json:"ids" validate:"required"`
+	Status        string   `json:"status" validate:"required,oneof=verified rejected" example:"verified"`
+	RejectionNote *string  `json:"rejection_note" example:"string"`
+}
+
+// BulkReviewResult hasil review satu id; Error berisi alasan jika gagal.
+type BulkReviewResult struct {
+	ID      string `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
 }
 
 // Detail structs untuk dokumentasi Swagger (oneOf)
@@ -122,9 +191,24 @@ type AchievementDetailsDoc struct {
 	Academic      AcademicDetails      `json:"academic,omitempty"`
 }
 
+// AchievementPublicVerification payload verifikasi publik (link/QR di sertifikat). Sengaja tanpa
+// identitas mahasiswa, deskripsi, detail, maupun attachment.
+type AchievementPublicVerification struct {
+	ID              uuid.UUID  `json:"id"`
+	AchievementType string     `json:"achievement_type"`
+	Title           string     `json:"title"`
+	Status          string     `json:"status"`
+	VerifiedAt      *time.Time `json:"verified_at"`
+	ProgramStudy    string     `json:"program_study"`
+}
+
 type AchievementWithReference struct {
 	Achievement Achievement          `json:"achievement"`
 	Reference   AchievementReference `json:"reference"`
+	// Student hanya diisi jika diminta dengan ?expand=student
+	Student *StudentSummary `json:"student,omitempty"`
+	// MongoMissing true jika dokumen Mongo reference ini tidak ada (id rusak atau dokumen terhapus)
+	MongoMissing bool `json:"mongo_missing,omitempty"`
 }
 
 type AchievementStatistics struct {
@@ -134,6 +218,59 @@ type AchievementStatistics struct {
 	CompetitionLevel map[string]int `json:"competition_level"`
 }
 
+// TagCount jumlah achievement yang memakai tag tertentu (GET /v1/achievements/tags).
+type TagCount struct {
+	Tag   string `bson:"_id" json:"tag"`
+	Count int64  `bson:"count" json:"count"`
+}
+
+// AchievementTimelinePoint jumlah achievement per periode (YYYY-MM). Verified hanya diisi jika diminta.
+type AchievementTimelinePoint struct {
+	Period   string `json:"period"`
+	Count    int64  `json:"count"`
+	Verified *int64 `json:"verified,omitempty"`
+}
+
+// AchievementFunnel jumlah reference per tahap dalam rentang waktu (dihitung dari timestamp reference).
+type AchievementFunnel struct {
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Created   int64     `json:"created"`
+	Submitted int64     `json:"submitted"`
+	Verified  int64     `json:"verified"`
+	Rejected  int64     `json:"rejected"`
+}
+
+// PublicAchievement achievement terverifikasi tanpa id internal, aman untuk dibagikan.
+type PublicAchievement struct {
+	AchievementType string                 `json:"achievement_type"`
+	Title           string                 `json:"title"`
+	Description     string                 `json:"description"`
+	Details         map[string]interface{} `json:"details,omitempty" swaggertype:"object"`
+	Tags            []string               `json:"tags,omitempty"`
+	Points          *float64               `json:"points,omitempty"`
+	VerifiedAt      *time.Time             `json:"verified_at,omitempty"`
+}
+
+// StudentAchievementSummary ringkasan prestasi mahasiswa untuk halaman profil publik.
+type StudentAchievementSummary struct {
+	FullName      string              `json:"full_name"`
+	StudentNumber string              `json:"student_number"`
+	ProgramStudy  string              `json:"program_study"`
+	AcademicYear  string              `json:"academic_year"`
+	TotalVerified int                 `json:"total_verified"`
+	TotalPoints   float64             `json:"total_points"`
+	Achievements  []PublicAchievement `json:"achievements"`
+}
+
+// AchievementWebhookPayload event yang dikirim ke WEBHOOK_URL setelah review berhasil.
+type AchievementWebhookPayload struct {
+	RefID     string    `json:"ref_id"`
+	StudentID string    `json:"student_id"`
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
 type TopStudent struct {
 	StudentID         uuid.UUID `json:"student_id"`
 	StudentName       string    `json:"student_name"`
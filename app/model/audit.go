@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+const (
+	AuditEntityUser           = "user"
+	AuditEntityRole           = "role"
+	AuditEntityPermission     = "permission"
+	AuditEntityRolePermission = "role_permission"
+)
+
+// AuditLog satu jejak aksi admin. ActorID nil jika aktor tidak diketahui atau sudah dihapus.
+type AuditLog struct {
+	ID        string    `json:"id"`
+	ActorID   *string   `json:"actor_id"`
+	Action    string    `json:"action"`
+	Entity    string    `json:"entity"`
+	EntityID  string    `json:"entity_id"`
+	IP        string    `json:"ip,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -1,10 +1,14 @@
 package model
 
+import "time"
+
 type RegisterRequest struct {
 	Username  string `json:"username" binding:"required"`
 	Email     string `json:"email" binding:"required"`
 	Password  string `json:"password" binding:"required"`
 	FullName  string `json:"full_name" binding:"required"`
+	// RoleID diisi server dari DEFAULT_REGISTER_ROLE; tidak pernah dibaca dari body request
+	RoleID string `json:"-"`
 }
 
 type LoginRequest struct {
@@ -12,10 +16,30 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// RefreshTokenRequest berisi refresh_token hasil Login/Refresh sebelumnya. Token (access JWT)
+// tetap diterima untuk client lama yang belum memakai refresh token.
 type RefreshTokenRequest struct {
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// VerifyTokenRequest body POST /v1/auth/verify.
+type VerifyTokenRequest struct {
 	Token string `json:"token" binding:"required"`
 }
 
 type LogoutRequest struct {
 	Token string `json:"token" binding:"required"`
 }
+
+// RefreshToken satu refresh token dalam sebuah family rotasi; hanya hash token yang disimpan.
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	FamilyID  string
+	TokenHash string
+	Used      bool
+	Revoked   bool
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
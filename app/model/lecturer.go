@@ -23,3 +23,14 @@ type UpdateLecturerRequest struct {
 	LecturerID *string `json:"lecturer_id"`
 	Department *string `json:"department"`
 }
+
+// AdviseeSummary mahasiswa bimbingan beserta jumlah achievement per status untuk dashboard dosen wali.
+type AdviseeSummary struct {
+	ID           uuid.UUID `json:"id"`
+	StudentID    string    `json:"student_id"`
+	FullName     string    `json:"full_name"`
+	ProgramStudy string    `json:"program_study"`
+	Submitted    int64     `json:"submitted"`
+	Verified     int64     `json:"verified"`
+	Rejected     int64     `json:"rejected"`
+}
@@ -0,0 +1,6 @@
+package model
+
+// MaintenanceRequest body PUT /v1/admin/maintenance.
+type MaintenanceRequest struct {
+	Enabled *bool `json:"enabled" binding:"required" example:"true"`
+}
@@ -9,6 +9,12 @@ type Role struct {
 	CreatedAt   time.Time `db:"created_at" json:"created_at"`
 }
 
+// RoleUserCount jumlah user yang memegang role (GET /v1/roles/{id}/user-count).
+type RoleUserCount struct {
+	RoleID string `json:"role_id"`
+	Count  int64  `json:"count"`
+}
+
 type Permission struct {
 	ID          string `db:"id" json:"id"`
 	Name        string `db:"name" json:"name"`
@@ -27,8 +33,9 @@ type UpdateRoleRequest struct {
 	Description string `json:"description"`
 }
 
+// CreatePermissionRequest; name boleh kosong dan akan diturunkan sebagai "resource:action".
 type CreatePermissionRequest struct {
-	Name        string `json:"name" binding:"required"`
+	Name        string `json:"name"`
 	Resource    string `json:"resource" binding:"required"`
 	Action      string `json:"action" binding:"required"`
 	Description string `json:"description"`
@@ -17,10 +17,26 @@ type MetaInfo struct {
 }
 
 type LoginResponse struct {
-	Success bool          `json:"success"`
-	Message string        `json:"message"`
-	Token   string        `json:"token,omitempty"`
-	User    *UserResponse `json:"user,omitempty"`
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Token   string `json:"token,omitempty"`
+	// ExpiresAt waktu expired token (RFC3339), ExpiresIn sisa detik saat token diterbitkan
+	ExpiresAt string `json:"expires_at,omitempty" example:"2025-01-02T15:04:05Z"`
+	ExpiresIn int64  `json:"expires_in,omitempty" example:"86400"`
+	// RefreshToken sekali pakai; kirim ke /v1/auth/refresh untuk mendapat pasangan token baru
+	RefreshToken string        `json:"refresh_token,omitempty"`
+	User         *UserResponse `json:"user,omitempty"`
+}
+
+// VerifyTokenResponse hasil validasi token tanpa menerbitkan token baru.
+type VerifyTokenResponse struct {
+	Success     bool     `json:"success" example:"true"`
+	Message     string   `json:"message" example:"Token valid"`
+	Valid       bool     `json:"valid" example:"true"`
+	UserID      string   `json:"user_id"`
+	RoleID      string   `json:"role_id"`
+	Permissions []string `json:"permissions"`
+	ExpiresAt   string   `json:"expires_at" example:"2025-01-02T15:04:05Z"`
 }
 
 type SuccessResponse struct {
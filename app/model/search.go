@@ -0,0 +1,36 @@
+package model
+
+type StudentSearchItem struct {
+	StudentResponse
+	FullName string `json:"full_name"`
+}
+
+type LecturerSearchItem struct {
+	LecturerResponse
+	FullName string `json:"full_name"`
+}
+
+type UserSearchSection struct {
+	Items []UserResponse `json:"items"`
+	Total int64          `json:"total"`
+}
+
+type StudentSearchSection struct {
+	Items []StudentSearchItem `json:"items"`
+	Total int64               `json:"total"`
+}
+
+type LecturerSearchSection struct {
+	Items []LecturerSearchItem `json:"items"`
+	Total int64                `json:"total"`
+}
+
+// SearchResult hasil pencarian global, dipisah per tipe entitas.
+type SearchResult struct {
+	Query     string                `json:"query"`
+	Page      int64                 `json:"page"`
+	Limit     int64                 `json:"limit"`
+	Users     UserSearchSection     `json:"users"`
+	Students  StudentSearchSection  `json:"students"`
+	Lecturers LecturerSearchSection `json:"lecturers"`
+}
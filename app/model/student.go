@@ -16,6 +16,15 @@ type Student struct {
 	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
 }
 
+// StudentSummary identitas ringkas student untuk ?expand=student di daftar achievement.
+type StudentSummary struct {
+	ID           uuid.UUID `json:"id"`
+	StudentID    string    `json:"student_id"`
+	FullName     string    `json:"full_name"`
+	ProgramStudy string    `json:"program_study"`
+	AdvisorName  string    `json:"advisor_name,omitempty"`
+}
+
 type CreateStudentRequest struct {
 	UserID       uuid.UUID  `json:"user_id"`
 	StudentID    string     `json:"student_id"`
@@ -24,9 +33,78 @@ type CreateStudentRequest struct {
 	AdvisorID    *uuid.UUID `json:"advisor_id,omitempty"`
 }
 
+// OnboardStudentRequest data user + student yang dibuat sekaligus dalam satu transaksi.
+type OnboardStudentRequest struct {
+	Username     string     `json:"username" example:"budi_s"`
+	Email        string     `json:"email" example:"budi@mail.com"`
+	Password     string     `json:"password" example:"Secret1"`
+	FullName     string     `json:"full_name" example:"Budi Santoso"`
+	StudentID    string     `json:"student_id" example:"2201001"`
+	ProgramStudy string     `json:"program_study" example:"Informatika"`
+	AcademicYear string     `json:"academic_year" example:"2022"`
+	AdvisorID    *uuid.UUID `json:"advisor_id,omitempty"`
+}
+
+// OnboardStudentResult id user dan student hasil onboarding.
+type OnboardStudentResult struct {
+	UserID    string `json:"user_id"`
+	StudentID string `json:"student_id"`
+}
+
+const (
+	StudentImportCreated     = "created"
+	StudentImportWouldCreate = "would_create"
+	StudentImportFailed      = "error"
+)
+
+// StudentImportRowResult hasil satu baris CSV import; Row adalah nomor baris file (header = 1).
+type StudentImportRowResult struct {
+	Row       int    `json:"row"`
+	StudentID string `json:"student_id"`
+	Status    string `json:"status"`
+	ID        string `json:"id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// StudentImportSummary ringkasan import; pada dry run Created selalu 0 dan WouldCreate berisi jumlah baris valid.
+type StudentImportSummary struct {
+	DryRun      bool                     `json:"dry_run"`
+	Total       int                      `json:"total"`
+	Created     int                      `json:"created"`
+	WouldCreate int                      `json:"would_create"`
+	Failed      int                      `json:"failed"`
+	Rows        []StudentImportRowResult `json:"rows"`
+}
+
 type UpdateStudentRequest struct {
 	StudentID    *string    `json:"student_id"`
 	ProgramStudy *string    `json:"program_study"`
 	AcademicYear *string    `json:"academic_year"`
 	AdvisorID    *uuid.UUID `json:"advisor_id"`
 }
+
+// SetStudentAdvisorRequest body PUT /v1/students/{id}/advisor; advisor_id null mengosongkan advisor.
+type SetStudentAdvisorRequest struct {
+	AdvisorID *uuid.UUID `json:"advisor_id"`
+}
+
+type AddStudentAdvisorRequest struct {
+	LecturerID uuid.UUID `json:"lecturer_id" validate:"required"`
+}
+
+const (
+	AdvisorChangePrimary          = "primary"
+	AdvisorChangeCoAdvisorAdded   = "co_advisor_added"
+	AdvisorChangeCoAdvisorRemoved = "co_advisor_removed"
+)
+
+// AdvisorHistory satu baris riwayat perubahan dosen wali student.
+type AdvisorHistory struct {
+	ID                uuid.UUID  `db:"id" json:"id"`
+	StudentID         uuid.UUID  `db:"student_id" json:"student_id"`
+	PreviousAdvisorID *uuid.UUID `db:"previous_advisor_id" json:"previous_advisor_id"`
+	NewAdvisorID      *uuid.UUID `db:"new_advisor_id" json:"new_advisor_id"`
+	ChangeType        string     `db:"change_type" json:"change_type"`
+	ChangedBy         *uuid.UUID `db:"changed_by" json:"changed_by"`
+	ChangedAt         time.Time  `db:"changed_at" json:"changed_at"`
+}
@@ -28,11 +28,15 @@ type UserResponse struct {
 }
 
 type CreateUserRequest struct {
-	Username  string `json:"username" binding:"required"`
-	Email     string `json:"email" binding:"required"`
-	Password  string `json:"password" binding:"required"`
-	FullName  string `json:"full_name" binding:"required"`
-	IsActive  bool   `json:"is_active"`
+	Username string `json:"username" binding:"required"`
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	FullName string `json:"full_name" binding:"required"`
+	IsActive bool   `json:"is_active"`
+	// RoleName opsional; jika diisi, role dicari berdasarkan nama dan role_id diset saat user dibuat.
+	RoleName string `json:"role_name,omitempty" example:"mahasiswa"`
+	// RoleID hasil resolve RoleName oleh service, tidak dibaca dari body.
+	RoleID string `json:"-"`
 }
 
 type UpdateUserRequest struct {
@@ -42,8 +46,47 @@ type UpdateUserRequest struct {
 	FullName string `json:"full_name"`
 	RoleID   string `json:"role_id"`
 	IsActive *bool  `json:"is_active"`
+	// ClearRole true mengosongkan role (role_id = NULL); RoleID kosong berarti role tidak diubah.
+	// Tidak boleh dikombinasikan dengan RoleID.
+	ClearRole bool `json:"clear_role,omitempty"`
+	// UpdatedAt opsional: jika diisi, update hanya berhasil bila updated_at di DB masih sama (optimistic lock)
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// PatchUserRequest body PATCH /v1/users/{id}: field yang tidak dikirim (nil) tidak diubah, field yang
+// dikirim selalu di-set termasuk string kosong. full_name boleh kosong; role_id "" mengosongkan role.
+type PatchUserRequest struct {
+	Username  *string    `json:"username,omitempty"`
+	Email     *string    `json:"email,omitempty"`
+	Password  *string    `json:"password,omitempty"`
+	FullName  *string    `json:"full_name,omitempty"`
+	RoleID    *string    `json:"role_id,omitempty"`
+	IsActive  *bool      `json:"is_active,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
 type UpdateUserRoleByNameRequest struct {
 	RoleName string `json:"role_name" binding:"required"`
-}
\ No newline at end of file
+}
+
+// SetUserActiveRequest body PUT /v1/users/{id}/active; is_active wajib diisi.
+type SetUserActiveRequest struct {
+	IsActive *bool `json:"is_active" binding:"required"`
+}
+
+// LockedUser user yang sedang terkunci karena terlalu banyak login gagal.
+type LockedUser struct {
+	ID                  string    `json:"id"`
+	Username            string    `json:"username"`
+	Email               string    `json:"email"`
+	FullName            string    `json:"full_name"`
+	FailedLoginAttempts int       `json:"failed_login_attempts"`
+	LockedUntil         time.Time `json:"locked_until"`
+}
+
+// UserFilter filter opsional untuk daftar user; field nil berarti tidak difilter.
+type UserFilter struct {
+	IsActive *bool
+	// ExcludeID jika diisi, user dengan id ini tidak ikut dihitung maupun dikembalikan (exclude_self)
+	ExcludeID string
+}
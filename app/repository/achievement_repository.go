@@ -11,6 +11,7 @@ import (
 	"hello-fiber/app/model"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
@@ -21,18 +22,32 @@ type AchievementMongoRepository interface {
 	GetByIDs(ctx context.Context, ids []string) ([]model.Achievement, error)
 	List(ctx context.Context, page, limit int64) ([]model.Achievement, int64, error)
 	Delete(ctx context.Context, id string) error
+	UpdateStudentID(ctx context.Context, id string, studentID uuid.UUID) error
+	UpdatePoints(ctx context.Context, id string, points *float64) error
+	TagCounts(ctx context.Context, ids []string) ([]model.TagCount, error)
 }
 
 type AchievementReferenceRepository interface {
 	CreateDraft(ctx context.Context, studentID uuid.UUID, mongoID string) (string, error)
 	SubmitDraft(ctx context.Context, refID string, studentID uuid.UUID) error
+	RevertToDraft(ctx context.Context, refID string, studentID uuid.UUID) error
 	Review(ctx context.Context, refID string, status string, adminID uuid.UUID, note *string) error
+	ReviewByAdvisor(ctx context.Context, refID string, status string, reviewerID uuid.UUID, lecturerID uuid.UUID, note *string) error
 	Delete(ctx context.Context, refID string, adminID uuid.UUID) error
 	DeleteByStudent(ctx context.Context, refID string, studentID uuid.UUID) error
 	HardDelete(ctx context.Context, refID string) error
+	UpdateStudentID(ctx context.Context, refID string, studentID uuid.UUID) error
 	GetByID(ctx context.Context, id string) (*model.AchievementReference, error)
 	List(ctx context.Context, page, limit int64) ([]model.AchievementReference, int64, error)
-	ListByStatuses(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64) ([]model.AchievementReference, int64, error)
+	ListByStatuses(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64, filter model.AchievementReferenceFilter) ([]model.AchievementReference, int64, error)
+	CountByStatuses(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, filter model.AchievementReferenceFilter) (int64, error)
+	TimelineByMonth(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, year int, verified bool) ([]model.AchievementTimelinePoint, error)
+	ListSubmittedBefore(ctx context.Context, before time.Time, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64) ([]model.AchievementReference, int64, error)
+	ListDeletedOlderThan(ctx context.Context, olderThan time.Duration) ([]model.AchievementReference, error)
+	ListDraftsOlderThan(ctx context.Context, olderThan time.Duration) ([]model.AchievementReference, error)
+	Funnel(ctx context.Context, from, to time.Time) (*model.AchievementFunnel, error)
+	StatusesByMongoIDs(ctx context.Context, mongoIDs []string, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID) (map[string]string, error)
+	MongoIDsByStatuses(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID) ([]string, error)
 }
 
 type achievementMongoRepository struct {
@@ -108,6 +123,8 @@ func (r *achievementMongoRepository) List(ctx context.Context, page, limit int64
 	return list, total, nil
 }
 
+// GetByIDs mengambil dokumen untuk ids; id yang bukan ObjectID valid dilewati, pemanggil menandai
+// reference-nya sebagai mongo_missing.
 func (r *achievementMongoRepository) GetByIDs(ctx context.Context, ids []string) ([]model.Achievement, error) {
 	if len(ids) == 0 {
 		return []model.Achievement{}, nil
@@ -150,6 +167,87 @@ func (r *achievementMongoRepository) Delete(ctx context.Context, id string) erro
 	return nil
 }
 
+func (r *achievementMongoRepository) UpdateStudentID(ctx context.Context, id string, studentID uuid.UUID) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid mongo achievement id: %w", err)
+	}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{
+		"$set": bson.M{"studentId": studentID.String(), "updatedAt": time.Now()},
+	})
+	if err != nil {
+		return fmt.Errorf("gagal update studentId achievement mongo: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("achievement mongo tidak ditemukan")
+	}
+	return nil
+}
+
+// UpdatePoints mengganti points achievement; points nil menghapus field points dari dokumen.
+func (r *achievementMongoRepository) UpdatePoints(ctx context.Context, id string, points *float64) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid mongo achievement id: %w", err)
+	}
+	update := bson.M{"$set": bson.M{"points": points, "updatedAt": time.Now()}}
+	if points == nil {
+		update = bson.M{"$set": bson.M{"updatedAt": time.Now()}, "$unset": bson.M{"points": ""}}
+	}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		return fmt.Errorf("gagal update points achievement mongo: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("achievement mongo tidak ditemukan")
+	}
+	return nil
+}
+
+// tagCountsPipeline $unwind tags lalu $group per tag, terbanyak dulu (seri diurutkan nama tag),
+// hanya untuk dokumen dengan _id di objectIDs.
+func tagCountsPipeline(objectIDs []bson.ObjectID) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": objectIDs}}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$tags"}, {Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+}
+
+// TagCounts tag yang dipakai achievement dengan id di ids beserta jumlahnya.
+func (r *achievementMongoRepository) TagCounts(ctx context.Context, ids []string) ([]model.TagCount, error) {
+	var objectIDs []bson.ObjectID
+	for _, id := range ids {
+		if oid, err := bson.ObjectIDFromHex(id); err == nil {
+			objectIDs = append(objectIDs, oid)
+		}
+	}
+	if len(objectIDs) == 0 {
+		return []model.TagCount{}, nil
+	}
+
+	cursor, err := r.col.Aggregate(ctx, tagCountsPipeline(objectIDs))
+	if err != nil {
+		return nil, fmt.Errorf("gagal agregasi tags achievement: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	tags := []model.TagCount{}
+	if err := cursor.All(ctx, &tags); err != nil {
+		return nil, fmt.Errorf("gagal decode tags achievement: %w", err)
+	}
+	return tags, nil
+}
+
+// advisedStudentsSubquery daftar student yang dibimbing lecturer (advisor utama maupun co-advisor).
+func advisedStudentsSubquery(lecturerParam string) string {
+	return fmt.Sprintf(
+		"SELECT id FROM students WHERE advisor_id = %[1]s UNION SELECT student_id FROM student_advisors WHERE lecturer_id = %[1]s",
+		lecturerParam,
+	)
+}
+
 type achievementReferenceRepository struct {
 	db *sql.DB
 }
@@ -196,6 +294,35 @@ func (r *achievementReferenceRepository) SubmitDraft(ctx context.Context, refID
 	return nil
 }
 
+// RevertToDraft mengembalikan achievement rejected milik mahasiswa ke draft agar bisa direvisi dan
+// di-submit ulang; jejak review sebelumnya (rejection_note, verified_at/by, submitted_at) dikosongkan.
+func (r *achievementReferenceRepository) RevertToDraft(ctx context.Context, refID string, studentID uuid.UUID) error {
+	query := `
+		UPDATE achievement_references
+		SET status = $1,
+			rejection_note = NULL,
+			verified_at = NULL,
+			verified_by = NULL,
+			submitted_at = NULL,
+			updated_at = NOW()
+		WHERE id = $2
+		  AND student_id = $3
+		  AND status = $4
+	`
+	result, err := r.db.ExecContext(ctx, query, model.AchievementStatusDraft, refID, studentID, model.AchievementStatusRejected)
+	if err != nil {
+		return fmt.Errorf("gagal membuka kembali achievement: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("gagal cek rows affected reopen: %w", err)
+	}
+	if affected == 0 {
+		return errors.New("achievement tidak ditemukan atau bukan milik anda atau status bukan rejected")
+	}
+	return nil
+}
+
 func (r *achievementReferenceRepository) Review(ctx context.Context, refID string, status string, adminID uuid.UUID, note *string) error {
 	status = strings.ToLower(strings.TrimSpace(status))
 	if status != model.AchievementStatusVerified &&
@@ -237,6 +364,47 @@ func (r *achievementReferenceRepository) Review(ctx context.Context, refID strin
 	return nil
 }
 
+// ReviewByAdvisor memproses review dosen wali dalam satu UPDATE: status submitted dan
+// kepemilikan mahasiswa bimbingan dicek atomik sehingga dua review paralel tidak bisa sama-sama sukses.
+func (r *achievementReferenceRepository) ReviewByAdvisor(ctx context.Context, refID string, status string, reviewerID uuid.UUID, lecturerID uuid.UUID, note *string) error {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status != model.AchievementStatusVerified &&
+		status != model.AchievementStatusRejected {
+		return errors.New("status review tidak valid")
+	}
+
+	var rejectionNote interface{}
+	if status == model.AchievementStatusRejected && note != nil {
+		if trimmed := strings.TrimSpace(*note); trimmed != "" {
+			rejectionNote = trimmed
+		}
+	}
+
+	query := `
+		UPDATE achievement_references
+		SET status = $1,
+			verified_at = NOW(),
+			verified_by = $2,
+			rejection_note = $3,
+			updated_at = NOW()
+		WHERE id = $4
+		  AND status = $5
+		  AND student_id IN (` + advisedStudentsSubquery("$6") + `)
+	`
+	result, err := r.db.ExecContext(ctx, query, status, reviewerID, rejectionNote, refID, model.AchievementStatusSubmitted, lecturerID)
+	if err != nil {
+		return fmt.Errorf("gagal review achievement: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("gagal cek rows affected review: %w", err)
+	}
+	if affected == 0 {
+		return errors.New("achievement sudah diproses atau tidak berhak")
+	}
+	return nil
+}
+
 func (r *achievementReferenceRepository) Delete(ctx context.Context, refID string, adminID uuid.UUID) error {
 	query := `
 		UPDATE achievement_references
@@ -303,6 +471,26 @@ func (r *achievementReferenceRepository) HardDelete(ctx context.Context, refID s
 	return nil
 }
 
+func (r *achievementReferenceRepository) UpdateStudentID(ctx context.Context, refID string, studentID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE achievement_references
+		SET student_id = $1,
+			updated_at = NOW()
+		WHERE id = $2
+	`, studentID, refID)
+	if err != nil {
+		return fmt.Errorf("gagal update student_id achievement reference: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("gagal cek rows affected update student_id: %w", err)
+	}
+	if affected == 0 {
+		return errors.New("achievement reference tidak ditemukan")
+	}
+	return nil
+}
+
 func (r *achievementReferenceRepository) GetByID(ctx context.Context, id string) (*model.AchievementReference, error) {
 	query := `
 		SELECT id, student_id, mongo_achievement_id, status, submitted_at, verified_at, verified_by, rejection_note, created_at, updated_at
@@ -383,15 +571,10 @@ func (r *achievementReferenceRepository) List(ctx context.Context, page, limit i
 	return refs, total, nil
 }
 
-func (r *achievementReferenceRepository) ListByStatuses(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64) ([]model.AchievementReference, int64, error) {
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 {
-		limit = 10
-	}
-	offset := (page - 1) * limit
-
+// ListByStatuses diurutkan created_at terbaru lebih dulu dengan id sebagai tie-breaker agar urutan
+// (dan pagination) stabil untuk reference yang dibuat pada waktu yang sama.
+// byStatusesWhere klausa WHERE (beserta args) yang dipakai bersama ListByStatuses dan CountByStatuses.
+func byStatusesWhere(statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, filter model.AchievementReferenceFilter) (string, []interface{}) {
 	args := []interface{}{}
 	placeholders := []string{}
 	for i, s := range statuses {
@@ -401,31 +584,125 @@ func (r *achievementReferenceRepository) ListByStatuses(ctx context.Context, sta
 	statusArray := fmt.Sprintf("ARRAY[%s]", strings.Join(placeholders, ","))
 
 	where := fmt.Sprintf("ar.status = ANY(%s)", statusArray)
-	join := ""
 	if studentID != nil {
 		args = append(args, *studentID)
 		where += fmt.Sprintf(" AND ar.student_id = $%d", len(args))
 	}
 	if advisorID != nil {
-		join = " JOIN students s ON ar.student_id = s.id"
 		args = append(args, *advisorID)
-		where += fmt.Sprintf(" AND s.advisor_id = $%d", len(args))
+		where += fmt.Sprintf(" AND ar.student_id IN (%s)", advisedStudentsSubquery(fmt.Sprintf("$%d", len(args))))
+	}
+	if filter.VerifiedFrom != nil && filter.VerifiedTo != nil {
+		// verified_at NULL (belum diverifikasi) tidak pernah masuk rentang
+		args = append(args, *filter.VerifiedFrom, *filter.VerifiedTo)
+		where += fmt.Sprintf(" AND ar.verified_at IS NOT NULL AND ar.verified_at BETWEEN $%d AND $%d", len(args)-1, len(args))
+	}
+	return where, args
+}
+
+// MongoIDsByStatuses semua mongo_achievement_id dalam scope yang sama seperti ListByStatuses, tanpa
+// pagination; dipakai untuk agregasi di Mongo yang harus dibatasi ke achievement yang boleh dilihat.
+func (r *achievementReferenceRepository) MongoIDsByStatuses(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID) ([]string, error) {
+	where, args := byStatusesWhere(statuses, studentID, advisorID, model.AchievementReferenceFilter{})
+	query := fmt.Sprintf(`SELECT ar.mongo_achievement_id FROM achievement_references ar WHERE %s`, where)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil mongo id achievement: %w", err)
 	}
+	defer rows.Close()
 
-	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM achievement_references ar%s WHERE %s`, join, where)
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("gagal scan mongo id achievement: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterasi mongo id achievement: %w", err)
+	}
+	return ids, nil
+}
+
+// CountByStatuses hanya menjalankan COUNT(*) dengan scope yang sama seperti ListByStatuses, tanpa mengambil baris.
+func (r *achievementReferenceRepository) CountByStatuses(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, filter model.AchievementReferenceFilter) (int64, error) {
+	where, args := byStatusesWhere(statuses, studentID, advisorID, filter)
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM achievement_references ar WHERE %s`, where)
 	var total int64
 	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
-		return nil, 0, fmt.Errorf("gagal menghitung total achievement_references: %w", err)
+		return 0, fmt.Errorf("gagal menghitung total achievement_references: %w", err)
+	}
+	return total, nil
+}
+
+// TimelineByMonth menghitung reference per bulan (date_trunc) dengan scope yang sama seperti CountByStatuses.
+// verified=false mengelompokkan created_at; verified=true hanya menghitung reference verified per verified_at.
+// year > 0 membatasi ke tahun tersebut. Hasil urut period (YYYY-MM) ascending.
+func (r *achievementReferenceRepository) TimelineByMonth(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, year int, verified bool) ([]model.AchievementTimelinePoint, error) {
+	where, args := byStatusesWhere(statuses, studentID, advisorID, model.AchievementReferenceFilter{})
+	column := "ar.created_at"
+	if verified {
+		column = "ar.verified_at"
+		args = append(args, model.AchievementStatusVerified)
+		where += fmt.Sprintf(" AND ar.status = $%d AND ar.verified_at IS NOT NULL", len(args))
+	}
+	if year > 0 {
+		args = append(args, year)
+		where += fmt.Sprintf(" AND EXTRACT(YEAR FROM %s) = $%d", column, len(args))
+	}
+	query := fmt.Sprintf(`
+		SELECT to_char(date_trunc('month', %s), 'YYYY-MM') AS period, COUNT(*)
+		FROM achievement_references ar
+		WHERE %s
+		GROUP BY period
+		ORDER BY period ASC
+	`, column, where)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghitung timeline achievement: %w", err)
+	}
+	defer rows.Close()
+
+	points := []model.AchievementTimelinePoint{}
+	for rows.Next() {
+		var p model.AchievementTimelinePoint
+		if err := rows.Scan(&p.Period, &p.Count); err != nil {
+			return nil, fmt.Errorf("gagal scan timeline achievement: %w", err)
+		}
+		points = append(points, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterasi timeline achievement: %w", err)
 	}
+	return points, nil
+}
+
+func (r *achievementReferenceRepository) ListByStatuses(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64, filter model.AchievementReferenceFilter) ([]model.AchievementReference, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
 
+	total, err := r.CountByStatuses(ctx, statuses, studentID, advisorID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where, args := byStatusesWhere(statuses, studentID, advisorID, filter)
 	args = append(args, limit, offset)
 	listQuery := fmt.Sprintf(`
 		SELECT ar.id, ar.student_id, ar.mongo_achievement_id, ar.status, ar.submitted_at, ar.verified_at, ar.verified_by, ar.rejection_note, ar.created_at, ar.updated_at
-		FROM achievement_references ar%s
+		FROM achievement_references ar
 		WHERE %s
-		ORDER BY ar.created_at DESC
+		ORDER BY ar.created_at DESC, ar.id DESC
 		LIMIT $%d OFFSET $%d
-	`, join, where, len(args)-1, len(args))
+	`, where, len(args)-1, len(args))
 
 	rows, err := r.db.QueryContext(ctx, listQuery, args...)
 	if err != nil {
@@ -458,3 +735,182 @@ func (r *achievementReferenceRepository) ListByStatuses(ctx context.Context, sta
 
 	return refs, total, nil
 }
+
+// StatusesByMongoIDs memetakan mongo_achievement_id -> status dalam satu query, dengan filter scope yang sama
+// seperti ListByStatuses. Id yang tidak ada / di luar scope tidak muncul di map.
+func (r *achievementReferenceRepository) StatusesByMongoIDs(ctx context.Context, mongoIDs []string, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID) (map[string]string, error) {
+	result := make(map[string]string)
+	if len(mongoIDs) == 0 || len(statuses) == 0 {
+		return result, nil
+	}
+
+	args := []interface{}{pq.Array(mongoIDs), pq.Array(statuses)}
+	where := "ar.mongo_achievement_id = ANY($1) AND ar.status = ANY($2)"
+	if studentID != nil {
+		args = append(args, *studentID)
+		where += fmt.Sprintf(" AND ar.student_id = $%d", len(args))
+	}
+	if advisorID != nil {
+		args = append(args, *advisorID)
+		where += fmt.Sprintf(" AND ar.student_id IN (%s)", advisedStudentsSubquery(fmt.Sprintf("$%d", len(args))))
+	}
+
+	query := fmt.Sprintf(`SELECT ar.mongo_achievement_id, ar.status FROM achievement_references ar WHERE %s`, where)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil status achievement_references: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var mongoID, status string
+		if err := rows.Scan(&mongoID, &status); err != nil {
+			return nil, fmt.Errorf("gagal scan status achievement_reference: %w", err)
+		}
+		result[mongoID] = status
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterasi status achievement_references: %w", err)
+	}
+	return result, nil
+}
+
+// ListDeletedOlderThan mengambil reference berstatus deleted yang terakhir diubah (saat soft delete)
+// lebih lama dari olderThan; dipakai job cleanup untuk hard delete.
+func (r *achievementReferenceRepository) ListDeletedOlderThan(ctx context.Context, olderThan time.Duration) ([]model.AchievementReference, error) {
+	return r.listByStatusUpdatedBefore(ctx, model.AchievementStatusDeleted, time.Now().Add(-olderThan))
+}
+
+// ListDraftsOlderThan mengambil reference berstatus draft yang tidak diubah lebih lama dari olderThan;
+// dipakai job expiry draft. Status lain (submitted, verified, dst.) tidak pernah ikut terambil.
+func (r *achievementReferenceRepository) ListDraftsOlderThan(ctx context.Context, olderThan time.Duration) ([]model.AchievementReference, error) {
+	return r.listByStatusUpdatedBefore(ctx, model.AchievementStatusDraft, time.Now().Add(-olderThan))
+}
+
+func (r *achievementReferenceRepository) listByStatusUpdatedBefore(ctx context.Context, status string, cutoff time.Time) ([]model.AchievementReference, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT ar.id, ar.student_id, ar.mongo_achievement_id, ar.status, ar.submitted_at, ar.verified_at, ar.verified_by, ar.rejection_note, ar.created_at, ar.updated_at
+		FROM achievement_references ar
+		WHERE ar.status = $1 AND ar.updated_at < $2
+		ORDER BY ar.updated_at ASC
+	`, status, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil achievement %s: %w", status, err)
+	}
+	defer rows.Close()
+
+	var refs []model.AchievementReference
+	for rows.Next() {
+		var ref model.AchievementReference
+		if err := rows.Scan(
+			&ref.ID,
+			&ref.StudentID,
+			&ref.MongoAchievementID,
+			&ref.Status,
+			&ref.SubmittedAt,
+			&ref.VerifiedAt,
+			&ref.VerifiedBy,
+			&ref.RejectionNote,
+			&ref.CreatedAt,
+			&ref.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("gagal scan achievement_reference: %w", err)
+		}
+		refs = append(refs, ref)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterasi achievement_references: %w", err)
+	}
+	return refs, nil
+}
+
+// ListSubmittedBefore mengambil reference berstatus submitted yang submitted_at-nya sebelum batas waktu (melewati SLA review).
+func (r *achievementReferenceRepository) ListSubmittedBefore(ctx context.Context, before time.Time, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64) ([]model.AchievementReference, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	args := []interface{}{model.AchievementStatusSubmitted, before}
+	where := "ar.status = $1 AND ar.submitted_at < $2"
+	if studentID != nil {
+		args = append(args, *studentID)
+		where += fmt.Sprintf(" AND ar.student_id = $%d", len(args))
+	}
+	if advisorID != nil {
+		args = append(args, *advisorID)
+		where += fmt.Sprintf(" AND ar.student_id IN (%s)", advisedStudentsSubquery(fmt.Sprintf("$%d", len(args))))
+	}
+
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM achievement_references ar WHERE %s`, where)
+	var total int64
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("gagal menghitung achievement overdue: %w", err)
+	}
+
+	args = append(args, limit, offset)
+	listQuery := fmt.Sprintf(`
+		SELECT ar.id, ar.student_id, ar.mongo_achievement_id, ar.status, ar.submitted_at, ar.verified_at, ar.verified_by, ar.rejection_note, ar.created_at, ar.updated_at
+		FROM achievement_references ar
+		WHERE %s
+		ORDER BY ar.submitted_at ASC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, listQuery, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal mengambil achievement overdue: %w", err)
+	}
+	defer rows.Close()
+
+	var refs []model.AchievementReference
+	for rows.Next() {
+		var ref model.AchievementReference
+		if err := rows.Scan(
+			&ref.ID,
+			&ref.StudentID,
+			&ref.MongoAchievementID,
+			&ref.Status,
+			&ref.SubmittedAt,
+			&ref.VerifiedAt,
+			&ref.VerifiedBy,
+			&ref.RejectionNote,
+			&ref.CreatedAt,
+			&ref.UpdatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("gagal scan achievement_reference: %w", err)
+		}
+		refs = append(refs, ref)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterasi achievement_references: %w", err)
+	}
+
+	return refs, total, nil
+}
+
+// Funnel menghitung jumlah reference yang dibuat, disubmit, diverifikasi, dan ditolak dalam rentang [from, to).
+func (r *achievementReferenceRepository) Funnel(ctx context.Context, from, to time.Time) (*model.AchievementFunnel, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE created_at >= $1 AND created_at < $2),
+			COUNT(*) FILTER (WHERE submitted_at >= $1 AND submitted_at < $2),
+			COUNT(*) FILTER (WHERE status = $3 AND verified_at >= $1 AND verified_at < $2),
+			COUNT(*) FILTER (WHERE status = $4 AND verified_at >= $1 AND verified_at < $2)
+		FROM achievement_references
+	`
+	funnel := model.AchievementFunnel{From: from, To: to}
+	err := r.db.QueryRowContext(ctx, query, from, to, model.AchievementStatusVerified, model.AchievementStatusRejected).Scan(
+		&funnel.Created,
+		&funnel.Submitted,
+		&funnel.Verified,
+		&funnel.Rejected,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghitung funnel achievement: %w", err)
+	}
+	return &funnel, nil
+}
@@ -0,0 +1,416 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"hello-fiber/app/model"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestStatusesByMongoIDs_SingleQueryMixedSet(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		return &fakeRowsResult{
+			columns: []string{"mongo_achievement_id", "status"},
+			rows: [][]driver.Value{
+				{"m1", model.AchievementStatusVerified},
+				{"m2", model.AchievementStatusSubmitted},
+			},
+		}, nil
+	}
+
+	repo := NewAchievementReferenceRepository(db)
+	studentID := uuid.New()
+	statuses := []string{model.AchievementStatusSubmitted, model.AchievementStatusVerified}
+	got, err := repo.StatusesByMongoIDs(context.Background(), []string{"m1", "m2", "missing"}, statuses, &studentID, nil)
+	if err != nil {
+		t.Fatalf("StatusesByMongoIDs: %v", err)
+	}
+
+	if len(fake.queries) != 1 {
+		t.Fatalf("expected a single query, got %d", len(fake.queries))
+	}
+	q := fake.queries[0]
+	if !strings.Contains(q.query, "mongo_achievement_id = ANY($1)") || !strings.Contains(q.query, "ar.student_id = $3") {
+		t.Fatalf("unexpected query: %s", q.query)
+	}
+	if q.args[0] != `{"m1","m2","missing"}` {
+		t.Fatalf("unexpected mongo ids arg: %v", q.args[0])
+	}
+
+	if len(got) != 2 || got["m1"] != model.AchievementStatusVerified || got["m2"] != model.AchievementStatusSubmitted {
+		t.Fatalf("unexpected result: %v", got)
+	}
+	if _, ok := got["missing"]; ok {
+		t.Fatalf("missing id should not be in result")
+	}
+}
+
+func TestStatusesByMongoIDs_EmptyInputSkipsQuery(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+
+	repo := NewAchievementReferenceRepository(db)
+	got, err := repo.StatusesByMongoIDs(context.Background(), nil, []string{model.AchievementStatusVerified}, nil, nil)
+	if err != nil {
+		t.Fatalf("StatusesByMongoIDs: %v", err)
+	}
+	if len(got) != 0 || len(fake.queries) != 0 {
+		t.Fatalf("expected no query and empty result, got %v (%d queries)", got, len(fake.queries))
+	}
+}
+
+func TestListByStatuses_VerifiedRangeExcludesUnverified(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	inRange, outOfRange := base.Add(24*time.Hour), base.Add(40*24*time.Hour)
+	type refRow struct {
+		id         string
+		status     string
+		verifiedAt *time.Time
+	}
+	dataset := []refRow{
+		{uuid.NewString(), model.AchievementStatusVerified, &inRange},
+		{uuid.NewString(), model.AchievementStatusVerified, &outOfRange},
+		{uuid.NewString(), model.AchievementStatusSubmitted, nil},
+		{uuid.NewString(), model.AchievementStatusRejected, nil},
+	}
+
+	// fake mengevaluasi "verified_at IS NOT NULL AND verified_at BETWEEN from AND to" dari argumen query
+	filtered := func(args []driver.Value) []refRow {
+		var bounds []time.Time
+		for _, a := range args {
+			if ts, ok := a.(time.Time); ok {
+				bounds = append(bounds, ts)
+			}
+		}
+		if len(bounds) != 2 {
+			t.Fatalf("expected from/to args, got %v", args)
+		}
+		var out []refRow
+		for _, r := range dataset {
+			if r.verifiedAt != nil && !r.verifiedAt.Before(bounds[0]) && !r.verifiedAt.After(bounds[1]) {
+				out = append(out, r)
+			}
+		}
+		return out
+	}
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		if !strings.Contains(query, "ar.verified_at IS NOT NULL AND ar.verified_at BETWEEN") {
+			t.Fatalf("range clause missing: %s", query)
+		}
+		rows := filtered(args)
+		if strings.Contains(query, "COUNT(*)") {
+			return &fakeRowsResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(len(rows))}}}, nil
+		}
+		res := &fakeRowsResult{columns: []string{"id", "student_id", "mongo_achievement_id", "status", "submitted_at", "verified_at", "verified_by", "rejection_note", "created_at", "updated_at"}}
+		for _, r := range rows {
+			res.rows = append(res.rows, []driver.Value{r.id, uuid.NewString(), "m-" + r.id, r.status, nil, *r.verifiedAt, nil, nil, base, base})
+		}
+		return res, nil
+	}
+
+	from, to := base, base.Add(7*24*time.Hour)
+	repo := NewAchievementReferenceRepository(db)
+	statuses := []string{model.AchievementStatusSubmitted, model.AchievementStatusVerified, model.AchievementStatusRejected}
+	refs, total, err := repo.ListByStatuses(context.Background(), statuses, nil, nil, 1, 10, model.AchievementReferenceFilter{VerifiedFrom: &from, VerifiedTo: &to})
+	if err != nil {
+		t.Fatalf("ListByStatuses: %v", err)
+	}
+	if total != 1 || len(refs) != 1 || refs[0].ID.String() != dataset[0].id {
+		t.Fatalf("expected only the in-range verified row, got total=%d refs=%+v", total, refs)
+	}
+}
+
+func TestListByStatuses_NoRangeKeepsQuery(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		if strings.Contains(query, "COUNT(*)") {
+			return &fakeRowsResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(0)}}}, nil
+		}
+		return &fakeRowsResult{}, nil
+	}
+
+	repo := NewAchievementReferenceRepository(db)
+	from := time.Now()
+	// hanya satu sisi rentang => filter tidak diterapkan
+	if _, _, err := repo.ListByStatuses(context.Background(), []string{model.AchievementStatusVerified}, nil, nil, 1, 10, model.AchievementReferenceFilter{VerifiedFrom: &from}); err != nil {
+		t.Fatalf("ListByStatuses: %v", err)
+	}
+	for _, q := range fake.queries {
+		if strings.Contains(q.query, "verified_at") && strings.Contains(q.query, "BETWEEN") {
+			t.Fatalf("unexpected range clause: %s", q.query)
+		}
+	}
+	if list := fake.queries[len(fake.queries)-1].query; !strings.Contains(list, "ORDER BY ar.created_at DESC, ar.id DESC") {
+		t.Fatalf("list query must have a stable tie-breaker: %s", list)
+	}
+}
+
+func TestCountByStatuses_OnlyRunsCountQuery(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		return &fakeRowsResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(4)}}}, nil
+	}
+
+	repo := NewAchievementReferenceRepository(db)
+	advisorID := uuid.New()
+	total, err := repo.CountByStatuses(context.Background(), []string{model.AchievementStatusSubmitted}, nil, &advisorID, model.AchievementReferenceFilter{})
+	if err != nil {
+		t.Fatalf("CountByStatuses: %v", err)
+	}
+	if total != 4 {
+		t.Fatalf("unexpected total: %d", total)
+	}
+	if len(fake.queries) != 1 || !strings.Contains(fake.queries[0].query, "COUNT(*)") {
+		t.Fatalf("expected a single COUNT query, got %+v", fake.queries)
+	}
+	if !strings.Contains(fake.queries[0].query, "student_advisors") {
+		t.Fatalf("advisor scope missing: %s", fake.queries[0].query)
+	}
+}
+
+func TestTimelineByMonth_GroupsByMonthInScope(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		return &fakeRowsResult{columns: []string{"period", "count"}, rows: [][]driver.Value{{"2025-01", int64(3)}, {"2025-03", int64(1)}}}, nil
+	}
+
+	repo := NewAchievementReferenceRepository(db)
+	studentID := uuid.New()
+	points, err := repo.TimelineByMonth(context.Background(), []string{model.AchievementStatusDraft, model.AchievementStatusVerified}, &studentID, nil, 2025, false)
+	if err != nil {
+		t.Fatalf("TimelineByMonth: %v", err)
+	}
+	if len(points) != 2 || points[0].Period != "2025-01" || points[0].Count != 3 || points[1].Period != "2025-03" {
+		t.Fatalf("unexpected points: %+v", points)
+	}
+	q := fake.queries[0]
+	for _, want := range []string{"date_trunc('month', ar.created_at)", "GROUP BY period", "ORDER BY period ASC", "ar.student_id = $3", "EXTRACT(YEAR FROM ar.created_at) = $4"} {
+		if !strings.Contains(q.query, want) {
+			t.Fatalf("query missing %q: %s", want, q.query)
+		}
+	}
+	if len(q.args) != 4 || q.args[3] != int64(2025) {
+		t.Fatalf("unexpected args: %v", q.args)
+	}
+
+	if _, err := repo.TimelineByMonth(context.Background(), []string{model.AchievementStatusVerified}, nil, nil, 0, true); err != nil {
+		t.Fatalf("TimelineByMonth verified: %v", err)
+	}
+	q = fake.queries[1]
+	if !strings.Contains(q.query, "date_trunc('month', ar.verified_at)") || !strings.Contains(q.query, "ar.status = $2 AND ar.verified_at IS NOT NULL") || strings.Contains(q.query, "EXTRACT") {
+		t.Fatalf("unexpected verified query: %s", q.query)
+	}
+}
+
+func TestListByStatuses_AdvisorScopeJoinsAdvisees(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		if strings.Contains(query, "COUNT(*)") {
+			return &fakeRowsResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(0)}}}, nil
+		}
+		return &fakeRowsResult{}, nil
+	}
+
+	repo := NewAchievementReferenceRepository(db)
+	advisorID := uuid.New()
+	if _, _, err := repo.ListByStatuses(context.Background(), []string{model.AchievementStatusSubmitted}, nil, &advisorID, 1, 10, model.AchievementReferenceFilter{}); err != nil {
+		t.Fatalf("ListByStatuses: %v", err)
+	}
+	if len(fake.queries) != 2 {
+		t.Fatalf("expected count + list queries, got %d", len(fake.queries))
+	}
+	for _, q := range fake.queries {
+		// status submitted = $1, advisor = $2 dipakai untuk advisor utama dan co-advisor
+		if !strings.Contains(q.query, "ar.student_id IN (SELECT id FROM students WHERE advisor_id = $2 UNION SELECT student_id FROM student_advisors WHERE lecturer_id = $2)") {
+			t.Fatalf("advisor join missing: %s", q.query)
+		}
+		if q.args[0] != model.AchievementStatusSubmitted || q.args[1] != advisorID.String() {
+			t.Fatalf("unexpected args: %v", q.args)
+		}
+	}
+}
+
+func TestListDeletedOlderThan_OnlyOldDeletedRows(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+
+	now := time.Now()
+	type row struct {
+		id        string
+		status    string
+		updatedAt time.Time
+	}
+	fixtures := []row{
+		{"old-deleted", model.AchievementStatusDeleted, now.Add(-45 * 24 * time.Hour)},
+		{"recent-deleted", model.AchievementStatusDeleted, now.Add(-2 * 24 * time.Hour)},
+		{"old-draft", model.AchievementStatusDraft, now.Add(-90 * 24 * time.Hour)},
+	}
+	// fake menerapkan predikat WHERE status = $1 AND updated_at < $2 terhadap fixture
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		if !strings.Contains(query, "ar.status = $1 AND ar.updated_at < $2") {
+			t.Fatalf("unexpected query: %s", query)
+		}
+		status, cutoff := args[0].(string), args[1].(time.Time)
+		res := &fakeRowsResult{columns: []string{"id", "student_id", "mongo_achievement_id", "status", "submitted_at", "verified_at", "verified_by", "rejection_note", "created_at", "updated_at"}}
+		for _, f := range fixtures {
+			if f.status == status && f.updatedAt.Before(cutoff) {
+				res.rows = append(res.rows, []driver.Value{uuid.NewString(), uuid.NewString(), f.id, f.status, nil, nil, nil, nil, f.updatedAt, f.updatedAt})
+			}
+		}
+		return res, nil
+	}
+
+	repo := NewAchievementReferenceRepository(db)
+	refs, err := repo.ListDeletedOlderThan(context.Background(), 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("ListDeletedOlderThan: %v", err)
+	}
+	if len(refs) != 1 || refs[0].MongoAchievementID != "old-deleted" {
+		t.Fatalf("expected only old-deleted, got %+v", refs)
+	}
+	if cutoff := fake.queries[0].args[1].(time.Time); now.Sub(cutoff) < 30*24*time.Hour-time.Minute {
+		t.Fatalf("cutoff too recent: %s", cutoff)
+	}
+}
+
+func TestListDraftsOlderThan_OnlyOldDrafts(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+
+	now := time.Now()
+	type row struct {
+		id        string
+		status    string
+		updatedAt time.Time
+	}
+	fixtures := []row{
+		{"old-draft", model.AchievementStatusDraft, now.Add(-120 * 24 * time.Hour)},
+		{"recent-draft", model.AchievementStatusDraft, now.Add(-10 * 24 * time.Hour)},
+		{"old-submitted", model.AchievementStatusSubmitted, now.Add(-200 * 24 * time.Hour)},
+		{"old-verified", model.AchievementStatusVerified, now.Add(-300 * 24 * time.Hour)},
+	}
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		if !strings.Contains(query, "ar.status = $1 AND ar.updated_at < $2") {
+			t.Fatalf("unexpected query: %s", query)
+		}
+		status, cutoff := args[0].(string), args[1].(time.Time)
+		res := &fakeRowsResult{columns: []string{"id", "student_id", "mongo_achievement_id", "status", "submitted_at", "verified_at", "verified_by", "rejection_note", "created_at", "updated_at"}}
+		for _, f := range fixtures {
+			if f.status == status && f.updatedAt.Before(cutoff) {
+				res.rows = append(res.rows, []driver.Value{uuid.NewString(), uuid.NewString(), f.id, f.status, nil, nil, nil, nil, f.updatedAt, f.updatedAt})
+			}
+		}
+		return res, nil
+	}
+
+	repo := NewAchievementReferenceRepository(db)
+	refs, err := repo.ListDraftsOlderThan(context.Background(), 90*24*time.Hour)
+	if err != nil {
+		t.Fatalf("ListDraftsOlderThan: %v", err)
+	}
+	if len(refs) != 1 || refs[0].MongoAchievementID != "old-draft" {
+		t.Fatalf("expected only old-draft, got %+v", refs)
+	}
+	if status := fake.queries[0].args[0]; status != model.AchievementStatusDraft {
+		t.Fatalf("expected status draft, got %v", status)
+	}
+	if len(fake.queries) != 1 {
+		t.Fatalf("listing must run a single SELECT, got %d statements", len(fake.queries))
+	}
+}
+
+func TestRevertToDraft_GuardedByOwnerAndRejected(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	studentID := uuid.New()
+	var affected int64 = 1
+	fake.execFn = func(query string, args []driver.Value) (int64, error) {
+		if !strings.Contains(query, "rejection_note = NULL") || !strings.Contains(query, "AND student_id = $3") || !strings.Contains(query, "AND status = $4") {
+			t.Fatalf("unexpected query: %s", query)
+		}
+		if args[0] != model.AchievementStatusDraft || args[3] != model.AchievementStatusRejected {
+			t.Fatalf("unexpected status args: %v", args)
+		}
+		return affected, nil
+	}
+
+	repo := NewAchievementReferenceRepository(db)
+	if err := repo.RevertToDraft(context.Background(), "ref-1", studentID); err != nil {
+		t.Fatalf("RevertToDraft: %v", err)
+	}
+
+	affected = 0
+	err := repo.RevertToDraft(context.Background(), "ref-1", studentID)
+	if err == nil || !strings.Contains(err.Error(), "tidak ditemukan") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestMongoIDsByStatuses_ScopedToStudent(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		return &fakeRowsResult{columns: []string{"mongo_achievement_id"}, rows: [][]driver.Value{{"m-1"}, {"m-2"}}}, nil
+	}
+
+	repo := NewAchievementReferenceRepository(db)
+	studentID := uuid.New()
+	ids, err := repo.MongoIDsByStatuses(context.Background(), []string{model.AchievementStatusDraft, model.AchievementStatusVerified}, &studentID, nil)
+	if err != nil {
+		t.Fatalf("MongoIDsByStatuses: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "m-1" || ids[1] != "m-2" {
+		t.Fatalf("unexpected ids: %v", ids)
+	}
+	q := fake.queries[0]
+	if !strings.Contains(q.query, "SELECT ar.mongo_achievement_id") || !strings.Contains(q.query, "ar.student_id = $3") {
+		t.Fatalf("query not scoped: %s", q.query)
+	}
+	if len(q.args) != 3 || q.args[2] != studentID.String() {
+		t.Fatalf("unexpected args: %v", q.args)
+	}
+}
+
+func TestTagCountsPipeline_UnwindGroupSortByCount(t *testing.T) {
+	oid := bson.NewObjectID()
+	pipeline := tagCountsPipeline([]bson.ObjectID{oid})
+
+	var stages []string
+	for _, stage := range pipeline {
+		stages = append(stages, stage[0].Key)
+	}
+	if strings.Join(stages, ",") != "$match,$unwind,$group,$sort" {
+		t.Fatalf("unexpected stages: %v", stages)
+	}
+
+	match := pipeline[0][0].Value.(bson.M)["_id"].(bson.M)["$in"].([]bson.ObjectID)
+	if len(match) != 1 || match[0] != oid {
+		t.Fatalf("match must be limited to scoped ids: %v", match)
+	}
+	if pipeline[1][0].Value != "$tags" {
+		t.Fatalf("unwind must target tags: %v", pipeline[1][0].Value)
+	}
+	group := pipeline[2][0].Value.(bson.D)
+	if group[0].Key != "_id" || group[0].Value != "$tags" || group[1].Key != "count" {
+		t.Fatalf("unexpected group: %v", group)
+	}
+	sort := pipeline[3][0].Value.(bson.D)
+	if sort[0].Key != "count" || sort[0].Value != -1 || sort[1].Key != "_id" {
+		t.Fatalf("sort must be count desc then tag: %v", sort)
+	}
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hello-fiber/app/model"
+	"time"
+)
+
+type AdvisorHistoryRepository interface {
+	RecordChange(entry model.AdvisorHistory) error
+	ListByStudent(studentID string) ([]model.AdvisorHistory, error)
+}
+
+type AdvisorHistoryRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewAdvisorHistoryRepositoryPostgres(db *sql.DB) *AdvisorHistoryRepositoryPostgres {
+	return &AdvisorHistoryRepositoryPostgres{db: db}
+}
+
+func (r *AdvisorHistoryRepositoryPostgres) RecordChange(entry model.AdvisorHistory) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO advisor_history (student_id, previous_advisor_id, new_advisor_id, change_type, changed_by, changed_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+	`, entry.StudentID, entry.PreviousAdvisorID, entry.NewAdvisorID, entry.ChangeType, entry.ChangedBy)
+	if err != nil {
+		return fmt.Errorf("gagal mencatat riwayat advisor: %w", err)
+	}
+	return nil
+}
+
+// ListByStudent mengembalikan riwayat advisor student, terbaru lebih dulu.
+func (r *AdvisorHistoryRepositoryPostgres) ListByStudent(studentID string) ([]model.AdvisorHistory, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, student_id, previous_advisor_id, new_advisor_id, change_type, changed_by, changed_at
+		FROM advisor_history
+		WHERE student_id = $1
+		ORDER BY changed_at DESC
+	`, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal query riwayat advisor: %w", err)
+	}
+	defer rows.Close()
+
+	var history []model.AdvisorHistory
+	for rows.Next() {
+		var h model.AdvisorHistory
+		if err := rows.Scan(&h.ID, &h.StudentID, &h.PreviousAdvisorID, &h.NewAdvisorID, &h.ChangeType, &h.ChangedBy, &h.ChangedAt); err != nil {
+			return nil, fmt.Errorf("gagal scan riwayat advisor: %w", err)
+		}
+		history = append(history, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterasi riwayat advisor: %w", err)
+	}
+	return history, nil
+}
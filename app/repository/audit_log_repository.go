@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hello-fiber/app/model"
+	"time"
+)
+
+type AuditLogRepository interface {
+	Record(entry model.AuditLog) error
+	List(page, limit int64, entity string) ([]model.AuditLog, int64, error)
+}
+
+type AuditLogRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewAuditLogRepositoryPostgres(db *sql.DB) *AuditLogRepositoryPostgres {
+	return &AuditLogRepositoryPostgres{db: db}
+}
+
+func (r *AuditLogRepositoryPostgres) Record(entry model.AuditLog) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var ipArg interface{}
+	if entry.IP != "" {
+		ipArg = entry.IP
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO audit_logs (actor_id, action, entity, entity_id, ip, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+	`, entry.ActorID, entry.Action, entry.Entity, entry.EntityID, ipArg)
+	if err != nil {
+		return fmt.Errorf("gagal mencatat audit log: %w", err)
+	}
+	return nil
+}
+
+// List mengembalikan audit log terbaru lebih dulu; entity kosong berarti semua entity.
+func (r *AuditLogRepositoryPostgres) List(page, limit int64, entity string) ([]model.AuditLog, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	where := ""
+	args := []interface{}{}
+	if entity != "" {
+		args = append(args, entity)
+		where = fmt.Sprintf("WHERE entity = $%d", len(args))
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM audit_logs "+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("gagal count audit log: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	query := fmt.Sprintf(`
+		SELECT id, actor_id, action, entity, entity_id, ip, created_at
+		FROM audit_logs
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var logs []model.AuditLog
+	for rows.Next() {
+		var l model.AuditLog
+		var actorID, ip sql.NullString
+		if err := rows.Scan(&l.ID, &actorID, &l.Action, &l.Entity, &l.EntityID, &ip, &l.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("gagal scan audit log: %w", err)
+		}
+		if actorID.Valid {
+			l.ActorID = &actorID.String
+		}
+		l.IP = ip.String
+		logs = append(logs, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterasi audit log: %w", err)
+	}
+	return logs, total, nil
+}
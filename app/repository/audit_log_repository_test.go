@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"hello-fiber/app/model"
+)
+
+func TestAuditLogRecord_InsertsRow(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.execFn = func(query string, args []driver.Value) (int64, error) { return 1, nil }
+
+	actor := "admin-1"
+	repo := NewAuditLogRepositoryPostgres(db)
+	err := repo.Record(model.AuditLog{
+		ActorID:  &actor,
+		Action:   model.AuditActionDelete,
+		Entity:   model.AuditEntityUser,
+		EntityID: "u1",
+		IP:       "10.0.0.1",
+	})
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	if len(fake.queries) != 1 || !strings.Contains(fake.queries[0].query, "INSERT INTO audit_logs") {
+		t.Fatalf("unexpected queries: %+v", fake.queries)
+	}
+	args := fake.queries[0].args
+	if args[0] != "admin-1" || args[1] != "delete" || args[2] != "user" || args[3] != "u1" || args[4] != "10.0.0.1" {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
+
+func TestAuditLogList_EntityFilter(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	now := time.Now()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		if strings.Contains(query, "COUNT(*)") {
+			return &fakeRowsResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(1)}}}, nil
+		}
+		return &fakeRowsResult{
+			columns: []string{"id", "actor_id", "action", "entity", "entity_id", "ip", "created_at"},
+			rows:    [][]driver.Value{{"a1", nil, "update", "role", "r1", nil, now}},
+		}, nil
+	}
+
+	repo := NewAuditLogRepositoryPostgres(db)
+	logs, total, err := repo.List(1, 10, model.AuditEntityRole)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 1 || len(logs) != 1 || logs[0].ActorID != nil || logs[0].EntityID != "r1" {
+		t.Fatalf("unexpected result: total=%d logs=%+v", total, logs)
+	}
+	list := fake.queries[1]
+	if !strings.Contains(list.query, "WHERE entity = $1") || !strings.Contains(list.query, "LIMIT $2 OFFSET $3") {
+		t.Fatalf("unexpected list query: %s", list.query)
+	}
+	if list.args[0] != "role" {
+		t.Fatalf("unexpected entity arg: %v", list.args[0])
+	}
+}
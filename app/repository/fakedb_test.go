@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+// fakeDB driver database/sql minimal untuk test repository tanpa Postgres.
+// Setiap query dicatat; hasil query diambil dari handler yang diset test.
+type fakeQuery struct {
+	query string
+	args  []driver.Value
+}
+
+type fakeRowsResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+type fakeDB struct {
+	queries []fakeQuery
+	queryFn func(query string, args []driver.Value) (*fakeRowsResult, error)
+	execFn  func(query string, args []driver.Value) (int64, error)
+
+	// jumlah transaksi yang di-commit / di-rollback
+	commits   int
+	rollbacks int
+}
+
+func newFakeDB() (*sql.DB, *fakeDB) {
+	f := &fakeDB{}
+	return sql.OpenDB(fakeConnector{f}), f
+}
+
+type fakeConnector struct{ f *fakeDB }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return &fakeConn{f: c.f}, nil }
+func (c fakeConnector) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, errors.New("gunakan sql.OpenDB") }
+
+type fakeConn struct{ f *fakeDB }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare tidak didukung")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{c.f}, nil }
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, named []driver.NamedValue) (driver.Rows, error) {
+	args := namedToValues(named)
+	c.f.queries = append(c.f.queries, fakeQuery{query: query, args: args})
+	if c.f.queryFn == nil {
+		return &fakeRows{}, nil
+	}
+	res, err := c.f.queryFn(query, args)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		res = &fakeRowsResult{}
+	}
+	return &fakeRows{columns: res.columns, rows: res.rows}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, named []driver.NamedValue) (driver.Result, error) {
+	args := namedToValues(named)
+	c.f.queries = append(c.f.queries, fakeQuery{query: query, args: args})
+	if c.f.execFn == nil {
+		return driver.RowsAffected(0), nil
+	}
+	n, err := c.f.execFn(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(n), nil
+}
+
+func namedToValues(named []driver.NamedValue) []driver.Value {
+	args := make([]driver.Value, len(named))
+	for i, nv := range named {
+		args[i] = nv.Value
+	}
+	return args
+}
+
+type fakeTx struct{ f *fakeDB }
+
+func (t fakeTx) Commit() error   { t.f.commits++; return nil }
+func (t fakeTx) Rollback() error { t.f.rollbacks++; return nil }
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	idx     int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.idx >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.idx])
+	r.idx++
+	return nil
+}
@@ -11,12 +11,13 @@ import (
 )
 
 type LecturerRepository interface {
-	GetAllLecturers(page, limit int64) ([]model.Lecturer, int64, error)
-	GetLecturerByID(id string) (*model.Lecturer, error)
-	GetLecturerByUserID(userID string) (*model.Lecturer, error)
-	CreateLecturer(req model.CreateLecturerRequest) (string, error)
-	UpdateLecturer(id string, req model.UpdateLecturerRequest) error
-	DeleteLecturer(id string) error
+	GetAllLecturers(ctx context.Context, page, limit int64) ([]model.Lecturer, int64, error)
+	GetLecturerByID(ctx context.Context, id string) (*model.Lecturer, error)
+	GetLecturerByUserID(ctx context.Context, userID string) (*model.Lecturer, error)
+	GetAdviseesSummary(ctx context.Context, lecturerID string) ([]model.AdviseeSummary, error)
+	CreateLecturer(ctx context.Context, req model.CreateLecturerRequest) (string, error)
+	UpdateLecturer(ctx context.Context, id string, req model.UpdateLecturerRequest) error
+	DeleteLecturer(ctx context.Context, id string) error
 }
 
 type LecturerRepositoryPostgres struct {
@@ -27,8 +28,8 @@ func NewLecturerRepositoryPostgres(db *sql.DB) *LecturerRepositoryPostgres {
 	return &LecturerRepositoryPostgres{db: db}
 }
 
-func (r *LecturerRepositoryPostgres) GetAllLecturers(page, limit int64) ([]model.Lecturer, int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *LecturerRepositoryPostgres) GetAllLecturers(ctx context.Context, page, limit int64) ([]model.Lecturer, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var total int64
@@ -65,8 +66,8 @@ func (r *LecturerRepositoryPostgres) GetAllLecturers(page, limit int64) ([]model
 	return lecturers, total, nil
 }
 
-func (r *LecturerRepositoryPostgres) GetLecturerByID(id string) (*model.Lecturer, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *LecturerRepositoryPostgres) GetLecturerByID(ctx context.Context, id string) (*model.Lecturer, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -86,8 +87,8 @@ func (r *LecturerRepositoryPostgres) GetLecturerByID(id string) (*model.Lecturer
 	return &l, nil
 }
 
-func (r *LecturerRepositoryPostgres) GetLecturerByUserID(userID string) (*model.Lecturer, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *LecturerRepositoryPostgres) GetLecturerByUserID(ctx context.Context, userID string) (*model.Lecturer, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -108,8 +109,48 @@ func (r *LecturerRepositoryPostgres) GetLecturerByUserID(userID string) (*model.
 	return &l, nil
 }
 
-func (r *LecturerRepositoryPostgres) CreateLecturer(req model.CreateLecturerRequest) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// GetAdviseesSummary semua mahasiswa bimbingan lecturer (advisor utama maupun co-advisor) beserta jumlah
+// achievement submitted/verified/rejected, dihitung dalam satu query ber-GROUP BY. Mahasiswa tanpa
+// achievement tetap muncul dengan hitungan 0.
+func (r *LecturerRepositoryPostgres) GetAdviseesSummary(ctx context.Context, lecturerID string) ([]model.AdviseeSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT s.id, s.student_id, COALESCE(u.full_name, ''), s.program_study,
+			COUNT(ar.id) FILTER (WHERE ar.status = $2),
+			COUNT(ar.id) FILTER (WHERE ar.status = $3),
+			COUNT(ar.id) FILTER (WHERE ar.status = $4)
+		FROM students s
+		LEFT JOIN users u ON u.id = s.user_id
+		LEFT JOIN achievement_references ar ON ar.student_id = s.id
+		WHERE s.id IN (` + advisedStudentsSubquery("$1") + `)
+		GROUP BY s.id, s.student_id, u.full_name, s.program_study
+		ORDER BY s.student_id ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, lecturerID,
+		model.AchievementStatusSubmitted, model.AchievementStatusVerified, model.AchievementStatusRejected)
+	if err != nil {
+		return nil, fmt.Errorf("gagal query ringkasan mahasiswa bimbingan: %w", err)
+	}
+	defer rows.Close()
+
+	advisees := []model.AdviseeSummary{}
+	for rows.Next() {
+		var a model.AdviseeSummary
+		if err := rows.Scan(&a.ID, &a.StudentID, &a.FullName, &a.ProgramStudy, &a.Submitted, &a.Verified, &a.Rejected); err != nil {
+			return nil, fmt.Errorf("gagal scan ringkasan mahasiswa bimbingan: %w", err)
+		}
+		advisees = append(advisees, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterasi ringkasan mahasiswa bimbingan: %w", err)
+	}
+	return advisees, nil
+}
+
+func (r *LecturerRepositoryPostgres) CreateLecturer(ctx context.Context, req model.CreateLecturerRequest) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -134,8 +175,8 @@ func (r *LecturerRepositoryPostgres) CreateLecturer(req model.CreateLecturerRequ
 	return id, nil
 }
 
-func (r *LecturerRepositoryPostgres) UpdateLecturer(id string, req model.UpdateLecturerRequest) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *LecturerRepositoryPostgres) UpdateLecturer(ctx context.Context, id string, req model.UpdateLecturerRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var updates []string
@@ -194,8 +235,8 @@ func (r *LecturerRepositoryPostgres) UpdateLecturer(id string, req model.UpdateL
 	return nil
 }
 
-func (r *LecturerRepositoryPostgres) DeleteLecturer(id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *LecturerRepositoryPostgres) DeleteLecturer(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	result, err := r.db.ExecContext(ctx, `DELETE FROM lecturers WHERE id = $1`, id)
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"hello-fiber/app/model"
+
+	"github.com/google/uuid"
+)
+
+func TestGetAdviseesSummary_GroupedCountsSingleQuery(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+
+	lecturerID := uuid.NewString()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		for _, want := range []string{"GROUP BY s.id", "LEFT JOIN achievement_references ar ON ar.student_id = s.id", "advisor_id = $1", "FILTER (WHERE ar.status = $2)"} {
+			if !strings.Contains(query, want) {
+				t.Fatalf("query missing %q: %s", want, query)
+			}
+		}
+		if args[0] != lecturerID || args[1] != model.AchievementStatusSubmitted || args[2] != model.AchievementStatusVerified || args[3] != model.AchievementStatusRejected {
+			t.Fatalf("unexpected args: %v", args)
+		}
+		return &fakeRowsResult{
+			columns: []string{"id", "student_id", "full_name", "program_study", "submitted", "verified", "rejected"},
+			rows: [][]driver.Value{
+				{uuid.NewString(), "S-01", "Budi", "Informatika", int64(2), int64(3), int64(1)},
+				{uuid.NewString(), "S-02", "Sari", "Informatika", int64(0), int64(0), int64(0)},
+			},
+		}, nil
+	}
+
+	repo := NewLecturerRepositoryPostgres(db)
+	got, err := repo.GetAdviseesSummary(context.Background(), lecturerID)
+	if err != nil {
+		t.Fatalf("GetAdviseesSummary: %v", err)
+	}
+	if len(fake.queries) != 1 {
+		t.Fatalf("expected a single grouped query, got %d", len(fake.queries))
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 advisees, got %+v", got)
+	}
+	if got[0].StudentID != "S-01" || got[0].Submitted != 2 || got[0].Verified != 3 || got[0].Rejected != 1 {
+		t.Fatalf("unexpected counts: %+v", got[0])
+	}
+	if got[1].Submitted != 0 || got[1].Verified != 0 || got[1].Rejected != 0 {
+		t.Fatalf("advisee without achievements must have zero counts: %+v", got[1])
+	}
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"hello-fiber/utils"
+)
+
+// warnf bisa diganti di test untuk menangkap log.
+var warnf = log.Printf
+
+// logWarning mencatat warning repository beserta request id dari ctx (jika ada), agar kegagalan
+// seperti decode baris bisa dikaitkan ke request tertentu di log.
+func logWarning(ctx context.Context, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if id := utils.RequestIDFromContext(ctx); id != "" {
+		warnf("[WARNING] [request_id=%s] %s", id, msg)
+		return
+	}
+	warnf("[WARNING] %s", msg)
+}
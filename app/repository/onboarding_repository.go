@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hello-fiber/app/model"
+	"hello-fiber/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OnboardingStudentRole nama role yang diberikan ke user hasil onboarding student.
+const OnboardingStudentRole = "mahasiswa"
+
+type OnboardingRepository interface {
+	OnboardStudent(req model.OnboardStudentRequest) (*model.OnboardStudentResult, error)
+}
+
+type OnboardingRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewOnboardingRepositoryPostgres(db *sql.DB) *OnboardingRepositoryPostgres {
+	return &OnboardingRepositoryPostgres{db: db}
+}
+
+// OnboardStudent membuat user (role mahasiswa) dan baris students dalam satu transaksi;
+// kegagalan di langkah mana pun me-rollback semuanya sehingga tidak ada user yatim.
+func (r *OnboardingRepositoryPostgres) OnboardStudent(req model.OnboardStudentRequest) (*model.OnboardStudentResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("gagal hash password: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	var roleID string
+	err = tx.QueryRowContext(ctx, `SELECT id FROM roles WHERE LOWER(name) = LOWER($1)`, OnboardingStudentRole).Scan(&roleID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("role %s tidak ditemukan", OnboardingStudentRole)
+		}
+		return nil, fmt.Errorf("gagal query role: %w", err)
+	}
+
+	var result model.OnboardStudentResult
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO users (id, username, email, password_hash, full_name, role_id, is_active, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, true, NOW(), NOW())
+		RETURNING id
+	`,
+		strings.TrimSpace(req.Username),
+		strings.ToLower(strings.TrimSpace(req.Email)),
+		hashedPassword,
+		req.FullName,
+		roleID,
+	).Scan(&result.UserID)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, errors.New("email atau username sudah terdaftar")
+		}
+		return nil, fmt.Errorf("gagal membuat user: %w", err)
+	}
+
+	var advisorArg interface{}
+	if req.AdvisorID != nil && *req.AdvisorID != uuid.Nil {
+		advisorArg = *req.AdvisorID
+	}
+	var progArg interface{}
+	if p := strings.TrimSpace(req.ProgramStudy); p != "" {
+		progArg = p
+	}
+	var yearArg interface{}
+	if y := strings.TrimSpace(req.AcademicYear); y != "" {
+		yearArg = y
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO students (user_id, student_id, program_study, academic_year, advisor_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, result.UserID, strings.TrimSpace(req.StudentID), progArg, yearArg, advisorArg).Scan(&result.StudentID)
+	if err != nil {
+		l := strings.ToLower(err.Error())
+		if strings.Contains(l, "duplicate key") || strings.Contains(l, "unique") {
+			return nil, errors.New("student_id sudah digunakan")
+		}
+		if strings.Contains(l, "students_advisor_id_fkey") {
+			return nil, errors.New("advisor_id tidak valid")
+		}
+		return nil, fmt.Errorf("gagal membuat student: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("gagal commit onboarding: %w", err)
+	}
+	return &result, nil
+}
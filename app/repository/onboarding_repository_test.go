@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"hello-fiber/app/model"
+)
+
+func onboardRequest() model.OnboardStudentRequest {
+	return model.OnboardStudentRequest{
+		Username:  "budi_s",
+		Email:     "Budi@Mail.com",
+		Password:  "Secret1",
+		FullName:  "Budi Santoso",
+		StudentID: "2201001",
+	}
+}
+
+func TestOnboardStudent_DuplicateStudentIDRollsBackUser(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		switch {
+		case strings.Contains(query, "FROM roles"):
+			return &fakeRowsResult{columns: []string{"id"}, rows: [][]driver.Value{{"role-mhs"}}}, nil
+		case strings.Contains(query, "INSERT INTO users"):
+			return &fakeRowsResult{columns: []string{"id"}, rows: [][]driver.Value{{"user-1"}}}, nil
+		case strings.Contains(query, "INSERT INTO students"):
+			return nil, errors.New(`pq: duplicate key value violates unique constraint "students_student_id_key"`)
+		}
+		return nil, errors.New("query tidak terduga: " + query)
+	}
+
+	repo := NewOnboardingRepositoryPostgres(db)
+	result, err := repo.OnboardStudent(onboardRequest())
+	if err == nil || err.Error() != "student_id sudah digunakan" {
+		t.Fatalf("expected duplicate student_id error, got result=%+v err=%v", result, err)
+	}
+
+	// user sudah di-insert di dalam transaksi, jadi harus ikut di-rollback dan tidak pernah di-commit
+	var userInserted bool
+	for _, q := range fake.queries {
+		if strings.Contains(q.query, "INSERT INTO users") {
+			userInserted = true
+			if q.args[1] != "budi@mail.com" || q.args[4] != "role-mhs" {
+				t.Fatalf("unexpected user insert args: %v", q.args)
+			}
+		}
+	}
+	if !userInserted {
+		t.Fatalf("expected user insert inside the transaction")
+	}
+	if fake.commits != 0 || fake.rollbacks != 1 {
+		t.Fatalf("expected rollback only, got commits=%d rollbacks=%d", fake.commits, fake.rollbacks)
+	}
+}
+
+func TestOnboardStudent_SuccessCommits(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		switch {
+		case strings.Contains(query, "FROM roles"):
+			return &fakeRowsResult{columns: []string{"id"}, rows: [][]driver.Value{{"role-mhs"}}}, nil
+		case strings.Contains(query, "INSERT INTO users"):
+			return &fakeRowsResult{columns: []string{"id"}, rows: [][]driver.Value{{"user-1"}}}, nil
+		case strings.Contains(query, "INSERT INTO students"):
+			if args[0] != "user-1" {
+				t.Fatalf("student must reference new user, got %v", args[0])
+			}
+			return &fakeRowsResult{columns: []string{"id"}, rows: [][]driver.Value{{"student-1"}}}, nil
+		}
+		return nil, errors.New("query tidak terduga: " + query)
+	}
+
+	repo := NewOnboardingRepositoryPostgres(db)
+	result, err := repo.OnboardStudent(onboardRequest())
+	if err != nil {
+		t.Fatalf("OnboardStudent: %v", err)
+	}
+	if result.UserID != "user-1" || result.StudentID != "student-1" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if fake.commits != 1 || fake.rollbacks != 0 {
+		t.Fatalf("expected single commit, got commits=%d rollbacks=%d", fake.commits, fake.rollbacks)
+	}
+}
+
+func TestOnboardStudent_MissingRoleRollsBack(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		return &fakeRowsResult{columns: []string{"id"}}, nil
+	}
+
+	repo := NewOnboardingRepositoryPostgres(db)
+	if _, err := repo.OnboardStudent(onboardRequest()); err == nil || !strings.Contains(err.Error(), "tidak ditemukan") {
+		t.Fatalf("expected role not found, got %v", err)
+	}
+	for _, q := range fake.queries {
+		if strings.Contains(q.query, "INSERT") {
+			t.Fatalf("no insert expected when role is missing: %s", q.query)
+		}
+	}
+	if fake.commits != 0 || fake.rollbacks != 1 {
+		t.Fatalf("expected rollback only, got commits=%d rollbacks=%d", fake.commits, fake.rollbacks)
+	}
+}
@@ -11,11 +11,13 @@ import (
 )
 
 type PermissionRepository interface {
-	GetAllPermissions(page, limit int64) ([]model.Permission, int64, error)
-	GetPermissionByID(id string) (*model.Permission, error)
-	CreatePermission(req model.CreatePermissionRequest) (string, error)
-	UpdatePermission(id string, req model.UpdatePermissionRequest) error
-	DeletePermission(id string) error
+	GetAllPermissions(ctx context.Context, page, limit int64, resource, action string) ([]model.Permission, int64, error)
+	GetPermissionByID(ctx context.Context, id string) (*model.Permission, error)
+	GetPermissionByName(ctx context.Context, name string) (*model.Permission, error)
+	CreatePermission(ctx context.Context, req model.CreatePermissionRequest) (string, error)
+	UpdatePermission(ctx context.Context, id string, req model.UpdatePermissionRequest) error
+	DeletePermission(ctx context.Context, id string, force bool) error
+	CountPermissionUsage(ctx context.Context, id string) (int64, error)
 }
 
 type PermissionRepositoryPostgres struct {
@@ -26,12 +28,28 @@ func NewPermissionRepositoryPostgres(db *sql.DB) *PermissionRepositoryPostgres {
 	return &PermissionRepositoryPostgres{db: db}
 }
 
-func (r *PermissionRepositoryPostgres) GetAllPermissions(page, limit int64) ([]model.Permission, int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// GetAllPermissions mengambil permission dengan pagination; resource/action kosong berarti tanpa filter.
+func (r *PermissionRepositoryPostgres) GetAllPermissions(ctx context.Context, page, limit int64, resource, action string) ([]model.Permission, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	conds := []string{}
+	args := []interface{}{}
+	if resource != "" {
+		args = append(args, resource)
+		conds = append(conds, fmt.Sprintf("resource = $%d", len(args)))
+	}
+	if action != "" {
+		args = append(args, action)
+		conds = append(conds, fmt.Sprintf("action = $%d", len(args)))
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+
 	var total int64
-	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM permissions").Scan(&total); err != nil {
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM permissions "+where, args...).Scan(&total); err != nil {
 		return nil, 0, fmt.Errorf("gagal count permissions: %w", err)
 	}
 
@@ -43,13 +61,14 @@ func (r *PermissionRepositoryPostgres) GetAllPermissions(page, limit int64) ([]m
 	}
 	offset := (page - 1) * limit
 
-	query := `
+	query := fmt.Sprintf(`
 		SELECT id, name, resource, action, description
 		FROM permissions
+		%s
 		ORDER BY name ASC
-		LIMIT $1 OFFSET $2
-	`
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("gagal query permissions: %w", err)
 	}
@@ -77,8 +96,8 @@ func (r *PermissionRepositoryPostgres) GetAllPermissions(page, limit int64) ([]m
 	return permissions, total, nil
 }
 
-func (r *PermissionRepositoryPostgres) GetPermissionByID(id string) (*model.Permission, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *PermissionRepositoryPostgres) GetPermissionByID(ctx context.Context, id string) (*model.Permission, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -105,8 +124,37 @@ func (r *PermissionRepositoryPostgres) GetPermissionByID(id string) (*model.Perm
 	return &perm, nil
 }
 
-func (r *PermissionRepositoryPostgres) CreatePermission(req model.CreatePermissionRequest) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// GetPermissionByName mencari permission berdasarkan nama (case-insensitive); nil, nil jika tidak ada.
+func (r *PermissionRepositoryPostgres) GetPermissionByName(ctx context.Context, name string) (*model.Permission, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT id, name, resource, action, description
+		FROM permissions
+		WHERE LOWER(name) = LOWER($1)
+	`
+
+	var perm model.Permission
+	err := r.db.QueryRowContext(ctx, query, strings.TrimSpace(name)).Scan(
+		&perm.ID,
+		&perm.Name,
+		&perm.Resource,
+		&perm.Action,
+		&perm.Description,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal query permission: %w", err)
+	}
+
+	return &perm, nil
+}
+
+func (r *PermissionRepositoryPostgres) CreatePermission(ctx context.Context, req model.CreatePermissionRequest) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -133,8 +181,8 @@ func (r *PermissionRepositoryPostgres) CreatePermission(req model.CreatePermissi
 	return id, nil
 }
 
-func (r *PermissionRepositoryPostgres) UpdatePermission(id string, req model.UpdatePermissionRequest) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *PermissionRepositoryPostgres) UpdatePermission(ctx context.Context, id string, req model.UpdatePermissionRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var updates []string
@@ -194,11 +242,25 @@ func (r *PermissionRepositoryPostgres) UpdatePermission(id string, req model.Upd
 	return nil
 }
 
-func (r *PermissionRepositoryPostgres) DeletePermission(id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// DeletePermission menghapus permission. Dengan force=true, mapping role_permissions
+// dihapus lebih dulu dalam transaksi yang sama.
+func (r *PermissionRepositoryPostgres) DeletePermission(ctx context.Context, id string, force bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctx, "DELETE FROM permissions WHERE id = $1", id)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	if force {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM role_permissions WHERE permission_id = $1", id); err != nil {
+			return fmt.Errorf("gagal hapus role_permissions: %w", err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM permissions WHERE id = $1", id)
 	if err != nil {
 		return fmt.Errorf("gagal delete permission: %w", err)
 	}
@@ -211,5 +273,20 @@ func (r *PermissionRepositoryPostgres) DeletePermission(id string) error {
 		return errors.New("permission tidak ditemukan")
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit delete permission: %w", err)
+	}
 	return nil
 }
+
+// CountPermissionUsage menghitung role_permissions yang masih memakai permission.
+func (r *PermissionRepositoryPostgres) CountPermissionUsage(ctx context.Context, id string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM role_permissions WHERE permission_id = $1", id).Scan(&total); err != nil {
+		return 0, fmt.Errorf("gagal cek pemakaian permission: %w", err)
+	}
+	return total, nil
+}
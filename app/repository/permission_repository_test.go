@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+func TestGetAllPermissions_ResourceAndActionFilter(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		if strings.Contains(query, "COUNT(*)") {
+			return &fakeRowsResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(1)}}}, nil
+		}
+		return &fakeRowsResult{
+			columns: []string{"id", "name", "resource", "action", "description"},
+			rows:    [][]driver.Value{{"p1", "achievement:read", "achievement", "read", "Read achievement"}},
+		}, nil
+	}
+
+	repo := NewPermissionRepositoryPostgres(db)
+	perms, total, err := repo.GetAllPermissions(context.Background(), 1, 10, "achievement", "read")
+	if err != nil {
+		t.Fatalf("GetAllPermissions: %v", err)
+	}
+	if total != 1 || len(perms) != 1 || perms[0].Name != "achievement:read" {
+		t.Fatalf("unexpected result: total=%d perms=%+v", total, perms)
+	}
+
+	count, list := fake.queries[0], fake.queries[1]
+	where := "WHERE resource = $1 AND action = $2"
+	if !strings.Contains(count.query, where) || len(count.args) != 2 || count.args[0] != "achievement" || count.args[1] != "read" {
+		t.Fatalf("unexpected count query: %s %v", count.query, count.args)
+	}
+	if !strings.Contains(list.query, where) || !strings.Contains(list.query, "LIMIT $3 OFFSET $4") || len(list.args) != 4 {
+		t.Fatalf("unexpected list query: %s %v", list.query, list.args)
+	}
+}
+
+func TestGetAllPermissions_ActionOnlyFilter(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		if strings.Contains(query, "COUNT(*)") {
+			return &fakeRowsResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(0)}}}, nil
+		}
+		return &fakeRowsResult{columns: []string{"id", "name", "resource", "action", "description"}}, nil
+	}
+
+	repo := NewPermissionRepositoryPostgres(db)
+	if _, _, err := repo.GetAllPermissions(context.Background(), 1, 10, "", "read"); err != nil {
+		t.Fatalf("GetAllPermissions: %v", err)
+	}
+	if q := fake.queries[0]; !strings.Contains(q.query, "WHERE action = $1") || strings.Contains(q.query, "resource =") {
+		t.Fatalf("unexpected count query: %s", q.query)
+	}
+}
+
+func TestGetPermissionByName_FoundAndMissing(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		res := &fakeRowsResult{columns: []string{"id", "name", "resource", "action", "description"}}
+		if args[0] == "achievement:read" {
+			res.rows = [][]driver.Value{{"p1", "achievement:read", "achievement", "read", "Read achievement"}}
+		}
+		return res, nil
+	}
+
+	repo := NewPermissionRepositoryPostgres(db)
+	perm, err := repo.GetPermissionByName(context.Background(), " achievement:read ")
+	if err != nil || perm == nil || perm.ID != "p1" {
+		t.Fatalf("expected permission p1, got perm=%+v err=%v", perm, err)
+	}
+	if q := fake.queries[0]; !strings.Contains(q.query, "LOWER(name) = LOWER($1)") {
+		t.Fatalf("unexpected query: %s", q.query)
+	}
+
+	perm, err = repo.GetPermissionByName(context.Background(), "achievement:unknown")
+	if err != nil || perm != nil {
+		t.Fatalf("expected nil, nil for missing permission, got perm=%+v err=%v", perm, err)
+	}
+}
+
+func TestCountPermissionUsage(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		return &fakeRowsResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(3)}}}, nil
+	}
+
+	repo := NewPermissionRepositoryPostgres(db)
+	n, err := repo.CountPermissionUsage(context.Background(), "p1")
+	if err != nil || n != 3 {
+		t.Fatalf("expected 3 usages, got n=%d err=%v", n, err)
+	}
+	if q := fake.queries[0]; !strings.Contains(q.query, "FROM role_permissions WHERE permission_id = $1") || q.args[0] != "p1" {
+		t.Fatalf("unexpected query: %s %v", q.query, q.args)
+	}
+}
+
+func TestDeletePermission_ForceRemovesMappingsFirst(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	var execs []string
+	fake.execFn = func(query string, args []driver.Value) (int64, error) {
+		execs = append(execs, query)
+		return 1, nil
+	}
+
+	repo := NewPermissionRepositoryPostgres(db)
+	if err := repo.DeletePermission(context.Background(), "p1", true); err != nil {
+		t.Fatalf("DeletePermission: %v", err)
+	}
+	if len(execs) != 2 || !strings.Contains(execs[0], "DELETE FROM role_permissions WHERE permission_id = $1") || !strings.Contains(execs[1], "DELETE FROM permissions") {
+		t.Fatalf("unexpected statements: %v", execs)
+	}
+	if fake.commits != 1 || fake.rollbacks != 0 {
+		t.Fatalf("expected single commit, got commits=%d rollbacks=%d", fake.commits, fake.rollbacks)
+	}
+}
+
+func TestDeletePermission_NotFoundRollsBackMappings(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.execFn = func(query string, args []driver.Value) (int64, error) {
+		if strings.Contains(query, "DELETE FROM permissions") {
+			return 0, nil
+		}
+		return 1, nil
+	}
+
+	repo := NewPermissionRepositoryPostgres(db)
+	if err := repo.DeletePermission(context.Background(), "missing", true); err == nil || err.Error() != "permission tidak ditemukan" {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if fake.commits != 0 || fake.rollbacks != 1 {
+		t.Fatalf("expected rollback, got commits=%d rollbacks=%d", fake.commits, fake.rollbacks)
+	}
+}
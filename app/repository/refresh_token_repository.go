@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hello-fiber/app/model"
+	"time"
+)
+
+// ErrRefreshTokenReused dikembalikan Rotate saat token lama ternyata sudah dipakai atau dicabut.
+var ErrRefreshTokenReused = errors.New("refresh token sudah digunakan")
+
+type RefreshTokenRepository interface {
+	Create(token model.RefreshToken) error
+	GetByHash(tokenHash string) (*model.RefreshToken, error)
+	Rotate(usedID string, next model.RefreshToken) error
+	RevokeFamily(familyID string) error
+}
+
+type RefreshTokenRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewRefreshTokenRepositoryPostgres(db *sql.DB) *RefreshTokenRepositoryPostgres {
+	return &RefreshTokenRepositoryPostgres{db: db}
+}
+
+func (r *RefreshTokenRepositoryPostgres) Create(token model.RefreshToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+	`, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan refresh token: %w", err)
+	}
+	return nil
+}
+
+// GetByHash mengembalikan nil, nil jika hash tidak terdaftar.
+func (r *RefreshTokenRepositoryPostgres) GetByHash(tokenHash string) (*model.RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var t model.RefreshToken
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, user_id, family_id, token_hash, used, revoked, expires_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`, tokenHash).Scan(&t.ID, &t.UserID, &t.FamilyID, &t.TokenHash, &t.Used, &t.Revoked, &t.ExpiresAt, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal query refresh token: %w", err)
+	}
+	return &t, nil
+}
+
+// Rotate menandai token usedID sebagai used dan menyimpan token penggantinya dalam satu transaksi.
+// Update bersyarat "used = FALSE" membuat dua refresh paralel dengan token yang sama tidak bisa
+// sama-sama berhasil: yang kalah mendapat ErrRefreshTokenReused.
+func (r *RefreshTokenRepositoryPostgres) Rotate(usedID string, next model.RefreshToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE refresh_tokens SET used = TRUE
+		WHERE id = $1 AND used = FALSE AND revoked = FALSE
+	`, usedID)
+	if err != nil {
+		return fmt.Errorf("gagal menandai refresh token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("gagal cek rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrRefreshTokenReused
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+	`, next.UserID, next.FamilyID, next.TokenHash, next.ExpiresAt); err != nil {
+		return fmt.Errorf("gagal menyimpan refresh token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit rotasi refresh token: %w", err)
+	}
+	return nil
+}
+
+// RevokeFamily mencabut semua refresh token dalam family, termasuk token terbaru yang belum dipakai.
+func (r *RefreshTokenRepositoryPostgres) RevokeFamily(familyID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, "UPDATE refresh_tokens SET revoked = TRUE WHERE family_id = $1", familyID); err != nil {
+		return fmt.Errorf("gagal mencabut refresh token family: %w", err)
+	}
+	return nil
+}
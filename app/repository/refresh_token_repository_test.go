@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"errors"
+	"hello-fiber/app/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRefreshTokenRotate_MarksUsedAndInsertsNext(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	var execs []string
+	fake.execFn = func(query string, args []driver.Value) (int64, error) {
+		execs = append(execs, strings.TrimSpace(query))
+		return 1, nil
+	}
+
+	repo := NewRefreshTokenRepositoryPostgres(db)
+	next := model.RefreshToken{UserID: "u1", FamilyID: "f1", TokenHash: "h2", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := repo.Rotate("t1", next); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if len(execs) != 2 ||
+		!strings.Contains(execs[0], "used = FALSE AND revoked = FALSE") ||
+		!strings.HasPrefix(execs[1], "INSERT INTO refresh_tokens") {
+		t.Fatalf("unexpected statements: %v", execs)
+	}
+	if fake.commits != 1 || fake.rollbacks != 0 {
+		t.Fatalf("expected single commit, got commits=%d rollbacks=%d", fake.commits, fake.rollbacks)
+	}
+}
+
+func TestRefreshTokenRotate_AlreadyUsedReturnsReused(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	var execs int
+	fake.execFn = func(query string, args []driver.Value) (int64, error) {
+		execs++
+		return 0, nil
+	}
+
+	repo := NewRefreshTokenRepositoryPostgres(db)
+	err := repo.Rotate("t1", model.RefreshToken{UserID: "u1", FamilyID: "f1", TokenHash: "h2"})
+	if !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("expected ErrRefreshTokenReused, got %v", err)
+	}
+	if execs != 1 {
+		t.Fatalf("next token must not be inserted, got %d statements", execs)
+	}
+	if fake.commits != 0 || fake.rollbacks != 1 {
+		t.Fatalf("expected rollback, got commits=%d rollbacks=%d", fake.commits, fake.rollbacks)
+	}
+}
+
+func TestRefreshTokenRevokeFamily(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	var query string
+	var args []driver.Value
+	fake.execFn = func(q string, a []driver.Value) (int64, error) {
+		query, args = q, a
+		return 3, nil
+	}
+
+	repo := NewRefreshTokenRepositoryPostgres(db)
+	if err := repo.RevokeFamily("f1"); err != nil {
+		t.Fatalf("RevokeFamily: %v", err)
+	}
+	if !strings.Contains(query, "SET revoked = TRUE WHERE family_id = $1") || args[0] != "f1" {
+		t.Fatalf("unexpected statement: %s %v", query, args)
+	}
+}
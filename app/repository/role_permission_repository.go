@@ -11,12 +11,13 @@ import (
 )
 
 type RolePermissionRepository interface {
-	GetAllRolePermissions(page, limit int64, roleID, permissionID string) ([]model.RolePermission, int64, error)
-	GetRolePermission(roleID, permissionID string) (*model.RolePermission, error)
-	GetPermissionsByRoleID(roleID string) ([]model.Permission, error)
-	CreateRolePermission(roleID, permissionID string) error
-	UpdateRolePermission(oldRoleID, oldPermissionID, newRoleID, newPermissionID string) error
-	DeleteRolePermission(roleID, permissionID string) error
+	GetAllRolePermissions(ctx context.Context, page, limit int64, roleID, permissionID string) ([]model.RolePermission, int64, error)
+	GetRolePermission(ctx context.Context, roleID, permissionID string) (*model.RolePermission, error)
+	GetPermissionsByRoleID(ctx context.Context, roleID string) ([]model.Permission, error)
+	ListPermissionsByRoleID(ctx context.Context, roleID string, page, limit int64) ([]model.Permission, int64, error)
+	CreateRolePermission(ctx context.Context, roleID, permissionID string) error
+	UpdateRolePermission(ctx context.Context, oldRoleID, oldPermissionID, newRoleID, newPermissionID string) error
+	DeleteRolePermission(ctx context.Context, roleID, permissionID string) error
 }
 
 type RolePermissionRepositoryPostgres struct {
@@ -27,8 +28,8 @@ func NewRolePermissionRepositoryPostgres(db *sql.DB) *RolePermissionRepositoryPo
 	return &RolePermissionRepositoryPostgres{db: db}
 }
 
-func (r *RolePermissionRepositoryPostgres) GetAllRolePermissions(page, limit int64, roleID, permissionID string) ([]model.RolePermission, int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RolePermissionRepositoryPostgres) GetAllRolePermissions(ctx context.Context, page, limit int64, roleID, permissionID string) ([]model.RolePermission, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	if page < 1 {
@@ -101,8 +102,8 @@ func (r *RolePermissionRepositoryPostgres) GetAllRolePermissions(page, limit int
 	return out, total, nil
 }
 
-func (r *RolePermissionRepositoryPostgres) GetRolePermission(roleID, permissionID string) (*model.RolePermission, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RolePermissionRepositoryPostgres) GetRolePermission(ctx context.Context, roleID, permissionID string) (*model.RolePermission, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -122,8 +123,8 @@ func (r *RolePermissionRepositoryPostgres) GetRolePermission(roleID, permissionI
 	return &rp, nil
 }
 
-func (r *RolePermissionRepositoryPostgres) GetPermissionsByRoleID(roleID string) ([]model.Permission, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RolePermissionRepositoryPostgres) GetPermissionsByRoleID(ctx context.Context, roleID string) ([]model.Permission, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -155,8 +156,56 @@ func (r *RolePermissionRepositoryPostgres) GetPermissionsByRoleID(roleID string)
 	return out, nil
 }
 
-func (r *RolePermissionRepositoryPostgres) CreateRolePermission(roleID, permissionID string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// ListPermissionsByRoleID versi berhalaman GetPermissionsByRoleID untuk endpoint list; cek RBAC tetap
+// memakai GetPermissionsByRoleID karena butuh semua permission. Role tanpa permission menghasilkan slice kosong.
+func (r *RolePermissionRepositoryPostgres) ListPermissionsByRoleID(ctx context.Context, roleID string, page, limit int64) ([]model.Permission, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM role_permissions WHERE role_id = $1`, roleID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("gagal count permissions by role: %w", err)
+	}
+
+	query := `
+		SELECT p.id, p.name, p.resource, p.action, p.description
+		FROM role_permissions rp
+		JOIN permissions p ON p.id = rp.permission_id
+		WHERE rp.role_id = $1
+		ORDER BY p.name ASC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, roleID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal query permissions by role: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]model.Permission, 0)
+	for rows.Next() {
+		var p model.Permission
+		if err := rows.Scan(&p.ID, &p.Name, &p.Resource, &p.Action, &p.Description); err != nil {
+			return nil, 0, fmt.Errorf("gagal scan permission: %w", err)
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterasi permissions: %w", err)
+	}
+
+	return out, total, nil
+}
+
+func (r *RolePermissionRepositoryPostgres) CreateRolePermission(ctx context.Context, roleID, permissionID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `INSERT INTO role_permissions (role_id, permission_id) VALUES ($1, $2)`
@@ -174,8 +223,8 @@ func (r *RolePermissionRepositoryPostgres) CreateRolePermission(roleID, permissi
 	return nil
 }
 
-func (r *RolePermissionRepositoryPostgres) UpdateRolePermission(oldRoleID, oldPermissionID, newRoleID, newPermissionID string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RolePermissionRepositoryPostgres) UpdateRolePermission(ctx context.Context, oldRoleID, oldPermissionID, newRoleID, newPermissionID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -203,8 +252,8 @@ func (r *RolePermissionRepositoryPostgres) UpdateRolePermission(oldRoleID, oldPe
 	return nil
 }
 
-func (r *RolePermissionRepositoryPostgres) DeleteRolePermission(roleID, permissionID string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RolePermissionRepositoryPostgres) DeleteRolePermission(ctx context.Context, roleID, permissionID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	res, err := r.db.ExecContext(ctx, `DELETE FROM role_permissions WHERE role_id = $1 AND permission_id = $2`, roleID, permissionID)
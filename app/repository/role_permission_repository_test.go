@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+func TestListPermissionsByRoleID_LimitOffset(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		if strings.Contains(query, "COUNT(*)") {
+			return &fakeRowsResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(0)}}}, nil
+		}
+		return &fakeRowsResult{columns: []string{"id", "name", "resource", "action", "description"}}, nil
+	}
+
+	repo := NewRolePermissionRepositoryPostgres(db)
+	perms, total, err := repo.ListPermissionsByRoleID(context.Background(), "r1", 3, 20)
+	if err != nil {
+		t.Fatalf("ListPermissionsByRoleID: %v", err)
+	}
+	if perms == nil || len(perms) != 0 || total != 0 {
+		t.Fatalf("expected empty non-nil slice, got %#v total=%d", perms, total)
+	}
+	if len(fake.queries) != 2 {
+		t.Fatalf("expected count + list queries, got %d", len(fake.queries))
+	}
+	list := fake.queries[1]
+	if !strings.Contains(list.query, "LIMIT $2 OFFSET $3") {
+		t.Fatalf("missing LIMIT/OFFSET: %s", list.query)
+	}
+	if list.args[0] != "r1" || list.args[1] != int64(20) || list.args[2] != int64(40) {
+		t.Fatalf("unexpected args: %v", list.args)
+	}
+}
@@ -12,12 +12,14 @@ import (
 )
 
 type RoleRepository interface {
-	GetAllRoles(page, limit int64) ([]model.Role, int64, error)
-	GetRoleByID(id string) (*model.Role, error)
-	GetRoleByName(name string) (*model.Role, error)
-	CreateRole(req model.CreateRoleRequest) (string, error)
-	UpdateRole(id string, req model.UpdateRoleRequest) error
-	DeleteRole(id string) error
+	GetAllRoles(ctx context.Context, page, limit int64) ([]model.Role, int64, error)
+	SearchRoles(ctx context.Context, q string, page, limit int64) ([]model.Role, int64, error)
+	GetRoleByID(ctx context.Context, id string) (*model.Role, error)
+	GetRoleByName(ctx context.Context, name string) (*model.Role, error)
+	CreateRole(ctx context.Context, req model.CreateRoleRequest) (string, error)
+	UpdateRole(ctx context.Context, id string, req model.UpdateRoleRequest) error
+	DeleteRole(ctx context.Context, id string, force bool) error
+	CountRoleUsage(ctx context.Context, id string) (users int64, rolePermissions int64, err error)
 }
 
 type RoleRepositoryPostgres struct {
@@ -28,8 +30,8 @@ func NewRoleRepositoryPostgres(db *sql.DB) *RoleRepositoryPostgres {
 	return &RoleRepositoryPostgres{db: db}
 }
 
-func (r *RoleRepositoryPostgres) GetAllRoles(page, limit int64) ([]model.Role, int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RoleRepositoryPostgres) GetAllRoles(ctx context.Context, page, limit int64) ([]model.Role, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// total
@@ -52,13 +54,49 @@ func (r *RoleRepositoryPostgres) GetAllRoles(page, limit int64) ([]model.Role, i
 	}
 	defer rows.Close()
 
+	roles, err := scanRoles(rows)
+	return roles, total, err
+}
+
+// SearchRoles mencari role dengan ILIKE pada name atau description (wildcard dari user di-escape).
+func (r *RoleRepositoryPostgres) SearchRoles(ctx context.Context, q string, page, limit int64) ([]model.Role, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	pattern := likePattern(q)
+	where := `WHERE name ILIKE $1 OR description ILIKE $1`
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM roles `+where, pattern).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("gagal count hasil pencarian roles: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	query := `
+		SELECT id, name, description, created_at
+		FROM roles
+		` + where + `
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, pattern, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal mencari roles: %w", err)
+	}
+	defer rows.Close()
+
+	roles, err := scanRoles(rows)
+	return roles, total, err
+}
+
+func scanRoles(rows *sql.Rows) ([]model.Role, error) {
 	roles := make([]model.Role, 0)
 	for rows.Next() {
 		var role model.Role
 		var desc sql.NullString
 
 		if err := rows.Scan(&role.ID, &role.Name, &desc, &role.CreatedAt); err != nil {
-			return nil, 0, fmt.Errorf("gagal scan role: %w", err)
+			return nil, fmt.Errorf("gagal scan role: %w", err)
 		}
 
 		role.Description = ""
@@ -68,12 +106,11 @@ func (r *RoleRepositoryPostgres) GetAllRoles(page, limit int64) ([]model.Role, i
 
 		roles = append(roles, role)
 	}
-
-	return roles, total, rows.Err()
+	return roles, rows.Err()
 }
 
-func (r *RoleRepositoryPostgres) GetRoleByID(id string) (*model.Role, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RoleRepositoryPostgres) GetRoleByID(ctx context.Context, id string) (*model.Role, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -101,8 +138,8 @@ func (r *RoleRepositoryPostgres) GetRoleByID(id string) (*model.Role, error) {
 	return &role, nil
 }
 
-func (r *RoleRepositoryPostgres) GetRoleByName(name string) (*model.Role, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RoleRepositoryPostgres) GetRoleByName(ctx context.Context, name string) (*model.Role, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -130,8 +167,8 @@ func (r *RoleRepositoryPostgres) GetRoleByName(name string) (*model.Role, error)
 	return &role, nil
 }
 
-func (r *RoleRepositoryPostgres) CreateRole(req model.CreateRoleRequest) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RoleRepositoryPostgres) CreateRole(ctx context.Context, req model.CreateRoleRequest) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	name := strings.TrimSpace(req.Name)
@@ -159,8 +196,8 @@ func (r *RoleRepositoryPostgres) CreateRole(req model.CreateRoleRequest) (string
 	return roleID, nil
 }
 
-func (r *RoleRepositoryPostgres) UpdateRole(id string, req model.UpdateRoleRequest) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RoleRepositoryPostgres) UpdateRole(ctx context.Context, id string, req model.UpdateRoleRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	updates := []string{}
@@ -207,11 +244,28 @@ func (r *RoleRepositoryPostgres) UpdateRole(id string, req model.UpdateRoleReque
 	return nil
 }
 
-func (r *RoleRepositoryPostgres) DeleteRole(id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// DeleteRole menghapus role. Dengan force=true, mapping role_permissions dihapus dan role_id user
+// dikosongkan lebih dulu dalam satu transaksi agar tidak ada foreign key yang menggantung.
+func (r *RoleRepositoryPostgres) DeleteRole(ctx context.Context, id string, force bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctx, "DELETE FROM roles WHERE id = $1", id)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	if force {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM role_permissions WHERE role_id = $1", id); err != nil {
+			return fmt.Errorf("gagal hapus role_permissions: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE users SET role_id = NULL, updated_at = NOW() WHERE role_id = $1", id); err != nil {
+			return fmt.Errorf("gagal lepas role dari users: %w", err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM roles WHERE id = $1", id)
 	if err != nil {
 		return fmt.Errorf("gagal delete role: %w", err)
 	}
@@ -225,5 +279,24 @@ func (r *RoleRepositoryPostgres) DeleteRole(id string) error {
 		return errors.New("role tidak ditemukan")
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit delete role: %w", err)
+	}
 	return nil
 }
+
+// CountRoleUsage menghitung user dan role_permissions yang masih mereferensikan role.
+func (r *RoleRepositoryPostgres) CountRoleUsage(ctx context.Context, id string) (users int64, rolePermissions int64, err error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err = r.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM users WHERE role_id = $1),
+			(SELECT COUNT(*) FROM role_permissions WHERE role_id = $1)
+	`, id).Scan(&users, &rolePermissions)
+	if err != nil {
+		return 0, 0, fmt.Errorf("gagal cek pemakaian role: %w", err)
+	}
+	return users, rolePermissions, nil
+}
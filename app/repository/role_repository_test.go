@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCountRoleUsage(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		return &fakeRowsResult{columns: []string{"users", "role_permissions"}, rows: [][]driver.Value{{int64(4), int64(0)}}}, nil
+	}
+
+	repo := NewRoleRepositoryPostgres(db)
+	users, mappings, err := repo.CountRoleUsage(context.Background(), "r1")
+	if err != nil {
+		t.Fatalf("CountRoleUsage: %v", err)
+	}
+	if users != 4 || mappings != 0 {
+		t.Fatalf("unexpected usage: users=%d mappings=%d", users, mappings)
+	}
+	q := fake.queries[0]
+	if !strings.Contains(q.query, "FROM users WHERE role_id = $1") || !strings.Contains(q.query, "FROM role_permissions WHERE role_id = $1") || q.args[0] != "r1" {
+		t.Fatalf("unexpected query: %s %v", q.query, q.args)
+	}
+}
+
+func TestDeleteRole_WithoutForceOnlyDeletesRole(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	var execs []string
+	fake.execFn = func(query string, args []driver.Value) (int64, error) {
+		execs = append(execs, query)
+		return 1, nil
+	}
+
+	repo := NewRoleRepositoryPostgres(db)
+	if err := repo.DeleteRole(context.Background(), "r1", false); err != nil {
+		t.Fatalf("DeleteRole: %v", err)
+	}
+	if len(execs) != 1 || !strings.Contains(execs[0], "DELETE FROM roles") {
+		t.Fatalf("unexpected statements: %v", execs)
+	}
+	if fake.commits != 1 {
+		t.Fatalf("expected commit, got %d", fake.commits)
+	}
+}
+
+func TestDeleteRole_ForceClearsMappingsInTransaction(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	var execs []string
+	fake.execFn = func(query string, args []driver.Value) (int64, error) {
+		execs = append(execs, strings.TrimSpace(query))
+		if args[0] != "r1" {
+			t.Fatalf("unexpected args: %v", args)
+		}
+		return 2, nil
+	}
+
+	repo := NewRoleRepositoryPostgres(db)
+	if err := repo.DeleteRole(context.Background(), "r1", true); err != nil {
+		t.Fatalf("DeleteRole: %v", err)
+	}
+	if len(execs) != 3 ||
+		!strings.HasPrefix(execs[0], "DELETE FROM role_permissions") ||
+		!strings.HasPrefix(execs[1], "UPDATE users SET role_id = NULL") ||
+		!strings.HasPrefix(execs[2], "DELETE FROM roles") {
+		t.Fatalf("unexpected statement order: %v", execs)
+	}
+	if fake.commits != 1 || fake.rollbacks != 0 {
+		t.Fatalf("expected single commit, got commits=%d rollbacks=%d", fake.commits, fake.rollbacks)
+	}
+}
+
+func TestDeleteRole_NotFoundRollsBack(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.execFn = func(query string, args []driver.Value) (int64, error) {
+		if strings.Contains(query, "DELETE FROM roles") {
+			return 0, nil
+		}
+		return 1, nil
+	}
+
+	repo := NewRoleRepositoryPostgres(db)
+	if err := repo.DeleteRole(context.Background(), "missing", true); err == nil || err.Error() != "role tidak ditemukan" {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if fake.commits != 0 || fake.rollbacks != 1 {
+		t.Fatalf("expected rollback, got commits=%d rollbacks=%d", fake.commits, fake.rollbacks)
+	}
+}
+
+func TestSearchRoles_EscapedILikePattern(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		if strings.Contains(query, "COUNT(*)") {
+			return &fakeRowsResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(1)}}}, nil
+		}
+		return &fakeRowsResult{columns: []string{"id", "name", "description", "created_at"}, rows: [][]driver.Value{{"r1", "Dosen Wali", nil, time.Now()}}}, nil
+	}
+
+	repo := NewRoleRepositoryPostgres(db)
+	roles, total, err := repo.SearchRoles(context.Background(), "  wa_li ", 2, 5)
+	if err != nil {
+		t.Fatalf("SearchRoles: %v", err)
+	}
+	if total != 1 || len(roles) != 1 || roles[0].Name != "Dosen Wali" {
+		t.Fatalf("unexpected result: total=%d roles=%+v", total, roles)
+	}
+	if len(fake.queries) != 2 {
+		t.Fatalf("expected count + select, got %d queries", len(fake.queries))
+	}
+	for _, q := range fake.queries {
+		if !strings.Contains(q.query, "name ILIKE $1 OR description ILIKE $1") {
+			t.Fatalf("missing ILIKE predicate: %s", q.query)
+		}
+		if q.args[0] != `%wa\_li%` {
+			t.Fatalf("unexpected pattern: %v", q.args[0])
+		}
+	}
+	if args := fake.queries[1].args; args[1] != int64(5) || args[2] != int64(5) {
+		t.Fatalf("unexpected limit/offset: %v", args)
+	}
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hello-fiber/app/model"
+	"strings"
+	"time"
+)
+
+type SearchRepository interface {
+	SearchUsers(q string, page, limit int64) ([]model.User, int64, error)
+	SearchStudents(q string, page, limit int64) ([]model.StudentSearchItem, int64, error)
+	SearchLecturers(q string, page, limit int64) ([]model.LecturerSearchItem, int64, error)
+}
+
+type SearchRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewSearchRepositoryPostgres(db *sql.DB) *SearchRepositoryPostgres {
+	return &SearchRepositoryPostgres{db: db}
+}
+
+// likePattern membungkus keyword menjadi pola ILIKE dan meng-escape wildcard bawaan user.
+func likePattern(q string) string {
+	q = strings.TrimSpace(q)
+	q = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(q)
+	return "%" + q + "%"
+}
+
+func (r *SearchRepositoryPostgres) SearchUsers(q string, page, limit int64) ([]model.User, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pattern := likePattern(q)
+	where := `WHERE username ILIKE $1 OR email ILIKE $1 OR full_name ILIKE $1`
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users `+where, pattern).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("gagal count hasil pencarian users: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	query := `
+		SELECT id, username, email, full_name, role_id, is_active, created_at, updated_at
+		FROM users
+		` + where + `
+		ORDER BY full_name ASC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, pattern, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal mencari users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []model.User
+	for rows.Next() {
+		var u model.User
+		var roleID sql.NullString
+		if err := rows.Scan(&u.ID, &u.Username, &u.Email, &u.FullName, &roleID, &u.IsActive, &u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, 0, fmt.Errorf("gagal scan user: %w", err)
+		}
+		if roleID.Valid {
+			u.RoleID = roleID.String
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterasi users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+func (r *SearchRepositoryPostgres) SearchStudents(q string, page, limit int64) ([]model.StudentSearchItem, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pattern := likePattern(q)
+	from := `
+		FROM students s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.student_id ILIKE $1 OR u.full_name ILIKE $1
+	`
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) `+from, pattern).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("gagal count hasil pencarian students: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	query := `
+		SELECT s.id, s.user_id, s.student_id, s.program_study, s.academic_year, s.advisor_id, s.created_at, u.full_name
+		` + from + `
+		ORDER BY u.full_name ASC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, pattern, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal mencari students: %w", err)
+	}
+	defer rows.Close()
+
+	var students []model.StudentSearchItem
+	for rows.Next() {
+		var s model.StudentSearchItem
+		if err := rows.Scan(&s.ID, &s.UserID, &s.StudentID, &s.ProgramStudy, &s.AcademicYear, &s.AdvisorID, &s.CreatedAt, &s.FullName); err != nil {
+			return nil, 0, fmt.Errorf("gagal scan student: %w", err)
+		}
+		students = append(students, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterasi students: %w", err)
+	}
+
+	return students, total, nil
+}
+
+func (r *SearchRepositoryPostgres) SearchLecturers(q string, page, limit int64) ([]model.LecturerSearchItem, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pattern := likePattern(q)
+	from := `
+		FROM lecturers l
+		JOIN users u ON u.id = l.user_id
+		WHERE l.lecturer_id ILIKE $1 OR l.department ILIKE $1 OR u.full_name ILIKE $1
+	`
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) `+from, pattern).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("gagal count hasil pencarian lecturers: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	query := `
+		SELECT l.id, l.user_id, l.lecturer_id, l.department, l.created_at, u.full_name
+		` + from + `
+		ORDER BY u.full_name ASC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, pattern, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal mencari lecturers: %w", err)
+	}
+	defer rows.Close()
+
+	var lecturers []model.LecturerSearchItem
+	for rows.Next() {
+		var l model.LecturerSearchItem
+		if err := rows.Scan(&l.ID, &l.UserID, &l.LecturerID, &l.Department, &l.CreatedAt, &l.FullName); err != nil {
+			return nil, 0, fmt.Errorf("gagal scan lecturer: %w", err)
+		}
+		lecturers = append(lecturers, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterasi lecturers: %w", err)
+	}
+
+	return lecturers, total, nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"hello-fiber/utils"
+)
+
+// SeedAdmin kredensial user admin bootstrap; dilewati jika Email atau Password kosong.
+type SeedAdmin struct {
+	Username string
+	Email    string
+	Password string
+	FullName string
+}
+
+// SeedResult jumlah baris yang benar-benar dibuat; baris yang sudah ada tidak dihitung.
+type SeedResult struct {
+	RolesCreated       int
+	PermissionsCreated int
+	MappingsCreated    int
+	AdminCreated       bool
+}
+
+type seedPermission struct {
+	name, resource, action, description string
+}
+
+var seedRoles = []struct {
+	name, description string
+}{
+	{"Admin", "Administrator sistem"},
+	{"Mahasiswa", "Mahasiswa pemilik prestasi"},
+	{"Dosen Wali", "Dosen wali yang memverifikasi prestasi mahasiswa bimbingan"},
+	{"Staff", "Staff akademik (lihat prestasi terverifikasi/ditolak)"},
+}
+
+var seedPermissions = []seedPermission{
+	{"user:manage", "user", "manage", "Kelola user, role, permission, student, dan lecturer"},
+	{"achievement:read", "achievement", "read", "Melihat achievement sesuai scope role"},
+	{"achievement:create", "achievement", "create", "Membuat draft achievement"},
+	{"achievement:update", "achievement", "update", "Mengubah dan submit achievement sendiri"},
+	{"achievement:delete", "achievement", "delete", "Menghapus draft achievement sendiri"},
+	{"achievement:verify", "achievement", "verify", "Memverifikasi/menolak achievement"},
+}
+
+// seedRolePermissions pemetaan role -> permission default; Admin mendapat semua permission.
+var seedRolePermissions = map[string][]string{
+	"Mahasiswa":  {"achievement:read", "achievement:create", "achievement:update", "achievement:delete"},
+	"Dosen Wali": {"achievement:read", "achievement:verify"},
+	"Staff":      {"achievement:read"},
+}
+
+// Seed membuat role, permission, mapping role_permissions default, dan admin bootstrap secara idempoten:
+// setiap baris dicek dulu dan hanya dibuat jika belum ada, sehingga aman dijalankan berulang kali.
+// Semua perubahan dilakukan dalam satu transaksi.
+func Seed(db *sql.DB, admin *SeedAdmin) (*SeedResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gagal memulai transaksi seed: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &SeedResult{}
+
+	roleIDs := make(map[string]string, len(seedRoles))
+	for _, role := range seedRoles {
+		id, created, err := seedRow(ctx, tx,
+			`SELECT id FROM roles WHERE LOWER(name) = LOWER($1)`, []interface{}{role.name},
+			`INSERT INTO roles (id, name, description, created_at) VALUES (gen_random_uuid(), $1, $2, NOW()) RETURNING id`,
+			[]interface{}{role.name, role.description})
+		if err != nil {
+			return nil, fmt.Errorf("gagal seed role %s: %w", role.name, err)
+		}
+		if created {
+			result.RolesCreated++
+		}
+		roleIDs[role.name] = id
+	}
+
+	permIDs := make(map[string]string, len(seedPermissions))
+	allPerms := make([]string, 0, len(seedPermissions))
+	for _, perm := range seedPermissions {
+		id, created, err := seedRow(ctx, tx,
+			`SELECT id FROM permissions WHERE name = $1`, []interface{}{perm.name},
+			`INSERT INTO permissions (id, name, resource, action, description) VALUES (gen_random_uuid(), $1, $2, $3, $4) RETURNING id`,
+			[]interface{}{perm.name, perm.resource, perm.action, perm.description})
+		if err != nil {
+			return nil, fmt.Errorf("gagal seed permission %s: %w", perm.name, err)
+		}
+		if created {
+			result.PermissionsCreated++
+		}
+		permIDs[perm.name] = id
+		allPerms = append(allPerms, perm.name)
+	}
+
+	for _, role := range seedRoles {
+		perms := seedRolePermissions[role.name]
+		if role.name == "Admin" {
+			perms = allPerms
+		}
+		for _, permName := range perms {
+			created, err := seedRolePermission(ctx, tx, roleIDs[role.name], permIDs[permName])
+			if err != nil {
+				return nil, fmt.Errorf("gagal seed role_permission %s -> %s: %w", role.name, permName, err)
+			}
+			if created {
+				result.MappingsCreated++
+			}
+		}
+	}
+
+	if admin != nil && strings.TrimSpace(admin.Email) != "" && admin.Password != "" {
+		created, err := seedAdminUser(ctx, tx, *admin, roleIDs["Admin"])
+		if err != nil {
+			return nil, fmt.Errorf("gagal seed admin: %w", err)
+		}
+		result.AdminCreated = created
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("gagal commit seed: %w", err)
+	}
+	return result, nil
+}
+
+// seedRow mengembalikan id baris hasil selectQuery, atau menjalankan insertQuery (RETURNING id) jika belum ada.
+func seedRow(ctx context.Context, tx *sql.Tx, selectQuery string, selectArgs []interface{}, insertQuery string, insertArgs []interface{}) (string, bool, error) {
+	var id string
+	err := tx.QueryRowContext(ctx, selectQuery, selectArgs...).Scan(&id)
+	if err == nil {
+		return id, false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", false, err
+	}
+	if err := tx.QueryRowContext(ctx, insertQuery, insertArgs...).Scan(&id); err != nil {
+		return "", false, err
+	}
+	return id, true, nil
+}
+
+func seedRolePermission(ctx context.Context, tx *sql.Tx, roleID, permissionID string) (bool, error) {
+	var exists bool
+	err := tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM role_permissions WHERE role_id = $1 AND permission_id = $2)`,
+		roleID, permissionID).Scan(&exists)
+	if err != nil || exists {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO role_permissions (role_id, permission_id) VALUES ($1, $2)`, roleID, permissionID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// seedAdminUser membuat admin bootstrap jika belum ada user dengan email/username yang sama.
+// User yang sudah ada tidak diubah (termasuk password dan role-nya).
+func seedAdminUser(ctx context.Context, tx *sql.Tx, admin SeedAdmin, roleID string) (bool, error) {
+	email := strings.ToLower(strings.TrimSpace(admin.Email))
+	username := strings.TrimSpace(admin.Username)
+	if username == "" {
+		username = "admin"
+	}
+	fullName := strings.TrimSpace(admin.FullName)
+	if fullName == "" {
+		fullName = "Administrator"
+	}
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM users WHERE LOWER(email) = $1 OR username = $2)`,
+		email, username).Scan(&exists); err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	hashed, err := utils.HashPassword(admin.Password)
+	if err != nil {
+		return false, fmt.Errorf("gagal hash password: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO users (id, username, email, password_hash, full_name, is_active, role_id, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, true, $5, NOW(), NOW())
+	`, username, email, hashed, fullName, roleID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// seedStore meniru isi tabel yang disentuh Seed agar query cek-lalu-insert bisa diuji berulang.
+type seedStore struct {
+	roles, permissions map[string]string
+	mappings           map[string]bool
+	users              map[string]bool
+	nextID             int
+}
+
+func newSeedStore() *seedStore {
+	return &seedStore{
+		roles:       map[string]string{},
+		permissions: map[string]string{},
+		mappings:    map[string]bool{},
+		users:       map[string]bool{},
+	}
+}
+
+func (s *seedStore) newID() string {
+	s.nextID++
+	return fmt.Sprintf("id-%d", s.nextID)
+}
+
+func (s *seedStore) query(query string, args []driver.Value) (*fakeRowsResult, error) {
+	idRow := func(id string, ok bool) *fakeRowsResult {
+		if !ok {
+			return &fakeRowsResult{columns: []string{"id"}}
+		}
+		return &fakeRowsResult{columns: []string{"id"}, rows: [][]driver.Value{{id}}}
+	}
+	existsRow := func(ok bool) *fakeRowsResult {
+		return &fakeRowsResult{columns: []string{"exists"}, rows: [][]driver.Value{{ok}}}
+	}
+	switch {
+	case strings.HasPrefix(query, "SELECT id FROM roles"):
+		id, ok := s.roles[strings.ToLower(args[0].(string))]
+		return idRow(id, ok), nil
+	case strings.HasPrefix(query, "INSERT INTO roles"):
+		id := s.newID()
+		s.roles[strings.ToLower(args[0].(string))] = id
+		return idRow(id, true), nil
+	case strings.HasPrefix(query, "SELECT id FROM permissions"):
+		id, ok := s.permissions[args[0].(string)]
+		return idRow(id, ok), nil
+	case strings.HasPrefix(query, "INSERT INTO permissions"):
+		id := s.newID()
+		s.permissions[args[0].(string)] = id
+		return idRow(id, true), nil
+	case strings.Contains(query, "FROM role_permissions"):
+		return existsRow(s.mappings[args[0].(string)+"/"+args[1].(string)]), nil
+	case strings.Contains(query, "FROM users"):
+		return existsRow(s.users[args[0].(string)] || s.users[args[1].(string)]), nil
+	}
+	return nil, fmt.Errorf("query tidak terduga: %s", query)
+}
+
+func (s *seedStore) exec(query string, args []driver.Value) (int64, error) {
+	switch {
+	case strings.HasPrefix(query, "INSERT INTO role_permissions"):
+		s.mappings[args[0].(string)+"/"+args[1].(string)] = true
+	case strings.Contains(query, "INSERT INTO users"):
+		s.users[args[0].(string)] = true
+		s.users[args[1].(string)] = true
+	default:
+		return 0, fmt.Errorf("exec tidak terduga: %s", query)
+	}
+	return 1, nil
+}
+
+func TestSeed_IsIdempotent(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	store := newSeedStore()
+	fake.queryFn = store.query
+	fake.execFn = store.exec
+
+	admin := &SeedAdmin{Username: "admin", Email: "Admin@Mail.com", Password: "Secret1"}
+
+	first, err := Seed(db, admin)
+	if err != nil {
+		t.Fatalf("first Seed: %v", err)
+	}
+	// Admin mendapat semua 6 permission; Mahasiswa 4, Dosen Wali 2, Staff 1
+	if first.RolesCreated != 4 || first.PermissionsCreated != 6 || first.MappingsCreated != 13 || !first.AdminCreated {
+		t.Fatalf("unexpected first result: %+v", first)
+	}
+	if len(store.users) != 2 || !store.users["admin@mail.com"] {
+		t.Fatalf("admin user not created with normalized email: %v", store.users)
+	}
+
+	rolesBefore, permsBefore, mappingsBefore := len(store.roles), len(store.permissions), len(store.mappings)
+	second, err := Seed(db, admin)
+	if err != nil {
+		t.Fatalf("second Seed: %v", err)
+	}
+	if *second != (SeedResult{}) {
+		t.Fatalf("re-running Seed must not create rows, got %+v", second)
+	}
+	if len(store.roles) != rolesBefore || len(store.permissions) != permsBefore || len(store.mappings) != mappingsBefore || len(store.users) != 2 {
+		t.Fatalf("rows duplicated on re-run")
+	}
+	if fake.commits != 2 {
+		t.Fatalf("expected each Seed to commit once, got %d", fake.commits)
+	}
+}
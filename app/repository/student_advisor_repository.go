@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hello-fiber/app/model"
+	"strings"
+	"time"
+)
+
+type StudentAdvisorRepository interface {
+	AddAdvisor(studentID, lecturerID string) error
+	RemoveAdvisor(studentID, lecturerID string) error
+	ListAdvisors(studentID string) ([]model.Lecturer, error)
+	IsAdvisor(studentID, lecturerID string) (bool, error)
+}
+
+type StudentAdvisorRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewStudentAdvisorRepositoryPostgres(db *sql.DB) *StudentAdvisorRepositoryPostgres {
+	return &StudentAdvisorRepositoryPostgres{db: db}
+}
+
+func (r *StudentAdvisorRepositoryPostgres) AddAdvisor(studentID, lecturerID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO student_advisors (student_id, lecturer_id, created_at) VALUES ($1, $2, NOW())`,
+		studentID, lecturerID,
+	)
+	if err != nil {
+		l := strings.ToLower(err.Error())
+		if strings.Contains(l, "duplicate key") || strings.Contains(l, "unique") {
+			return errors.New("advisor sudah terdaftar untuk student ini")
+		}
+		if strings.Contains(l, "student_advisors_student_id_fkey") {
+			return errors.New("student tidak ditemukan")
+		}
+		if strings.Contains(l, "student_advisors_lecturer_id_fkey") {
+			return errors.New("lecturer tidak ditemukan")
+		}
+		if strings.Contains(l, "foreign key") {
+			return errors.New("student atau lecturer tidak ditemukan")
+		}
+		return fmt.Errorf("gagal menambah advisor: %w", err)
+	}
+	return nil
+}
+
+func (r *StudentAdvisorRepositoryPostgres) RemoveAdvisor(studentID, lecturerID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM student_advisors WHERE student_id = $1 AND lecturer_id = $2`,
+		studentID, lecturerID,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menghapus advisor: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("gagal cek rows affected hapus advisor: %w", err)
+	}
+	if affected == 0 {
+		return errors.New("advisor tidak ditemukan untuk student ini")
+	}
+	return nil
+}
+
+// ListAdvisors mengembalikan advisor utama (students.advisor_id) dan co-advisor dari student_advisors.
+func (r *StudentAdvisorRepositoryPostgres) ListAdvisors(studentID string) ([]model.Lecturer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT l.id, l.user_id, l.lecturer_id, l.department, l.created_at
+		FROM lecturers l
+		WHERE l.id IN (
+			SELECT advisor_id FROM students WHERE id = $1 AND advisor_id IS NOT NULL
+			UNION
+			SELECT lecturer_id FROM student_advisors WHERE student_id = $1
+		)
+		ORDER BY l.created_at ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal query advisors: %w", err)
+	}
+	defer rows.Close()
+
+	var lecturers []model.Lecturer
+	for rows.Next() {
+		var l model.Lecturer
+		if err := rows.Scan(&l.ID, &l.UserID, &l.LecturerID, &l.Department, &l.CreatedAt); err != nil {
+			return nil, fmt.Errorf("gagal scan advisor: %w", err)
+		}
+		lecturers = append(lecturers, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterasi advisors: %w", err)
+	}
+	return lecturers, nil
+}
+
+func (r *StudentAdvisorRepositoryPostgres) IsAdvisor(studentID, lecturerID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM students WHERE id = $1 AND advisor_id = $2
+			UNION ALL
+			SELECT 1 FROM student_advisors WHERE student_id = $1 AND lecturer_id = $2
+		)
+	`, studentID, lecturerID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("gagal cek advisor: %w", err)
+	}
+	return exists, nil
+}
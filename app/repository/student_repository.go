@@ -14,12 +14,14 @@ import (
 )
 
 type StudentRepository interface {
-	GetAllStudents(page, limit int64) ([]model.Student, int64, error)
-	GetStudentByID(id string) (*model.Student, error)
-	GetStudentByUserID(userID string) (*model.Student, error)
-	CreateStudent(req model.CreateStudentRequest) (string, error)
-	UpdateStudent(id string, req model.UpdateStudentRequest) error
-	DeleteStudent(id string) error
+	GetAllStudents(ctx context.Context, page, limit int64) ([]model.Student, int64, error)
+	GetStudentByID(ctx context.Context, id string) (*model.Student, error)
+	GetStudentByUserID(ctx context.Context, userID string) (*model.Student, error)
+	CreateStudent(ctx context.Context, req model.CreateStudentRequest) (string, error)
+	UpdateStudent(ctx context.Context, id string, req model.UpdateStudentRequest) error
+	SetAdvisor(ctx context.Context, id string, advisorID *uuid.UUID) error
+	DeleteStudent(ctx context.Context, id string) error
+	GetStudentSummaries(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]model.StudentSummary, error)
 }
 
 type StudentRepositoryPostgres struct {
@@ -30,8 +32,8 @@ func NewStudentRepositoryPostgres(db *sql.DB) *StudentRepositoryPostgres {
 	return &StudentRepositoryPostgres{db: db}
 }
 
-func (r *StudentRepositoryPostgres) GetAllStudents(page, limit int64) ([]model.Student, int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *StudentRepositoryPostgres) GetAllStudents(ctx context.Context, page, limit int64) ([]model.Student, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var total int64
@@ -95,8 +97,8 @@ func (r *StudentRepositoryPostgres) GetAllStudents(page, limit int64) ([]model.S
 	return students, total, nil
 }
 
-func (r *StudentRepositoryPostgres) GetStudentByID(id string) (*model.Student, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *StudentRepositoryPostgres) GetStudentByID(ctx context.Context, id string) (*model.Student, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -143,8 +145,8 @@ func (r *StudentRepositoryPostgres) GetStudentByID(id string) (*model.Student, e
 	return &s, nil
 }
 
-func (r *StudentRepositoryPostgres) GetStudentByUserID(userID string) (*model.Student, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *StudentRepositoryPostgres) GetStudentByUserID(ctx context.Context, userID string) (*model.Student, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -192,8 +194,8 @@ func (r *StudentRepositoryPostgres) GetStudentByUserID(userID string) (*model.St
 	return &s, nil
 }
 
-func (r *StudentRepositoryPostgres) CreateStudent(req model.CreateStudentRequest) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *StudentRepositoryPostgres) CreateStudent(ctx context.Context, req model.CreateStudentRequest) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	req.StudentID = strings.TrimSpace(req.StudentID)
@@ -229,6 +231,9 @@ func (r *StudentRepositoryPostgres) CreateStudent(req model.CreateStudentRequest
 	if err != nil {
 		l := strings.ToLower(err.Error())
 
+		if strings.Contains(l, "idx_students_user_id_unique") {
+			return "", errors.New("user sudah terdaftar sebagai student")
+		}
 		if strings.Contains(l, "duplicate key") || strings.Contains(l, "unique") || strings.Contains(l, "student_id_key") {
 			return "", errors.New("student_id sudah digunakan")
 		}
@@ -248,8 +253,8 @@ func (r *StudentRepositoryPostgres) CreateStudent(req model.CreateStudentRequest
 	return id, nil
 }
 
-func (r *StudentRepositoryPostgres) UpdateStudent(id string, req model.UpdateStudentRequest) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *StudentRepositoryPostgres) UpdateStudent(ctx context.Context, id string, req model.UpdateStudentRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var updates []string
@@ -332,8 +337,37 @@ func (r *StudentRepositoryPostgres) UpdateStudent(id string, req model.UpdateStu
 	return nil
 }
 
-func (r *StudentRepositoryPostgres) DeleteStudent(id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// SetAdvisor mengisi advisor_id student; advisorID nil mengosongkan kolom (NULL).
+func (r *StudentRepositoryPostgres) SetAdvisor(ctx context.Context, id string, advisorID *uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var advArg interface{}
+	if advisorID != nil {
+		advArg = *advisorID
+	}
+
+	result, err := r.db.ExecContext(ctx, `UPDATE students SET advisor_id = $1 WHERE id = $2`, advArg, id)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "foreign key") {
+			return errors.New("advisor_id tidak valid")
+		}
+		return fmt.Errorf("gagal update advisor student: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("gagal cek rows affected: %w", err)
+	}
+	if affected == 0 {
+		return errors.New("student tidak ditemukan")
+	}
+
+	return nil
+}
+
+func (r *StudentRepositoryPostgres) DeleteStudent(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	result, err := r.db.ExecContext(ctx, `DELETE FROM students WHERE id = $1`, id)
@@ -351,3 +385,54 @@ func (r *StudentRepositoryPostgres) DeleteStudent(id string) error {
 
 	return nil
 }
+
+// GetStudentSummaries mengambil nama (users.full_name), NIM, program studi, dan nama advisor utama
+// untuk banyak student sekaligus dalam satu query. Id yang tidak ada tidak muncul di map.
+func (r *StudentRepositoryPostgres) GetStudentSummaries(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]model.StudentSummary, error) {
+	summaries := make(map[uuid.UUID]model.StudentSummary, len(ids))
+	if len(ids) == 0 {
+		return summaries, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	args := make([]interface{}, 0, len(ids))
+	placeholders := make([]string, 0, len(ids))
+	for i, id := range ids {
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+1))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			s.id,
+			s.student_id,
+			COALESCE(u.full_name, ''),
+			COALESCE(s.program_study, ''),
+			COALESCE(au.full_name, '')
+		FROM students s
+		LEFT JOIN users u ON u.id = s.user_id
+		LEFT JOIN lecturers l ON l.id = s.advisor_id
+		LEFT JOIN users au ON au.id = l.user_id
+		WHERE s.id IN (%s)
+	`, strings.Join(placeholders, ","))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("gagal query ringkasan student: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s model.StudentSummary
+		if err := rows.Scan(&s.ID, &s.StudentID, &s.FullName, &s.ProgramStudy, &s.AdvisorName); err != nil {
+			return nil, fmt.Errorf("gagal scan ringkasan student: %w", err)
+		}
+		summaries[s.ID] = s
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterasi ringkasan student: %w", err)
+	}
+	return summaries, nil
+}
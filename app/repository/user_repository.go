@@ -12,18 +12,27 @@ import (
 )
 
 type UserRepository interface {
-	Register(req model.RegisterRequest) (string, error)
-	Login(email, password string) (*model.User, error)
-	RefreshToken(userID string) (*model.User, error)
-	GetUserByEmail(email string) (*model.User, error)
-	GetUserByID(id string) (*model.User, error)
-	GetUserByUsername(username string) (*model.User, error)
-	GetAllUsers(page, limit int64) ([]model.User, int64, error)
-	GetUsersByRoleName(roleName string, page, limit int64) ([]model.User, int64, error)
-	CreateUser(req model.CreateUserRequest) (string, error)
-	UpdateUser(id string, req model.UpdateUserRequest) error
-	DeleteUser(id string) error
-	GetUserPermissions(userID string) ([]model.Permission, error)
+	Register(ctx context.Context, req model.RegisterRequest) (string, error)
+	Login(ctx context.Context, email, password string) (*model.User, error)
+	RefreshToken(ctx context.Context, userID string) (*model.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
+	GetUserByID(ctx context.Context, id string) (*model.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*model.User, error)
+	GetAllUsers(ctx context.Context, page, limit int64, filter model.UserFilter) ([]model.User, int64, error)
+	GetUsersByRoleName(ctx context.Context, roleName string, page, limit int64) ([]model.User, int64, error)
+	GetUsersWithoutRole(ctx context.Context, page, limit int64) ([]model.User, int64, error)
+	CountUsersByRoleName(ctx context.Context, roleName string) (int64, error)
+	CreateUser(ctx context.Context, req model.CreateUserRequest) (string, error)
+	UpdateUser(ctx context.Context, id string, req model.UpdateUserRequest) error
+	PatchUser(ctx context.Context, id string, req model.PatchUserRequest) error
+	DeleteUser(ctx context.Context, id string) error
+	GetUserPermissions(ctx context.Context, userID string) ([]model.Permission, error)
+	GetLockedUntil(ctx context.Context, email string) (*time.Time, error)
+	RecordFailedLogin(ctx context.Context, email string, maxAttempts int, lockFor time.Duration) error
+	ResetLoginAttempts(ctx context.Context, userID string) error
+	GetLockedUsers(ctx context.Context, page, limit int64) ([]model.LockedUser, int64, error)
+	IsUserDisabled(ctx context.Context, userID string) (bool, error)
+	SetUserDisabled(ctx context.Context, userID string, disabled bool) error
 }
 
 type UserRepositoryPostgres struct {
@@ -34,8 +43,8 @@ func NewUserRepositoryPostgres(db *sql.DB) *UserRepositoryPostgres {
 	return &UserRepositoryPostgres{db: db}
 }
 
-func (r *UserRepositoryPostgres) Register(req model.RegisterRequest) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *UserRepositoryPostgres) Register(ctx context.Context, req model.RegisterRequest) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	hashedPassword, err := utils.HashPassword(req.Password)
@@ -44,11 +53,17 @@ func (r *UserRepositoryPostgres) Register(req model.RegisterRequest) (string, er
 	}
 
 	query := `
-		INSERT INTO users (id, username, email, password_hash, full_name, is_active, created_at, updated_at)
-		VALUES (gen_random_uuid(), $1, $2, $3, $4, true, NOW(), NOW())
+		INSERT INTO users (id, username, email, password_hash, full_name, is_active, role_id, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, true, $5, NOW(), NOW())
 		RETURNING id
 	`
 
+	// role_id kosong => NULL
+	var roleArg interface{}
+	if req.RoleID != "" {
+		roleArg = req.RoleID
+	}
+
 	var userID string
 	err = r.db.QueryRowContext(
 		ctx,
@@ -57,6 +72,7 @@ func (r *UserRepositoryPostgres) Register(req model.RegisterRequest) (string, er
 		strings.ToLower(strings.TrimSpace(req.Email)),
 		hashedPassword,
 		req.FullName,
+		roleArg,
 	).Scan(&userID)
 
 	if err != nil {
@@ -69,8 +85,8 @@ func (r *UserRepositoryPostgres) Register(req model.RegisterRequest) (string, er
 	return userID, nil
 }
 
-func (r *UserRepositoryPostgres) Login(email, password string) (*model.User, error) {
-	user, err := r.GetUserByEmail(email)
+func (r *UserRepositoryPostgres) Login(ctx context.Context, email, password string) (*model.User, error) {
+	user, err := r.GetUserByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
@@ -82,9 +98,9 @@ func (r *UserRepositoryPostgres) Login(email, password string) (*model.User, err
 	return user, nil
 }
 
-func (r *UserRepositoryPostgres) RefreshToken(userID string) (*model.User, error) {
+func (r *UserRepositoryPostgres) RefreshToken(ctx context.Context, userID string) (*model.User, error) {
 	userRepo := NewUserRepositoryPostgres(r.db)
-	user, err := userRepo.GetUserByID(userID)
+	user, err := userRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -97,8 +113,12 @@ func (r *UserRepositoryPostgres) RefreshToken(userID string) (*model.User, error
 }
 
 
-func (r *UserRepositoryPostgres) GetUserByEmail(email string) (*model.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// ErrUserNotFound dikembalikan GetUserByEmail, GetUserByID, dan GetUserByUsername jika user tidak ada;
+// cek dengan errors.Is. Pesannya sengaja sama dengan pesan lama agar pencocokan string lama tetap jalan.
+var ErrUserNotFound = errors.New("user tidak ditemukan")
+
+func (r *UserRepositoryPostgres) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -123,7 +143,7 @@ func (r *UserRepositoryPostgres) GetUserByEmail(email string) (*model.User, erro
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("user tidak ditemukan")
+			return nil, ErrUserNotFound
 		}
 		return nil, fmt.Errorf("gagal query user: %w", err)
 	}
@@ -136,8 +156,8 @@ func (r *UserRepositoryPostgres) GetUserByEmail(email string) (*model.User, erro
 	return &user, nil
 }
 
-func (r *UserRepositoryPostgres) GetUserByID(id string) (*model.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *UserRepositoryPostgres) GetUserByID(ctx context.Context, id string) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -162,7 +182,7 @@ func (r *UserRepositoryPostgres) GetUserByID(id string) (*model.User, error) {
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("user tidak ditemukan")
+			return nil, ErrUserNotFound
 		}
 		return nil, fmt.Errorf("gagal query user: %w", err)
 	}
@@ -175,8 +195,8 @@ func (r *UserRepositoryPostgres) GetUserByID(id string) (*model.User, error) {
 	return &user, nil
 }
 
-func (r *UserRepositoryPostgres) GetUserByUsername(username string) (*model.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *UserRepositoryPostgres) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -201,7 +221,7 @@ func (r *UserRepositoryPostgres) GetUserByUsername(username string) (*model.User
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, nil
+			return nil, ErrUserNotFound
 		}
 		return nil, fmt.Errorf("gagal query user: %w", err)
 	}
@@ -214,25 +234,42 @@ func (r *UserRepositoryPostgres) GetUserByUsername(username string) (*model.User
 	return &user, nil
 }
 
-func (r *UserRepositoryPostgres) GetAllUsers(page, limit int64) ([]model.User, int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *UserRepositoryPostgres) GetAllUsers(ctx context.Context, page, limit int64, filter model.UserFilter) ([]model.User, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	var conds []string
+	args := []interface{}{}
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conds = append(conds, fmt.Sprintf("is_active = $%d", len(args)))
+	}
+	// exclusion dilakukan di SQL agar total dan ukuran halaman tetap konsisten antar halaman
+	if filter.ExcludeID != "" {
+		args = append(args, filter.ExcludeID)
+		conds = append(conds, fmt.Sprintf("id <> $%d", len(args)))
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+
 	var total int64
-	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users").Scan(&total)
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users "+where, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("gagal count users: %w", err)
 	}
 
 	offset := (page - 1) * limit
-	query := `
+	query := fmt.Sprintf(`
 		SELECT id, username, email, password_hash, full_name, role_id, is_active, created_at, updated_at
 		FROM users
+		%s
 		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
-	`
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("gagal query users: %w", err)
 	}
@@ -254,7 +291,7 @@ func (r *UserRepositoryPostgres) GetAllUsers(page, limit int64) ([]model.User, i
 			&user.UpdatedAt,
 		)
 		if err != nil {
-			fmt.Printf("[WARNING] Gagal decode user: %v\n", err)
+			logWarning(ctx, "Gagal decode user: %v", err)
 			continue
 		}
 		user.RoleID = ""
@@ -267,8 +304,8 @@ func (r *UserRepositoryPostgres) GetAllUsers(page, limit int64) ([]model.User, i
 	return users, total, rows.Err()
 }
 
-func (r *UserRepositoryPostgres) GetUsersByRoleName(roleName string, page, limit int64) ([]model.User, int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *UserRepositoryPostgres) GetUsersByRoleName(ctx context.Context, roleName string, page, limit int64) ([]model.User, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	roleName = strings.TrimSpace(roleName)
@@ -347,8 +384,71 @@ func (r *UserRepositoryPostgres) GetUsersByRoleName(roleName string, page, limit
 	return users, total, nil
 }
 
-func (r *UserRepositoryPostgres) CreateUser(req model.CreateUserRequest) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// GetUsersWithoutRole user dengan role_id NULL; akun seperti ini tidak punya permission apa pun
+// sampai role-nya diisi admin.
+func (r *UserRepositoryPostgres) GetUsersWithoutRole(ctx context.Context, page, limit int64) ([]model.User, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE role_id IS NULL`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("gagal count users tanpa role: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	query := `
+		SELECT id, username, email, password_hash, full_name, is_active, created_at, updated_at
+		FROM users
+		WHERE role_id IS NULL
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal query users tanpa role: %w", err)
+	}
+	defer rows.Close()
+
+	users := []model.User{}
+	for rows.Next() {
+		var u model.User
+		if err := rows.Scan(&u.ID, &u.Username, &u.Email, &u.PasswordHash, &u.FullName, &u.IsActive, &u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, 0, fmt.Errorf("gagal scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterasi users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+func (r *UserRepositoryPostgres) CountUsersByRoleName(ctx context.Context, roleName string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	roleName = strings.TrimSpace(roleName)
+	if roleName == "" {
+		return 0, errors.New("nama role harus diisi")
+	}
+
+	var total int64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM users u
+		JOIN roles r ON r.id = u.role_id
+		WHERE LOWER(r.name) = LOWER($1) AND u.is_active = true
+	`, roleName).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("gagal count users by role: %w", err)
+	}
+
+	return total, nil
+}
+
+func (r *UserRepositoryPostgres) CreateUser(ctx context.Context, req model.CreateUserRequest) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	hashedPassword, err := utils.HashPassword(req.Password)
@@ -357,11 +457,17 @@ func (r *UserRepositoryPostgres) CreateUser(req model.CreateUserRequest) (string
 	}
 
 	query := `
-		INSERT INTO users (id, username, email, password_hash, full_name, is_active, created_at, updated_at)
-		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, NOW(), NOW())
+		INSERT INTO users (id, username, email, password_hash, full_name, is_active, role_id, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, NOW(), NOW())
 		RETURNING id
 	`
 
+	// role_id kosong => NULL
+	var roleArg interface{}
+	if req.RoleID != "" {
+		roleArg = req.RoleID
+	}
+
 	var userID string
 	err = r.db.QueryRowContext(
 		ctx,
@@ -371,6 +477,7 @@ func (r *UserRepositoryPostgres) CreateUser(req model.CreateUserRequest) (string
 		hashedPassword,
 		req.FullName,
 		req.IsActive,
+		roleArg,
 	).Scan(&userID)
 
 	if err != nil {
@@ -383,26 +490,59 @@ func (r *UserRepositoryPostgres) CreateUser(req model.CreateUserRequest) (string
 	return userID, nil
 }
 
-func (r *UserRepositoryPostgres) UpdateUser(id string, req model.UpdateUserRequest) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// ErrConcurrentUpdate dikembalikan saat updated_at yang dikirim sudah tidak sama dengan di DB.
+var ErrConcurrentUpdate = errors.New("data telah diubah oleh proses lain")
+
+// UpdateUser semantik PUT: string kosong berarti tidak diubah. Diterjemahkan ke PatchUser agar
+// query UPDATE hanya dibangun di satu tempat.
+func (r *UserRepositoryPostgres) UpdateUser(ctx context.Context, id string, req model.UpdateUserRequest) error {
+	if req.ClearRole && req.RoleID != "" {
+		return errors.New("role_id dan clear_role tidak boleh diisi bersamaan")
+	}
+	patch := model.PatchUserRequest{IsActive: req.IsActive, UpdatedAt: req.UpdatedAt}
+	if req.Username != "" {
+		patch.Username = &req.Username
+	}
+	if req.Email != "" {
+		patch.Email = &req.Email
+	}
+	if req.Password != "" {
+		patch.Password = &req.Password
+	}
+	if req.FullName != "" {
+		patch.FullName = &req.FullName
+	}
+	if req.RoleID != "" {
+		patch.RoleID = &req.RoleID
+	} else if req.ClearRole {
+		noRole := ""
+		patch.RoleID = &noRole
+	}
+	return r.PatchUser(ctx, id, patch)
+}
+
+// PatchUser semantik PATCH: hanya field non-nil yang di-set, termasuk string kosong. RoleID "" berarti
+// role_id = NULL.
+func (r *UserRepositoryPostgres) PatchUser(ctx context.Context, id string, req model.PatchUserRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	updates := []string{}
 	args := []interface{}{}
 	argIndex := 1
 
-	if req.Username != "" {
+	if req.Username != nil {
 		updates = append(updates, fmt.Sprintf("username = $%d", argIndex))
-		args = append(args, strings.TrimSpace(req.Username))
+		args = append(args, strings.TrimSpace(*req.Username))
 		argIndex++
 	}
-	if req.Email != "" {
+	if req.Email != nil {
 		updates = append(updates, fmt.Sprintf("email = $%d", argIndex))
-		args = append(args, strings.ToLower(strings.TrimSpace(req.Email)))
+		args = append(args, strings.ToLower(strings.TrimSpace(*req.Email)))
 		argIndex++
 	}
-	if req.Password != "" {
-		hashed, err := utils.HashPassword(req.Password)
+	if req.Password != nil {
+		hashed, err := utils.HashPassword(*req.Password)
 		if err != nil {
 			return fmt.Errorf("gagal hash password: %w", err)
 		}
@@ -410,15 +550,20 @@ func (r *UserRepositoryPostgres) UpdateUser(id string, req model.UpdateUserReque
 		args = append(args, hashed)
 		argIndex++
 	}
-	if req.FullName != "" {
+	if req.FullName != nil {
 		updates = append(updates, fmt.Sprintf("full_name = $%d", argIndex))
-		args = append(args, req.FullName)
+		args = append(args, *req.FullName)
 		argIndex++
 	}
-	if req.RoleID != "" {
-		updates = append(updates, fmt.Sprintf("role_id = $%d", argIndex))
-		args = append(args, req.RoleID)
-		argIndex++
+	if req.RoleID != nil {
+		if *req.RoleID == "" {
+			// user tanpa role tidak mendapat permission apa pun (JOIN role_permissions tidak cocok dengan NULL)
+			updates = append(updates, "role_id = NULL")
+		} else {
+			updates = append(updates, fmt.Sprintf("role_id = $%d", argIndex))
+			args = append(args, *req.RoleID)
+			argIndex++
+		}
 	}
 	if req.IsActive != nil {
 		updates = append(updates, fmt.Sprintf("is_active = $%d", argIndex))
@@ -434,6 +579,10 @@ func (r *UserRepositoryPostgres) UpdateUser(id string, req model.UpdateUserReque
 
 	query := fmt.Sprintf("UPDATE users SET %s WHERE id = $%d", strings.Join(updates, ", "), argIndex)
 	args = append(args, id)
+	if req.UpdatedAt != nil {
+		query += fmt.Sprintf(" AND updated_at = $%d", argIndex+1)
+		args = append(args, *req.UpdatedAt)
+	}
 
 	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
@@ -449,14 +598,24 @@ func (r *UserRepositoryPostgres) UpdateUser(id string, req model.UpdateUserReque
 	}
 
 	if rowsAffected == 0 {
+		if req.UpdatedAt != nil {
+			// bedakan user yang tidak ada dengan updated_at yang sudah basi
+			var exists bool
+			if err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`, id).Scan(&exists); err != nil {
+				return fmt.Errorf("gagal cek user: %w", err)
+			}
+			if exists {
+				return ErrConcurrentUpdate
+			}
+		}
 		return errors.New("user tidak ditemukan")
 	}
 
 	return nil
 }
 
-func (r *UserRepositoryPostgres) DeleteUser(id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *UserRepositoryPostgres) DeleteUser(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	result, err := r.db.ExecContext(ctx, "DELETE FROM users WHERE id = $1", id)
@@ -476,8 +635,8 @@ func (r *UserRepositoryPostgres) DeleteUser(id string) error {
 	return nil
 }
 
-func (r *UserRepositoryPostgres) GetUserPermissions(userID string) ([]model.Permission, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *UserRepositoryPostgres) GetUserPermissions(ctx context.Context, userID string) ([]model.Permission, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
@@ -499,11 +658,158 @@ func (r *UserRepositoryPostgres) GetUserPermissions(userID string) ([]model.Perm
 		var perm model.Permission
 		err := rows.Scan(&perm.ID, &perm.Name, &perm.Resource, &perm.Action, &perm.Description)
 		if err != nil {
-			fmt.Printf("[WARNING] Gagal decode permission: %v\n", err)
+			logWarning(ctx, "Gagal decode permission: %v", err)
 			continue
 		}
 		permissions = append(permissions, perm)
 	}
 
 	return permissions, rows.Err()
-}
\ No newline at end of file
+}
+
+// GetLockedUntil mengembalikan locked_until jika akun masih terkunci; nil jika tidak terkunci atau email tidak ada.
+func (r *UserRepositoryPostgres) GetLockedUntil(ctx context.Context, email string) (*time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var lockedUntil sql.NullTime
+	err := r.db.QueryRowContext(ctx,
+		`SELECT locked_until FROM users WHERE email = $1 AND locked_until > NOW()`,
+		strings.ToLower(strings.TrimSpace(email)),
+	).Scan(&lockedUntil)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal cek lockout user: %w", err)
+	}
+	if !lockedUntil.Valid {
+		return nil, nil
+	}
+	return &lockedUntil.Time, nil
+}
+
+// RecordFailedLogin menambah counter login gagal; saat mencapai maxAttempts akun dikunci selama lockFor.
+// Counter dipertahankan selama lock aktif (ditampilkan di /users/locked); kegagalan pertama setelah lock
+// berakhir memulai hitungan dari 1. Counter dikosongkan oleh ResetLoginAttempts (login sukses / unlock).
+func (r *UserRepositoryPostgres) RecordFailedLogin(ctx context.Context, email string, maxAttempts int, lockFor time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE users
+		SET failed_login_attempts = CASE WHEN locked_until <= NOW() THEN 1 ELSE failed_login_attempts + 1 END,
+			locked_until = CASE
+				WHEN (CASE WHEN locked_until <= NOW() THEN 1 ELSE failed_login_attempts + 1 END) >= $2 THEN NOW() + ($3 * INTERVAL '1 second')
+				WHEN locked_until <= NOW() THEN NULL
+				ELSE locked_until
+			END
+		WHERE email = $1
+	`, strings.ToLower(strings.TrimSpace(email)), maxAttempts, int64(lockFor.Seconds()))
+	if err != nil {
+		return fmt.Errorf("gagal mencatat login gagal: %w", err)
+	}
+	return nil
+}
+
+// ResetLoginAttempts membuka kunci akun dan mengosongkan counter login gagal.
+func (r *UserRepositoryPostgres) ResetLoginAttempts(ctx context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE users SET failed_login_attempts = 0, locked_until = NULL WHERE id = $1`,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal reset lockout user: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("gagal cek rows affected reset lockout: %w", err)
+	}
+	if affected == 0 {
+		return errors.New("user tidak ditemukan")
+	}
+	return nil
+}
+
+func (r *UserRepositoryPostgres) GetLockedUsers(ctx context.Context, page, limit int64) ([]model.LockedUser, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var total int64
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE locked_until > NOW()`).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal count locked users: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, username, email, full_name, failed_login_attempts, locked_until
+		FROM users
+		WHERE locked_until > NOW()
+		ORDER BY locked_until DESC
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal query locked users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []model.LockedUser
+	for rows.Next() {
+		var u model.LockedUser
+		if err := rows.Scan(&u.ID, &u.Username, &u.Email, &u.FullName, &u.FailedLoginAttempts, &u.LockedUntil); err != nil {
+			return nil, 0, fmt.Errorf("gagal scan locked user: %w", err)
+		}
+		users = append(users, u)
+	}
+
+	return users, total, rows.Err()
+}
+
+// IsUserDisabled mengembalikan true jika akun dinonaktifkan admin (disabled_at terisi).
+// Berbeda dengan is_active yang di-reset setiap login/logout.
+func (r *UserRepositoryPostgres) IsUserDisabled(ctx context.Context, userID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var disabled bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT disabled_at IS NOT NULL FROM users WHERE id = $1`,
+		userID,
+	).Scan(&disabled)
+	if err == sql.ErrNoRows {
+		return false, ErrUserNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("gagal cek status nonaktif user: %w", err)
+	}
+	return disabled, nil
+}
+
+// SetUserDisabled mengisi (disabled=true) atau mengosongkan disabled_at sekaligus menyamakan is_active.
+func (r *UserRepositoryPostgres) SetUserDisabled(ctx context.Context, userID string, disabled bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE users
+		SET disabled_at = CASE WHEN $2 THEN COALESCE(disabled_at, NOW()) ELSE NULL END,
+			is_active = NOT $2,
+			updated_at = NOW()
+		WHERE id = $1
+	`, userID, disabled)
+	if err != nil {
+		return fmt.Errorf("gagal update status nonaktif user: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("gagal cek rows affected status nonaktif: %w", err)
+	}
+	if affected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
@@ -0,0 +1,380 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"hello-fiber/app/model"
+	"hello-fiber/utils"
+)
+
+func fakeUserListQueries(fake *fakeDB, isActive bool) {
+	now := time.Now()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		if strings.Contains(query, "COUNT(*)") {
+			return &fakeRowsResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(1)}}}, nil
+		}
+		return &fakeRowsResult{
+			columns: []string{"id", "username", "email", "password_hash", "full_name", "role_id", "is_active", "created_at", "updated_at"},
+			rows:    [][]driver.Value{{"u1", "budi", "budi@example.com", "hash", "Budi", nil, isActive, now, now}},
+		}, nil
+	}
+}
+
+func TestGetAllUsers_IsActiveFilter(t *testing.T) {
+	for _, isActive := range []bool{true, false} {
+		db, fake := newFakeDB()
+		fakeUserListQueries(fake, isActive)
+
+		repo := NewUserRepositoryPostgres(db)
+		users, total, err := repo.GetAllUsers(context.Background(), 2, 10, model.UserFilter{IsActive: &isActive})
+		db.Close()
+		if err != nil {
+			t.Fatalf("GetAllUsers(is_active=%v): %v", isActive, err)
+		}
+		if total != 1 || len(users) != 1 || users[0].IsActive != isActive {
+			t.Fatalf("unexpected result for is_active=%v: total=%d users=%+v", isActive, total, users)
+		}
+
+		if len(fake.queries) != 2 {
+			t.Fatalf("expected count + select, got %d queries", len(fake.queries))
+		}
+		count, list := fake.queries[0], fake.queries[1]
+		if !strings.Contains(count.query, "WHERE is_active = $1") || len(count.args) != 1 || count.args[0] != isActive {
+			t.Fatalf("unexpected count query: %s %v", count.query, count.args)
+		}
+		if !strings.Contains(list.query, "WHERE is_active = $1") || !strings.Contains(list.query, "LIMIT $2 OFFSET $3") {
+			t.Fatalf("unexpected list query: %s", list.query)
+		}
+		if len(list.args) != 3 || list.args[0] != isActive || list.args[1] != int64(10) || list.args[2] != int64(10) {
+			t.Fatalf("unexpected list args: %v", list.args)
+		}
+	}
+}
+
+func TestGetAllUsers_ExcludeIDInCountAndList(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fakeUserListQueries(fake, true)
+
+	isActive := true
+	repo := NewUserRepositoryPostgres(db)
+	if _, _, err := repo.GetAllUsers(context.Background(), 1, 10, model.UserFilter{IsActive: &isActive, ExcludeID: "self-id"}); err != nil {
+		t.Fatalf("GetAllUsers: %v", err)
+	}
+
+	if len(fake.queries) != 2 {
+		t.Fatalf("expected count + select, got %d queries", len(fake.queries))
+	}
+	count, list := fake.queries[0], fake.queries[1]
+	if !strings.Contains(count.query, "WHERE is_active = $1 AND id <> $2") || len(count.args) != 2 || count.args[1] != "self-id" {
+		t.Fatalf("unexpected count query: %s %v", count.query, count.args)
+	}
+	if !strings.Contains(list.query, "WHERE is_active = $1 AND id <> $2") || !strings.Contains(list.query, "LIMIT $3 OFFSET $4") {
+		t.Fatalf("unexpected list query: %s", list.query)
+	}
+	if len(list.args) != 4 || list.args[1] != "self-id" {
+		t.Fatalf("unexpected list args: %v", list.args)
+	}
+}
+
+func TestGetAllUsers_NoFilterKeepsQuery(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fakeUserListQueries(fake, true)
+
+	repo := NewUserRepositoryPostgres(db)
+	if _, _, err := repo.GetAllUsers(context.Background(), 1, 10, model.UserFilter{}); err != nil {
+		t.Fatalf("GetAllUsers: %v", err)
+	}
+	for _, q := range fake.queries {
+		if strings.Contains(q.query, "WHERE") {
+			t.Fatalf("unexpected WHERE clause: %s", q.query)
+		}
+	}
+	if list := fake.queries[1]; !strings.Contains(list.query, "LIMIT $1 OFFSET $2") || len(list.args) != 2 {
+		t.Fatalf("unexpected list query: %s %v", list.query, list.args)
+	}
+}
+
+func TestUpdateUser_StaleUpdatedAtReturnsConflict(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	stale := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake.execFn = func(query string, args []driver.Value) (int64, error) {
+		if !strings.Contains(query, "WHERE id = $2 AND updated_at = $3") {
+			t.Fatalf("unexpected update query: %s", query)
+		}
+		if args[1] != "u1" || args[2] != stale {
+			t.Fatalf("unexpected update args: %v", args)
+		}
+		// updated_at di DB sudah berubah => tidak ada baris yang cocok
+		return 0, nil
+	}
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		return &fakeRowsResult{columns: []string{"exists"}, rows: [][]driver.Value{{true}}}, nil
+	}
+
+	repo := NewUserRepositoryPostgres(db)
+	err := repo.UpdateUser(context.Background(), "u1", model.UpdateUserRequest{FullName: "Nama Baru", UpdatedAt: &stale})
+	if !errors.Is(err, ErrConcurrentUpdate) {
+		t.Fatalf("expected ErrConcurrentUpdate, got %v", err)
+	}
+}
+
+func TestUpdateUser_MissingUserWithUpdatedAtNotFound(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.execFn = func(query string, args []driver.Value) (int64, error) { return 0, nil }
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		return &fakeRowsResult{columns: []string{"exists"}, rows: [][]driver.Value{{false}}}, nil
+	}
+
+	now := time.Now()
+	repo := NewUserRepositoryPostgres(db)
+	err := repo.UpdateUser(context.Background(), "missing", model.UpdateUserRequest{FullName: "X", UpdatedAt: &now})
+	if err == nil || errors.Is(err, ErrConcurrentUpdate) || err.Error() != "user tidak ditemukan" {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestUserLookups_NoRowsReturnErrUserNotFound(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		return &fakeRowsResult{}, nil
+	}
+
+	repo := NewUserRepositoryPostgres(db)
+	lookups := map[string]func() (*model.User, error){
+		"GetUserByEmail":    func() (*model.User, error) { return repo.GetUserByEmail(context.Background(), "x@example.com") },
+		"GetUserByID":       func() (*model.User, error) { return repo.GetUserByID(context.Background(), "u404") },
+		"GetUserByUsername": func() (*model.User, error) { return repo.GetUserByUsername(context.Background(), "nobody") },
+	}
+	for name, lookup := range lookups {
+		user, err := lookup()
+		if !errors.Is(err, ErrUserNotFound) {
+			t.Fatalf("%s: expected ErrUserNotFound, got %v", name, err)
+		}
+		if user != nil {
+			t.Fatalf("%s: expected nil user, got %+v", name, user)
+		}
+	}
+}
+
+func TestUpdateUser_RoleSemantics(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		req  model.UpdateUserRequest
+		want string
+	}{
+		{"keep", model.UpdateUserRequest{FullName: "Budi"}, ""},
+		{"change", model.UpdateUserRequest{RoleID: "r2"}, "role_id = $1"},
+		{"clear", model.UpdateUserRequest{ClearRole: true}, "role_id = NULL"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db, fake := newFakeDB()
+			defer db.Close()
+			fake.execFn = func(query string, args []driver.Value) (int64, error) { return 1, nil }
+
+			repo := NewUserRepositoryPostgres(db)
+			if err := repo.UpdateUser(context.Background(), "u1", tc.req); err != nil {
+				t.Fatalf("UpdateUser: %v", err)
+			}
+			q := fake.queries[0]
+			if tc.want == "" {
+				if strings.Contains(q.query, "role_id") {
+					t.Fatalf("role_id must not be touched: %s", q.query)
+				}
+			} else if !strings.Contains(q.query, tc.want) {
+				t.Fatalf("query missing %q: %s", tc.want, q.query)
+			}
+			if tc.name == "change" && q.args[0] != "r2" {
+				t.Fatalf("unexpected role arg: %v", q.args)
+			}
+		})
+	}
+
+	db, _ := newFakeDB()
+	defer db.Close()
+	if err := NewUserRepositoryPostgres(db).UpdateUser(context.Background(), "u1", model.UpdateUserRequest{RoleID: "r2", ClearRole: true}); err == nil {
+		t.Fatal("expected error when role_id and clear_role are combined")
+	}
+}
+
+func TestGetUsersWithoutRole_OnlyNullRole(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+
+	now := time.Now()
+	// kolom terakhir role_id; dipakai fake untuk meniru WHERE role_id IS NULL
+	fixtures := [][]driver.Value{
+		{"u1", "admin1", "a@example.com", "hash", "Admin", true, now, now, "role-admin"},
+		{"u2", "yatim", "y@example.com", "hash", "Yatim", true, now, now, nil},
+		{"u3", "mhs1", "m@example.com", "hash", "Mhs", false, now, now, "role-mhs"},
+		{"u4", "yatim2", "y2@example.com", "hash", "Yatim Dua", false, now, now, nil},
+	}
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		if !strings.Contains(query, "WHERE role_id IS NULL") {
+			t.Fatalf("query must filter role_id IS NULL: %s", query)
+		}
+		var matched [][]driver.Value
+		for _, f := range fixtures {
+			if f[8] == nil {
+				matched = append(matched, f[:8])
+			}
+		}
+		if strings.Contains(query, "COUNT(*)") {
+			return &fakeRowsResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(len(matched))}}}, nil
+		}
+		if args[0] != int64(10) || args[1] != int64(0) {
+			t.Fatalf("unexpected limit/offset: %v", args)
+		}
+		return &fakeRowsResult{
+			columns: []string{"id", "username", "email", "password_hash", "full_name", "is_active", "created_at", "updated_at"},
+			rows:    matched,
+		}, nil
+	}
+
+	repo := NewUserRepositoryPostgres(db)
+	users, total, err := repo.GetUsersWithoutRole(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("GetUsersWithoutRole: %v", err)
+	}
+	if total != 2 || len(users) != 2 || users[0].ID != "u2" || users[1].ID != "u4" {
+		t.Fatalf("expected only role-less users, got total=%d users=%+v", total, users)
+	}
+	for _, u := range users {
+		if u.RoleID != "" {
+			t.Fatalf("unexpected role_id for %s: %q", u.ID, u.RoleID)
+		}
+	}
+}
+
+func TestPatchUser_SetsExplicitEmptyFullName(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.execFn = func(query string, args []driver.Value) (int64, error) { return 1, nil }
+
+	empty := ""
+	repo := NewUserRepositoryPostgres(db)
+	if err := repo.PatchUser(context.Background(), "u1", model.PatchUserRequest{FullName: &empty}); err != nil {
+		t.Fatalf("PatchUser: %v", err)
+	}
+	q := fake.queries[0]
+	if !strings.Contains(q.query, "full_name = $1") || q.args[0] != "" {
+		t.Fatalf("full_name must be set to empty: %s %v", q.query, q.args)
+	}
+	for _, col := range []string{"username", "email", "password_hash", "role_id", "is_active"} {
+		if strings.Contains(q.query, col+" =") {
+			t.Fatalf("omitted field %s must not be touched: %s", col, q.query)
+		}
+	}
+
+	// PUT dengan full_name kosong tetap berarti tidak diubah
+	if err := repo.UpdateUser(context.Background(), "u1", model.UpdateUserRequest{FullName: ""}); err == nil {
+		t.Fatal("expected error for PUT without changes")
+	}
+}
+
+func TestGetUserPermissions_DecodeWarningIncludesRequestID(t *testing.T) {
+	var logs []string
+	warnf = func(format string, args ...interface{}) {
+		logs = append(logs, fmt.Sprintf(format, args...))
+	}
+	t.Cleanup(func() { warnf = log.Printf })
+
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		return &fakeRowsResult{
+			columns: []string{"id", "name", "resource", "action", "description"},
+			rows: [][]driver.Value{
+				{nil, "rusak", "user", "read", ""},
+				{"p1", "user:read", "user", "read", ""},
+			},
+		}, nil
+	}
+
+	repo := NewUserRepositoryPostgres(db)
+	ctx := utils.WithRequestID(context.Background(), "req-42")
+	perms, err := repo.GetUserPermissions(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetUserPermissions: %v", err)
+	}
+	if len(perms) != 1 || perms[0].ID != "p1" {
+		t.Fatalf("undecodable row must be skipped: %+v", perms)
+	}
+	if len(logs) != 1 || !strings.Contains(logs[0], "request_id=req-42") || !strings.Contains(logs[0], "Gagal decode permission") {
+		t.Fatalf("unexpected logs: %v", logs)
+	}
+}
+
+func TestCountUsersByRoleName_OnlyActiveUsers(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.queryFn = func(query string, args []driver.Value) (*fakeRowsResult, error) {
+		return &fakeRowsResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(2)}}}, nil
+	}
+
+	total, err := NewUserRepositoryPostgres(db).CountUsersByRoleName(context.Background(), "admin")
+	if err != nil {
+		t.Fatalf("CountUsersByRoleName: %v", err)
+	}
+	if total != 2 {
+		t.Fatalf("expected 2, got %d", total)
+	}
+	if len(fake.queries) != 1 || !strings.Contains(fake.queries[0].query, "u.is_active = true") {
+		t.Fatalf("count admin harus hanya menghitung user aktif: %+v", fake.queries)
+	}
+}
+
+func TestRecordFailedLogin_KeepsCounterWhenLocking(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	fake.execFn = func(query string, args []driver.Value) (int64, error) { return 1, nil }
+
+	if err := NewUserRepositoryPostgres(db).RecordFailedLogin(context.Background(), "Budi@Example.com ", 5, 15*time.Minute); err != nil {
+		t.Fatalf("RecordFailedLogin: %v", err)
+	}
+	if len(fake.queries) != 1 {
+		t.Fatalf("expected 1 exec, got %d", len(fake.queries))
+	}
+	q := fake.queries[0]
+	if strings.Contains(q.query, "THEN 0") {
+		t.Fatalf("counter tidak boleh direset saat akun dikunci: %s", q.query)
+	}
+	if q.args[0] != "budi@example.com" || q.args[1] != int64(5) || q.args[2] != int64(900) {
+		t.Fatalf("unexpected args: %v", q.args)
+	}
+}
+
+func TestSetUserDisabled_SetsDisabledAtAndIsActive(t *testing.T) {
+	db, fake := newFakeDB()
+	defer db.Close()
+	var affected int64 = 1
+	fake.execFn = func(query string, args []driver.Value) (int64, error) { return affected, nil }
+
+	repo := NewUserRepositoryPostgres(db)
+	if err := repo.SetUserDisabled(context.Background(), "u1", true); err != nil {
+		t.Fatalf("SetUserDisabled: %v", err)
+	}
+	q := fake.queries[0]
+	if !strings.Contains(q.query, "disabled_at") || !strings.Contains(q.query, "is_active = NOT $2") {
+		t.Fatalf("query harus mengubah disabled_at dan is_active: %s", q.query)
+	}
+	if q.args[0] != "u1" || q.args[1] != true {
+		t.Fatalf("unexpected args: %v", q.args)
+	}
+
+	affected = 0
+	if err := repo.SetUserDisabled(context.Background(), "missing", false); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"hello-fiber/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// achievementTransitions matriks role -> status saat ini -> aksi yang diizinkan. Harus sejalan dengan
+// kondisi di service/repository mutasi: SubmitDraft & DeleteByStudent hanya untuk draft milik sendiri,
+// RevertToDraft (reopen) hanya untuk rejected milik sendiri,
+// Review & ReviewByAdvisor hanya untuk submitted (dosen wali: mahasiswa bimbingan), admin soft delete
+// untuk status selain deleted, hard delete hanya untuk deleted, dan reassign untuk status apa pun.
+// Kepemilikan (mahasiswa) dan bimbingan (dosen wali) dicek lewat canViewReference sebelum matriks dipakai.
+// Service mutasi memeriksa matriks yang sama lewat achievementTransitionAllowed sebelum update, sehingga
+// /actions tidak bisa berbeda dari yang diizinkan server; kondisi WHERE di repository tetap menjadi
+// penjaga atomik terhadap perubahan status yang terjadi bersamaan.
+var achievementTransitions = map[string]map[string][]string{
+	"mahasiswa": {
+		model.AchievementStatusDraft:    {model.AchievementActionSubmit, model.AchievementActionSoftDelete},
+		model.AchievementStatusRejected: {model.AchievementActionReopen},
+	},
+	"dosen wali": {
+		model.AchievementStatusSubmitted: {model.AchievementActionVerify, model.AchievementActionReject},
+	},
+	"admin": {
+		model.AchievementStatusDraft:     {model.AchievementActionAdminSoftDelete, model.AchievementActionReassign},
+		model.AchievementStatusSubmitted: {model.AchievementActionVerify, model.AchievementActionReject, model.AchievementActionAdminSoftDelete, model.AchievementActionReassign},
+		model.AchievementStatusVerified:  {model.AchievementActionAdminSoftDelete, model.AchievementActionReassign},
+		model.AchievementStatusRejected:  {model.AchievementActionAdminSoftDelete, model.AchievementActionReassign},
+		model.AchievementStatusDeleted:   {model.AchievementActionHardDelete, model.AchievementActionReassign},
+	},
+}
+
+// achievementTransitionAllowed true jika roleName boleh melakukan action terhadap achievement berstatus status.
+func achievementTransitionAllowed(roleName, status, action string) bool {
+	for _, a := range achievementTransitions[strings.ToLower(strings.TrimSpace(roleName))][status] {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// achievementActions aksi yang boleh dilakukan roleName terhadap achievement berstatus status;
+// selalu non-nil agar di-encode sebagai [] bukan null.
+func achievementActions(roleName, status string) []string {
+	actions := achievementTransitions[strings.ToLower(strings.TrimSpace(roleName))][status]
+	return append([]string{}, actions...)
+}
+
+// GetAchievementActionsService godoc
+// @Summary Aksi yang diizinkan untuk achievement
+// @Description Mengembalikan daftar aksi (submit, soft_delete, reopen, verify, reject, admin_soft_delete, hard_delete, reassign) yang boleh dilakukan pemanggil berdasarkan role dan status reference saat ini
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement reference ID (UUID)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /v1/achievements/{id}/actions [get]
+// @Security BearerAuth
+func GetAchievementActionsService(c *fiber.Ctx) error {
+	refID := strings.TrimSpace(c.Params("id"))
+	if refID == "" {
+		return errorJSON(c, fiber.StatusBadRequest, "ID reference harus diisi")
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	ref, err := achievementRefRepo.GetByID(ctx, refID)
+	if err != nil || ref == nil {
+		return errorJSON(c, fiber.StatusNotFound, "achievement reference tidak ditemukan")
+	}
+
+	allowed, err := canViewReference(c, ref)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+	if !allowed {
+		return errorJSON(c, fiber.StatusForbidden, "Tidak berhak melihat achievement ini")
+	}
+
+	// role sudah tervalidasi oleh canViewReference
+	roleName, _ := resolveRoleName(c)
+
+	return successJSON(c, fiber.StatusOK, "Aksi achievement berhasil diambil", fiber.Map{
+		"id":      ref.ID,
+		"status":  ref.Status,
+		"actions": achievementActions(roleName, ref.Status),
+	})
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"hello-fiber/app/model"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestAchievementActions_Matrix(t *testing.T) {
+	cases := []struct {
+		role, status string
+		want         []string
+	}{
+		{"mahasiswa", model.AchievementStatusDraft, []string{"submit", "soft_delete"}},
+		{"mahasiswa", model.AchievementStatusSubmitted, []string{}},
+		{"mahasiswa", model.AchievementStatusVerified, []string{}},
+		{"mahasiswa", model.AchievementStatusRejected, []string{"reopen"}},
+		{"dosen wali", model.AchievementStatusSubmitted, []string{"verify", "reject"}},
+		{"dosen wali", model.AchievementStatusDraft, []string{}},
+		{"dosen wali", model.AchievementStatusVerified, []string{}},
+		{"admin", model.AchievementStatusDraft, []string{"admin_soft_delete", "reassign"}},
+		{"admin", model.AchievementStatusSubmitted, []string{"verify", "reject", "admin_soft_delete", "reassign"}},
+		{"admin", model.AchievementStatusVerified, []string{"admin_soft_delete", "reassign"}},
+		{"admin", model.AchievementStatusRejected, []string{"admin_soft_delete", "reassign"}},
+		{"admin", model.AchievementStatusDeleted, []string{"hard_delete", "reassign"}},
+		{"Admin", model.AchievementStatusDeleted, []string{"hard_delete", "reassign"}},
+		{"staff", model.AchievementStatusVerified, []string{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.role+"/"+tc.status, func(t *testing.T) {
+			got := achievementActions(tc.role, tc.status)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %v want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAchievementActions_ReturnsCopy(t *testing.T) {
+	got := achievementActions("mahasiswa", model.AchievementStatusDraft)
+	got[0] = "mutated"
+	if achievementTransitions["mahasiswa"][model.AchievementStatusDraft][0] != model.AchievementActionSubmit {
+		t.Fatal("caller must not be able to mutate the transition matrix")
+	}
+}
+
+// getAchievementActions memanggil handler sebagai user roleName dengan reference ref.
+func getAchievementActions(t *testing.T, roleName string, ref *model.AchievementReference) (int, map[string]any) {
+	t.Helper()
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: roleName}, nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return ref, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievements/:id/actions", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-1")
+		c.Locals("user_id", uuid.NewString())
+		return GetAchievementActionsService(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/"+ref.ID.String()+"/actions", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	return resp.StatusCode, decodeMapAchievement(t, resp)
+}
+
+func actionsFromBody(t *testing.T, body map[string]any) []any {
+	t.Helper()
+	data, ok := body["data"].(map[string]any)
+	if !ok {
+		t.Fatalf("data missing: %#v", body)
+	}
+	actions, ok := data["actions"].([]any)
+	if !ok {
+		t.Fatalf("actions must be an array: %#v", data["actions"])
+	}
+	return actions
+}
+
+func TestGetAchievementActionsService_StudentOwnDraft(t *testing.T) {
+	studentID := uuid.New()
+	achievementStudentRepo = &mockStudentRepo{
+		GetStudentByUserIDFn: func(userID string) (*model.Student, error) {
+			return &model.Student{ID: studentID}, nil
+		},
+	}
+	ref := &model.AchievementReference{ID: uuid.New(), StudentID: studentID, Status: model.AchievementStatusDraft}
+
+	status, body := getAchievementActions(t, "Mahasiswa", ref)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d %#v", status, body)
+	}
+	if got := actionsFromBody(t, body); !reflect.DeepEqual(got, []any{"submit", "soft_delete"}) {
+		t.Fatalf("unexpected actions: %v", got)
+	}
+}
+
+func TestGetAchievementActionsService_StudentOtherDraftForbidden(t *testing.T) {
+	achievementStudentRepo = &mockStudentRepo{
+		GetStudentByUserIDFn: func(userID string) (*model.Student, error) {
+			return &model.Student{ID: uuid.New()}, nil
+		},
+	}
+	ref := &model.AchievementReference{ID: uuid.New(), StudentID: uuid.New(), Status: model.AchievementStatusDraft}
+
+	if status, _ := getAchievementActions(t, "Mahasiswa", ref); status != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", status)
+	}
+}
+
+func TestGetAchievementActionsService_AdvisorSubmittedAdvisee(t *testing.T) {
+	lecturerID := uuid.New()
+	achievementLecturerRepo = &mockLectRepo{
+		GetLecturerByUserIDFn: func(userID string) (*model.Lecturer, error) {
+			return &model.Lecturer{ID: lecturerID}, nil
+		},
+	}
+	ref := &model.AchievementReference{ID: uuid.New(), StudentID: uuid.New(), Status: model.AchievementStatusSubmitted}
+	achievementAdvisorRepo = &mockStudentAdvisorRepo{
+		IsAdvisorFn: func(studentID, lectID string) (bool, error) {
+			return studentID == ref.StudentID.String() && lectID == lecturerID.String(), nil
+		},
+	}
+
+	status, body := getAchievementActions(t, "Dosen Wali", ref)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d %#v", status, body)
+	}
+	if got := actionsFromBody(t, body); !reflect.DeepEqual(got, []any{"verify", "reject"}) {
+		t.Fatalf("unexpected actions: %v", got)
+	}
+}
+
+func TestGetAchievementActionsService_AdminVerifiedHasNoReview(t *testing.T) {
+	ref := &model.AchievementReference{ID: uuid.New(), StudentID: uuid.New(), Status: model.AchievementStatusVerified}
+
+	status, body := getAchievementActions(t, "Admin", ref)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d %#v", status, body)
+	}
+	if got := actionsFromBody(t, body); !reflect.DeepEqual(got, []any{"admin_soft_delete", "reassign"}) {
+		t.Fatalf("unexpected actions: %v", got)
+	}
+}
+
+func TestGetAchievementActionsService_NotFound(t *testing.T) {
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return nil, nil
+		},
+	}
+	app := fiber.New()
+	app.Get("/achievements/:id/actions", GetAchievementActionsService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/x/actions", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", resp.StatusCode)
+	}
+}
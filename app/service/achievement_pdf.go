@@ -0,0 +1,131 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"hello-fiber/app/model"
+
+	"github.com/go-pdf/fpdf"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// renderAchievementPDF membuat ringkasan achievement terverifikasi dalam satu dokumen A4. Attachment
+// hanya dicantumkan nama filenya sebagai referensi, isinya tidak disematkan.
+func renderAchievementPDF(ach model.Achievement, ref model.AchievementReference, reviewer string) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(ach.Title, true)
+	pdf.SetMargins(20, 20, 20)
+	pdf.AddPage()
+	// font inti PDF memakai cp1252; teks UTF-8 dikonversi agar huruf non-ASCII tidak rusak
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.MultiCell(0, 8, tr(ach.Title), "", "L", false)
+	pdf.Ln(4)
+
+	row := func(label, value string) {
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(45, 7, tr(label), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.MultiCell(0, 7, tr(value), "", "L", false)
+	}
+
+	verifiedAt := "-"
+	if ref.VerifiedAt != nil {
+		verifiedAt = ref.VerifiedAt.Format("02 Jan 2006 15:04 MST")
+	}
+	row("Jenis", ach.AchievementType)
+	row("Status", ref.Status)
+	row("Diverifikasi", verifiedAt)
+	row("Reviewer", reviewer)
+	row("ID Reference", ref.ID.String())
+	if ach.Description != "" {
+		row("Deskripsi", ach.Description)
+	}
+
+	if len(ach.Details) > 0 {
+		pdf.Ln(3)
+		pdf.SetFont("Helvetica", "B", 13)
+		pdf.CellFormat(0, 8, "Detail", "", 1, "L", false, 0, "")
+		keys := make([]string, 0, len(ach.Details))
+		for k := range ach.Details {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			row(k, formatDetailValue(ach.Details[k]))
+		}
+	}
+
+	if len(ach.Attachments) > 0 {
+		pdf.Ln(3)
+		pdf.SetFont("Helvetica", "B", 13)
+		pdf.CellFormat(0, 8, "Lampiran", "", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 11)
+		for i, att := range ach.Attachments {
+			pdf.MultiCell(0, 7, tr(fmt.Sprintf("%d. %s", i+1, att.FileName)), "", "L", false)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("gagal membuat PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// formatDetailValue menulis nilai details agar terbaca di PDF: dokumen bersarang (bson.D/map) menjadi
+// "key: value" dipisah "; ", array dipisah ", ", bukan sintaks Go seperti map[...] atau [...].
+func formatDetailValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		return val
+	case []byte:
+		return string(val)
+	case bson.DateTime:
+		return val.Time().Format("02 Jan 2006")
+	case bson.D:
+		if len(val) == 0 {
+			return "-"
+		}
+		parts := make([]string, 0, len(val))
+		for _, e := range val {
+			parts = append(parts, e.Key+": "+formatDetailValue(e.Value))
+		}
+		return strings.Join(parts, "; ")
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Len() == 0 {
+			return "-"
+		}
+		parts := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			parts = append(parts, fmt.Sprint(k.Interface())+": "+formatDetailValue(rv.MapIndex(k).Interface()))
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, "; ")
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 {
+			return "-"
+		}
+		parts := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			item := formatDetailValue(rv.Index(i).Interface())
+			if k := reflect.ValueOf(rv.Index(i).Interface()).Kind(); k == reflect.Map || k == reflect.Slice {
+				item = "(" + item + ")"
+			}
+			parts = append(parts, item)
+		}
+		return strings.Join(parts, ", ")
+	}
+	return fmt.Sprint(v)
+}
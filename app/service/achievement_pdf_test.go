@@ -0,0 +1,27 @@
+package service
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestFormatDetailValue_NestedValues(t *testing.T) {
+	cases := []struct {
+		in   interface{}
+		want string
+	}{
+		{"Juara 1", "Juara 1"},
+		{nil, "-"},
+		{int32(3), "3"},
+		{map[string]interface{}{"rank": 1, "level": "nasional"}, "level: nasional; rank: 1"},
+		{bson.D{{Key: "name", Value: "Tim A"}, {Key: "members", Value: bson.A{"Budi", "Sari"}}}, "name: Tim A; members: Budi, Sari"},
+		{bson.A{bson.D{{Key: "name", Value: "Budi"}}, "Sari"}, "(name: Budi), Sari"},
+		{[]interface{}{}, "-"},
+	}
+	for _, tc := range cases {
+		if got := formatDetailValue(tc.in); got != tc.want {
+			t.Errorf("formatDetailValue(%#v) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
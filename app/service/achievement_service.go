@@ -4,18 +4,25 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"mime"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"hello-fiber/app/model"
 	"hello-fiber/app/repository"
+	"hello-fiber/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
@@ -24,6 +31,10 @@ var achievementRefRepo repository.AchievementReferenceRepository
 var achievementRoleRepo repository.RoleRepository
 var achievementStudentRepo repository.StudentRepository
 var achievementLecturerRepo repository.LecturerRepository
+var achievementAdvisorRepo repository.StudentAdvisorRepository
+var achievementUserRepo repository.UserRepository
+var achievementNotifier utils.Notifier = utils.NoopNotifier{}
+var achievementWebhook *utils.Webhook
 
 func InitAchievementService(db *sql.DB, mongoDB *mongo.Database) {
 	achievementMongoRepo = repository.NewAchievementMongoRepository(mongoDB)
@@ -31,10 +42,21 @@ func InitAchievementService(db *sql.DB, mongoDB *mongo.Database) {
 	achievementRoleRepo = repository.NewRoleRepositoryPostgres(db)
 	achievementStudentRepo = repository.NewStudentRepositoryPostgres(db)
 	achievementLecturerRepo = repository.NewLecturerRepositoryPostgres(db)
+	achievementAdvisorRepo = repository.NewStudentAdvisorRepositoryPostgres(db)
+	achievementUserRepo = repository.NewUserRepositoryPostgres(db)
+	achievementNotifier = utils.NewNotifierFromEnv()
+	achievementWebhook = utils.NewWebhookFromEnv()
 }
 
+// errBodyTooLarge dikembalikan parser multipart jika body melebihi UPLOAD_BODY_LIMIT_MB (-> 413).
+var errBodyTooLarge = errors.New("ukuran request melebihi batas upload")
+
 // parse multipart payload for achievement create, including attachments.
-func parseMultipartCreateAchievement(c *fiber.Ctx) (*model.CreateAchievementRequest, error) {
+func parseMultipartCreateAchievement(c *fiber.Ctx, strict bool) (*model.CreateAchievementRequest, []model.RejectedAttachment, error) {
+	if len(c.Body()) > utils.BodyLimitBytes("UPLOAD_BODY_LIMIT_MB", utils.DefaultUploadBodyLimitMB) {
+		return nil, nil, errBodyTooLarge
+	}
+
 	req := model.CreateAchievementRequest{}
 
 	req.AchievementType = c.FormValue("achievement_type")
@@ -44,17 +66,15 @@ func parseMultipartCreateAchievement(c *fiber.Ctx) (*model.CreateAchievementRequ
 	if detailsStr := c.FormValue("details"); detailsStr != "" {
 		var det map[string]interface{}
 		if err := json.Unmarshal([]byte(detailsStr), &det); err != nil {
-			return nil, fmt.Errorf("details harus JSON: %w", err)
+			return nil, nil, fmt.Errorf("details harus JSON: %w", err)
 		}
 		req.Details = det
 	}
 
 	if tagsStr := c.FormValue("tags"); tagsStr != "" {
-		tags := []string{}
-		for _, t := range strings.Split(tagsStr, ",") {
-			if v := strings.TrimSpace(t); v != "" {
-				tags = append(tags, v)
-			}
+		tags, err := normalizeTags(strings.Split(tagsStr, ","))
+		if err != nil {
+			return nil, nil, err
 		}
 		req.Tags = tags
 	}
@@ -62,39 +82,42 @@ func parseMultipartCreateAchievement(c *fiber.Ctx) (*model.CreateAchievementRequ
 	if pointsStr := c.FormValue("points"); pointsStr != "" {
 		p, err := strconv.ParseFloat(pointsStr, 64)
 		if err != nil {
-			return nil, fmt.Errorf("points harus numerik: %w", err)
+			return nil, nil, fmt.Errorf("points harus numerik: %w", err)
 		}
 		req.Points = &p
 	}
 
+	var rejected []model.RejectedAttachment
 	form, err := c.MultipartForm()
 	if err == nil && form != nil && form.File != nil {
 		files := form.File["attachments"]
 		if len(files) > 0 {
-			if err := os.MkdirAll("uploads", 0o755); err != nil {
-				return nil, fmt.Errorf("gagal buat folder uploads: %w", err)
+			if err := os.MkdirAll(uploadDir(), 0o755); err != nil {
+				return nil, nil, fmt.Errorf("gagal buat folder uploads: %w", err)
 			}
+			usedNames := map[string]bool{}
 			for _, fh := range files {
-				if fh.Size > 7*1024*1024 {
-					return nil, fmt.Errorf("ukuran file maksimal 7MB")
-				}
 				ext := strings.ToLower(filepath.Ext(fh.Filename))
 				ctype := fh.Header.Get("Content-Type")
-				if ext != ".pdf" && !strings.EqualFold(strings.ToLower(ctype), "application/pdf") {
-					return nil, fmt.Errorf("hanya file PDF yang diperbolehkan")
+				if err := validateAttachmentFile(fh.Size, ext, ctype); err != nil {
+					if strict {
+						return nil, nil, err
+					}
+					rejected = append(rejected, model.RejectedAttachment{FileName: fh.Filename, Reason: err.Error()})
+					continue
 				}
 				storedName := fmt.Sprintf("%d-%s", time.Now().UnixNano(), filepath.Base(fh.Filename))
-				savePath := filepath.Join("uploads", storedName)
+				savePath := filepath.Join(uploadDir(), storedName)
 				if err := c.SaveFile(fh, savePath); err != nil {
-					return nil, fmt.Errorf("gagal simpan file %s: %w", fh.Filename, err)
+					return nil, nil, fmt.Errorf("gagal simpan file %s: %w", fh.Filename, err)
 				}
 				fileType := ctype
 				if fileType == "" {
 					fileType = strings.TrimPrefix(ext, ".")
 				}
 				req.Attachments = append(req.Attachments, model.Attachment{
-					FileName:   fh.Filename,
-					FileURL:    "/" + filepath.ToSlash(savePath),
+					FileName:   uniqueAttachmentName(fh.Filename, usedNames),
+					FileURL:    uploadsURLPrefix + storedName,
 					FileType:   fileType,
 					UploadedAt: time.Now(),
 				})
@@ -102,7 +125,102 @@ func parseMultipartCreateAchievement(c *fiber.Ctx) (*model.CreateAchievementRequ
 		}
 	}
 
-	return &req, nil
+	return &req, rejected, nil
+}
+
+// validateAttachmentFile aturan file attachment: PDF (ekstensi atau Content-Type) dan maksimal 7MB.
+func validateAttachmentFile(size int64, ext, ctype string) error {
+	if size > 7*1024*1024 {
+		return fmt.Errorf("ukuran file maksimal 7MB")
+	}
+	if ext != ".pdf" && !strings.EqualFold(strings.ToLower(ctype), "application/pdf") {
+		return fmt.Errorf("hanya file PDF yang diperbolehkan")
+	}
+	return nil
+}
+
+const (
+	maxTagLength = 30
+	maxTagCount  = 10
+)
+
+// normalizeTags lowercase + trim tiap tag, membuang tag kosong dan duplikat (urutan pertama dipertahankan),
+// lalu menolak tag lebih dari maxTagLength karakter atau jumlah tag lebih dari maxTagCount.
+func normalizeTags(tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+	result := []string{}
+	seen := map[string]bool{}
+	for _, t := range tags {
+		v := strings.ToLower(strings.TrimSpace(t))
+		if v == "" || seen[v] {
+			continue
+		}
+		if utf8.RuneCountInString(v) > maxTagLength {
+			return nil, fmt.Errorf("tag %q melebihi %d karakter", v, maxTagLength)
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	if len(result) > maxTagCount {
+		return nil, fmt.Errorf("maksimal %d tag", maxTagCount)
+	}
+	return result, nil
+}
+
+// uniqueAttachmentName memberi suffix " (2)", " (3)", ... pada nama file yang sudah dipakai
+// di request yang sama (case-insensitive) agar FileName tiap attachment bisa dibedakan.
+func uniqueAttachmentName(name string, used map[string]bool) string {
+	candidate := name
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for n := 2; used[strings.ToLower(candidate)]; n++ {
+		candidate = fmt.Sprintf("%s (%d)%s", base, n, ext)
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// uploadsURLPrefix prefix FileURL attachment yang disimpan di Mongo.
+const uploadsURLPrefix = "/uploads/"
+
+// uploadDir folder penyimpanan attachment di disk (env UPLOAD_DIR, default "uploads").
+func uploadDir() string {
+	return utils.GetEnv("UPLOAD_DIR", "uploads")
+}
+
+// resolveAttachmentPath mengubah FileURL tersimpan (/uploads/xxx) menjadi path lokal di UPLOAD_DIR
+// dan menolak path yang keluar dari folder tersebut (path traversal).
+func resolveAttachmentPath(fileURL string) (string, error) {
+	urlPath := "/" + strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(fileURL)), "/")
+	if !strings.HasPrefix(urlPath, uploadsURLPrefix) {
+		return "", fmt.Errorf("path attachment tidak valid")
+	}
+	base, err := filepath.Abs(uploadDir())
+	if err != nil {
+		return "", fmt.Errorf("path attachment tidak valid")
+	}
+	full, err := filepath.Abs(filepath.Join(base, filepath.FromSlash(strings.TrimPrefix(urlPath, uploadsURLPrefix))))
+	if err != nil || !strings.HasPrefix(full, base+string(filepath.Separator)) {
+		return "", fmt.Errorf("path attachment tidak valid")
+	}
+	return full, nil
+}
+
+// removeAttachmentFiles menghapus file attachment dari disk; error per file hanya di-log
+// agar satu file yang hilang tidak menggagalkan seluruh proses.
+func removeAttachmentFiles(attachments []model.Attachment) {
+	for _, att := range attachments {
+		path, err := resolveAttachmentPath(att.FileURL)
+		if err != nil {
+			log.Printf("[WARNING] Lewati hapus attachment %q: %v", att.FileURL, err)
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			log.Printf("[WARNING] Gagal hapus attachment %q: %v", path, err)
+		}
+	}
 }
 
 // normalizeDetails memastikan tipe data sesuai schema Mongo (misal rank harus int).
@@ -129,21 +247,162 @@ func normalizeDetails(achType string, details map[string]interface{}) (map[strin
 				return nil, fmt.Errorf("rank harus numerik (int)")
 			}
 		}
-		if level, ok := out["competitionLevel"].(string); ok {
-			out["competitionLevel"] = strings.ToLower(strings.TrimSpace(level))
+		if raw, ok := out["competitionLevel"]; ok && raw != nil {
+			level, isStr := raw.(string)
+			level = strings.ToLower(strings.TrimSpace(level))
+			if level != "" || !isStr {
+				if _, allowed := competitionLevelPoints()[level]; !allowed {
+					return nil, errors.New("competitionLevel tidak valid")
+				}
+			}
+			out["competitionLevel"] = level
 		}
 	}
 
 	return out, nil
 }
 
+// defaultCompetitionLevels level kompetisi yang diterima beserta points default-nya.
+const defaultCompetitionLevels = "local=10,regional=25,national=50,international=100"
+
+// competitionLevelPoints membaca ACHIEVEMENT_COMPETITION_LEVELS ("level=points" dipisah koma). Level tanpa
+// "=points" tetap diterima tetapi tidak memberi points default (nilai nil). Konfigurasi yang tidak
+// menghasilkan level apa pun jatuh ke default.
+func competitionLevelPoints() map[string]*float64 {
+	if levels := parseCompetitionLevels(utils.GetEnv("ACHIEVEMENT_COMPETITION_LEVELS", "")); len(levels) > 0 {
+		return levels
+	}
+	return parseCompetitionLevels(defaultCompetitionLevels)
+}
+
+func parseCompetitionLevels(raw string) map[string]*float64 {
+	levels := map[string]*float64{}
+	for _, entry := range strings.Split(raw, ",") {
+		name, value, hasPoints := strings.Cut(entry, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		levels[name] = nil
+		if hasPoints {
+			if p, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && p >= 0 {
+				levels[name] = &p
+			}
+		}
+	}
+	return levels
+}
+
+// defaultCompetitionPoints points default untuk achievement competition tanpa points eksplisit,
+// berdasarkan competitionLevel yang sudah dinormalisasi; nil jika level kosong atau tanpa mapping.
+func defaultCompetitionPoints(details map[string]interface{}) *float64 {
+	level, _ := details["competitionLevel"].(string)
+	if level == "" {
+		return nil
+	}
+	if p := competitionLevelPoints()[level]; p != nil {
+		v := *p
+		return &v
+	}
+	return nil
+}
+
+// requiredDetailFields daftar field details yang wajib ada per achievement_type.
+var requiredDetailFields = map[string][]string{
+	"competition":   {"competitionName", "rank"},
+	"publication":   {"publicationTitle", "publisher"},
+	"organization":  {"organizationName", "position"},
+	"certification": {"certificationName", "issuedBy"},
+	"academic":      {"score"},
+}
+
+// missingDetailFields mengembalikan field wajib yang belum diisi (nil atau string kosong).
+func missingDetailFields(achType string, details map[string]interface{}) []string {
+	achType = strings.ToLower(strings.TrimSpace(achType))
+	var missing []string
+	for _, field := range requiredDetailFields[achType] {
+		v, ok := details[field]
+		if !ok || v == nil {
+			missing = append(missing, field)
+			continue
+		}
+		if str, isStr := v.(string); isStr && strings.TrimSpace(str) == "" {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+// defaultReviewSLADays dipakai jika ACHIEVEMENT_REVIEW_SLA_DAYS kosong/tidak valid.
+const defaultReviewSLADays = 7
+
+// reviewSLADays batas hari achievement boleh berstatus submitted sebelum dianggap overdue.
+func reviewSLADays() int {
+	days, err := strconv.Atoi(utils.GetEnv("ACHIEVEMENT_REVIEW_SLA_DAYS", strconv.Itoa(defaultReviewSLADays)))
+	if err != nil || days < 1 {
+		return defaultReviewSLADays
+	}
+	return days
+}
+
+// applyReviewSLA mengisi days_pending & overdue untuk reference berstatus submitted.
+func applyReviewSLA(ref *model.AchievementReference, now time.Time, slaDays int) {
+	if ref == nil || ref.Status != model.AchievementStatusSubmitted || ref.SubmittedAt == nil {
+		return
+	}
+	days := int(now.Sub(*ref.SubmittedAt).Hours() / 24)
+	if days < 0 {
+		days = 0
+	}
+	overdue := days >= slaDays
+	ref.DaysPending = &days
+	ref.Overdue = &overdue
+}
+
+// strictEvidenceEnabled: ACHIEVEMENT_STRICT_EVIDENCE=false mematikan cek RequiresAttachment saat submit.
+func strictEvidenceEnabled() bool {
+	return !strings.EqualFold(strings.TrimSpace(utils.GetEnv("ACHIEVEMENT_STRICT_EVIDENCE", "true")), "false")
+}
+
+// missingRequiredEvidence mengembalikan pesan error jika tipe achievement wajib attachment tetapi belum ada.
+// Kegagalan mengambil dokumen Mongo dikembalikan sebagai error agar submit tidak lolos tanpa pengecekan.
+func missingRequiredEvidence(ctx context.Context, ref *model.AchievementReference) (string, error) {
+	achs, err := achievementMongoRepo.GetByIDs(ctx, []string{ref.MongoAchievementID})
+	if err != nil {
+		return "", err
+	}
+	if len(achs) == 0 {
+		return "", nil
+	}
+	ach := achs[0]
+	def := model.AllowedAchievementTypes[strings.ToLower(ach.AchievementType)]
+	if def.RequiresAttachment && len(ach.Attachments) == 0 {
+		return fmt.Sprintf("lampiran bukti wajib untuk achievement_type %s sebelum submit", ach.AchievementType), nil
+	}
+	return "", nil
+}
+
+// loadStudentTransitionRef memuat reference milik studentID dan memastikan aksi mahasiswa action diizinkan
+// oleh achievementTransitions. Reference yang tidak ada, milik mahasiswa lain, atau berstatus tidak sesuai
+// dijawab 404 notAllowedMsg (sama seperti UPDATE yang tidak mengubah baris). Jika ref nil, kembalikan errResp.
+func loadStudentTransitionRef(ctx context.Context, c *fiber.Ctx, refID string, studentID uuid.UUID, action, notAllowedMsg string) (*model.AchievementReference, error) {
+	ref, err := achievementRefRepo.GetByID(ctx, refID)
+	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+		return nil, errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data achievement", err)
+	}
+	if ref == nil || ref.StudentID != studentID || !achievementTransitionAllowed("mahasiswa", ref.Status, action) {
+		return nil, errorJSON(c, fiber.StatusNotFound, notAllowedMsg)
+	}
+	return ref, nil
+}
+
 func resolveRoleName(c *fiber.Ctx) (string, error) {
 	roleIDVal := c.Locals("role_id")
 	roleID, ok := roleIDVal.(string)
 	if roleIDVal == nil || !ok || strings.TrimSpace(roleID) == "" {
 		return "", fmt.Errorf("role tidak ditemukan")
 	}
-	role, err := achievementRoleRepo.GetRoleByID(roleID)
+	role, err := achievementRoleRepo.GetRoleByID(c.UserContext(), roleID)
 	if err != nil || role == nil {
 		return "", fmt.Errorf("role tidak ditemukan")
 	}
@@ -152,7 +411,7 @@ func resolveRoleName(c *fiber.Ctx) (string, error) {
 
 // allowedStatusesByRole menentukan status apa saja yang boleh diakses.
 // jika forAchievements=true dan role mahasiswa, filter juga ke student_id miliknya.
-// untuk dosen wali, filter ke advisor_id (lecturer) yang sesuai.
+// untuk dosen wali, filter ke lecturer yang membimbing (advisor_id atau student_advisors).
 func allowedStatusesByRole(c *fiber.Ctx, roleName string, forAchievements bool) ([]string, *uuid.UUID, *uuid.UUID, error) {
 	roleName = strings.ToLower(strings.TrimSpace(roleName))
 
@@ -173,7 +432,7 @@ func allowedStatusesByRole(c *fiber.Ctx, roleName string, forAchievements bool)
 			if !okUser || strings.TrimSpace(userIDStr) == "" {
 				return nil, nil, nil, fmt.Errorf("mahasiswa tidak memiliki student_id")
 			}
-			st, err := achievementStudentRepo.GetStudentByUserID(userIDStr)
+			st, err := achievementStudentRepo.GetStudentByUserID(c.UserContext(), userIDStr)
 			if err != nil || st == nil {
 				return nil, nil, nil, fmt.Errorf("mahasiswa tidak memiliki student_id")
 			}
@@ -196,7 +455,7 @@ func allowedStatusesByRole(c *fiber.Ctx, roleName string, forAchievements bool)
 		if cachedLect, ok := c.Locals("lecturer_uuid").(uuid.UUID); ok {
 			return []string{model.AchievementStatusSubmitted}, nil, &cachedLect, nil
 		}
-		lect, err := achievementLecturerRepo.GetLecturerByUserID(userIDStr)
+		lect, err := achievementLecturerRepo.GetLecturerByUserID(c.UserContext(), userIDStr)
 		if err != nil || lect == nil {
 			return nil, nil, nil, fmt.Errorf("dosen wali tidak ditemukan")
 		}
@@ -209,16 +468,54 @@ func allowedStatusesByRole(c *fiber.Ctx, roleName string, forAchievements bool)
 	}
 }
 
+// canViewReference memastikan reference masuk scope pemanggil sesuai allowedStatusesByRole
+// (status yang boleh dilihat, milik mahasiswa sendiri, atau mahasiswa bimbingan dosen wali).
+func canViewReference(c *fiber.Ctx, ref *model.AchievementReference) (bool, error) {
+	roleName, err := resolveRoleName(c)
+	if err != nil {
+		return false, err
+	}
+	statuses, studentFilter, advisorFilter, err := allowedStatusesByRole(c, roleName, true)
+	if err != nil {
+		return false, err
+	}
+
+	statusAllowed := false
+	for _, st := range statuses {
+		if st == ref.Status {
+			statusAllowed = true
+			break
+		}
+	}
+	if !statusAllowed {
+		return false, nil
+	}
+	if studentFilter != nil && *studentFilter != ref.StudentID {
+		return false, nil
+	}
+	if advisorFilter != nil {
+		// advisor utama maupun co-advisor (student_advisors) boleh melihat
+		isAdvisor, err := achievementAdvisorRepo.IsAdvisor(ref.StudentID.String(), advisorFilter.String())
+		if err != nil || !isAdvisor {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // CreateAchievementService godoc
 // @Summary Mahasiswa membuat achievement (Mongo) + reference draft (Postgres)
+// @Description Upload multipart: default (strict=true) satu attachment tidak valid menggagalkan create. Dengan strict=false file tidak valid dilewati dan dilaporkan di data.rejected_attachments. competitionLevel harus salah satu level di ACHIEVEMENT_COMPETITION_LEVELS (default local, regional, national, international); jika points kosong, points default level dipakai.
 // @Tags Achievements
 // @Accept json
 // @Produce json
-// @Param body body model.CreateAchievementRequest true "Data achievement (details mengikuti achievement_type). oneOf examples: competition {competitionName, competitionLevel, rank}, publication {publicationType, publicationTitle, authors, publisher, issn}, organization {organizationName, position, periodStart, periodEnd}, certification {certificationName, issuedBy, certificationNumber, validUntil}, academic {description, score}."
+// @Param strict query bool false "false = lewati attachment tidak valid (default: true)"
+// @Param body body model.CreateAchievementRequest true "Data achievement (details mengikuti achievement_type). Field wajib: competition {competitionName, rank}, publication {publicationTitle, publisher}, organization {organizationName, position}, certification {certificationName, issuedBy}, academic {score}. oneOf examples: competition {competitionName, competitionLevel, rank}, publication {publicationType, publicationTitle, authors, publisher, issn}, organization {organizationName, position, periodStart, periodEnd}, certification {certificationName, issuedBy, certificationNumber, validUntil}, academic {description, score}."
 // @Success 201 {object} map[string]interface{}
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
+// @Failure 413 {object} model.ErrorResponse "Body melebihi UPLOAD_BODY_LIMIT_MB"
 // @Failure 500 {object} model.ErrorResponse
 // @Router /v1/achievements [post]
 // @Security BearerAuth
@@ -228,41 +525,40 @@ func CreateAchievementService(c *fiber.Ctx) error {
 		userIDVal := c.Locals("user_id")
 		userID, ok := userIDVal.(string)
 		if userIDVal == nil || !ok || strings.TrimSpace(userID) == "" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "User tidak valid",
-			})
+			return errorJSON(c, fiber.StatusForbidden, "User tidak valid")
 		}
-		st, err := achievementStudentRepo.GetStudentByUserID(userID)
+		st, err := achievementStudentRepo.GetStudentByUserID(c.UserContext(), userID)
 		if err != nil || st == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "mahasiswa tidak memiliki student_id",
-			})
+			return errorJSON(c, fiber.StatusForbidden, "mahasiswa tidak memiliki student_id")
 		}
 		studentUUID = st.ID
 		c.Locals("student_uuid", studentUUID)
 	}
 
 	var req model.CreateAchievementRequest
+	var rejected []model.RejectedAttachment
 	ct := strings.ToLower(c.Get("Content-Type"))
 	if strings.HasPrefix(ct, "multipart/") {
-		parsed, err := parseMultipartCreateAchievement(c)
+		// strict=false: file yang tidak valid dilewati (alasan dikembalikan di response), bukan menggagalkan create
+		strict := !strings.EqualFold(strings.TrimSpace(c.Query("strict")), "false")
+		parsed, skipped, err := parseMultipartCreateAchievement(c, strict)
+		if errors.Is(err, errBodyTooLarge) {
+			return errorJSON(c, fiber.StatusRequestEntityTooLarge, err.Error())
+		}
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": err.Error(),
-			})
+			return errorJSON(c, fiber.StatusBadRequest, err.Error())
 		}
 		req = *parsed
+		rejected = skipped
 	} else {
 		if err := c.BodyParser(&req); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": "Request body tidak valid",
-				"error":   err.Error(),
-			})
+			return errorWithDetail(c, fiber.StatusBadRequest, "Request body tidak valid", err)
+		}
+		tags, err := normalizeTags(req.Tags)
+		if err != nil {
+			return errorJSON(c, fiber.StatusBadRequest, err.Error())
 		}
+		req.Tags = tags
 	}
 
 	req.AchievementType = strings.ToLower(strings.TrimSpace(req.AchievementType))
@@ -270,55 +566,60 @@ func CreateAchievementService(c *fiber.Ctx) error {
 	req.Description = strings.TrimSpace(req.Description)
 
 	if req.AchievementType == "" || req.Title == "" || req.Description == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "achievement_type, title, dan description wajib diisi",
-		})
+		return errorJSON(c, fiber.StatusBadRequest, "achievement_type, title, dan description wajib diisi")
+	}
+	if descriptionTooLong(req.Description) {
+		return errorJSON(c, fiber.StatusBadRequest, "description terlalu panjang")
+	}
+
+	if _, ok := model.AllowedAchievementTypes[req.AchievementType]; !ok {
+		return errorJSON(c, fiber.StatusBadRequest, "achievement_type tidak dikenal")
 	}
 
 	normalizedDetails, err := normalizeDetails(req.AchievementType, req.Details)
 	if err != nil {
+		return errorJSON(c, fiber.StatusBadRequest, err.Error())
+	}
+	req.Details = normalizedDetails
+	if req.Points == nil && req.AchievementType == "competition" {
+		req.Points = defaultCompetitionPoints(req.Details)
+	}
+
+	if missing := missingDetailFields(req.AchievementType, req.Details); len(missing) > 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": err.Error(),
+			"success":        false,
+			"message":        fmt.Sprintf("details untuk %s wajib memuat: %s", req.AchievementType, strings.Join(missing, ", ")),
+			"missing_fields": missing,
 		})
 	}
-	req.Details = normalizedDetails
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
 	mongoID, err := achievementMongoRepo.Create(ctx, studentUUID, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal menyimpan achievement",
-			"error":   err.Error(),
-		})
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal menyimpan achievement", err)
 	}
 
 	refID, err := achievementRefRepo.CreateDraft(ctx, studentUUID, mongoID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal membuat reference",
-			"error":   err.Error(),
-		})
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal membuat reference", err)
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"success": true,
-		"message": "Achievement berhasil dibuat",
-		"data": fiber.Map{
-			"reference_id":         refID,
-			"mongo_achievement_id": mongoID,
-			"status":               model.AchievementStatusDraft,
-		},
-	})
+	data := fiber.Map{
+		"reference_id":         refID,
+		"mongo_achievement_id": mongoID,
+		"status":               model.AchievementStatusDraft,
+	}
+	if len(rejected) > 0 {
+		data["rejected_attachments"] = rejected
+	}
+	return successJSON(c, fiber.StatusCreated, "Achievement berhasil dibuat", data)
 }
 
 // SubmitAchievementService godoc
 // @Summary Mahasiswa submit achievement (draft -> submitted)
+// @Description Tipe yang requires_attachment (competition, certification) wajib punya attachment; nonaktifkan dengan ACHIEVEMENT_STRICT_EVIDENCE=false
 // @Tags Achievements
 // @Accept json
 // @Produce json
@@ -334,10 +635,7 @@ func CreateAchievementService(c *fiber.Ctx) error {
 func SubmitAchievementService(c *fiber.Ctx) error {
 	refID := strings.TrimSpace(c.Params("id"))
 	if refID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "ID reference harus diisi",
-		})
+		return errorJSON(c, fiber.StatusBadRequest, "ID reference harus diisi")
 	}
 
 	studentUUID, ok := c.Locals("student_uuid").(uuid.UUID)
@@ -345,47 +643,109 @@ func SubmitAchievementService(c *fiber.Ctx) error {
 		userIDVal := c.Locals("user_id")
 		userID, ok := userIDVal.(string)
 		if userIDVal == nil || !ok || strings.TrimSpace(userID) == "" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "User tidak valid",
-			})
+			return errorJSON(c, fiber.StatusForbidden, "User tidak valid")
 		}
-		st, err := achievementStudentRepo.GetStudentByUserID(userID)
+		st, err := achievementStudentRepo.GetStudentByUserID(c.UserContext(), userID)
 		if err != nil || st == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "mahasiswa tidak memiliki student_id",
-			})
+			return errorJSON(c, fiber.StatusForbidden, "mahasiswa tidak memiliki student_id")
 		}
 		studentUUID = st.ID
 		c.Locals("student_uuid", studentUUID)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
+	ref, errResp := loadStudentTransitionRef(ctx, c, refID, studentUUID, model.AchievementActionSubmit,
+		"achievement tidak ditemukan atau bukan milik anda atau status bukan draft")
+	if ref == nil {
+		return errResp
+	}
+
+	if strictEvidenceEnabled() {
+		msg, err := missingRequiredEvidence(ctx, ref)
+		if err != nil {
+			return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal memeriksa lampiran achievement", err)
+		}
+		if msg != "" {
+			return errorJSON(c, fiber.StatusBadRequest, msg)
+		}
+	}
+
 	if err := achievementRefRepo.SubmitDraft(ctx, refID, studentUUID); err != nil {
 		msg := strings.ToLower(err.Error())
 		if strings.Contains(msg, "tidak ditemukan") || strings.Contains(msg, "bukan milik") {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": err.Error(),
-			})
+			return errorJSON(c, fiber.StatusNotFound, err.Error())
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": err.Error(),
-		})
+		return errorJSON(c, fiber.StatusBadRequest, err.Error())
 	}
 
-	return c.JSON(model.SuccessResponse{
-		Success: true,
-		Message: "Status achievement berubah ke submitted",
-	})
+	return successJSON(c, fiber.StatusOK, "Status achievement berubah ke submitted", nil)
+}
+
+// ReopenAchievementService godoc
+// @Summary Mahasiswa membuka kembali achievement rejected (rejected -> draft)
+// @Description Achievement rejected milik sendiri dikembalikan ke draft (rejection_note dikosongkan) agar bisa direvisi lalu di-submit ulang lewat /submit
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement reference ID (UUID)"
+// @Success 200 {object} model.SuccessResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse "Status bukan rejected"
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievements/{id}/reopen [put]
+// @Security BearerAuth
+func ReopenAchievementService(c *fiber.Ctx) error {
+	refID := strings.TrimSpace(c.Params("id"))
+	if refID == "" {
+		return errorJSON(c, fiber.StatusBadRequest, "ID reference harus diisi")
+	}
+
+	studentUUID, ok := c.Locals("student_uuid").(uuid.UUID)
+	if !ok {
+		userIDVal := c.Locals("user_id")
+		userID, ok := userIDVal.(string)
+		if userIDVal == nil || !ok || strings.TrimSpace(userID) == "" {
+			return errorJSON(c, fiber.StatusForbidden, "User tidak valid")
+		}
+		st, err := achievementStudentRepo.GetStudentByUserID(c.UserContext(), userID)
+		if err != nil || st == nil {
+			return errorJSON(c, fiber.StatusForbidden, "mahasiswa tidak memiliki student_id")
+		}
+		studentUUID = st.ID
+		c.Locals("student_uuid", studentUUID)
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	// milik mahasiswa lain diperlakukan sama dengan tidak ada agar keberadaannya tidak bocor
+	ref, err := achievementRefRepo.GetByID(ctx, refID)
+	if err != nil || ref == nil || ref.StudentID != studentUUID {
+		return errorJSON(c, fiber.StatusNotFound, "achievement tidak ditemukan atau bukan milik anda")
+	}
+	if !achievementTransitionAllowed("mahasiswa", ref.Status, model.AchievementActionReopen) {
+		return errorJSON(c, fiber.StatusConflict, "hanya achievement rejected yang dapat dibuka kembali")
+	}
+
+	if err := achievementRefRepo.RevertToDraft(ctx, refID, studentUUID); err != nil {
+		// status berubah di antara GetByID dan update (mis. reopen ganda)
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return errorJSON(c, fiber.StatusConflict, "hanya achievement rejected yang dapat dibuka kembali")
+		}
+		return errorJSON(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return successJSON(c, fiber.StatusOK, "Status achievement berubah ke draft", nil)
 }
 
 // ReviewAchievementService godoc
 // @Summary Dosen review achievement (submitted -> verified/rejected)
+// @Description points opsional: saat verified, points achievement di Mongo diganti nilai tersebut (harus >= 0); diabaikan untuk rejected.
 // @Tags Achievements
 // @Accept json
 // @Produce json
@@ -394,225 +754,509 @@ func SubmitAchievementService(c *fiber.Ctx) error {
 // @Success 200 {object} model.SuccessResponse
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
-// @Failure 403 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse "Dosen wali bukan advisor mahasiswa ini"
 // @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse "Sudah diproses"
 // @Failure 500 {object} model.ErrorResponse
 // @Router /v1/achievements/{id}/review [put]
 // @Security BearerAuth
 func ReviewAchievementService(c *fiber.Ctx) error {
 	refID := strings.TrimSpace(c.Params("id"))
 	if refID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "ID reference harus diisi",
-		})
+		return errorJSON(c, fiber.StatusBadRequest, "ID reference harus diisi")
 	}
 
 	var req model.UpdateAchievementStatusRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Request body tidak valid",
-			"error":   err.Error(),
-		})
+		return errorWithDetail(c, fiber.StatusBadRequest, "Request body tidak valid", err)
 	}
 
 	req.Status = strings.ToLower(strings.TrimSpace(req.Status))
+	if req.Points != nil && *req.Points < 0 {
+		return errorJSON(c, fiber.StatusBadRequest, "points tidak boleh negatif")
+	}
 
 	roleName, err := resolveRoleName(c)
 	if err != nil {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"success": false,
-			"message": err.Error(),
-		})
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
 	}
 
 	userIDVal := c.Locals("user_id")
 	userIDStr, ok := userIDVal.(string)
 	if userIDVal == nil || !ok || userIDStr == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "Unauthorized",
-		})
+		return errorJSON(c, fiber.StatusUnauthorized, "Unauthorized")
 	}
 	actorID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "Unauthorized",
-		})
+		return errorJSON(c, fiber.StatusUnauthorized, "Unauthorized")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	switch roleName {
 	case "admin":
 		if req.Status == model.AchievementStatusRejected {
 			if req.RejectionNote == nil || strings.TrimSpace(*req.RejectionNote) == "" {
-				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-					"success": false,
-					"message": "rejection_note wajib diisi jika status rejected",
-				})
+				return errorJSON(c, fiber.StatusBadRequest, "rejection_note wajib diisi jika status rejected")
 			}
 		}
 		if req.Status != model.AchievementStatusVerified &&
 			req.Status != model.AchievementStatusRejected {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": "Status harus verified/rejected",
-			})
+			return errorJSON(c, fiber.StatusBadRequest, "Status harus verified/rejected")
+		}
+		restorePoints, err := applyReviewPoints(ctx, refID, req, adminReviewGuard)
+		if err != nil {
+			return reviewPointsError(c, err)
 		}
 		if err := achievementRefRepo.Review(ctx, refID, req.Status, actorID, req.RejectionNote); err != nil {
+			restorePoints()
 			msg := strings.ToLower(err.Error())
 			if strings.Contains(msg, "tidak ditemukan") {
-				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-					"success": false,
-					"message": err.Error(),
-				})
+				return errorJSON(c, fiber.StatusNotFound, err.Error())
 			}
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": err.Error(),
-			})
+			return errorJSON(c, fiber.StatusBadRequest, err.Error())
 		}
 	case "dosen wali":
 		if req.Status == model.AchievementStatusRejected {
 			if req.RejectionNote == nil || strings.TrimSpace(*req.RejectionNote) == "" {
-				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-					"success": false,
-					"message": "rejection_note wajib diisi jika status rejected",
-				})
+				return errorJSON(c, fiber.StatusBadRequest, "rejection_note wajib diisi jika status rejected")
 			}
 		}
 		if req.Status != model.AchievementStatusVerified &&
 			req.Status != model.AchievementStatusRejected {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": "Status harus verified/rejected",
-			})
+			return errorJSON(c, fiber.StatusBadRequest, "Status harus verified/rejected")
 		}
-		ref, err := achievementRefRepo.GetByID(ctx, refID)
-		if err != nil {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": err.Error(),
-			})
-		}
-		if ref.Status != model.AchievementStatusSubmitted {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": "Hanya boleh memproses status submitted",
-			})
-		}
-		lect, err := achievementLecturerRepo.GetLecturerByUserID(userIDStr)
+		lect, err := achievementLecturerRepo.GetLecturerByUserID(c.UserContext(), userIDStr)
 		if err != nil || lect == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Dosen wali tidak ditemukan",
-			})
-		}
-		st, err := achievementStudentRepo.GetStudentByID(ref.StudentID.String())
-		if err != nil || st == nil || st.AdvisorID == nil || st.AdvisorID.String() != lect.ID.String() {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Tidak berhak memproses mahasiswa ini",
-			})
+			return errorJSON(c, fiber.StatusForbidden, "Dosen wali tidak ditemukan")
 		}
-		if err := achievementRefRepo.Review(ctx, refID, req.Status, actorID, req.RejectionNote); err != nil {
+		// status submitted + kepemilikan bimbingan dicek di satu UPDATE (tanpa GetByID terpisah)
+		restorePoints, err := applyReviewPoints(ctx, refID, req, advisorReviewGuard(lect.ID))
+		if err != nil {
+			return reviewPointsError(c, err)
+		}
+		if err := achievementRefRepo.ReviewByAdvisor(ctx, refID, req.Status, actorID, lect.ID, req.RejectionNote); err != nil {
+			restorePoints()
 			msg := strings.ToLower(err.Error())
-			if strings.Contains(msg, "tidak ditemukan") {
-				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-					"success": false,
-					"message": err.Error(),
-				})
+			if strings.Contains(msg, "sudah diproses atau tidak berhak") {
+				return advisorReviewRejected(ctx, c, refID, lect.ID, err)
 			}
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": err.Error(),
-			})
+			return errorJSON(c, fiber.StatusBadRequest, err.Error())
 		}
 	default:
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"success": false,
-			"message": "Role tidak diperbolehkan untuk aksi ini",
-		})
+		return errorJSON(c, fiber.StatusForbidden, "Role tidak diperbolehkan untuk aksi ini")
 	}
 
-	return c.JSON(model.SuccessResponse{
-		Success: true,
-		Message: "Status achievement berhasil diupdate",
-	})
+	note := ""
+	if req.RejectionNote != nil {
+		note = strings.TrimSpace(*req.RejectionNote)
+	}
+	if _, noop := achievementNotifier.(utils.NoopNotifier); !noop {
+		go notifyAchievementStatusChanged(strings.Clone(refID), req.Status, note)
+	}
+	if achievementWebhook != nil {
+		go dispatchReviewWebhook(achievementWebhook, strings.Clone(refID), req.Status)
+	}
+
+	return successJSON(c, fiber.StatusOK, "Status achievement berhasil diupdate", nil)
 }
 
-// SoftDeleteAchievementService godoc
-// @Summary Mahasiswa menghapus (soft delete) draft achievement reference (draft -> deleted)
+// advisorReviewRejected memetakan UPDATE ReviewByAdvisor yang tidak mengubah baris ke respons yang tepat.
+// Otorisasi tetap diputuskan oleh UPDATE yang sama (tanpa celah GetByID -> cek -> update); pembacaan di
+// sini hanya untuk membedakan penyebabnya setelah UPDATE gagal: reference tidak ada (404), mahasiswa
+// bukan bimbingan lecturer, termasuk yang belum punya advisor (403), atau sudah diproses (409).
+func advisorReviewRejected(ctx context.Context, c *fiber.Ctx, refID string, lecturerID uuid.UUID, reviewErr error) error {
+	ref, err := achievementRefRepo.GetByID(ctx, refID)
+	if err != nil || ref == nil {
+		return errorJSON(c, fiber.StatusNotFound, "achievement reference tidak ditemukan")
+	}
+	isAdvisor, err := achievementAdvisorRepo.IsAdvisor(ref.StudentID.String(), lecturerID.String())
+	if err != nil || !isAdvisor {
+		return errorJSON(c, fiber.StatusForbidden, "Tidak berhak memproses mahasiswa ini")
+	}
+	return errorJSON(c, fiber.StatusConflict, reviewErr.Error())
+}
+
+// errReviewPoints menandai kegagalan membaca/menulis points di Mongo saat review.
+var errReviewPoints = errors.New("gagal mengupdate points achievement")
+
+var (
+	errReviewForbidden    = errors.New("Tidak berhak memproses mahasiswa ini")
+	errReviewNotSubmitted = errors.New("achievement sudah diproses")
+)
+
+// adminReviewGuard syarat review admin yang sama dengan UPDATE di Review: status harus submitted.
+func adminReviewGuard(ref *model.AchievementReference) error {
+	if !achievementTransitionAllowed("admin", ref.Status, model.AchievementActionVerify) {
+		return errors.New("achievement tidak ditemukan atau status bukan submitted")
+	}
+	return nil
+}
+
+// advisorReviewGuard syarat review dosen wali yang sama dengan UPDATE di ReviewByAdvisor:
+// mahasiswa bimbingan lecturerID dan status submitted.
+func advisorReviewGuard(lecturerID uuid.UUID) func(*model.AchievementReference) error {
+	return func(ref *model.AchievementReference) error {
+		isAdvisor, err := achievementAdvisorRepo.IsAdvisor(ref.StudentID.String(), lecturerID.String())
+		if err != nil || !isAdvisor {
+			return errReviewForbidden
+		}
+		if !achievementTransitionAllowed("dosen wali", ref.Status, model.AchievementActionVerify) {
+			return errReviewNotSubmitted
+		}
+		return nil
+	}
+}
+
+// applyReviewPoints menulis req.Points ke dokumen Mongo sebelum reference ditandai verified.
+// guard (kepemilikan dan status) dicek lebih dulu sehingga caller yang tidak berhak tidak pernah
+// menulis points. Fungsi yang dikembalikan mengembalikan points lama dan dipanggil jika review gagal,
+// agar points dan status tetap konsisten sebagai satu operasi. Tanpa points atau untuk rejected tidak ada efek.
+func applyReviewPoints(ctx context.Context, refID string, req model.UpdateAchievementStatusRequest, guard func(*model.AchievementReference) error) (func(), error) {
+	noop := func() {}
+	if req.Points == nil || req.Status != model.AchievementStatusVerified {
+		return noop, nil
+	}
+
+	ref, err := achievementRefRepo.GetByID(ctx, refID)
+	if err != nil {
+		return noop, err
+	}
+	if ref == nil {
+		return noop, errors.New("achievement reference tidak ditemukan")
+	}
+	if err := guard(ref); err != nil {
+		return noop, err
+	}
+
+	docs, err := achievementMongoRepo.GetByIDs(ctx, []string{ref.MongoAchievementID})
+	if err != nil {
+		return noop, fmt.Errorf("%w: %v", errReviewPoints, err)
+	}
+	var previous *float64
+	if len(docs) > 0 {
+		previous = docs[0].Points
+	}
+
+	if err := achievementMongoRepo.UpdatePoints(ctx, ref.MongoAchievementID, req.Points); err != nil {
+		return noop, fmt.Errorf("%w: %v", errReviewPoints, err)
+	}
+	return func() {
+		if err := achievementMongoRepo.UpdatePoints(ctx, ref.MongoAchievementID, previous); err != nil {
+			log.Printf("[WARNING] gagal mengembalikan points achievement %s: %v", refID, err)
+		}
+	}, nil
+}
+
+// reviewPointsError memetakan error applyReviewPoints ke response.
+func reviewPointsError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errReviewPoints) {
+		return errorWithDetail(c, fiber.StatusInternalServerError, errReviewPoints.Error(), err)
+	}
+	if errors.Is(err, errReviewForbidden) {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+	if errors.Is(err, errReviewNotSubmitted) {
+		return errorJSON(c, fiber.StatusConflict, err.Error())
+	}
+	if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+		return errorJSON(c, fiber.StatusNotFound, err.Error())
+	}
+	return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data achievement", err)
+}
+
+// maxBulkReviewIDs batas jumlah id per request bulk review.
+const maxBulkReviewIDs = 100
+
+// bulkReviewErrorMessage alasan gagal per id. Error DB/driver (dibungkus %w oleh repository) selalu dicatat
+// ke log dan, seperti errorWithDetail, detailnya disembunyikan di production.
+func bulkReviewErrorMessage(refID string, err error) string {
+	if errors.Unwrap(err) == nil {
+		return err.Error()
+	}
+	log.Printf("[ERROR] bulk review achievement %s: %v", refID, err)
+	if isProduction() {
+		return "Gagal review achievement"
+	}
+	return err.Error()
+}
+
+// BulkReviewAchievementService godoc
+// @Summary Review banyak achievement sekaligus (submitted -> verified/rejected)
+// @Description Setiap id diproses dengan otorisasi dan guard status yang sama seperti review tunggal; tiap id atomik dan independen, hasil dikembalikan per id. Maks 100 id.
 // @Tags Achievements
 // @Accept json
 // @Produce json
-// @Param id path string true "Achievement reference ID (UUID)"
-// @Success 200 {object} model.SuccessResponse
+// @Param body body model.BulkReviewAchievementRequest true "Daftar id reference, status, dan rejection_note"
+// @Success 200 {object} map[string]interface{} "data: {results, succeeded, failed}"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
-// @Failure 404 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
-// @Router /v1/achievements/{id}/soft-delete [put]
+// @Router /v1/achievements/bulk-review [put]
 // @Security BearerAuth
-func SoftDeleteAchievementService(c *fiber.Ctx) error {
-	refID := strings.TrimSpace(c.Params("id"))
-	if refID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "ID reference harus diisi",
-		})
+func BulkReviewAchievementService(c *fiber.Ctx) error {
+	var req model.BulkReviewAchievementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorWithDetail(c, fiber.StatusBadRequest, "Request body tidak valid", err)
 	}
 
-	userIDVal := c.Locals("user_id")
-	userIDStr, ok := userIDVal.(string)
-	if userIDVal == nil || !ok || userIDStr == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "Unauthorized",
-		})
+	seen := make(map[string]bool)
+	var ids []string
+	for _, id := range req.IDs {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return errorJSON(c, fiber.StatusBadRequest, "ids harus diisi")
+	}
+	if len(ids) > maxBulkReviewIDs {
+		return errorJSON(c, fiber.StatusBadRequest, fmt.Sprintf("ids maksimal %d", maxBulkReviewIDs))
+	}
+
+	req.Status = strings.ToLower(strings.TrimSpace(req.Status))
+	if req.Status != model.AchievementStatusVerified && req.Status != model.AchievementStatusRejected {
+		return errorJSON(c, fiber.StatusBadRequest, "Status harus verified/rejected")
+	}
+	if req.Status == model.AchievementStatusRejected &&
+		(req.RejectionNote == nil || strings.TrimSpace(*req.RejectionNote) == "") {
+		return errorJSON(c, fiber.StatusBadRequest, "rejection_note wajib diisi jika status rejected")
+	}
+
+	roleName, err := resolveRoleName(c)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+
+	userIDStr, ok := c.Locals("user_id").(string)
+	if !ok || userIDStr == "" {
+		return errorJSON(c, fiber.StatusUnauthorized, "Unauthorized")
+	}
+	actorID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return errorJSON(c, fiber.StatusUnauthorized, "Unauthorized")
+	}
+
+	var review func(ctx context.Context, refID string) error
+	switch roleName {
+	case "admin":
+		review = func(ctx context.Context, refID string) error {
+			return achievementRefRepo.Review(ctx, refID, req.Status, actorID, req.RejectionNote)
+		}
+	case "dosen wali":
+		lect, err := achievementLecturerRepo.GetLecturerByUserID(c.UserContext(), userIDStr)
+		if err != nil || lect == nil {
+			return errorJSON(c, fiber.StatusForbidden, "Dosen wali tidak ditemukan")
+		}
+		// kepemilikan bimbingan + status submitted dicek per id di UPDATE yang sama dengan review tunggal
+		review = func(ctx context.Context, refID string) error {
+			return achievementRefRepo.ReviewByAdvisor(ctx, refID, req.Status, actorID, lect.ID, req.RejectionNote)
+		}
+	default:
+		return errorJSON(c, fiber.StatusForbidden, "Role tidak diperbolehkan untuk aksi ini")
+	}
+
+	note := ""
+	if req.RejectionNote != nil {
+		note = strings.TrimSpace(*req.RejectionNote)
+	}
+	_, noopNotifier := achievementNotifier.(utils.NoopNotifier)
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
+	defer cancel()
+
+	results := make([]model.BulkReviewResult, 0, len(ids))
+	succeeded := 0
+	for _, refID := range ids {
+		if err := review(ctx, refID); err != nil {
+			results = append(results, model.BulkReviewResult{ID: refID, Error: bulkReviewErrorMessage(refID, err)})
+			continue
+		}
+		succeeded++
+		results = append(results, model.BulkReviewResult{ID: refID, Success: true})
+		if !noopNotifier {
+			go notifyAchievementStatusChanged(refID, req.Status, note)
+		}
+		if achievementWebhook != nil {
+			go dispatchReviewWebhook(achievementWebhook, refID, req.Status)
+		}
 	}
 
+	return successJSON(c, fiber.StatusOK, "Bulk review selesai diproses", fiber.Map{
+		"results":   results,
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+	})
+}
+
+// dispatchReviewWebhook mengirim event review ke WEBHOOK_URL (best-effort, retry di Webhook.Send).
+func dispatchReviewWebhook(wh *utils.Webhook, refID, status string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	ref, err := achievementRefRepo.GetByID(ctx, refID)
+	if err != nil || ref == nil {
+		log.Printf("[WARNING] webhook achievement %s: reference tidak ditemukan: %v", refID, err)
+		return
+	}
+	payload := model.AchievementWebhookPayload{
+		RefID:     refID,
+		StudentID: ref.StudentID.String(),
+		Status:    status,
+		Timestamp: time.Now().UTC(),
+	}
+	if err := wh.Send(payload); err != nil {
+		log.Printf("[WARNING] webhook achievement %s: %v", refID, err)
+	}
+}
+
+// notifyAchievementStatusChanged mengirim email ke mahasiswa pemilik achievement.
+// Dipanggil async setelah review berhasil; kegagalan hanya di-log.
+func notifyAchievementStatusChanged(refID, status, note string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ref, err := achievementRefRepo.GetByID(ctx, refID)
+	if err != nil || ref == nil {
+		log.Printf("[WARNING] notifikasi achievement %s: reference tidak ditemukan: %v", refID, err)
+		return
+	}
+	st, err := achievementStudentRepo.GetStudentByID(ctx, ref.StudentID.String())
+	if err != nil || st == nil {
+		log.Printf("[WARNING] notifikasi achievement %s: student tidak ditemukan: %v", refID, err)
+		return
+	}
+	user, err := achievementUserRepo.GetUserByID(ctx, st.UserID.String())
+	if err != nil || user == nil {
+		log.Printf("[WARNING] notifikasi achievement %s: user tidak ditemukan: %v", refID, err)
+		return
+	}
+
+	title := ""
+	if achs, err := achievementMongoRepo.GetByIDs(ctx, []string{ref.MongoAchievementID}); err == nil && len(achs) > 0 {
+		title = achs[0].Title
+	}
+
+	if err := achievementNotifier.SendAchievementStatusChanged(user.Email, title, status, note); err != nil {
+		log.Printf("[WARNING] notifikasi achievement %s: %v", refID, err)
+	}
+}
+
+// SoftDeleteAchievementService godoc
+// @Summary Mahasiswa menghapus (soft delete) draft achievement reference (draft -> deleted)
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement reference ID (UUID)"
+// @Success 200 {object} model.SuccessResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievements/{id}/soft-delete [put]
+// @Security BearerAuth
+func SoftDeleteAchievementService(c *fiber.Ctx) error {
+	refID := strings.TrimSpace(c.Params("id"))
+	if refID == "" {
+		return errorJSON(c, fiber.StatusBadRequest, "ID reference harus diisi")
+	}
+
+	userIDVal := c.Locals("user_id")
+	userIDStr, ok := userIDVal.(string)
+	if userIDVal == nil || !ok || userIDStr == "" {
+		return errorJSON(c, fiber.StatusUnauthorized, "Unauthorized")
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
 	studentUUID, ok := c.Locals("student_uuid").(uuid.UUID)
 	if !ok {
-		st, err := achievementStudentRepo.GetStudentByUserID(userIDStr)
+		st, err := achievementStudentRepo.GetStudentByUserID(c.UserContext(), userIDStr)
 		if err != nil || st == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "mahasiswa tidak memiliki student_id",
-			})
+			return errorJSON(c, fiber.StatusForbidden, "mahasiswa tidak memiliki student_id")
 		}
 		studentUUID = st.ID
 	}
+	if ref, errResp := loadStudentTransitionRef(ctx, c, refID, studentUUID, model.AchievementActionSoftDelete,
+		"achievement tidak ditemukan atau bukan milik anda atau status bukan draft"); ref == nil {
+		return errResp
+	}
 	if err := achievementRefRepo.DeleteByStudent(ctx, refID, studentUUID); err != nil {
 		msg := strings.ToLower(err.Error())
 		if strings.Contains(msg, "tidak ditemukan") {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": err.Error(),
-			})
+			return errorJSON(c, fiber.StatusNotFound, err.Error())
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": err.Error(),
-		})
+		return errorJSON(c, fiber.StatusBadRequest, err.Error())
 	}
 
-	return c.JSON(model.SuccessResponse{
-		Success: true,
-		Message: "Status achievement berubah ke deleted (soft delete)",
-	})
+	return successJSON(c, fiber.StatusOK, "Status achievement berubah ke deleted (soft delete)", nil)
+}
+
+// AdminSoftDeleteAchievementService godoc
+// @Summary Admin menghapus (soft delete) achievement reference status apa pun (-> deleted)
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement reference ID (UUID)"
+// @Success 200 {object} model.SuccessResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievements/{id}/admin-soft-delete [put]
+// @Security BearerAuth
+func AdminSoftDeleteAchievementService(c *fiber.Ctx) error {
+	refID := strings.TrimSpace(c.Params("id"))
+	if refID == "" {
+		return errorJSON(c, fiber.StatusBadRequest, "ID reference harus diisi")
+	}
+
+	roleName, err := resolveRoleName(c)
+	if err != nil || roleName != "admin" {
+		return errorJSON(c, fiber.StatusForbidden, "Hanya admin yang dapat menghapus achievement ini")
+	}
+
+	userIDVal := c.Locals("user_id")
+	userIDStr, ok := userIDVal.(string)
+	if userIDVal == nil || !ok || userIDStr == "" {
+		return errorJSON(c, fiber.StatusUnauthorized, "Unauthorized")
+	}
+	actorID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return errorJSON(c, fiber.StatusUnauthorized, "Unauthorized")
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	ref, err := achievementRefRepo.GetByID(ctx, refID)
+	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data achievement", err)
+	}
+	if ref == nil || !achievementTransitionAllowed("admin", ref.Status, model.AchievementActionAdminSoftDelete) {
+		return errorJSON(c, fiber.StatusNotFound, "achievement tidak ditemukan atau sudah berstatus deleted")
+	}
+
+	if err := achievementRefRepo.Delete(ctx, refID, actorID); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return errorJSON(c, fiber.StatusNotFound, err.Error())
+		}
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal menghapus achievement", err)
+	}
+
+	return successJSON(c, fiber.StatusOK, "Status achievement berubah ke deleted (soft delete)", nil)
 }
 
 // HardDeleteAchievementService godoc
-// @Summary Hard delete achievement (hapus permanen Mongo + reference) untuk status deleted
+// @Summary Hard delete achievement (hapus permanen Mongo + reference + file attachment) untuk status deleted
 // @Tags Achievements
 // @Accept json
 // @Produce json
@@ -628,106 +1272,180 @@ func SoftDeleteAchievementService(c *fiber.Ctx) error {
 func HardDeleteAchievementService(c *fiber.Ctx) error {
 	refID := strings.TrimSpace(c.Params("id"))
 	if refID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "ID reference harus diisi",
-		})
+		return errorJSON(c, fiber.StatusBadRequest, "ID reference harus diisi")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	ref, err := achievementRefRepo.GetByID(ctx, refID)
 	if err != nil || ref == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "achievement reference tidak ditemukan",
-		})
+		return errorJSON(c, fiber.StatusNotFound, "achievement reference tidak ditemukan")
 	}
-	if ref.Status != model.AchievementStatusDeleted {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Hard delete hanya boleh untuk status deleted",
-		})
+	if !achievementTransitionAllowed("admin", ref.Status, model.AchievementActionHardDelete) {
+		return errorJSON(c, fiber.StatusBadRequest, "Hard delete hanya boleh untuk status deleted")
 	}
 
-	if err := achievementMongoRepo.Delete(ctx, ref.MongoAchievementID); err != nil {
-		msg := strings.ToLower(err.Error())
-		if strings.Contains(msg, "tidak ditemukan") {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": err.Error(),
-			})
+	if err := purgeAchievement(ctx, ref); err != nil {
+		if errors.Is(err, errAchievementLookup) {
+			return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data achievement", err)
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": err.Error(),
-		})
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return errorJSON(c, fiber.StatusNotFound, err.Error())
+		}
+		return errorJSON(c, fiber.StatusBadRequest, err.Error())
 	}
 
-	if err := achievementRefRepo.HardDelete(ctx, refID); err != nil {
-		msg := strings.ToLower(err.Error())
-		if strings.Contains(msg, "tidak ditemukan") {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": err.Error(),
-		})
+	return successJSON(c, fiber.StatusOK, "Achievement dihapus permanen", nil)
+}
+
+// errAchievementLookup menandai kegagalan mengambil dokumen Mongo sebelum hard delete.
+var errAchievementLookup = errors.New("gagal mengambil data achievement")
+
+// mongoDocumentGone true jika Delete gagal karena dokumen tidak ada atau id-nya bukan ObjectID valid.
+func mongoDocumentGone(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "tidak ditemukan") || strings.Contains(msg, "invalid mongo achievement id")
+}
+
+// purgeAchievement menghapus permanen dokumen Mongo, reference (harus berstatus deleted),
+// lalu file attachment-nya. Dipakai endpoint hard delete dan job cleanup.
+func purgeAchievement(ctx context.Context, ref *model.AchievementReference) error {
+	// ambil daftar attachment sebelum dokumen Mongo dihapus
+	var attachments []model.Attachment
+	docs, err := achievementMongoRepo.GetByIDs(ctx, []string{ref.MongoAchievementID})
+	if err != nil {
+		return fmt.Errorf("%w: %v", errAchievementLookup, err)
+	}
+	if len(docs) > 0 {
+		attachments = docs[0].Attachments
 	}
 
-	return c.JSON(model.SuccessResponse{
-		Success: true,
-		Message: "Achievement dihapus permanen",
-	})
+	// dokumen yang sudah tidak ada atau id Mongo yang rusak tidak bisa dihapus lagi; reference tetap
+	// di-hard delete agar job cleanup tidak gagal di reference yang sama setiap kali jalan
+	if err := achievementMongoRepo.Delete(ctx, ref.MongoAchievementID); err != nil && !mongoDocumentGone(err) {
+		return err
+	}
+
+	if err := achievementRefRepo.HardDelete(ctx, ref.ID.String()); err != nil {
+		return err
+	}
+
+	removeAttachmentFiles(attachments)
+	return nil
 }
 
-// GetAchievementsService godoc
-// @Summary Daftar semua achievements (Mongo)
+// AdminReassignAchievementService godoc
+// @Summary Admin memindahkan kepemilikan achievement ke student lain
+// @Description Update student_id di reference (Postgres) dan studentId di dokumen Mongo; reference di-rollback jika update Mongo gagal.
 // @Tags Achievements
 // @Accept json
 // @Produce json
-// @Param page query int false "Halaman (default 1)"
-// @Param limit query int false "Jumlah per halaman (default 10)"
-// @Success 200 {object} map[string]interface{}
+// @Param id path string true "Achievement reference ID (UUID)"
+// @Param body body model.ReassignAchievementRequest true "Student tujuan"
+// @Success 200 {object} model.SuccessResponse
+// @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
-// @Router /v1/achievements [get]
+// @Router /v1/achievements/{id}/reassign [put]
 // @Security BearerAuth
-func GetAchievementsService(c *fiber.Ctx) error {
-	page := int64(c.QueryInt("page", 1))
-	limit := int64(c.QueryInt("limit", 10))
+func AdminReassignAchievementService(c *fiber.Ctx) error {
+	refID := strings.TrimSpace(c.Params("id"))
+	if refID == "" {
+		return errorJSON(c, fiber.StatusBadRequest, "ID reference harus diisi")
+	}
 
 	roleName, err := resolveRoleName(c)
-	if err != nil {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"success": false,
-			"message": err.Error(),
-		})
+	if err != nil || roleName != "admin" {
+		return errorJSON(c, fiber.StatusForbidden, "Hanya admin yang dapat memindahkan achievement")
 	}
 
-	statuses, studentFilter, advisorFilter, err := allowedStatusesByRole(c, roleName, true)
+	var req model.ReassignAchievementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorWithDetail(c, fiber.StatusBadRequest, "Request body tidak valid", err)
+	}
+	targetID, err := uuid.Parse(strings.TrimSpace(req.StudentID))
 	if err != nil {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"success": false,
-			"message": err.Error(),
-		})
+		return errorJSON(c, fiber.StatusBadRequest, "student_id harus UUID yang valid")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	target, err := achievementStudentRepo.GetStudentByID(c.UserContext(), targetID.String())
+	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data student tujuan", err)
+	}
+	if target == nil {
+		return errorJSON(c, fiber.StatusNotFound, "Student tujuan tidak ditemukan")
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
-	refs, total, err := achievementRefRepo.ListByStatuses(ctx, statuses, studentFilter, advisorFilter, page, limit)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengambil achievement references",
-			"error":   err.Error(),
-		})
+	ref, err := achievementRefRepo.GetByID(ctx, refID)
+	if err != nil || ref == nil {
+		return errorJSON(c, fiber.StatusNotFound, "achievement reference tidak ditemukan")
+	}
+	if ref.StudentID == target.ID {
+		return errorJSON(c, fiber.StatusBadRequest, "Achievement sudah dimiliki student tersebut")
+	}
+	previousStudentID := ref.StudentID
+
+	if err := achievementRefRepo.UpdateStudentID(ctx, refID, target.ID); err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal memindahkan achievement reference", err)
+	}
+
+	if err := achievementMongoRepo.UpdateStudentID(ctx, ref.MongoAchievementID, target.ID); err != nil {
+		// rollback reference agar Postgres dan Mongo tetap konsisten
+		if rbErr := achievementRefRepo.UpdateStudentID(ctx, refID, previousStudentID); rbErr != nil {
+			return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal memindahkan achievement dan rollback reference gagal", fmt.Errorf("%v; rollback: %w", err, rbErr))
+		}
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal memindahkan achievement", err)
+	}
+
+	return successJSON(c, fiber.StatusOK, "Achievement berhasil dipindahkan", nil)
+}
+
+// summaryPageSize ukuran batch saat mengumpulkan seluruh achievement verified untuk summary.
+const summaryPageSize int64 = 100
+
+// GetMyAchievementSummaryService godoc
+// @Summary Ringkasan prestasi terverifikasi milik mahasiswa (public-safe)
+// @Description Mengembalikan achievement berstatus verified tanpa id internal beserta total poin, untuk halaman profil yang bisa dibagikan
+// @Tags Students
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.StudentAchievementSummary
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/students/me/summary [get]
+// @Security BearerAuth
+func GetMyAchievementSummaryService(c *fiber.Ctx) error {
+	userIDVal := c.Locals("user_id")
+	userIDStr, ok := userIDVal.(string)
+	if userIDVal == nil || !ok || userIDStr == "" {
+		return errorJSON(c, fiber.StatusUnauthorized, "Unauthorized")
+	}
+
+	st, err := achievementStudentRepo.GetStudentByUserID(c.UserContext(), userIDStr)
+	if err != nil || st == nil {
+		return errorJSON(c, fiber.StatusForbidden, "Hanya mahasiswa yang dapat mengakses")
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	var refs []model.AchievementReference
+	for page := int64(1); ; page++ {
+		batch, total, err := achievementRefRepo.ListByStatuses(ctx, []string{model.AchievementStatusVerified}, &st.ID, nil, page, summaryPageSize, model.AchievementReferenceFilter{})
+		if err != nil {
+			return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil achievement references", err)
+		}
+		refs = append(refs, batch...)
+		if len(batch) == 0 || int64(len(refs)) >= total {
+			break
+		}
 	}
 
 	var ids []string
@@ -736,93 +1454,895 @@ func GetAchievementsService(c *fiber.Ctx) error {
 	}
 	achievements, err := achievementMongoRepo.GetByIDs(ctx, ids)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengambil data achievements",
-			"error":   err.Error(),
-		})
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data achievements", err)
 	}
-
 	achMap := make(map[string]model.Achievement)
 	for _, a := range achievements {
 		achMap[a.ID.Hex()] = a
 	}
 
-	var combined []model.AchievementWithReference
+	summary := model.StudentAchievementSummary{
+		StudentNumber: st.StudentID,
+		ProgramStudy:  st.ProgramStudy,
+		AcademicYear:  st.AcademicYear,
+		Achievements:  []model.PublicAchievement{},
+	}
+	if user, err := achievementUserRepo.GetUserByID(c.UserContext(), userIDStr); err == nil && user != nil {
+		summary.FullName = user.FullName
+	}
 	for _, r := range refs {
-		if a, ok := achMap[r.MongoAchievementID]; ok {
-			combined = append(combined, model.AchievementWithReference{
-				Achievement: a,
-				Reference:   r,
-			})
-		} else {
-			combined = append(combined, model.AchievementWithReference{
-				Reference: r,
-			})
+		// defensif: hanya verified yang boleh tampil di summary publik
+		if r.Status != model.AchievementStatusVerified {
+			continue
+		}
+		a, ok := achMap[r.MongoAchievementID]
+		if !ok {
+			continue
+		}
+		summary.Achievements = append(summary.Achievements, model.PublicAchievement{
+			AchievementType: a.AchievementType,
+			Title:           a.Title,
+			Description:     a.Description,
+			Details:         a.Details,
+			Tags:            a.Tags,
+			Points:          a.Points,
+			VerifiedAt:      r.VerifiedAt,
+		})
+		if a.Points != nil {
+			summary.TotalPoints += *a.Points
 		}
 	}
+	summary.TotalVerified = len(summary.Achievements)
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Data achievements berhasil diambil",
-		"data":    combined,
-		"total":   total,
-		"page":    page,
-		"limit":   limit,
-	})
+	return successJSON(c, fiber.StatusOK, "Ringkasan prestasi berhasil diambil", summary)
 }
 
-// GetAchievementReferencesService godoc
-// @Summary Daftar semua achievement references (Postgres)
+// GetAchievementsService godoc
+// @Summary Daftar semua achievements (Mongo)
 // @Tags Achievements
 // @Accept json
 // @Produce json
 // @Param page query int false "Halaman (default 1)"
-// @Param limit query int false "Jumlah per halaman (default 10)"
+// @Param limit query int false "Jumlah per halaman (default DEFAULT_PAGE_LIMIT, 10)"
+// @Param expand query string false "student: sertakan nama, NIM, dan program studi student (plus nama advisor untuk dosen wali)"
 // @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.ErrorResponse "Parameter page/limit tidak valid"
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
-// @Router /v1/achievement-references [get]
+// @Router /v1/achievements [get]
 // @Security BearerAuth
-func GetAchievementReferencesService(c *fiber.Ctx) error {
-	page := int64(c.QueryInt("page", 1))
-	limit := int64(c.QueryInt("limit", 10))
+func GetAchievementsService(c *fiber.Ctx) error {
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
+	}
 
 	roleName, err := resolveRoleName(c)
 	if err != nil {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"success": false,
-			"message": err.Error(),
-		})
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
 	}
 
-	statuses, studentFilter, advisorFilter, err := allowedStatusesByRole(c, roleName, false)
+	statuses, studentFilter, advisorFilter, err := allowedStatusesByRole(c, roleName, true)
 	if err != nil {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"success": false,
-			"message": err.Error(),
-		})
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 	defer cancel()
 
-	data, total, err := achievementRefRepo.ListByStatuses(ctx, statuses, studentFilter, advisorFilter, page, limit)
+	refs, total, err := achievementRefRepo.ListByStatuses(ctx, statuses, studentFilter, advisorFilter, page, limit, model.AchievementReferenceFilter{})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengambil achievement references",
-			"error":   err.Error(),
-		})
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil achievement references", err)
+	}
+
+	now, slaDays := time.Now(), reviewSLADays()
+	var ids []string
+	for i := range refs {
+		applyReviewSLA(&refs[i], now, slaDays)
+		ids = append(ids, refs[i].MongoAchievementID)
+	}
+	achievements, err := achievementMongoRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data achievements", err)
+	}
+
+	combined := combineByReferenceOrder(refs, achievements)
+
+	if strings.EqualFold(strings.TrimSpace(c.Query("expand")), "student") {
+		if err := expandStudents(ctx, combined, roleName == "dosen wali"); err != nil {
+			return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data student", err)
+		}
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Data achievement references berhasil diambil",
-		"data":    data,
+		"message": "Data achievements berhasil diambil",
+		"data":    combined,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+// GetAchievementCountService godoc
+// @Summary Jumlah achievements dalam scope pemanggil
+// @Description Hanya mengembalikan total (scope sama dengan GET /v1/achievements) tanpa mengambil data, untuk badge dashboard.
+// @Tags Achievements
+// @Produce json
+// @Success 200 {object} map[string]interface{} "{success, message, total}"
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievements/count [get]
+// @Security BearerAuth
+func GetAchievementCountService(c *fiber.Ctx) error {
+	roleName, err := resolveRoleName(c)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+
+	statuses, studentFilter, advisorFilter, err := allowedStatusesByRole(c, roleName, true)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	total, err := achievementRefRepo.CountByStatuses(ctx, statuses, studentFilter, advisorFilter, model.AchievementReferenceFilter{})
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal menghitung achievements", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Jumlah achievements berhasil diambil",
+		"total":   total,
+	})
+}
+
+// GetAchievementTagsService godoc
+// @Summary Tag yang dipakai achievements dalam scope pemanggil
+// @Description Daftar tag unik beserta jumlah achievement, terbanyak dulu, untuk filter tag di UI. Scope sama dengan GET /v1/achievements.
+// @Tags Achievements
+// @Produce json
+// @Success 200 {array} model.TagCount
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievements/tags [get]
+// @Security BearerAuth
+func GetAchievementTagsService(c *fiber.Ctx) error {
+	roleName, err := resolveRoleName(c)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+
+	statuses, studentFilter, advisorFilter, err := allowedStatusesByRole(c, roleName, true)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	ids, err := achievementRefRepo.MongoIDsByStatuses(ctx, statuses, studentFilter, advisorFilter)
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil achievement references", err)
+	}
+	tags, err := achievementMongoRepo.TagCounts(ctx, ids)
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil tags achievement", err)
+	}
+
+	return successJSON(c, fiber.StatusOK, "Tags achievement berhasil diambil", tags)
+}
+
+// GetAchievementTimelineService godoc
+// @Summary Jumlah achievements per bulan dalam scope pemanggil
+// @Description Untuk grafik tren: count = achievement dibuat per bulan (created_at). include_verified=true menambahkan verified = achievement diverifikasi per bulan (verified_at). Scope sama dengan GET /v1/achievements.
+// @Tags Achievements
+// @Produce json
+// @Param group query string false "Pengelompokan, saat ini hanya month (default: month)"
+// @Param year query int false "Batasi ke tahun tertentu, mis. 2025"
+// @Param include_verified query bool false "Sertakan jumlah verified per bulan"
+// @Success 200 {object} map[string]interface{} "data: [{period, count, verified}] urut period"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievements/timeline [get]
+// @Security BearerAuth
+func GetAchievementTimelineService(c *fiber.Ctx) error {
+	if group := strings.ToLower(strings.TrimSpace(c.Query("group", "month"))); group != "month" {
+		return errorJSON(c, fiber.StatusBadRequest, "group hanya mendukung month")
+	}
+	year := 0
+	if v := strings.TrimSpace(c.Query("year")); v != "" {
+		y, err := strconv.Atoi(v)
+		if err != nil || y < 1 || y > 9999 {
+			return errorJSON(c, fiber.StatusBadRequest, "year harus berupa tahun yang valid")
+		}
+		year = y
+	}
+	includeVerified := strings.EqualFold(strings.TrimSpace(c.Query("include_verified")), "true")
+
+	roleName, err := resolveRoleName(c)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+	statuses, studentFilter, advisorFilter, err := allowedStatusesByRole(c, roleName, true)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	points, err := achievementRefRepo.TimelineByMonth(ctx, statuses, studentFilter, advisorFilter, year, false)
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil timeline achievement", err)
+	}
+	if includeVerified {
+		verified, err := achievementRefRepo.TimelineByMonth(ctx, statuses, studentFilter, advisorFilter, year, true)
+		if err != nil {
+			return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil timeline achievement", err)
+		}
+		points = mergeVerifiedTimeline(points, verified)
+	}
+
+	return successJSON(c, fiber.StatusOK, "Timeline achievement berhasil diambil", points)
+}
+
+// mergeVerifiedTimeline mengisi Verified tiap periode dari verified; periode yang hanya punya verifikasi
+// ikut ditambahkan dengan count 0. Hasil tetap urut period.
+func mergeVerifiedTimeline(created, verified []model.AchievementTimelinePoint) []model.AchievementTimelinePoint {
+	byPeriod := make(map[string]int64, len(verified))
+	for _, v := range verified {
+		byPeriod[v.Period] = v.Count
+	}
+	merged := make([]model.AchievementTimelinePoint, 0, len(created)+len(verified))
+	for _, p := range created {
+		n := byPeriod[p.Period]
+		p.Verified = &n
+		delete(byPeriod, p.Period)
+		merged = append(merged, p)
+	}
+	for period, count := range byPeriod {
+		n := count
+		merged = append(merged, model.AchievementTimelinePoint{Period: period, Verified: &n})
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Period < merged[j].Period })
+	return merged
+}
+
+// combineByReferenceOrder menggabungkan reference dengan dokumen Mongo-nya mengikuti urutan refs
+// (urutan sort dan pagination dari Postgres). GetByIDs memakai $in sehingga urutan dokumennya tidak
+// dijamin; reference tanpa dokumen tetap disertakan dengan Achievement kosong dan mongo_missing=true,
+// dan di-log agar data yang rusak bisa dideteksi operator.
+func combineByReferenceOrder(refs []model.AchievementReference, achievements []model.Achievement) []model.AchievementWithReference {
+	achMap := make(map[string]model.Achievement, len(achievements))
+	for _, a := range achievements {
+		achMap[a.ID.Hex()] = a
+	}
+
+	combined := make([]model.AchievementWithReference, 0, len(refs))
+	for _, r := range refs {
+		ach, ok := achMap[r.MongoAchievementID]
+		if !ok {
+			if _, err := bson.ObjectIDFromHex(r.MongoAchievementID); err != nil {
+				log.Printf("[WARNING] achievement reference %s: mongo_achievement_id %q tidak valid", r.ID, r.MongoAchievementID)
+			} else {
+				log.Printf("[WARNING] achievement reference %s: dokumen mongo %s tidak ditemukan", r.ID, r.MongoAchievementID)
+			}
+		}
+		combined = append(combined, model.AchievementWithReference{
+			Achievement:  ach,
+			Reference:    r,
+			MongoMissing: !ok,
+		})
+	}
+	return combined
+}
+
+// expandStudents mengisi ringkasan student tiap item dengan satu query batch (bukan per item).
+// Nama advisor hanya disertakan untuk scope dosen wali.
+func expandStudents(ctx context.Context, items []model.AchievementWithReference, includeAdvisor bool) error {
+	seen := make(map[uuid.UUID]bool, len(items))
+	var ids []uuid.UUID
+	for _, it := range items {
+		if id := it.Reference.StudentID; !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	summaries, err := achievementStudentRepo.GetStudentSummaries(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		sum, ok := summaries[items[i].Reference.StudentID]
+		if !ok {
+			continue
+		}
+		if !includeAdvisor {
+			sum.AdvisorName = ""
+		}
+		items[i].Student = &sum
+	}
+	return nil
+}
+
+// maxStatusLookupIDs batas jumlah mongo_ids per request batch status.
+const maxStatusLookupIDs = 500
+
+// GetReferenceStatusesService godoc
+// @Summary Batch status reference berdasarkan mongo id
+// @Description Mengembalikan map mongo_id -> status; id yang tidak ada atau di luar scope role tidak disertakan
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param body body model.ReferenceStatusesRequest true "Daftar mongo achievement id (maks 500)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievement-references/statuses [post]
+// @Security BearerAuth
+func GetReferenceStatusesService(c *fiber.Ctx) error {
+	var req model.ReferenceStatusesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorWithDetail(c, fiber.StatusBadRequest, "Request body tidak valid", err)
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, id := range req.MongoIDs {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return errorJSON(c, fiber.StatusBadRequest, "mongo_ids harus diisi")
+	}
+	if len(ids) > maxStatusLookupIDs {
+		return errorJSON(c, fiber.StatusBadRequest, fmt.Sprintf("mongo_ids maksimal %d", maxStatusLookupIDs))
+	}
+
+	roleName, err := resolveRoleName(c)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+	statuses, studentFilter, advisorFilter, err := allowedStatusesByRole(c, roleName, true)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	data, err := achievementRefRepo.StatusesByMongoIDs(ctx, ids, statuses, studentFilter, advisorFilter)
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil status achievement references", err)
+	}
+
+	return successJSON(c, fiber.StatusOK, "Status achievement references berhasil diambil", data)
+}
+
+// parseVerifiedRange membaca verified_from/verified_to (RFC3339). Keduanya harus diisi bersamaan dan from <= to.
+func parseVerifiedRange(fromRaw, toRaw string) (model.AchievementReferenceFilter, error) {
+	var filter model.AchievementReferenceFilter
+	fromRaw, toRaw = strings.TrimSpace(fromRaw), strings.TrimSpace(toRaw)
+	if fromRaw == "" && toRaw == "" {
+		return filter, nil
+	}
+	if fromRaw == "" || toRaw == "" {
+		return filter, errors.New("verified_from dan verified_to harus diisi bersamaan")
+	}
+	from, err := time.Parse(time.RFC3339, fromRaw)
+	if err != nil {
+		return filter, errors.New("verified_from harus format RFC3339")
+	}
+	to, err := time.Parse(time.RFC3339, toRaw)
+	if err != nil {
+		return filter, errors.New("verified_to harus format RFC3339")
+	}
+	if from.After(to) {
+		return filter, errors.New("verified_from tidak boleh setelah verified_to")
+	}
+	filter.VerifiedFrom, filter.VerifiedTo = &from, &to
+	return filter, nil
+}
+
+// GetAchievementReferencesService godoc
+// @Summary Daftar semua achievement references (Postgres)
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman (default DEFAULT_PAGE_LIMIT, 10)"
+// @Param verified_from query string false "Awal rentang verified_at (RFC3339), wajib bersama verified_to"
+// @Param verified_to query string false "Akhir rentang verified_at (RFC3339), wajib bersama verified_from"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.ErrorResponse "Rentang verified_from/verified_to tidak valid"
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievement-references [get]
+// @Security BearerAuth
+func GetAchievementReferencesService(c *fiber.Ctx) error {
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
+	}
+
+	roleName, err := resolveRoleName(c)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+
+	statuses, studentFilter, advisorFilter, err := allowedStatusesByRole(c, roleName, false)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+
+	filter, err := parseVerifiedRange(c.Query("verified_from"), c.Query("verified_to"))
+	if err != nil {
+		return errorJSON(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	data, total, err := achievementRefRepo.ListByStatuses(ctx, statuses, studentFilter, advisorFilter, page, limit, filter)
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil achievement references", err)
+	}
+
+	now, slaDays := time.Now(), reviewSLADays()
+	for i := range data {
+		applyReviewSLA(&data[i], now, slaDays)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Data achievement references berhasil diambil",
+		"data":    data,
 		"total":   total,
 		"page":    page,
 		"limit":   limit,
 	})
 }
+
+// GetOverdueAchievementsService godoc
+// @Summary Daftar achievement submitted yang melewati SLA review
+// @Description SLA diatur lewat env ACHIEVEMENT_REVIEW_SLA_DAYS (default 7 hari). Dosen wali hanya melihat mahasiswa bimbingannya.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman (default DEFAULT_PAGE_LIMIT, 10)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.ErrorResponse "Parameter page/limit tidak valid"
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievements/overdue [get]
+// @Security BearerAuth
+func GetOverdueAchievementsService(c *fiber.Ctx) error {
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
+	}
+
+	roleName, err := resolveRoleName(c)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+
+	statuses, studentFilter, advisorFilter, err := allowedStatusesByRole(c, roleName, false)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+	canSeeSubmitted := false
+	for _, st := range statuses {
+		if st == model.AchievementStatusSubmitted {
+			canSeeSubmitted = true
+			break
+		}
+	}
+	if !canSeeSubmitted {
+		return errorJSON(c, fiber.StatusForbidden, "Role tidak diperbolehkan untuk aksi ini")
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	now, slaDays := time.Now(), reviewSLADays()
+	cutoff := now.AddDate(0, 0, -slaDays)
+	data, total, err := achievementRefRepo.ListSubmittedBefore(ctx, cutoff, studentFilter, advisorFilter, page, limit)
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil achievement overdue", err)
+	}
+	for i := range data {
+		applyReviewSLA(&data[i], now, slaDays)
+	}
+
+	return c.JSON(fiber.Map{
+		"success":  true,
+		"message":  "Data achievement overdue berhasil diambil",
+		"data":     data,
+		"total":    total,
+		"page":     page,
+		"limit":    limit,
+		"sla_days": slaDays,
+	})
+}
+
+// GetReviewQueueService godoc
+// @Summary Antrian review dosen wali
+// @Description Achievement berstatus submitted milik mahasiswa bimbingan pemanggil (advisor utama maupun co-advisor), terbaru dulu. Hanya untuk dosen wali.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman (default DEFAULT_PAGE_LIMIT, 10)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.ErrorResponse "Parameter page/limit tidak valid"
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse "Bukan dosen wali"
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievements/review-queue [get]
+// @Security BearerAuth
+func GetReviewQueueService(c *fiber.Ctx) error {
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
+	}
+
+	roleName, err := resolveRoleName(c)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+	if roleName != "dosen wali" {
+		return errorJSON(c, fiber.StatusForbidden, "Antrian review hanya untuk dosen wali")
+	}
+
+	_, _, advisorFilter, err := allowedStatusesByRole(c, roleName, true)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	refs, total, err := achievementRefRepo.ListByStatuses(ctx, []string{model.AchievementStatusSubmitted}, nil, advisorFilter, page, limit, model.AchievementReferenceFilter{})
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil antrian review", err)
+	}
+
+	now, slaDays := time.Now(), reviewSLADays()
+	ids := make([]string, 0, len(refs))
+	for i := range refs {
+		applyReviewSLA(&refs[i], now, slaDays)
+		ids = append(ids, refs[i].MongoAchievementID)
+	}
+	achievements, err := achievementMongoRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data achievements", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Antrian review berhasil diambil",
+		"data":    combineByReferenceOrder(refs, achievements),
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+// GetAchievementByIDService godoc
+// @Summary Detail achievement (Mongo + reference Postgres)
+// @Description Mengembalikan dokumen achievement beserta reference dan metadata review. Akses mengikuti scope role pemanggil.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement reference ID (UUID)"
+// @Success 200 {object} model.AchievementWithReference
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievements/{id} [get]
+// @Security BearerAuth
+func GetAchievementByIDService(c *fiber.Ctx) error {
+	refID := strings.TrimSpace(c.Params("id"))
+	if refID == "" {
+		return errorJSON(c, fiber.StatusBadRequest, "ID reference harus diisi")
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	ref, err := achievementRefRepo.GetByID(ctx, refID)
+	if err != nil || ref == nil {
+		return errorJSON(c, fiber.StatusNotFound, "achievement reference tidak ditemukan")
+	}
+
+	allowed, err := canViewReference(c, ref)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+	if !allowed {
+		return errorJSON(c, fiber.StatusForbidden, "Tidak berhak melihat achievement ini")
+	}
+
+	achievements, err := achievementMongoRepo.GetByIDs(ctx, []string{ref.MongoAchievementID})
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data achievement", err)
+	}
+	if len(achievements) == 0 {
+		return errorJSON(c, fiber.StatusNotFound, "achievement mongo tidak ditemukan")
+	}
+
+	applyReviewSLA(ref, time.Now(), reviewSLADays())
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Data achievement berhasil diambil",
+		"data": model.AchievementWithReference{
+			Achievement: achievements[0],
+			Reference:   *ref,
+		},
+	})
+}
+
+// VerifyAchievementPublicService godoc
+// @Summary Verifikasi publik achievement (tanpa auth)
+// @Description Untuk link verifikasi di sertifikat. Hanya achievement verified yang dikembalikan; selain itu 404. Payload minimal tanpa data pribadi mahasiswa.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement reference ID (UUID)"
+// @Success 200 {object} model.AchievementPublicVerification
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievements/{id}/verify-public [get]
+func VerifyAchievementPublicService(c *fiber.Ctx) error {
+	// id tidak dikenal, belum verified, dan data yang tidak lengkap dijawab sama agar status
+	// achievement yang belum diverifikasi tidak bisa ditebak dari luar
+	const notFound = "achievement terverifikasi tidak ditemukan"
+
+	refID := strings.TrimSpace(c.Params("id"))
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	ref, err := achievementRefRepo.GetByID(ctx, refID)
+	if err != nil || ref == nil || ref.Status != model.AchievementStatusVerified {
+		return errorJSON(c, fiber.StatusNotFound, notFound)
+	}
+
+	achievements, err := achievementMongoRepo.GetByIDs(ctx, []string{ref.MongoAchievementID})
+	if err != nil {
+		return errorJSON(c, fiber.StatusInternalServerError, "Gagal mengambil data achievement")
+	}
+	if len(achievements) == 0 {
+		return errorJSON(c, fiber.StatusNotFound, notFound)
+	}
+
+	var programStudy string
+	if st, err := achievementStudentRepo.GetStudentByID(c.UserContext(), ref.StudentID.String()); err == nil && st != nil {
+		programStudy = st.ProgramStudy
+	}
+
+	return successJSON(c, fiber.StatusOK, "Achievement terverifikasi", model.AchievementPublicVerification{
+		ID:              ref.ID,
+		AchievementType: achievements[0].AchievementType,
+		Title:           achievements[0].Title,
+		Status:          ref.Status,
+		VerifiedAt:      ref.VerifiedAt,
+		ProgramStudy:    programStudy,
+	})
+}
+
+// GetAchievementFunnelService godoc
+// @Summary Funnel achievement (created/submitted/verified/rejected) dalam rentang tanggal (Admin)
+// @Description Default rentang 30 hari terakhir. Tanggal format YYYY-MM-DD, to bersifat inklusif.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param from query string false "Tanggal awal (YYYY-MM-DD)"
+// @Param to query string false "Tanggal akhir (YYYY-MM-DD)"
+// @Success 200 {object} model.AchievementFunnel
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievements/funnel [get]
+// @Security BearerAuth
+func GetAchievementFunnelService(c *fiber.Ctx) error {
+	const layout = "2006-01-02"
+
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if v := strings.TrimSpace(c.Query("to")); v != "" {
+		parsed, err := time.ParseInLocation(layout, v, now.Location())
+		if err != nil {
+			return errorJSON(c, fiber.StatusBadRequest, "Format tanggal to harus YYYY-MM-DD")
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -30)
+	if v := strings.TrimSpace(c.Query("from")); v != "" {
+		parsed, err := time.ParseInLocation(layout, v, now.Location())
+		if err != nil {
+			return errorJSON(c, fiber.StatusBadRequest, "Format tanggal from harus YYYY-MM-DD")
+		}
+		from = parsed
+	}
+	if from.After(to) {
+		return errorJSON(c, fiber.StatusBadRequest, "from tidak boleh setelah to")
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	// to inklusif: hitung sampai awal hari berikutnya
+	funnel, err := achievementRefRepo.Funnel(ctx, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil funnel achievement", err)
+	}
+	funnel.To = to
+
+	return successJSON(c, fiber.StatusOK, "Funnel achievement berhasil diambil", funnel)
+}
+
+// GetAchievementAttachmentService godoc
+// @Summary Download attachment achievement
+// @Description File hanya dikirim jika pemanggil berhak melihat achievement (scope sama dengan detail achievement).
+// @Tags Achievements
+// @Produce application/octet-stream
+// @Param id path string true "Achievement reference ID (UUID)"
+// @Param index path int true "Index attachment (mulai dari 0)"
+// @Success 200 {file} file
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievements/{id}/attachments/{index} [get]
+// @Security BearerAuth
+func GetAchievementAttachmentService(c *fiber.Ctx) error {
+	refID := strings.TrimSpace(c.Params("id"))
+	if refID == "" {
+		return errorJSON(c, fiber.StatusBadRequest, "ID reference harus diisi")
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	ref, err := achievementRefRepo.GetByID(ctx, refID)
+	if err != nil || ref == nil {
+		return errorJSON(c, fiber.StatusNotFound, "achievement reference tidak ditemukan")
+	}
+
+	allowed, err := canViewReference(c, ref)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+	if !allowed {
+		return errorJSON(c, fiber.StatusForbidden, "Tidak berhak melihat achievement ini")
+	}
+
+	achievements, err := achievementMongoRepo.GetByIDs(ctx, []string{ref.MongoAchievementID})
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data achievement", err)
+	}
+	if len(achievements) == 0 {
+		return errorJSON(c, fiber.StatusNotFound, "achievement mongo tidak ditemukan")
+	}
+
+	index, err := strconv.Atoi(c.Params("index"))
+	attachments := achievements[0].Attachments
+	if err != nil || index < 0 || index >= len(attachments) {
+		return errorJSON(c, fiber.StatusNotFound, "attachment tidak ditemukan")
+	}
+	att := attachments[index]
+
+	path, err := resolveAttachmentPath(att.FileURL)
+	if err != nil {
+		return errorJSON(c, fiber.StatusBadRequest, err.Error())
+	}
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return errorJSON(c, fiber.StatusNotFound, "file attachment tidak ditemukan")
+	}
+
+	contentType := att.FileType
+	if !strings.Contains(contentType, "/") {
+		contentType = mime.TypeByExtension(filepath.Ext(path))
+	}
+
+	c.Attachment(att.FileName)
+	if err := c.SendFile(path); err != nil {
+		return err
+	}
+	if contentType != "" {
+		c.Set(fiber.HeaderContentType, contentType)
+	}
+	return nil
+}
+
+// GetAchievementPDFService godoc
+// @Summary Export achievement terverifikasi sebagai PDF
+// @Description Ringkasan achievement (judul, jenis, detail, tanggal verifikasi, reviewer) dalam PDF siap cetak. Hanya untuk achievement verified yang boleh dilihat pemanggil; nama file lampiran dicantumkan sebagai referensi.
+// @Tags Achievements
+// @Produce application/pdf
+// @Param id path string true "Achievement reference ID (UUID)"
+// @Success 200 {file} file
+// @Failure 400 {object} model.ErrorResponse "Achievement belum verified"
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/achievements/{id}/pdf [get]
+// @Security BearerAuth
+func GetAchievementPDFService(c *fiber.Ctx) error {
+	refID := strings.TrimSpace(c.Params("id"))
+	if refID == "" {
+		return errorJSON(c, fiber.StatusBadRequest, "ID reference harus diisi")
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	ref, err := achievementRefRepo.GetByID(ctx, refID)
+	if err != nil || ref == nil {
+		return errorJSON(c, fiber.StatusNotFound, "achievement reference tidak ditemukan")
+	}
+
+	allowed, err := canViewReference(c, ref)
+	if err != nil {
+		return errorJSON(c, fiber.StatusForbidden, err.Error())
+	}
+	if !allowed {
+		return errorJSON(c, fiber.StatusForbidden, "Tidak berhak melihat achievement ini")
+	}
+	if ref.Status != model.AchievementStatusVerified {
+		return errorJSON(c, fiber.StatusBadRequest, "Hanya achievement verified yang dapat diekspor ke PDF")
+	}
+
+	achievements, err := achievementMongoRepo.GetByIDs(ctx, []string{ref.MongoAchievementID})
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data achievement", err)
+	}
+	if len(achievements) == 0 {
+		return errorJSON(c, fiber.StatusNotFound, "achievement mongo tidak ditemukan")
+	}
+
+	reviewer := "-"
+	if ref.VerifiedBy != nil {
+		reviewer = ref.VerifiedBy.String()
+		if user, err := achievementUserRepo.GetUserByID(c.UserContext(), ref.VerifiedBy.String()); err == nil && user != nil && user.FullName != "" {
+			reviewer = user.FullName
+		}
+	}
+
+	out, err := renderAchievementPDF(achievements[0], *ref, reviewer)
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal membuat PDF achievement", err)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="achievement-%s.pdf"`, ref.ID))
+	return c.Send(out)
+}
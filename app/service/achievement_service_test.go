@@ -5,15 +5,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"hello-fiber/app/model"
+	"hello-fiber/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -25,6 +29,17 @@ type mockAchievementMongoRepo struct {
 	GetByIDsFn func(ctx context.Context, ids []string) ([]model.Achievement, error)
 	ListFn     func(ctx context.Context, page, limit int64) ([]model.Achievement, int64, error)
 	DeleteFn   func(ctx context.Context, id string) error
+
+	UpdateStudentIDFn func(ctx context.Context, id string, studentID uuid.UUID) error
+	UpdatePointsFn    func(ctx context.Context, id string, points *float64) error
+	TagCountsFn       func(ctx context.Context, ids []string) ([]model.TagCount, error)
+}
+
+func (m *mockAchievementMongoRepo) TagCounts(ctx context.Context, ids []string) ([]model.TagCount, error) {
+	if m.TagCountsFn != nil {
+		return m.TagCountsFn(ctx, ids)
+	}
+	return []model.TagCount{}, nil
 }
 
 func (m *mockAchievementMongoRepo) Create(ctx context.Context, studentID uuid.UUID, req model.CreateAchievementRequest) (string, error) {
@@ -55,6 +70,20 @@ func (m *mockAchievementMongoRepo) Delete(ctx context.Context, id string) error
 	return nil
 }
 
+func (m *mockAchievementMongoRepo) UpdateStudentID(ctx context.Context, id string, studentID uuid.UUID) error {
+	if m.UpdateStudentIDFn != nil {
+		return m.UpdateStudentIDFn(ctx, id, studentID)
+	}
+	return nil
+}
+
+func (m *mockAchievementMongoRepo) UpdatePoints(ctx context.Context, id string, points *float64) error {
+	if m.UpdatePointsFn != nil {
+		return m.UpdatePointsFn(ctx, id, points)
+	}
+	return nil
+}
+
 type mockAchievementRefRepo struct {
 	CreateDraftFn     func(ctx context.Context, studentID uuid.UUID, mongoID string) (string, error)
 	SubmitDraftFn     func(ctx context.Context, refID string, studentID uuid.UUID) error
@@ -64,7 +93,82 @@ type mockAchievementRefRepo struct {
 	HardDeleteFn      func(ctx context.Context, refID string) error
 	GetByIDFn         func(ctx context.Context, id string) (*model.AchievementReference, error)
 	ListFn            func(ctx context.Context, page, limit int64) ([]model.AchievementReference, int64, error)
-	ListByStatusesFn  func(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64) ([]model.AchievementReference, int64, error)
+	ListByStatusesFn  func(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64, filter model.AchievementReferenceFilter) ([]model.AchievementReference, int64, error)
+	CountByStatusesFn func(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, filter model.AchievementReferenceFilter) (int64, error)
+	UpdateStudentIDFn func(ctx context.Context, refID string, studentID uuid.UUID) error
+	ReviewByAdvisorFn func(ctx context.Context, refID string, status string, reviewerID uuid.UUID, lecturerID uuid.UUID, note *string) error
+
+	ListSubmittedBeforeFn  func(ctx context.Context, before time.Time, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64) ([]model.AchievementReference, int64, error)
+	FunnelFn               func(ctx context.Context, from, to time.Time) (*model.AchievementFunnel, error)
+	StatusesByMongoIDsFn   func(ctx context.Context, mongoIDs []string, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID) (map[string]string, error)
+	ListDeletedOlderThanFn func(ctx context.Context, olderThan time.Duration) ([]model.AchievementReference, error)
+	ListDraftsOlderThanFn  func(ctx context.Context, olderThan time.Duration) ([]model.AchievementReference, error)
+	TimelineByMonthFn      func(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, year int, verified bool) ([]model.AchievementTimelinePoint, error)
+	RevertToDraftFn        func(ctx context.Context, refID string, studentID uuid.UUID) error
+	MongoIDsByStatusesFn   func(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID) ([]string, error)
+}
+
+func (m *mockAchievementRefRepo) MongoIDsByStatuses(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID) ([]string, error) {
+	if m.MongoIDsByStatusesFn != nil {
+		return m.MongoIDsByStatusesFn(ctx, statuses, studentID, advisorID)
+	}
+	return []string{}, nil
+}
+
+func (m *mockAchievementRefRepo) TimelineByMonth(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, year int, verified bool) ([]model.AchievementTimelinePoint, error) {
+	if m.TimelineByMonthFn != nil {
+		return m.TimelineByMonthFn(ctx, statuses, studentID, advisorID, year, verified)
+	}
+	return nil, nil
+}
+
+func (m *mockAchievementRefRepo) ListDraftsOlderThan(ctx context.Context, olderThan time.Duration) ([]model.AchievementReference, error) {
+	if m.ListDraftsOlderThanFn != nil {
+		return m.ListDraftsOlderThanFn(ctx, olderThan)
+	}
+	return nil, nil
+}
+
+func (m *mockAchievementRefRepo) ListDeletedOlderThan(ctx context.Context, olderThan time.Duration) ([]model.AchievementReference, error) {
+	if m.ListDeletedOlderThanFn != nil {
+		return m.ListDeletedOlderThanFn(ctx, olderThan)
+	}
+	return nil, nil
+}
+
+func (m *mockAchievementRefRepo) StatusesByMongoIDs(ctx context.Context, mongoIDs []string, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID) (map[string]string, error) {
+	if m.StatusesByMongoIDsFn != nil {
+		return m.StatusesByMongoIDsFn(ctx, mongoIDs, statuses, studentID, advisorID)
+	}
+	return map[string]string{}, nil
+}
+
+func (m *mockAchievementRefRepo) Funnel(ctx context.Context, from, to time.Time) (*model.AchievementFunnel, error) {
+	if m.FunnelFn != nil {
+		return m.FunnelFn(ctx, from, to)
+	}
+	return &model.AchievementFunnel{From: from, To: to}, nil
+}
+
+func (m *mockAchievementRefRepo) ListSubmittedBefore(ctx context.Context, before time.Time, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64) ([]model.AchievementReference, int64, error) {
+	if m.ListSubmittedBeforeFn != nil {
+		return m.ListSubmittedBeforeFn(ctx, before, studentID, advisorID, page, limit)
+	}
+	return nil, 0, nil
+}
+
+func (m *mockAchievementRefRepo) ReviewByAdvisor(ctx context.Context, refID string, status string, reviewerID uuid.UUID, lecturerID uuid.UUID, note *string) error {
+	if m.ReviewByAdvisorFn != nil {
+		return m.ReviewByAdvisorFn(ctx, refID, status, reviewerID, lecturerID, note)
+	}
+	return nil
+}
+
+func (m *mockAchievementRefRepo) UpdateStudentID(ctx context.Context, refID string, studentID uuid.UUID) error {
+	if m.UpdateStudentIDFn != nil {
+		return m.UpdateStudentIDFn(ctx, refID, studentID)
+	}
+	return nil
 }
 
 func (m *mockAchievementRefRepo) CreateDraft(ctx context.Context, studentID uuid.UUID, mongoID string) (string, error) {
@@ -81,6 +185,13 @@ func (m *mockAchievementRefRepo) SubmitDraft(ctx context.Context, refID string,
 	return nil
 }
 
+func (m *mockAchievementRefRepo) RevertToDraft(ctx context.Context, refID string, studentID uuid.UUID) error {
+	if m.RevertToDraftFn != nil {
+		return m.RevertToDraftFn(ctx, refID, studentID)
+	}
+	return nil
+}
+
 func (m *mockAchievementRefRepo) Review(ctx context.Context, refID string, status string, adminID uuid.UUID, note *string) error {
 	if m.ReviewFn != nil {
 		return m.ReviewFn(ctx, refID, status, adminID, note)
@@ -123,109 +234,140 @@ func (m *mockAchievementRefRepo) List(ctx context.Context, page, limit int64) ([
 	return nil, 0, nil
 }
 
-func (m *mockAchievementRefRepo) ListByStatuses(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64) ([]model.AchievementReference, int64, error) {
+func (m *mockAchievementRefRepo) ListByStatuses(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64, filter model.AchievementReferenceFilter) ([]model.AchievementReference, int64, error) {
 	if m.ListByStatusesFn != nil {
-		return m.ListByStatusesFn(ctx, statuses, studentID, advisorID, page, limit)
+		return m.ListByStatusesFn(ctx, statuses, studentID, advisorID, page, limit, filter)
 	}
 	return nil, 0, nil
 }
 
+func (m *mockAchievementRefRepo) CountByStatuses(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, filter model.AchievementReferenceFilter) (int64, error) {
+	if m.CountByStatusesFn != nil {
+		return m.CountByStatusesFn(ctx, statuses, studentID, advisorID, filter)
+	}
+	return 0, nil
+}
+
 type mockStudentRepo struct {
-	GetAllStudentsFn     func(page, limit int64) ([]model.Student, int64, error)
-	GetStudentByIDFn     func(id string) (*model.Student, error)
-	GetStudentByUserIDFn func(userID string) (*model.Student, error)
-	CreateStudentFn      func(req model.CreateStudentRequest) (string, error)
-	UpdateStudentFn      func(id string, req model.UpdateStudentRequest) error
-	DeleteStudentFn      func(id string) error
+	GetAllStudentsFn      func(page, limit int64) ([]model.Student, int64, error)
+	GetStudentByIDFn      func(id string) (*model.Student, error)
+	GetStudentByUserIDFn  func(userID string) (*model.Student, error)
+	CreateStudentFn       func(req model.CreateStudentRequest) (string, error)
+	UpdateStudentFn       func(id string, req model.UpdateStudentRequest) error
+	DeleteStudentFn       func(id string) error
+	GetStudentSummariesFn func(ids []uuid.UUID) (map[uuid.UUID]model.StudentSummary, error)
+	SetAdvisorFn          func(id string, advisorID *uuid.UUID) error
 }
 
-func (m *mockStudentRepo) GetAllStudents(page, limit int64) ([]model.Student, int64, error) {
+func (m *mockStudentRepo) GetAllStudents(ctx context.Context, page, limit int64) ([]model.Student, int64, error) {
 	if m.GetAllStudentsFn != nil {
 		return m.GetAllStudentsFn(page, limit)
 	}
 	return nil, 0, nil
 }
 
-func (m *mockStudentRepo) GetStudentByID(id string) (*model.Student, error) {
+func (m *mockStudentRepo) GetStudentByID(ctx context.Context, id string) (*model.Student, error) {
 	if m.GetStudentByIDFn != nil {
 		return m.GetStudentByIDFn(id)
 	}
 	return nil, nil
 }
 
-func (m *mockStudentRepo) GetStudentByUserID(userID string) (*model.Student, error) {
+func (m *mockStudentRepo) GetStudentByUserID(ctx context.Context, userID string) (*model.Student, error) {
 	if m.GetStudentByUserIDFn != nil {
 		return m.GetStudentByUserIDFn(userID)
 	}
 	return nil, nil
 }
 
-func (m *mockStudentRepo) CreateStudent(req model.CreateStudentRequest) (string, error) {
+func (m *mockStudentRepo) CreateStudent(ctx context.Context, req model.CreateStudentRequest) (string, error) {
 	if m.CreateStudentFn != nil {
 		return m.CreateStudentFn(req)
 	}
 	return "", nil
 }
 
-func (m *mockStudentRepo) UpdateStudent(id string, req model.UpdateStudentRequest) error {
+func (m *mockStudentRepo) UpdateStudent(ctx context.Context, id string, req model.UpdateStudentRequest) error {
 	if m.UpdateStudentFn != nil {
 		return m.UpdateStudentFn(id, req)
 	}
 	return nil
 }
 
-func (m *mockStudentRepo) DeleteStudent(id string) error {
+func (m *mockStudentRepo) SetAdvisor(ctx context.Context, id string, advisorID *uuid.UUID) error {
+	if m.SetAdvisorFn != nil {
+		return m.SetAdvisorFn(id, advisorID)
+	}
+	return nil
+}
+
+func (m *mockStudentRepo) DeleteStudent(ctx context.Context, id string) error {
 	if m.DeleteStudentFn != nil {
 		return m.DeleteStudentFn(id)
 	}
 	return nil
 }
 
+func (m *mockStudentRepo) GetStudentSummaries(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]model.StudentSummary, error) {
+	if m.GetStudentSummariesFn != nil {
+		return m.GetStudentSummariesFn(ids)
+	}
+	return map[uuid.UUID]model.StudentSummary{}, nil
+}
+
 type mockLectRepo struct {
 	GetAllLecturersFn     func(page, limit int64) ([]model.Lecturer, int64, error)
 	GetLecturerByIDFn     func(id string) (*model.Lecturer, error)
 	GetLecturerByUserIDFn func(userID string) (*model.Lecturer, error)
+	GetAdviseesSummaryFn  func(lecturerID string) ([]model.AdviseeSummary, error)
 	CreateLecturerFn      func(req model.CreateLecturerRequest) (string, error)
 	UpdateLecturerFn      func(id string, req model.UpdateLecturerRequest) error
 	DeleteLecturerFn      func(id string) error
 }
 
-func (m *mockLectRepo) GetAllLecturers(page, limit int64) ([]model.Lecturer, int64, error) {
+func (m *mockLectRepo) GetAdviseesSummary(ctx context.Context, lecturerID string) ([]model.AdviseeSummary, error) {
+	if m.GetAdviseesSummaryFn != nil {
+		return m.GetAdviseesSummaryFn(lecturerID)
+	}
+	return []model.AdviseeSummary{}, nil
+}
+
+func (m *mockLectRepo) GetAllLecturers(ctx context.Context, page, limit int64) ([]model.Lecturer, int64, error) {
 	if m.GetAllLecturersFn != nil {
 		return m.GetAllLecturersFn(page, limit)
 	}
 	return nil, 0, nil
 }
 
-func (m *mockLectRepo) GetLecturerByID(id string) (*model.Lecturer, error) {
+func (m *mockLectRepo) GetLecturerByID(ctx context.Context, id string) (*model.Lecturer, error) {
 	if m.GetLecturerByIDFn != nil {
 		return m.GetLecturerByIDFn(id)
 	}
 	return nil, nil
 }
 
-func (m *mockLectRepo) GetLecturerByUserID(userID string) (*model.Lecturer, error) {
+func (m *mockLectRepo) GetLecturerByUserID(ctx context.Context, userID string) (*model.Lecturer, error) {
 	if m.GetLecturerByUserIDFn != nil {
 		return m.GetLecturerByUserIDFn(userID)
 	}
 	return nil, nil
 }
 
-func (m *mockLectRepo) CreateLecturer(req model.CreateLecturerRequest) (string, error) {
+func (m *mockLectRepo) CreateLecturer(ctx context.Context, req model.CreateLecturerRequest) (string, error) {
 	if m.CreateLecturerFn != nil {
 		return m.CreateLecturerFn(req)
 	}
 	return "", nil
 }
 
-func (m *mockLectRepo) UpdateLecturer(id string, req model.UpdateLecturerRequest) error {
+func (m *mockLectRepo) UpdateLecturer(ctx context.Context, id string, req model.UpdateLecturerRequest) error {
 	if m.UpdateLecturerFn != nil {
 		return m.UpdateLecturerFn(id, req)
 	}
 	return nil
 }
 
-func (m *mockLectRepo) DeleteLecturer(id string) error {
+func (m *mockLectRepo) DeleteLecturer(ctx context.Context, id string) error {
 	if m.DeleteLecturerFn != nil {
 		return m.DeleteLecturerFn(id)
 	}
@@ -304,6 +446,168 @@ func TestCreateAchievementService_MultipartPDF_Success(t *testing.T) {
 	}
 }
 
+func TestCreateAchievementService_MultipartDuplicateFileNames(t *testing.T) {
+	t.Setenv("UPLOAD_DIR", t.TempDir())
+
+	studentID := uuid.New()
+	var got []model.Attachment
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		CreateFn: func(ctx context.Context, sID uuid.UUID, req model.CreateAchievementRequest) (string, error) {
+			got = req.Attachments
+			return "mongo123", nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		CreateDraftFn: func(ctx context.Context, sID uuid.UUID, mongoID string) (string, error) {
+			return "ref123", nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/achievements", func(c *fiber.Ctx) error {
+		c.Locals("student_uuid", studentID)
+		return CreateAchievementService(c)
+	})
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	_ = w.WriteField("achievement_type", "academic")
+	_ = w.WriteField("title", "Sertifikat")
+	_ = w.WriteField("description", "Dua sertifikat")
+	_ = w.WriteField("details", `{"score":8}`)
+	for i := 0; i < 3; i++ {
+		fw, _ := w.CreateFormFile("attachments", "cert.pdf")
+		fw.Write([]byte("dummy"))
+	}
+	w.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/achievements", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusCreated)
+	}
+
+	want := []string{"cert.pdf", "cert (2).pdf", "cert (3).pdf"}
+	if len(got) != len(want) {
+		t.Fatalf("attachments: got %d want %d", len(got), len(want))
+	}
+	urls := map[string]bool{}
+	for i, att := range got {
+		if att.FileName != want[i] {
+			t.Fatalf("attachment %d file name: got %q want %q", i, att.FileName, want[i])
+		}
+		urls[att.FileURL] = true
+	}
+	if len(urls) != len(want) {
+		t.Fatalf("stored file urls must stay unique: %+v", got)
+	}
+}
+
+func TestCreateAchievementService_MultipartStrictMode(t *testing.T) {
+	newUpload := func(t *testing.T, path string) *http.Request {
+		t.Helper()
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		_ = w.WriteField("achievement_type", "academic")
+		_ = w.WriteField("title", "Sertifikat")
+		_ = w.WriteField("description", "PDF dan catatan")
+		_ = w.WriteField("details", `{"score":8}`)
+		fw, _ := w.CreateFormFile("attachments", "bukti.pdf")
+		fw.Write([]byte("%PDF-1.4"))
+		fw, _ = w.CreateFormFile("attachments", "catatan.txt")
+		fw.Write([]byte("catatan"))
+		w.Close()
+		req := httptest.NewRequest(http.MethodPost, path, &body)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		return req
+	}
+
+	for _, tc := range []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{"default strict rejects all", "/achievements", http.StatusBadRequest},
+		{"strict=false keeps valid files", "/achievements?strict=false", http.StatusCreated},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("UPLOAD_DIR", t.TempDir())
+			var created *model.CreateAchievementRequest
+			achievementMongoRepo = &mockAchievementMongoRepo{
+				CreateFn: func(ctx context.Context, sID uuid.UUID, req model.CreateAchievementRequest) (string, error) {
+					created = &req
+					return "mongo123", nil
+				},
+			}
+			achievementRefRepo = &mockAchievementRefRepo{
+				CreateDraftFn: func(ctx context.Context, sID uuid.UUID, mongoID string) (string, error) {
+					return "ref123", nil
+				},
+			}
+
+			studentID := uuid.New()
+			app := fiber.New()
+			app.Post("/achievements", func(c *fiber.Ctx) error {
+				c.Locals("student_uuid", studentID)
+				return CreateAchievementService(c)
+			})
+
+			resp, err := app.Test(newUpload(t, tc.path), -1)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("status: got %d want %d", resp.StatusCode, tc.wantStatus)
+			}
+			var out map[string]any
+			if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+
+			if tc.wantStatus == http.StatusBadRequest {
+				if created != nil {
+					t.Fatal("strict mode must not create the achievement")
+				}
+				if out["message"] != "hanya file PDF yang diperbolehkan" {
+					t.Fatalf("unexpected message: %#v", out["message"])
+				}
+				return
+			}
+
+			if created == nil || len(created.Attachments) != 1 || created.Attachments[0].FileName != "bukti.pdf" {
+				t.Fatalf("expected only bukti.pdf to be stored, got %+v", created)
+			}
+			data, _ := out["data"].(map[string]any)
+			rejected, _ := data["rejected_attachments"].([]any)
+			if len(rejected) != 1 {
+				t.Fatalf("expected 1 rejected attachment, got %#v", data["rejected_attachments"])
+			}
+			r := rejected[0].(map[string]any)
+			if r["file_name"] != "catatan.txt" || r["reason"] != "hanya file PDF yang diperbolehkan" {
+				t.Fatalf("unexpected rejection: %#v", r)
+			}
+		})
+	}
+}
+
+func TestUniqueAttachmentName(t *testing.T) {
+	used := map[string]bool{}
+	for _, tc := range []struct{ in, want string }{
+		{"cert.pdf", "cert.pdf"},
+		{"cert (2).pdf", "cert (2).pdf"},
+		{"CERT.pdf", "CERT (3).pdf"},
+		{"other.pdf", "other.pdf"},
+	} {
+		if got := uniqueAttachmentName(tc.in, used); got != tc.want {
+			t.Fatalf("uniqueAttachmentName(%q): got %q want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
 func TestCreateAchievementService_MultipartRejectNonPDF(t *testing.T) {
 	os.RemoveAll("uploads")
 	defer os.RemoveAll("uploads")
@@ -375,6 +679,7 @@ func TestCreateAchievementService_Success(t *testing.T) {
 		"title":            "Juara 1",
 		"description":      "Menang lomba",
 		"details": map[string]any{
+			"competitionName":  "ICPC National",
 			"rank":             1.0,
 			"competitionLevel": "National",
 		},
@@ -395,6 +700,147 @@ func TestCreateAchievementService_Success(t *testing.T) {
 	}
 }
 
+func TestCreateAchievementService_MissingDetailFields(t *testing.T) {
+	cases := []struct {
+		achType string
+		details map[string]any
+		missing []string
+	}{
+		{"competition", map[string]any{"competitionLevel": "national"}, []string{"competitionName", "rank"}},
+		{"publication", map[string]any{"publicationTitle": "Efficient Algorithms"}, []string{"publisher"}},
+		{"organization", map[string]any{"organizationName": "BEM", "position": "  "}, []string{"position"}},
+		{"certification", map[string]any{"issuedBy": "Amazon"}, []string{"certificationName"}},
+		{"academic", map[string]any{"description": "IPK 3.9"}, []string{"score"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.achType, func(t *testing.T) {
+			achievementMongoRepo = &mockAchievementMongoRepo{
+				CreateFn: func(ctx context.Context, sID uuid.UUID, req model.CreateAchievementRequest) (string, error) {
+					t.Fatalf("Create should not be called when details incomplete")
+					return "", nil
+				},
+			}
+			achievementRefRepo = &mockAchievementRefRepo{}
+
+			app := fiber.New()
+			app.Post("/achievements", func(c *fiber.Ctx) error {
+				c.Locals("student_uuid", uuid.New())
+				return CreateAchievementService(c)
+			})
+
+			payload := map[string]any{
+				"achievement_type": tc.achType,
+				"title":            "Prestasi",
+				"description":      "Deskripsi",
+				"details":          tc.details,
+			}
+			req := httptest.NewRequest(http.MethodPost, "/achievements", toJSONReaderAchievement(t, payload))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
+			}
+			body := decodeMapAchievement(t, resp)
+			got, _ := body["missing_fields"].([]any)
+			if len(got) != len(tc.missing) {
+				t.Fatalf("missing_fields: got %v want %v", got, tc.missing)
+			}
+			for i, f := range tc.missing {
+				if got[i] != f {
+					t.Fatalf("missing_fields[%d]: got %v want %s", i, got[i], f)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateAchievementService_UnknownType(t *testing.T) {
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		CreateFn: func(ctx context.Context, sID uuid.UUID, req model.CreateAchievementRequest) (string, error) {
+			t.Fatalf("Create should not be called for unknown type")
+			return "", nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{}
+
+	app := fiber.New()
+	app.Post("/achievements", func(c *fiber.Ctx) error {
+		c.Locals("student_uuid", uuid.New())
+		return CreateAchievementService(c)
+	})
+
+	payload := map[string]any{
+		"achievement_type": "compitition",
+		"title":            "Juara 1",
+		"description":      "Menang lomba",
+		"details":          map[string]any{"competitionName": "ICPC", "rank": 1},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/achievements", toJSONReaderAchievement(t, payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	body := decodeMapAchievement(t, resp)
+	if body["message"] != "achievement_type tidak dikenal" {
+		t.Fatalf("unexpected message: %v", body["message"])
+	}
+}
+
+func TestCreateAchievementService_AllowedTypes(t *testing.T) {
+	validDetails := map[string]map[string]any{
+		"competition":   {"competitionName": "ICPC", "rank": 1},
+		"publication":   {"publicationTitle": "Efficient Algorithms", "publisher": "Springer"},
+		"organization":  {"organizationName": "BEM", "position": "Ketua"},
+		"certification": {"certificationName": "AWS Cloud Practitioner", "issuedBy": "Amazon"},
+		"academic":      {"score": 3.9},
+	}
+
+	for achType := range model.AllowedAchievementTypes {
+		t.Run(achType, func(t *testing.T) {
+			achievementMongoRepo = &mockAchievementMongoRepo{
+				CreateFn: func(ctx context.Context, sID uuid.UUID, req model.CreateAchievementRequest) (string, error) {
+					return "mongo123", nil
+				},
+			}
+			achievementRefRepo = &mockAchievementRefRepo{
+				CreateDraftFn: func(ctx context.Context, sID uuid.UUID, mongoID string) (string, error) {
+					return "ref123", nil
+				},
+			}
+
+			app := fiber.New()
+			app.Post("/achievements", func(c *fiber.Ctx) error {
+				c.Locals("student_uuid", uuid.New())
+				return CreateAchievementService(c)
+			})
+
+			payload := map[string]any{
+				"achievement_type": achType,
+				"title":            "Prestasi",
+				"description":      "Deskripsi",
+				"details":          validDetails[achType],
+			}
+			req := httptest.NewRequest(http.MethodPost, "/achievements", toJSONReaderAchievement(t, payload))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != http.StatusCreated {
+				t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusCreated)
+			}
+		})
+	}
+}
+
 func TestCreateAchievementService_NoStudent(t *testing.T) {
 	app := fiber.New()
 	app.Post("/achievements", func(c *fiber.Ctx) error {
@@ -419,6 +865,9 @@ func TestSubmitAchievementService_Success(t *testing.T) {
 	studentID := uuid.New()
 	called := false
 	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{StudentID: studentID, Status: model.AchievementStatusDraft}, nil
+		},
 		SubmitDraftFn: func(ctx context.Context, refID string, sID uuid.UUID) error {
 			called = true
 			if refID != "ref-1" {
@@ -450,6 +899,35 @@ func TestSubmitAchievementService_Success(t *testing.T) {
 	}
 }
 
+func TestSubmitAchievementService_NonDraftRejectedByTransitionTable(t *testing.T) {
+	studentID := uuid.New()
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{StudentID: studentID, Status: model.AchievementStatusVerified}, nil
+		},
+		SubmitDraftFn: func(ctx context.Context, refID string, sID uuid.UUID) error {
+			t.Fatalf("SubmitDraft must not run when /actions does not offer submit")
+			return nil
+		},
+	}
+	if achievementTransitionAllowed("mahasiswa", model.AchievementStatusVerified, model.AchievementActionSubmit) {
+		t.Fatalf("matrix must not allow submit on verified")
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/submit", func(c *fiber.Ctx) error {
+		c.Locals("student_uuid", studentID)
+		return SubmitAchievementService(c)
+	})
+	resp, err := app.Test(httptest.NewRequest(http.MethodPut, "/achievements/ref-1/submit", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
 func TestReviewAchievementService_AdminVerified(t *testing.T) {
 	userID := uuid.New()
 	roleID := "role-admin"
@@ -500,52 +978,398 @@ func TestReviewAchievementService_AdminVerified(t *testing.T) {
 	}
 }
 
-func TestSoftDeleteAchievementService_NotFound(t *testing.T) {
-	studentID := uuid.New()
-	userID := uuid.New().String()
-	achievementStudentRepo = &mockStudentRepo{}
+func TestReviewAchievementService_Points(t *testing.T) {
+	userID := uuid.New()
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	oldPoints := 10.0
+	var written []*float64
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			return []model.Achievement{{Points: &oldPoints}}, nil
+		},
+		UpdatePointsFn: func(ctx context.Context, id string, points *float64) error {
+			if id != "mongo-1" {
+				t.Fatalf("unexpected mongo id: %s", id)
+			}
+			written = append(written, points)
+			return nil
+		},
+	}
+	reviewErr := error(nil)
+	var reviewed []string
 	achievementRefRepo = &mockAchievementRefRepo{
-		DeleteByStudentFn: func(ctx context.Context, refID string, sID uuid.UUID) error {
-			return errors.New("tidak ditemukan")
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{MongoAchievementID: "mongo-1", Status: model.AchievementStatusSubmitted}, nil
+		},
+		ReviewFn: func(ctx context.Context, refID string, status string, adminID uuid.UUID, note *string) error {
+			if len(written) == 0 && status == model.AchievementStatusVerified {
+				t.Fatalf("points must be written before the reference is verified")
+			}
+			reviewed = append(reviewed, status)
+			return reviewErr
 		},
 	}
 
 	app := fiber.New()
-	app.Put("/achievements/:id/soft-delete", func(c *fiber.Ctx) error {
-		c.Locals("student_uuid", studentID)
-		c.Locals("user_id", userID)
-		return SoftDeleteAchievementService(c)
+	app.Put("/achievements/:id/review", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		c.Locals("user_id", userID.String())
+		return ReviewAchievementService(c)
 	})
+	put := func(payload map[string]any) int {
+		req := httptest.NewRequest(http.MethodPut, "/achievements/ref-1/review", toJSONReaderAchievement(t, payload))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		return resp.StatusCode
+	}
 
-	req := httptest.NewRequest(http.MethodPut, "/achievements/ref-404/soft-delete", nil)
-	resp, err := app.Test(req, -1)
-	if err != nil {
-		t.Fatalf("app.Test: %v", err)
+	if status := put(map[string]any{"status": "verified", "points": 75}); status != http.StatusOK {
+		t.Fatalf("verify: got %d want %d", status, http.StatusOK)
 	}
-	if resp.StatusCode != http.StatusNotFound {
-		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusNotFound)
+	if len(written) != 1 || *written[0] != 75 {
+		t.Fatalf("expected points 75 written once, got %v", written)
 	}
-}
 
-func TestHardDeleteAchievementService_WrongStatus(t *testing.T) {
-	refID := "ref-1"
-	achievementRefRepo = &mockAchievementRefRepo{
-		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
-			return &model.AchievementReference{
-				ID:                 uuid.New(),
-				MongoAchievementID: "mongo-x",
-				Status:             model.AchievementStatusSubmitted,
-			}, nil
-		},
+	written = nil
+	if status := put(map[string]any{"status": "rejected", "rejection_note": "kurang bukti", "points": 75}); status != http.StatusOK {
+		t.Fatalf("reject: got %d want %d", status, http.StatusOK)
+	}
+	if len(written) != 0 {
+		t.Fatalf("points must be ignored on reject, got %v", written)
 	}
 
-	app := fiber.New()
-	app.Delete("/achievements/:id/delete", HardDeleteAchievementService)
-
-	req := httptest.NewRequest(http.MethodDelete, "/achievements/"+refID+"/delete", nil)
-	resp, err := app.Test(req, -1)
-	if err != nil {
-		t.Fatalf("app.Test: %v", err)
+	reviewed = nil
+	if status := put(map[string]any{"status": "verified", "points": -1}); status != http.StatusBadRequest {
+		t.Fatalf("negative points: got %d want %d", status, http.StatusBadRequest)
+	}
+	if len(reviewed) != 0 || len(written) != 0 {
+		t.Fatalf("negative points must not touch any data")
+	}
+
+	// review gagal: points dikembalikan ke nilai lama
+	reviewErr = errors.New("achievement sudah diproses")
+	if status := put(map[string]any{"status": "verified", "points": 90}); status != http.StatusBadRequest {
+		t.Fatalf("failed review: got %d want %d", status, http.StatusBadRequest)
+	}
+	if len(written) != 2 || *written[0] != 90 || *written[1] != oldPoints {
+		t.Fatalf("expected points restored after failed review, got %v", written)
+	}
+}
+
+func TestReviewAchievementService_PointsNotWrittenForNonAdvisor(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Dosen Wali"}, nil
+		},
+	}
+	achievementLecturerRepo = &mockLectRepo{
+		GetLecturerByUserIDFn: func(userID string) (*model.Lecturer, error) {
+			return &model.Lecturer{ID: uuid.New()}, nil
+		},
+	}
+	achievementAdvisorRepo = &mockStudentAdvisorRepo{
+		IsAdvisorFn: func(studentID, lectID string) (bool, error) { return false, nil },
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		UpdatePointsFn: func(ctx context.Context, id string, points *float64) error {
+			t.Fatalf("points must not be written before the advisor check")
+			return nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{StudentID: uuid.New(), MongoAchievementID: "mongo-1", Status: model.AchievementStatusSubmitted}, nil
+		},
+		ReviewByAdvisorFn: func(ctx context.Context, refID string, status string, reviewerID uuid.UUID, lecturerID uuid.UUID, note *string) error {
+			t.Fatalf("ReviewByAdvisor must not be called for a non-advisor")
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/review", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-dosen")
+		c.Locals("user_id", uuid.NewString())
+		return ReviewAchievementService(c)
+	})
+	req := httptest.NewRequest(http.MethodPut, "/achievements/ref-1/review", toJSONReaderAchievement(t, map[string]any{"status": "verified", "points": 80}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusForbidden)
+	}
+}
+
+func TestBulkReviewAchievementService_MixedAuthorization(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Dosen Wali"}, nil
+		},
+	}
+	lecturerID := uuid.New()
+	achievementLecturerRepo = &mockLectRepo{
+		GetLecturerByUserIDFn: func(userID string) (*model.Lecturer, error) {
+			return &model.Lecturer{ID: lecturerID}, nil
+		},
+	}
+	achievementNotifier = utils.NoopNotifier{}
+	achievementWebhook = nil
+	var reviewed []string
+	achievementRefRepo = &mockAchievementRefRepo{
+		ReviewByAdvisorFn: func(ctx context.Context, refID string, status string, reviewerID uuid.UUID, lid uuid.UUID, note *string) error {
+			if lid != lecturerID {
+				t.Fatalf("unexpected lecturer: %s", lid)
+			}
+			reviewed = append(reviewed, refID)
+			if refID == "ref-other" {
+				return errors.New("achievement sudah diproses atau tidak berhak")
+			}
+			return nil
+		},
+		ReviewFn: func(ctx context.Context, refID string, status string, adminID uuid.UUID, note *string) error {
+			t.Fatal("dosen wali must not use the admin review path")
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/bulk-review", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-dw")
+		c.Locals("user_id", uuid.New().String())
+		return BulkReviewAchievementService(c)
+	})
+	put := func(payload map[string]any) (int, map[string]any) {
+		req := httptest.NewRequest(http.MethodPut, "/achievements/bulk-review", toJSONReaderAchievement(t, payload))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return resp.StatusCode, body
+	}
+
+	status, body := put(map[string]any{"ids": []string{"ref-mine", "ref-other", "ref-mine"}, "status": "verified"})
+	if status != http.StatusOK {
+		t.Fatalf("status: got %d want %d", status, http.StatusOK)
+	}
+	if len(reviewed) != 2 {
+		t.Fatalf("duplicate ids must be reviewed once, got %v", reviewed)
+	}
+	data := body["data"].(map[string]any)
+	if data["succeeded"] != float64(1) || data["failed"] != float64(1) {
+		t.Fatalf("unexpected counts: %#v", data)
+	}
+	results := data["results"].([]any)
+	first, second := results[0].(map[string]any), results[1].(map[string]any)
+	if first["id"] != "ref-mine" || first["success"] != true {
+		t.Fatalf("unexpected first result: %#v", first)
+	}
+	if second["id"] != "ref-other" || second["success"] != false || second["error"] == "" {
+		t.Fatalf("unexpected second result: %#v", second)
+	}
+
+	reviewed = nil
+	status, body = put(map[string]any{"ids": []string{"ref-mine"}, "status": "rejected"})
+	if status != http.StatusBadRequest || body["message"] != "rejection_note wajib diisi jika status rejected" {
+		t.Fatalf("missing note: got %d %v", status, body["message"])
+	}
+	if len(reviewed) != 0 {
+		t.Fatalf("nothing may be reviewed without a rejection note")
+	}
+}
+
+func TestBulkReviewAchievementService_HidesDBErrorInProduction(t *testing.T) {
+	t.Setenv("APP_ENV", "production")
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	achievementNotifier = utils.NoopNotifier{}
+	achievementWebhook = nil
+	achievementRefRepo = &mockAchievementRefRepo{
+		ReviewFn: func(ctx context.Context, refID string, status string, adminID uuid.UUID, note *string) error {
+			if refID == "ref-db" {
+				return fmt.Errorf("gagal review achievement: %w", errors.New("pq: connection refused to 10.0.0.5"))
+			}
+			return errors.New("achievement tidak ditemukan atau status bukan submitted")
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/bulk-review", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		c.Locals("user_id", uuid.New().String())
+		return BulkReviewAchievementService(c)
+	})
+	req := httptest.NewRequest(http.MethodPut, "/achievements/bulk-review", toJSONReaderAchievement(t, map[string]any{
+		"ids": []string{"ref-db", "ref-draft"}, "status": "verified",
+	}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	results := body["data"].(map[string]any)["results"].([]any)
+	if got := results[0].(map[string]any)["error"]; got != "Gagal review achievement" {
+		t.Fatalf("db error must be sanitized, got %#v", got)
+	}
+	if got := results[1].(map[string]any)["error"]; got != "achievement tidak ditemukan atau status bukan submitted" {
+		t.Fatalf("domain error must be kept, got %#v", got)
+	}
+}
+
+func TestReviewAchievementService_DosenWaliConcurrentReview(t *testing.T) {
+	lecturerID := uuid.New()
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Dosen Wali"}, nil
+		},
+	}
+	achievementLecturerRepo = &mockLectRepo{
+		GetLecturerByUserIDFn: func(userID string) (*model.Lecturer, error) {
+			return &model.Lecturer{ID: lecturerID}, nil
+		},
+	}
+
+	achievementAdvisorRepo = &mockStudentAdvisorRepo{
+		IsAdvisorFn: func(studentID, lectID string) (bool, error) { return true, nil },
+	}
+
+	// simulasi UPDATE ... WHERE status='submitted': hanya satu yang bisa mengubah baris
+	var mu sync.Mutex
+	status := model.AchievementStatusSubmitted
+	reviewCalls := 0
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			// boleh dibaca hanya untuk menjelaskan UPDATE yang gagal, tidak sebelum UPDATE
+			mu.Lock()
+			defer mu.Unlock()
+			if reviewCalls == 0 {
+				t.Errorf("GetByID must not run before the guarded UPDATE on the dosen wali review path")
+			}
+			return &model.AchievementReference{StudentID: uuid.New(), Status: status}, nil
+		},
+		ReviewByAdvisorFn: func(ctx context.Context, refID string, newStatus string, reviewerID uuid.UUID, lectID uuid.UUID, note *string) error {
+			if lectID != lecturerID {
+				t.Errorf("unexpected lecturerID: %s", lectID)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			reviewCalls++
+			if status != model.AchievementStatusSubmitted {
+				return errors.New("achievement sudah diproses atau tidak berhak")
+			}
+			status = newStatus
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/review", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-dosen")
+		c.Locals("user_id", c.Get("X-User"))
+		return ReviewAchievementService(c)
+	})
+
+	var wg sync.WaitGroup
+	codes := make([]int, 2)
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			payload := map[string]any{"status": "verified"}
+			req := httptest.NewRequest(http.MethodPut, "/achievements/ref-1/review", toJSONReaderAchievement(t, payload))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-User", uuid.New().String())
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Errorf("app.Test: %v", err)
+				return
+			}
+			codes[i] = resp.StatusCode
+		}(i)
+	}
+	wg.Wait()
+
+	ok, conflict := 0, 0
+	for _, code := range codes {
+		switch code {
+		case http.StatusOK:
+			ok++
+		case http.StatusConflict:
+			conflict++
+		}
+	}
+	if ok != 1 || conflict != 1 {
+		t.Fatalf("expected exactly one success and one conflict, got %v", codes)
+	}
+}
+
+func TestSoftDeleteAchievementService_NotFound(t *testing.T) {
+	studentID := uuid.New()
+	userID := uuid.New().String()
+	achievementStudentRepo = &mockStudentRepo{}
+	achievementRefRepo = &mockAchievementRefRepo{
+		DeleteByStudentFn: func(ctx context.Context, refID string, sID uuid.UUID) error {
+			return errors.New("tidak ditemukan")
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/soft-delete", func(c *fiber.Ctx) error {
+		c.Locals("student_uuid", studentID)
+		c.Locals("user_id", userID)
+		return SoftDeleteAchievementService(c)
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/achievements/ref-404/soft-delete", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestHardDeleteAchievementService_WrongStatus(t *testing.T) {
+	refID := "ref-1"
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{
+				ID:                 uuid.New(),
+				MongoAchievementID: "mongo-x",
+				Status:             model.AchievementStatusSubmitted,
+			}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Delete("/achievements/:id/delete", HardDeleteAchievementService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/achievements/"+refID+"/delete", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
 	}
 	if resp.StatusCode != http.StatusBadRequest {
 		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
@@ -565,7 +1389,7 @@ func TestGetAchievementsService_AdminSuccess(t *testing.T) {
 	}
 	refID := uuid.New()
 	achievementRefRepo = &mockAchievementRefRepo{
-		ListByStatusesFn: func(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64) ([]model.AchievementReference, int64, error) {
+		ListByStatusesFn: func(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64, filter model.AchievementReferenceFilter) ([]model.AchievementReference, int64, error) {
 			if len(statuses) == 0 {
 				t.Fatalf("statuses empty")
 			}
@@ -605,3 +1429,2167 @@ func TestGetAchievementsService_AdminSuccess(t *testing.T) {
 		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
 	}
 }
+
+func TestGetAchievementCountService_ReturnsRepositoryTotal(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Mahasiswa"}, nil
+		},
+	}
+	studentID := uuid.New()
+	achievementRefRepo = &mockAchievementRefRepo{
+		CountByStatusesFn: func(ctx context.Context, statuses []string, sid *uuid.UUID, advisorID *uuid.UUID, filter model.AchievementReferenceFilter) (int64, error) {
+			if sid == nil || *sid != studentID {
+				t.Fatalf("count must be scoped to the caller's student, got %v", sid)
+			}
+			return 7, nil
+		},
+		ListByStatusesFn: func(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64, filter model.AchievementReferenceFilter) ([]model.AchievementReference, int64, error) {
+			t.Fatal("count endpoint must not list rows")
+			return nil, 0, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievements/count", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-mhs")
+		c.Locals("student_uuid", studentID)
+		return GetAchievementCountService(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/count", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["total"] != float64(7) {
+		t.Fatalf("unexpected total: %#v", body["total"])
+	}
+}
+
+func TestGetAchievementTimelineService_ScopedToCaller(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Mahasiswa"}, nil
+		},
+	}
+	studentID := uuid.New()
+	var calls []bool
+	achievementRefRepo = &mockAchievementRefRepo{
+		TimelineByMonthFn: func(ctx context.Context, statuses []string, sid *uuid.UUID, advisorID *uuid.UUID, year int, verified bool) ([]model.AchievementTimelinePoint, error) {
+			if sid == nil || *sid != studentID || advisorID != nil {
+				t.Fatalf("timeline must be scoped to the caller's student, got student=%v advisor=%v", sid, advisorID)
+			}
+			if year != 2025 {
+				t.Fatalf("unexpected year: %d", year)
+			}
+			calls = append(calls, verified)
+			if verified {
+				return []model.AchievementTimelinePoint{{Period: "2025-02", Count: 1}, {Period: "2025-04", Count: 2}}, nil
+			}
+			return []model.AchievementTimelinePoint{{Period: "2025-02", Count: 3}, {Period: "2025-03", Count: 1}}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievements/timeline", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-mhs")
+		c.Locals("student_uuid", studentID)
+		return GetAchievementTimelineService(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/timeline?group=week", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unsupported group: got %d want 400", resp.StatusCode)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/achievements/timeline?group=month&year=2025&include_verified=true", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	if len(calls) != 2 || calls[0] || !calls[1] {
+		t.Fatalf("expected created then verified aggregation, got %v", calls)
+	}
+	var body struct {
+		Data []model.AchievementTimelinePoint `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	got := []string{}
+	for _, p := range body.Data {
+		v := int64(-1)
+		if p.Verified != nil {
+			v = *p.Verified
+		}
+		got = append(got, fmt.Sprintf("%s:%d/%d", p.Period, p.Count, v))
+	}
+	want := []string{"2025-02:3/1", "2025-03:1/0", "2025-04:0/2"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("timeline: got %v want %v", got, want)
+	}
+}
+
+func TestGetAchievementsService_PreservesReferenceOrder(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	first, second, third := bson.NewObjectID(), bson.NewObjectID(), bson.NewObjectID()
+	now := time.Now()
+	achievementRefRepo = &mockAchievementRefRepo{
+		ListByStatusesFn: func(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64, filter model.AchievementReferenceFilter) ([]model.AchievementReference, int64, error) {
+			return []model.AchievementReference{
+				{ID: uuid.New(), MongoAchievementID: first.Hex(), Status: model.AchievementStatusSubmitted, CreatedAt: now},
+				{ID: uuid.New(), MongoAchievementID: second.Hex(), Status: model.AchievementStatusSubmitted, CreatedAt: now.Add(-time.Minute)},
+				{ID: uuid.New(), MongoAchievementID: third.Hex(), Status: model.AchievementStatusSubmitted, CreatedAt: now.Add(-time.Hour)},
+			}, 3, nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		// urutan $in Mongo tidak dijamin: kembalikan terbalik dan tanpa dokumen kedua
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			return []model.Achievement{
+				{ID: third, Title: "Ketiga"},
+				{ID: first, Title: "Pertama"},
+			}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievements", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		return GetAchievementsService(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	data, _ := decodeMapAchievement(t, resp)["data"].([]any)
+	if len(data) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(data))
+	}
+	wantIDs := []string{first.Hex(), second.Hex(), third.Hex()}
+	wantTitles := []string{"Pertama", "", "Ketiga"}
+	for i, item := range data {
+		entry := item.(map[string]any)
+		ref := entry["reference"].(map[string]any)
+		ach := entry["achievement"].(map[string]any)
+		if ref["mongo_achievement_id"] != wantIDs[i] || ach["title"] != wantTitles[i] {
+			t.Fatalf("item %d: got ref %v title %q", i, ref["mongo_achievement_id"], ach["title"])
+		}
+	}
+}
+
+func TestGetAchievementsService_ExpandStudent(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	budi, sari := uuid.New(), uuid.New()
+	achievementRefRepo = &mockAchievementRefRepo{
+		ListByStatusesFn: func(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64, filter model.AchievementReferenceFilter) ([]model.AchievementReference, int64, error) {
+			return []model.AchievementReference{
+				{ID: uuid.New(), StudentID: budi, MongoAchievementID: "m1", Status: model.AchievementStatusSubmitted},
+				{ID: uuid.New(), StudentID: sari, MongoAchievementID: "m2", Status: model.AchievementStatusSubmitted},
+				{ID: uuid.New(), StudentID: budi, MongoAchievementID: "m3", Status: model.AchievementStatusVerified},
+			}, 3, nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{}
+	lookups := 0
+	achievementStudentRepo = &mockStudentRepo{
+		GetStudentSummariesFn: func(ids []uuid.UUID) (map[uuid.UUID]model.StudentSummary, error) {
+			lookups++
+			if len(ids) != 2 {
+				t.Fatalf("student ids must be deduplicated, got %v", ids)
+			}
+			return map[uuid.UUID]model.StudentSummary{
+				budi: {ID: budi, StudentID: "2201001", FullName: "Budi", ProgramStudy: "Informatika", AdvisorName: "Pak Dosen"},
+				sari: {ID: sari, StudentID: "2201002", FullName: "Sari", ProgramStudy: "Sistem Informasi"},
+			}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievements", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		return GetAchievementsService(c)
+	})
+	list := func(query string) []any {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements"+query, nil), -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+		}
+		data, _ := decodeMapAchievement(t, resp)["data"].([]any)
+		return data
+	}
+
+	for _, item := range list("") {
+		if _, ok := item.(map[string]any)["student"]; ok {
+			t.Fatalf("student must not be included without expand: %v", item)
+		}
+	}
+	if lookups != 0 {
+		t.Fatalf("student lookup must not run without expand, got %d", lookups)
+	}
+
+	data := list("?expand=student")
+	if lookups != 1 {
+		t.Fatalf("expected a single batch lookup, got %d", lookups)
+	}
+	wantNames := []string{"Budi", "Sari", "Budi"}
+	for i, item := range data {
+		student, ok := item.(map[string]any)["student"].(map[string]any)
+		if !ok {
+			t.Fatalf("item %d: student missing: %v", i, item)
+		}
+		if student["full_name"] != wantNames[i] {
+			t.Fatalf("item %d: full_name got %v want %s", i, student["full_name"], wantNames[i])
+		}
+		if _, ok := student["advisor_name"]; ok {
+			t.Fatalf("item %d: advisor_name is only for dosen wali scope", i)
+		}
+	}
+	if data[0].(map[string]any)["student"].(map[string]any)["program_study"] != "Informatika" {
+		t.Fatalf("program_study missing: %v", data[0])
+	}
+}
+
+func TestAdminReassignAchievementService_NonAdmin(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Mahasiswa"}, nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		UpdateStudentIDFn: func(ctx context.Context, refID string, studentID uuid.UUID) error {
+			t.Fatalf("UpdateStudentID should not be called for non-admin")
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/reassign", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-mhs")
+		return AdminReassignAchievementService(c)
+	})
+
+	payload := map[string]any{"student_id": uuid.New().String()}
+	req := httptest.NewRequest(http.MethodPut, "/achievements/ref-1/reassign", toJSONReaderAchievement(t, payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusForbidden)
+	}
+}
+
+func TestAdminReassignAchievementService_UnknownStudent(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	achievementStudentRepo = &mockStudentRepo{
+		GetStudentByIDFn: func(id string) (*model.Student, error) {
+			return nil, errors.New("student tidak ditemukan")
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/reassign", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		return AdminReassignAchievementService(c)
+	})
+
+	payload := map[string]any{"student_id": uuid.New().String()}
+	req := httptest.NewRequest(http.MethodPut, "/achievements/ref-1/reassign", toJSONReaderAchievement(t, payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusNotFound)
+	}
+	body := decodeMapAchievement(t, resp)
+	if body["message"] != "Student tujuan tidak ditemukan" {
+		t.Fatalf("unexpected message: %v", body["message"])
+	}
+}
+
+func TestAdminReassignAchievementService_StudentLookupError(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	achievementStudentRepo = &mockStudentRepo{
+		GetStudentByIDFn: func(id string) (*model.Student, error) {
+			return nil, errors.New("gagal mengambil student: context deadline exceeded")
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/reassign", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		return AdminReassignAchievementService(c)
+	})
+
+	payload := map[string]any{"student_id": uuid.New().String()}
+	req := httptest.NewRequest(http.MethodPut, "/achievements/ref-1/reassign", toJSONReaderAchievement(t, payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusInternalServerError)
+	}
+}
+
+func TestAdminReassignAchievementService_Success(t *testing.T) {
+	oldStudent := uuid.New()
+	newStudent := uuid.New()
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	achievementStudentRepo = &mockStudentRepo{
+		GetStudentByIDFn: func(id string) (*model.Student, error) {
+			return &model.Student{ID: newStudent}, nil
+		},
+	}
+
+	var refStudent, mongoStudent uuid.UUID
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{StudentID: oldStudent, MongoAchievementID: "mongo-1"}, nil
+		},
+		UpdateStudentIDFn: func(ctx context.Context, refID string, studentID uuid.UUID) error {
+			if refID != "ref-1" {
+				t.Fatalf("unexpected refID: %s", refID)
+			}
+			refStudent = studentID
+			return nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		UpdateStudentIDFn: func(ctx context.Context, id string, studentID uuid.UUID) error {
+			if id != "mongo-1" {
+				t.Fatalf("unexpected mongo id: %s", id)
+			}
+			mongoStudent = studentID
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/reassign", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		return AdminReassignAchievementService(c)
+	})
+
+	payload := map[string]any{"student_id": newStudent.String()}
+	req := httptest.NewRequest(http.MethodPut, "/achievements/ref-1/reassign", toJSONReaderAchievement(t, payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	if refStudent != newStudent {
+		t.Fatalf("reference student not updated: %s", refStudent)
+	}
+	if mongoStudent != newStudent {
+		t.Fatalf("mongo student not updated: %s", mongoStudent)
+	}
+}
+
+func TestAdminReassignAchievementService_RollbackOnMongoError(t *testing.T) {
+	oldStudent := uuid.New()
+	newStudent := uuid.New()
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	achievementStudentRepo = &mockStudentRepo{
+		GetStudentByIDFn: func(id string) (*model.Student, error) {
+			return &model.Student{ID: newStudent}, nil
+		},
+	}
+
+	var calls []uuid.UUID
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{StudentID: oldStudent, MongoAchievementID: "mongo-1"}, nil
+		},
+		UpdateStudentIDFn: func(ctx context.Context, refID string, studentID uuid.UUID) error {
+			calls = append(calls, studentID)
+			return nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		UpdateStudentIDFn: func(ctx context.Context, id string, studentID uuid.UUID) error {
+			return errors.New("mongo down")
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/reassign", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		return AdminReassignAchievementService(c)
+	})
+
+	payload := map[string]any{"student_id": newStudent.String()}
+	req := httptest.NewRequest(http.MethodPut, "/achievements/ref-1/reassign", toJSONReaderAchievement(t, payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusInternalServerError)
+	}
+	if len(calls) != 2 || calls[0] != newStudent || calls[1] != oldStudent {
+		t.Fatalf("expected update then rollback, got %v", calls)
+	}
+}
+
+func TestGetAchievementReferencesService_FlagsOverdueSubmission(t *testing.T) {
+	t.Setenv("ACHIEVEMENT_REVIEW_SLA_DAYS", "7")
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	oldSubmit := time.Now().Add(-10 * 24 * time.Hour)
+	freshSubmit := time.Now().Add(-2 * 24 * time.Hour)
+	achievementRefRepo = &mockAchievementRefRepo{
+		ListByStatusesFn: func(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64, filter model.AchievementReferenceFilter) ([]model.AchievementReference, int64, error) {
+			return []model.AchievementReference{
+				{ID: uuid.New(), Status: model.AchievementStatusSubmitted, SubmittedAt: &oldSubmit},
+				{ID: uuid.New(), Status: model.AchievementStatusSubmitted, SubmittedAt: &freshSubmit},
+				{ID: uuid.New(), Status: model.AchievementStatusVerified, SubmittedAt: &oldSubmit},
+			}, 3, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievement-references", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		return GetAchievementReferencesService(c)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/achievement-references", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	body := decodeMapAchievement(t, resp)
+	data, _ := body["data"].([]any)
+	if len(data) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(data))
+	}
+	old := data[0].(map[string]any)
+	if old["overdue"] != true || old["days_pending"] != float64(10) {
+		t.Fatalf("old submission not flagged overdue: %#v", old)
+	}
+	fresh := data[1].(map[string]any)
+	if fresh["overdue"] != false || fresh["days_pending"] != float64(2) {
+		t.Fatalf("fresh submission flagged incorrectly: %#v", fresh)
+	}
+	if _, ok := data[2].(map[string]any)["overdue"]; ok {
+		t.Fatalf("verified reference should not carry SLA fields")
+	}
+}
+
+func TestGetAchievementReferencesService_VerifiedRangeValidation(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	var gotFilter model.AchievementReferenceFilter
+	achievementRefRepo = &mockAchievementRefRepo{
+		ListByStatusesFn: func(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64, filter model.AchievementReferenceFilter) ([]model.AchievementReference, int64, error) {
+			gotFilter = filter
+			return nil, 0, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievement-references", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		return GetAchievementReferencesService(c)
+	})
+
+	bad := map[string]string{
+		"verified_from=2025-03-01T00:00:00Z":                                  "verified_from dan verified_to harus diisi bersamaan",
+		"verified_from=2025-03-01&verified_to=2025-03-31T00:00:00Z":           "verified_from harus format RFC3339",
+		"verified_from=2025-03-31T00:00:00Z&verified_to=2025-03-01T00:00:00Z": "verified_from tidak boleh setelah verified_to",
+	}
+	for query, wantMsg := range bad {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievement-references?"+query, nil), -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("%s: status got %d want 400", query, resp.StatusCode)
+		}
+		if body := decodeMapAchievement(t, resp); body["message"] != wantMsg {
+			t.Fatalf("%s: unexpected message %v", query, body["message"])
+		}
+	}
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievement-references?verified_from=2025-03-01T00:00:00Z&verified_to=2025-03-31T23:59:59Z", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("valid range: status got %d want 200", resp.StatusCode)
+	}
+	if gotFilter.VerifiedFrom == nil || gotFilter.VerifiedTo == nil || gotFilter.VerifiedTo.Day() != 31 {
+		t.Fatalf("range not passed to repository: %+v", gotFilter)
+	}
+}
+
+func TestGetReviewQueueService_RoleGuard(t *testing.T) {
+	roleName := ""
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: roleName}, nil
+		},
+	}
+	lecturerID := uuid.New()
+	achievementLecturerRepo = &mockLectRepo{
+		GetLecturerByUserIDFn: func(userID string) (*model.Lecturer, error) {
+			return &model.Lecturer{ID: lecturerID}, nil
+		},
+	}
+	listed := 0
+	achievementRefRepo = &mockAchievementRefRepo{
+		ListByStatusesFn: func(ctx context.Context, statuses []string, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64, filter model.AchievementReferenceFilter) ([]model.AchievementReference, int64, error) {
+			listed++
+			if len(statuses) != 1 || statuses[0] != model.AchievementStatusSubmitted {
+				t.Fatalf("queue must only contain submitted, got %v", statuses)
+			}
+			if studentID != nil || advisorID == nil || *advisorID != lecturerID {
+				t.Fatalf("queue must be scoped to the caller as advisor, got student=%v advisor=%v", studentID, advisorID)
+			}
+			return []model.AchievementReference{{ID: uuid.New(), MongoAchievementID: "mongo-1", Status: model.AchievementStatusSubmitted}}, 1, nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{}
+
+	app := fiber.New()
+	app.Get("/achievements/review-queue", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-x")
+		c.Locals("user_id", uuid.New().String())
+		return GetReviewQueueService(c)
+	})
+
+	for _, tc := range []struct {
+		role string
+		want int
+	}{
+		{"Admin", http.StatusForbidden},
+		{"Mahasiswa", http.StatusForbidden},
+		{"Staff", http.StatusForbidden},
+		{"Dosen Wali", http.StatusOK},
+	} {
+		roleName = tc.role
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/review-queue", nil), -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != tc.want {
+			t.Fatalf("%s: got %d want %d", tc.role, resp.StatusCode, tc.want)
+		}
+	}
+	if listed != 1 {
+		t.Fatalf("only dosen wali may reach the repository, got %d calls", listed)
+	}
+}
+
+func TestGetOverdueAchievementsService_UsesSLACutoff(t *testing.T) {
+	t.Setenv("ACHIEVEMENT_REVIEW_SLA_DAYS", "5")
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	oldSubmit := time.Now().Add(-6 * 24 * time.Hour)
+	achievementRefRepo = &mockAchievementRefRepo{
+		ListSubmittedBeforeFn: func(ctx context.Context, before time.Time, studentID *uuid.UUID, advisorID *uuid.UUID, page, limit int64) ([]model.AchievementReference, int64, error) {
+			expected := time.Now().AddDate(0, 0, -5)
+			if diff := expected.Sub(before); diff < -time.Minute || diff > time.Minute {
+				t.Fatalf("unexpected cutoff: %v (expected ~%v)", before, expected)
+			}
+			return []model.AchievementReference{
+				{ID: uuid.New(), Status: model.AchievementStatusSubmitted, SubmittedAt: &oldSubmit},
+			}, 1, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievements/overdue", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		return GetOverdueAchievementsService(c)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/achievements/overdue", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	body := decodeMapAchievement(t, resp)
+	data, _ := body["data"].([]any)
+	if len(data) != 1 || data[0].(map[string]any)["overdue"] != true {
+		t.Fatalf("expected overdue item, got %#v", body["data"])
+	}
+	if body["sla_days"] != float64(5) {
+		t.Fatalf("unexpected sla_days: %v", body["sla_days"])
+	}
+}
+
+func TestGetAchievementByIDService_OwnerView(t *testing.T) {
+	studentID := uuid.New()
+	mongoID := bson.NewObjectID()
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Mahasiswa"}, nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{StudentID: studentID, MongoAchievementID: mongoID.Hex(), Status: model.AchievementStatusDraft}, nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			if len(ids) != 1 || ids[0] != mongoID.Hex() {
+				t.Fatalf("unexpected ids: %v", ids)
+			}
+			return []model.Achievement{{ID: mongoID, Title: "Juara 1"}}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievements/:id", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-mhs")
+		c.Locals("student_uuid", studentID)
+		return GetAchievementByIDService(c)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/achievements/ref-1", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	body := decodeMapAchievement(t, resp)
+	data, _ := body["data"].(map[string]any)
+	ach, _ := data["achievement"].(map[string]any)
+	if ach["title"] != "Juara 1" {
+		t.Fatalf("unexpected achievement: %#v", data)
+	}
+}
+
+func TestGetAchievementByIDService_OtherStudentForbidden(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Mahasiswa"}, nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{StudentID: uuid.New(), MongoAchievementID: bson.NewObjectID().Hex(), Status: model.AchievementStatusVerified}, nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			t.Fatalf("GetByIDs should not be called when out of scope")
+			return nil, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievements/:id", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-mhs")
+		c.Locals("student_uuid", uuid.New())
+		return GetAchievementByIDService(c)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/achievements/ref-1", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusForbidden)
+	}
+}
+
+func TestGetAchievementByIDService_AdminViewAndNotFound(t *testing.T) {
+	mongoID := bson.NewObjectID()
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			if id == "missing" {
+				return nil, errors.New("achievement reference tidak ditemukan")
+			}
+			return &model.AchievementReference{StudentID: uuid.New(), MongoAchievementID: mongoID.Hex(), Status: model.AchievementStatusDeleted}, nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			return []model.Achievement{{ID: mongoID}}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievements/:id", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		return GetAchievementByIDService(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/ref-1", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/achievements/missing", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestGetAchievementFunnelService_Counts(t *testing.T) {
+	achievementRefRepo = &mockAchievementRefRepo{
+		FunnelFn: func(ctx context.Context, from, to time.Time) (*model.AchievementFunnel, error) {
+			if from.Format("2006-01-02") != "2025-01-01" {
+				t.Fatalf("unexpected from: %v", from)
+			}
+			// to inklusif -> repo menerima awal hari berikutnya
+			if to.Format("2006-01-02") != "2025-02-01" {
+				t.Fatalf("unexpected to: %v", to)
+			}
+			return &model.AchievementFunnel{From: from, To: to, Created: 10, Submitted: 7, Verified: 4, Rejected: 2}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievements/funnel", GetAchievementFunnelService)
+
+	req := httptest.NewRequest(http.MethodGet, "/achievements/funnel?from=2025-01-01&to=2025-01-31", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	body := decodeMapAchievement(t, resp)
+	data, _ := body["data"].(map[string]any)
+	if data["created"] != float64(10) || data["submitted"] != float64(7) || data["verified"] != float64(4) || data["rejected"] != float64(2) {
+		t.Fatalf("unexpected funnel: %#v", data)
+	}
+}
+
+func TestGetAchievementFunnelService_InvalidRange(t *testing.T) {
+	achievementRefRepo = &mockAchievementRefRepo{}
+
+	app := fiber.New()
+	app.Get("/achievements/funnel", GetAchievementFunnelService)
+
+	for _, q := range []string{"from=01-01-2025", "from=2025-02-01&to=2025-01-01"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/funnel?"+q, nil), -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("%s: status got %d want %d", q, resp.StatusCode, http.StatusBadRequest)
+		}
+	}
+}
+
+func setupAttachmentTest(t *testing.T, fileURL string) *fiber.App {
+	t.Helper()
+	studentID := uuid.New()
+	mongoID := bson.NewObjectID()
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Mahasiswa"}, nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{StudentID: studentID, MongoAchievementID: mongoID.Hex(), Status: model.AchievementStatusDraft}, nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			return []model.Achievement{{
+				ID: mongoID,
+				Attachments: []model.Attachment{
+					{FileName: "sertifikat.pdf", FileURL: fileURL, FileType: "application/pdf"},
+				},
+			}}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievements/:id/attachments/:index", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-mhs")
+		c.Locals("student_uuid", studentID)
+		return GetAchievementAttachmentService(c)
+	})
+	return app
+}
+
+func TestGetAchievementAttachmentService_AuthorizedDownload(t *testing.T) {
+	os.RemoveAll("uploads")
+	defer os.RemoveAll("uploads")
+	if err := os.MkdirAll("uploads", 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile("uploads/123-sertifikat.pdf", []byte("%PDF-dummy"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	app := setupAttachmentTest(t, "/uploads/123-sertifikat.pdf")
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/ref-1/attachments/0", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "application/pdf" {
+		t.Fatalf("unexpected content type: %s", got)
+	}
+	if got := resp.Header.Get("Content-Disposition"); !strings.Contains(got, "sertifikat.pdf") {
+		t.Fatalf("unexpected content disposition: %s", got)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	if string(data) != "%PDF-dummy" {
+		t.Fatalf("unexpected body: %q", data)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/achievements/ref-1/attachments/3", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("bad index status: got %d want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestGetAchievementAttachmentService_RejectsTraversal(t *testing.T) {
+	for _, fileURL := range []string{"/uploads/../go.mod", "/etc/passwd", "uploads/../../secret"} {
+		app := setupAttachmentTest(t, fileURL)
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/ref-1/attachments/0", nil), -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("%s: status got %d want %d", fileURL, resp.StatusCode, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestReviewAchievementService_CoAdvisorsCanReview(t *testing.T) {
+	studentID := uuid.New()
+	primary, coAdvisor, outsider := uuid.New(), uuid.New(), uuid.New()
+	advisorsOf := map[uuid.UUID]bool{primary: true, coAdvisor: true}
+	lecturerByUser := map[string]uuid.UUID{}
+
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Dosen Wali"}, nil
+		},
+	}
+	achievementLecturerRepo = &mockLectRepo{
+		GetLecturerByUserIDFn: func(userID string) (*model.Lecturer, error) {
+			return &model.Lecturer{ID: lecturerByUser[userID]}, nil
+		},
+	}
+	achievementAdvisorRepo = &mockStudentAdvisorRepo{
+		IsAdvisorFn: func(sID, lecturerID string) (bool, error) {
+			return sID == studentID.String() && advisorsOf[uuid.MustParse(lecturerID)], nil
+		},
+	}
+	reviewed := map[string]uuid.UUID{}
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{StudentID: studentID, MongoAchievementID: bson.NewObjectID().Hex(), Status: model.AchievementStatusSubmitted}, nil
+		},
+		ReviewByAdvisorFn: func(ctx context.Context, refID string, status string, reviewerID uuid.UUID, lecturerID uuid.UUID, note *string) error {
+			// meniru subquery advisor_id UNION student_advisors
+			if !advisorsOf[lecturerID] {
+				return errors.New("achievement sudah diproses atau tidak berhak")
+			}
+			reviewed[strings.Clone(refID)] = lecturerID
+			return nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			return []model.Achievement{{}}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/review", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-dosen")
+		c.Locals("user_id", c.Get("X-User"))
+		return ReviewAchievementService(c)
+	})
+	app.Get("/achievements/:id", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-dosen")
+		c.Locals("user_id", c.Get("X-User"))
+		return GetAchievementByIDService(c)
+	})
+
+	cases := []struct {
+		refID      string
+		lecturer   uuid.UUID
+		wantReview int
+		wantView   int
+	}{
+		{"ref-1", primary, http.StatusOK, http.StatusOK},
+		{"ref-2", coAdvisor, http.StatusOK, http.StatusOK},
+		{"ref-3", outsider, http.StatusForbidden, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		userID := uuid.New().String()
+		lecturerByUser[userID] = tc.lecturer
+
+		viewReq := httptest.NewRequest(http.MethodGet, "/achievements/"+tc.refID, nil)
+		viewReq.Header.Set("X-User", userID)
+		resp, err := app.Test(viewReq, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != tc.wantView {
+			t.Fatalf("%s view: got %d want %d", tc.refID, resp.StatusCode, tc.wantView)
+		}
+
+		req := httptest.NewRequest(http.MethodPut, "/achievements/"+tc.refID+"/review", toJSONReaderAchievement(t, map[string]any{"status": "verified"}))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User", userID)
+		resp, err = app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != tc.wantReview {
+			t.Fatalf("%s review: got %d want %d", tc.refID, resp.StatusCode, tc.wantReview)
+		}
+	}
+	if reviewed["ref-1"] != primary || reviewed["ref-2"] != coAdvisor {
+		t.Fatalf("unexpected reviewers: %v", reviewed)
+	}
+}
+
+func TestHardDeleteAchievementService_RemovesAttachmentFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("UPLOAD_DIR", dir)
+	filePath := dir + "/111-bukti.pdf"
+	if err := os.WriteFile(filePath, []byte("dummy"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	mongoID := bson.NewObjectID()
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{MongoAchievementID: mongoID.Hex(), Status: model.AchievementStatusDeleted}, nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			return []model.Achievement{{
+				ID: mongoID,
+				Attachments: []model.Attachment{
+					{FileName: "bukti.pdf", FileURL: "/uploads/111-bukti.pdf"},
+					{FileName: "hilang.pdf", FileURL: "/uploads/222-hilang.pdf"},
+				},
+			}}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Delete("/achievements/:id/delete", HardDeleteAchievementService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/achievements/ref-1/delete", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Fatalf("expected attachment file removed, stat err: %v", err)
+	}
+}
+
+func TestAdminSoftDeleteAchievementService_NonAdmin(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Mahasiswa"}, nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		DeleteFn: func(ctx context.Context, refID string, adminID uuid.UUID) error {
+			t.Fatalf("Delete should not be called for non-admin")
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/admin-soft-delete", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-mhs")
+		c.Locals("user_id", uuid.New().String())
+		return AdminSoftDeleteAchievementService(c)
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/achievements/ref-1/admin-soft-delete", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusForbidden)
+	}
+}
+
+func TestAdminSoftDeleteAchievementService_AdminDeletesVerified(t *testing.T) {
+	adminID := uuid.New()
+	refID := uuid.New().String()
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	status := model.AchievementStatusVerified
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{Status: status}, nil
+		},
+		DeleteFn: func(ctx context.Context, id string, actor uuid.UUID) error {
+			if id != refID {
+				t.Fatalf("unexpected refID: %s", id)
+			}
+			if actor != adminID {
+				t.Fatalf("unexpected actor: %s", actor)
+			}
+			if status == model.AchievementStatusDeleted {
+				return errors.New("achievement tidak ditemukan atau sudah berstatus deleted")
+			}
+			status = model.AchievementStatusDeleted
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/admin-soft-delete", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		c.Locals("user_id", adminID.String())
+		return AdminSoftDeleteAchievementService(c)
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/achievements/"+refID+"/admin-soft-delete", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	if status != model.AchievementStatusDeleted {
+		t.Fatalf("expected status deleted, got %s", status)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/achievements/"+refID+"/admin-soft-delete", nil)
+	resp, err = app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("second delete status: got %d want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+type notifierCall struct {
+	to, title, status, note string
+}
+
+type mockNotifier struct {
+	calls chan notifierCall
+}
+
+func (m *mockNotifier) SendAchievementStatusChanged(to, title, status, note string) error {
+	m.calls <- notifierCall{to: to, title: title, status: status, note: note}
+	return nil
+}
+
+func TestReviewAchievementService_NotifiesStudent(t *testing.T) {
+	adminID := uuid.New()
+	studentID := uuid.New()
+	studentUserID := uuid.New()
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		ReviewFn: func(ctx context.Context, refID string, status string, adminID uuid.UUID, note *string) error {
+			return nil
+		},
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{StudentID: studentID, MongoAchievementID: "mongo-1"}, nil
+		},
+	}
+	achievementStudentRepo = &mockStudentRepo{
+		GetStudentByIDFn: func(id string) (*model.Student, error) {
+			return &model.Student{ID: studentID, UserID: studentUserID}, nil
+		},
+	}
+	achievementUserRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			if id != studentUserID.String() {
+				t.Errorf("unexpected user id: %s", id)
+			}
+			return &model.User{ID: id, Email: "mhs@example.com"}, nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			return []model.Achievement{{Title: "Juara ICPC"}}, nil
+		},
+	}
+	notifier := &mockNotifier{calls: make(chan notifierCall, 1)}
+	achievementNotifier = notifier
+	t.Cleanup(func() { achievementNotifier = utils.NoopNotifier{} })
+
+	app := fiber.New()
+	app.Put("/achievements/:id/review", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		c.Locals("user_id", adminID.String())
+		return ReviewAchievementService(c)
+	})
+
+	cases := []struct {
+		payload map[string]any
+		status  string
+		note    string
+	}{
+		{payload: map[string]any{"status": "verified"}, status: model.AchievementStatusVerified},
+		{payload: map[string]any{"status": "rejected", "rejection_note": "Bukti kurang"}, status: model.AchievementStatusRejected, note: "Bukti kurang"},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPut, "/achievements/ref-1/review", toJSONReaderAchievement(t, tc.payload))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+		}
+
+		select {
+		case call := <-notifier.calls:
+			if call.to != "mhs@example.com" || call.title != "Juara ICPC" || call.status != tc.status || call.note != tc.note {
+				t.Fatalf("unexpected notifier call: %+v", call)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("notifier was not called for status %s", tc.status)
+		}
+	}
+}
+
+func TestGetMyAchievementSummaryService_OnlyVerifiedPublicFields(t *testing.T) {
+	userID := uuid.New()
+	studentID := uuid.New()
+	verifiedOID, draftOID := bson.NewObjectID(), bson.NewObjectID()
+	verifiedAt := time.Now().Add(-time.Hour)
+	fixtures := []model.AchievementReference{
+		{ID: uuid.New(), StudentID: studentID, MongoAchievementID: verifiedOID.Hex(), Status: model.AchievementStatusVerified, VerifiedAt: &verifiedAt},
+		{ID: uuid.New(), StudentID: studentID, MongoAchievementID: draftOID.Hex(), Status: model.AchievementStatusDraft},
+	}
+	achievementStudentRepo = &mockStudentRepo{
+		GetStudentByUserIDFn: func(id string) (*model.Student, error) {
+			return &model.Student{ID: studentID, UserID: userID, StudentID: "2201001", ProgramStudy: "Informatika"}, nil
+		},
+	}
+	achievementUserRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			return &model.User{ID: id, FullName: "Budi"}, nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		ListByStatusesFn: func(ctx context.Context, statuses []string, sID *uuid.UUID, advisorID *uuid.UUID, page, limit int64, filter model.AchievementReferenceFilter) ([]model.AchievementReference, int64, error) {
+			if sID == nil || *sID != studentID {
+				t.Fatalf("expected student filter %s", studentID)
+			}
+			var out []model.AchievementReference
+			for _, r := range fixtures {
+				for _, st := range statuses {
+					if r.Status == st {
+						out = append(out, r)
+					}
+				}
+			}
+			return out, int64(len(out)), nil
+		},
+	}
+	verifiedPoints, draftPoints := 50.0, 30.0
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			return []model.Achievement{
+				{ID: verifiedOID, StudentID: studentID.String(), AchievementType: "competition", Title: "Juara ICPC", Points: &verifiedPoints},
+				{ID: draftOID, StudentID: studentID.String(), AchievementType: "academic", Title: "Draft", Points: &draftPoints},
+			}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/students/me/summary", func(c *fiber.Ctx) error {
+		c.Locals("user_id", userID.String())
+		return GetMyAchievementSummaryService(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/students/me/summary", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	raw, _ := io.ReadAll(resp.Body)
+	for _, internal := range []string{studentID.String(), userID.String(), verifiedOID.Hex(), fixtures[0].ID.String(), `"id"`, `"student_id"`, "mongo_achievement_id"} {
+		if strings.Contains(string(raw), internal) {
+			t.Fatalf("response leaks internal field %q: %s", internal, raw)
+		}
+	}
+
+	var body struct {
+		Data model.StudentAchievementSummary `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Data.TotalVerified != 1 || len(body.Data.Achievements) != 1 {
+		t.Fatalf("expected 1 verified achievement, got %+v", body.Data)
+	}
+	if body.Data.Achievements[0].Title != "Juara ICPC" {
+		t.Fatalf("unexpected achievement: %+v", body.Data.Achievements[0])
+	}
+	if body.Data.TotalPoints != verifiedPoints {
+		t.Fatalf("total points: got %v want %v", body.Data.TotalPoints, verifiedPoints)
+	}
+	if body.Data.FullName != "Budi" || body.Data.StudentNumber != "2201001" {
+		t.Fatalf("unexpected profile fields: %+v", body.Data)
+	}
+}
+
+func setupSubmitEvidenceTest(t *testing.T, achType string, attachments []model.Attachment) (*fiber.App, *bool) {
+	t.Helper()
+	studentID := uuid.New()
+	oid := bson.NewObjectID()
+	submitted := false
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{StudentID: studentID, MongoAchievementID: oid.Hex(), Status: model.AchievementStatusDraft}, nil
+		},
+		SubmitDraftFn: func(ctx context.Context, refID string, sID uuid.UUID) error {
+			submitted = true
+			return nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			return []model.Achievement{{ID: oid, AchievementType: achType, Attachments: attachments}}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/submit", func(c *fiber.Ctx) error {
+		c.Locals("student_uuid", studentID)
+		return SubmitAchievementService(c)
+	})
+	return app, &submitted
+}
+
+func TestSubmitAchievementService_CertificationWithoutEvidenceRejected(t *testing.T) {
+	app, submitted := setupSubmitEvidenceTest(t, "certification", nil)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPut, "/achievements/ref-1/submit", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	if *submitted {
+		t.Fatalf("SubmitDraft should not be called without evidence")
+	}
+}
+
+func TestSubmitAchievementService_CompetitionRequiresAttachment(t *testing.T) {
+	app, submitted := setupSubmitEvidenceTest(t, "competition", nil)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPut, "/achievements/ref-1/submit", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if msg, _ := body["message"].(string); !strings.HasPrefix(msg, "lampiran bukti wajib") {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+	if *submitted {
+		t.Fatalf("SubmitDraft should not be called without evidence")
+	}
+
+	app, submitted = setupSubmitEvidenceTest(t, "competition", []model.Attachment{{FileName: "sertifikat.pdf"}})
+	resp, err = app.Test(httptest.NewRequest(http.MethodPut, "/achievements/ref-1/submit", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("with attachment: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	if !*submitted {
+		t.Fatalf("SubmitDraft was not called")
+	}
+}
+
+func TestSubmitAchievementService_AcademicWithoutEvidenceAllowed(t *testing.T) {
+	app, submitted := setupSubmitEvidenceTest(t, "academic", nil)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPut, "/achievements/ref-1/submit", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	if !*submitted {
+		t.Fatalf("SubmitDraft was not called")
+	}
+}
+
+func TestSubmitAchievementService_EvidenceLookupErrorReturns500(t *testing.T) {
+	app, submitted := setupSubmitEvidenceTest(t, "competition", nil)
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			return nil, errors.New("mongo down")
+		},
+	}
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPut, "/achievements/ref-1/submit", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusInternalServerError)
+	}
+	if *submitted {
+		t.Fatalf("SubmitDraft should not be called when evidence lookup fails")
+	}
+}
+
+func TestReviewAchievementService_DispatchesSignedWebhook(t *testing.T) {
+	adminID := uuid.New()
+	studentID := uuid.New()
+	refID := uuid.New().String()
+	secret := "webhook-secret"
+
+	type delivery struct {
+		body      []byte
+		signature string
+	}
+	received := make(chan delivery, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- delivery{body: body, signature: r.Header.Get(utils.WebhookSignatureHeader)}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	achievementWebhook = &utils.Webhook{URL: srv.URL, Secret: secret, MaxAttempts: 3, Backoff: time.Millisecond, Client: srv.Client()}
+	t.Cleanup(func() { achievementWebhook = nil })
+
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		ReviewFn: func(ctx context.Context, id string, status string, adminID uuid.UUID, note *string) error {
+			return nil
+		},
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{StudentID: studentID, Status: model.AchievementStatusVerified}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/review", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		c.Locals("user_id", adminID.String())
+		return ReviewAchievementService(c)
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/achievements/"+refID+"/review", toJSONReaderAchievement(t, map[string]any{"status": "verified"}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	select {
+	case d := <-received:
+		if d.signature != utils.SignWebhookPayload(secret, d.body) {
+			t.Fatalf("invalid signature: %s", d.signature)
+		}
+		var payload model.AchievementWebhookPayload
+		if err := json.Unmarshal(d.body, &payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload.RefID != refID || payload.StudentID != studentID.String() || payload.Status != model.AchievementStatusVerified {
+			t.Fatalf("unexpected payload: %+v", payload)
+		}
+		if payload.Timestamp.IsZero() {
+			t.Fatalf("timestamp should be set")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("webhook was not delivered")
+	}
+}
+
+func TestGetReferenceStatusesService_MixedSetScopedToStudent(t *testing.T) {
+	studentID := uuid.New()
+	otherStudent := uuid.New()
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Mahasiswa"}, nil
+		},
+	}
+	fixtures := map[string]model.AchievementReference{
+		"m-own-verified": {StudentID: studentID, Status: model.AchievementStatusVerified},
+		"m-own-draft":    {StudentID: studentID, Status: model.AchievementStatusDraft},
+		"m-own-deleted":  {StudentID: studentID, Status: model.AchievementStatusDeleted},
+		"m-other":        {StudentID: otherStudent, Status: model.AchievementStatusVerified},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		StatusesByMongoIDsFn: func(ctx context.Context, mongoIDs []string, statuses []string, sID *uuid.UUID, advisorID *uuid.UUID) (map[string]string, error) {
+			if sID == nil || *sID != studentID {
+				t.Fatalf("expected student scope %s", studentID)
+			}
+			out := map[string]string{}
+			for _, id := range mongoIDs {
+				ref, ok := fixtures[id]
+				if !ok || ref.StudentID != *sID {
+					continue
+				}
+				for _, st := range statuses {
+					if st == ref.Status {
+						out[id] = ref.Status
+					}
+				}
+			}
+			return out, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/achievement-references/statuses", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-mhs")
+		c.Locals("student_uuid", studentID)
+		return GetReferenceStatusesService(c)
+	})
+
+	payload := map[string]any{"mongo_ids": []string{"m-own-verified", "m-own-draft", "m-own-deleted", "m-other", "m-unknown"}}
+	req := httptest.NewRequest(http.MethodPost, "/achievement-references/statuses", toJSONReaderAchievement(t, payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var body struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := map[string]string{
+		"m-own-verified": model.AchievementStatusVerified,
+		"m-own-draft":    model.AchievementStatusDraft,
+	}
+	if len(body.Data) != len(want) {
+		t.Fatalf("unexpected data: %v", body.Data)
+	}
+	for k, v := range want {
+		if body.Data[k] != v {
+			t.Fatalf("status %s: got %q want %q", k, body.Data[k], v)
+		}
+	}
+}
+
+func TestGetReferenceStatusesService_EmptyIDs(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{}
+
+	app := fiber.New()
+	app.Post("/achievement-references/statuses", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-admin")
+		return GetReferenceStatusesService(c)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/achievement-references/statuses", toJSONReaderAchievement(t, map[string]any{"mongo_ids": []string{}}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestCreateAchievementService_MultipartOversizedBody(t *testing.T) {
+	t.Setenv("UPLOAD_BODY_LIMIT_MB", "1")
+	t.Setenv("UPLOAD_DIR", t.TempDir())
+
+	studentID := uuid.New()
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		CreateFn: func(ctx context.Context, sID uuid.UUID, req model.CreateAchievementRequest) (string, error) {
+			t.Fatalf("Create should not be called for oversized body")
+			return "", nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{}
+
+	app := fiber.New(fiber.Config{BodyLimit: 4 * 1024 * 1024})
+	app.Post("/achievements", func(c *fiber.Ctx) error {
+		c.Locals("student_uuid", studentID)
+		return CreateAchievementService(c)
+	})
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	_ = w.WriteField("achievement_type", "academic")
+	_ = w.WriteField("title", "Hasil Turnitin")
+	_ = w.WriteField("description", "Cek turnitin")
+	_ = w.WriteField("details", `{"score":8}`)
+	fw, _ := w.CreateFormFile("attachments", "big.pdf")
+	fw.Write(bytes.Repeat([]byte("a"), 2*1024*1024))
+	w.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/achievements", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestNormalizeTags_LowercaseDedupeDropEmpty(t *testing.T) {
+	got, err := normalizeTags([]string{" AI ", "", "ai", "Robotik", "  ", "robotik", "lomba"})
+	if err != nil {
+		t.Fatalf("normalizeTags: %v", err)
+	}
+	want := []string{"ai", "robotik", "lomba"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("got %v want %v", got, want)
+	}
+}
+
+func TestNormalizeTags_LengthCap(t *testing.T) {
+	if _, err := normalizeTags([]string{strings.Repeat("a", maxTagLength)}); err != nil {
+		t.Fatalf("tag with exactly %d chars must be accepted: %v", maxTagLength, err)
+	}
+	if _, err := normalizeTags([]string{"ok", strings.Repeat("a", maxTagLength+1)}); err == nil {
+		t.Fatalf("expected error for tag longer than %d chars", maxTagLength)
+	}
+}
+
+func TestNormalizeTags_CountCap(t *testing.T) {
+	tags := []string{}
+	for i := 0; i < maxTagCount; i++ {
+		tags = append(tags, fmt.Sprintf("tag%d", i))
+	}
+	// duplikat tidak dihitung
+	if _, err := normalizeTags(append(tags, "TAG0")); err != nil {
+		t.Fatalf("%d unique tags must be accepted: %v", maxTagCount, err)
+	}
+	if _, err := normalizeTags(append(tags, "extra")); err == nil || err.Error() != "maksimal 10 tag" {
+		t.Fatalf("expected count cap error, got %v", err)
+	}
+}
+
+func TestCreateAchievementService_JSONTooManyTagsRejected(t *testing.T) {
+	studentID := uuid.New()
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		CreateFn: func(ctx context.Context, sID uuid.UUID, req model.CreateAchievementRequest) (string, error) {
+			t.Fatalf("Create should not be called when tags are invalid")
+			return "", nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{}
+
+	app := fiber.New()
+	app.Post("/achievements", func(c *fiber.Ctx) error {
+		c.Locals("student_uuid", studentID)
+		return CreateAchievementService(c)
+	})
+
+	tags := []string{}
+	for i := 0; i <= maxTagCount; i++ {
+		tags = append(tags, fmt.Sprintf("tag%d", i))
+	}
+	payload, _ := json.Marshal(map[string]interface{}{
+		"achievement_type": "academic",
+		"title":            "Juara",
+		"description":      "Lomba",
+		"details":          map[string]interface{}{"score": 8},
+		"tags":             tags,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/achievements", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestReopenAchievementService(t *testing.T) {
+	studentID := uuid.New()
+	cases := []struct {
+		name       string
+		ref        *model.AchievementReference
+		wantStatus int
+		wantRevert bool
+	}{
+		{"milik mahasiswa lain", &model.AchievementReference{StudentID: uuid.New(), Status: model.AchievementStatusRejected}, http.StatusNotFound, false},
+		{"tidak ada", nil, http.StatusNotFound, false},
+		{"status submitted", &model.AchievementReference{StudentID: studentID, Status: model.AchievementStatusSubmitted}, http.StatusConflict, false},
+		{"status draft", &model.AchievementReference{StudentID: studentID, Status: model.AchievementStatusDraft}, http.StatusConflict, false},
+		{"rejected milik sendiri", &model.AchievementReference{StudentID: studentID, Status: model.AchievementStatusRejected}, http.StatusOK, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reverted := false
+			achievementRefRepo = &mockAchievementRefRepo{
+				GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+					if tc.ref == nil {
+						return nil, errors.New("achievement reference tidak ditemukan")
+					}
+					return tc.ref, nil
+				},
+				RevertToDraftFn: func(ctx context.Context, refID string, sID uuid.UUID) error {
+					if refID != "ref-1" || sID != studentID {
+						t.Fatalf("unexpected args: %s %v", refID, sID)
+					}
+					reverted = true
+					return nil
+				},
+			}
+
+			app := fiber.New()
+			app.Put("/achievements/:id/reopen", func(c *fiber.Ctx) error {
+				c.Locals("student_uuid", studentID)
+				return ReopenAchievementService(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodPut, "/achievements/ref-1/reopen", nil), -1)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("status: got %d want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if reverted != tc.wantRevert {
+				t.Fatalf("RevertToDraft called=%v want %v", reverted, tc.wantRevert)
+			}
+		})
+	}
+}
+
+func TestVerifyAchievementPublicService(t *testing.T) {
+	studentID := uuid.New()
+	verifiedAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	refs := map[string]*model.AchievementReference{
+		"ref-verified": {ID: uuid.New(), StudentID: studentID, MongoAchievementID: "m1", Status: model.AchievementStatusVerified, VerifiedAt: &verifiedAt},
+		"ref-draft":    {ID: uuid.New(), StudentID: studentID, MongoAchievementID: "m2", Status: model.AchievementStatusDraft},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			if ref, ok := refs[id]; ok {
+				return ref, nil
+			}
+			return nil, errors.New("achievement reference tidak ditemukan")
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			return []model.Achievement{{StudentID: studentID.String(), AchievementType: "competition", Title: "Juara 1 Hackathon", Description: "rahasia"}}, nil
+		},
+	}
+	achievementStudentRepo = &mockStudentRepo{
+		GetStudentByIDFn: func(id string) (*model.Student, error) {
+			return &model.Student{ID: studentID, StudentID: "2201001", ProgramStudy: "Informatika"}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievements/:id/verify-public", VerifyAchievementPublicService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/ref-verified/verify-public", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("verified: got %d want 200", resp.StatusCode)
+	}
+	body := decodeMapAchievement(t, resp)
+	data, _ := body["data"].(map[string]any)
+	if data["title"] != "Juara 1 Hackathon" || data["program_study"] != "Informatika" || data["status"] != "verified" || data["verified_at"] == nil {
+		t.Fatalf("unexpected data: %#v", data)
+	}
+	for _, key := range []string{"student_id", "description", "details", "attachments"} {
+		if _, ok := data[key]; ok {
+			t.Fatalf("public payload must not expose %s: %#v", key, data)
+		}
+	}
+
+	for _, id := range []string{"ref-draft", "unknown"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/"+id+"/verify-public", nil), -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("%s: got %d want 404", id, resp.StatusCode)
+		}
+	}
+}
+
+func TestReviewAchievementService_DosenWaliNotAssignedForbidden(t *testing.T) {
+	lecturerID, otherLecturer := uuid.New(), uuid.New()
+	students := map[uuid.UUID]*model.Student{}
+	assigned := &model.Student{ID: uuid.New(), AdvisorID: &otherLecturer}
+	noAdvisor := &model.Student{ID: uuid.New(), AdvisorID: nil}
+	students[assigned.ID] = assigned
+	students[noAdvisor.ID] = noAdvisor
+	refs := map[string]uuid.UUID{"ref-other": assigned.ID, "ref-no-advisor": noAdvisor.ID}
+
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Dosen Wali"}, nil
+		},
+	}
+	achievementLecturerRepo = &mockLectRepo{
+		GetLecturerByUserIDFn: func(userID string) (*model.Lecturer, error) {
+			return &model.Lecturer{ID: lecturerID}, nil
+		},
+	}
+	// meniru students.advisor_id UNION student_advisors (tanpa co-advisor)
+	achievementAdvisorRepo = &mockStudentAdvisorRepo{
+		IsAdvisorFn: func(studentID, lectID string) (bool, error) {
+			st := students[uuid.MustParse(studentID)]
+			return st != nil && st.AdvisorID != nil && st.AdvisorID.String() == lectID, nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+			return &model.AchievementReference{StudentID: refs[id], Status: model.AchievementStatusSubmitted}, nil
+		},
+		ReviewByAdvisorFn: func(ctx context.Context, refID string, status string, reviewerID uuid.UUID, lectID uuid.UUID, note *string) error {
+			st := students[refs[refID]]
+			if st.AdvisorID == nil || *st.AdvisorID != lectID {
+				return errors.New("achievement sudah diproses atau tidak berhak")
+			}
+			t.Fatalf("review must not succeed for %s", refID)
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/achievements/:id/review", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-dosen")
+		c.Locals("user_id", uuid.NewString())
+		return ReviewAchievementService(c)
+	})
+
+	for _, refID := range []string{"ref-other", "ref-no-advisor"} {
+		req := httptest.NewRequest(http.MethodPut, "/achievements/"+refID+"/review", toJSONReaderAchievement(t, map[string]any{"status": "verified"}))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("%s: got %d want 403", refID, resp.StatusCode)
+		}
+		if body := decodeMapAchievement(t, resp); body["message"] != "Tidak berhak memproses mahasiswa ini" {
+			t.Fatalf("%s: unexpected message: %v", refID, body["message"])
+		}
+	}
+}
+
+func TestCreateAchievementService_DescriptionTooLong(t *testing.T) {
+	// dihitung per rune: 2000 karakter multi-byte (4000 byte) masih diterima
+	if descriptionTooLong(strings.Repeat("é", defaultMaxDescriptionLength)) {
+		t.Fatalf("description of exactly %d runes must be accepted", defaultMaxDescriptionLength)
+	}
+
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		CreateFn: func(ctx context.Context, sID uuid.UUID, req model.CreateAchievementRequest) (string, error) {
+			t.Fatalf("Create must not be called for an over-length description")
+			return "", nil
+		},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{}
+
+	app := fiber.New()
+	app.Post("/achievements", func(c *fiber.Ctx) error {
+		c.Locals("student_uuid", uuid.New())
+		return CreateAchievementService(c)
+	})
+
+	payload := map[string]any{
+		"achievement_type": "competition",
+		"title":            "Juara 1",
+		"description":      strings.Repeat("é", defaultMaxDescriptionLength+1),
+		"details":          map[string]any{"competitionName": "ICPC", "rank": 1},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/achievements", toJSONReaderAchievement(t, payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	if body := decodeMapAchievement(t, resp); body["message"] != "description terlalu panjang" {
+		t.Fatalf("unexpected message: %v", body["message"])
+	}
+}
+
+func TestCreateAchievementService_CompetitionLevel(t *testing.T) {
+	cases := []struct {
+		name       string
+		level      any
+		points     any
+		wantStatus int
+		wantPoints *float64
+	}{
+		{"valid level, points diisi", "Regional", 7.0, http.StatusCreated, ptrFloat(7)},
+		{"valid level, points otomatis", " National ", nil, http.StatusCreated, ptrFloat(50)},
+		{"level tidak dikenal", "galaksi", nil, http.StatusBadRequest, nil},
+		{"level bukan string", 3, nil, http.StatusBadRequest, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var saved *model.CreateAchievementRequest
+			achievementMongoRepo = &mockAchievementMongoRepo{
+				CreateFn: func(ctx context.Context, sID uuid.UUID, req model.CreateAchievementRequest) (string, error) {
+					saved = &req
+					return "mongo123", nil
+				},
+			}
+			achievementRefRepo = &mockAchievementRefRepo{
+				CreateDraftFn: func(ctx context.Context, sID uuid.UUID, mongoID string) (string, error) {
+					return "ref123", nil
+				},
+			}
+
+			app := fiber.New()
+			app.Post("/achievements", func(c *fiber.Ctx) error {
+				c.Locals("student_uuid", uuid.New())
+				return CreateAchievementService(c)
+			})
+
+			payload := map[string]any{
+				"achievement_type": "competition",
+				"title":            "Juara 1",
+				"description":      "Menang lomba",
+				"details":          map[string]any{"competitionName": "ICPC", "rank": 1, "competitionLevel": tc.level},
+			}
+			if tc.points != nil {
+				payload["points"] = tc.points
+			}
+			req := httptest.NewRequest(http.MethodPost, "/achievements", toJSONReaderAchievement(t, payload))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("status: got %d want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if tc.wantStatus != http.StatusCreated {
+				if body := decodeMapAchievement(t, resp); body["message"] != "competitionLevel tidak valid" {
+					t.Fatalf("unexpected message: %v", body["message"])
+				}
+				if saved != nil {
+					t.Fatal("Create must not be called for an invalid level")
+				}
+				return
+			}
+			if saved.Points == nil || *saved.Points != *tc.wantPoints {
+				t.Fatalf("points: got %v want %v", saved.Points, *tc.wantPoints)
+			}
+		})
+	}
+}
+
+func TestCompetitionLevelPoints_FromEnv(t *testing.T) {
+	t.Setenv("ACHIEVEMENT_COMPETITION_LEVELS", "kampus=5, Nasional=40, internasional")
+	levels := competitionLevelPoints()
+	if len(levels) != 3 || *levels["kampus"] != 5 || *levels["nasional"] != 40 || levels["internasional"] != nil {
+		t.Fatalf("unexpected levels: %v", levels)
+	}
+	if _, ok := levels["national"]; ok {
+		t.Fatal("default levels must be replaced by the configured set")
+	}
+}
+
+func ptrFloat(v float64) *float64 { return &v }
+
+func TestGetAchievementTagsService_ScopedToCallerAchievements(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Mahasiswa"}, nil
+		},
+	}
+	studentID := uuid.New()
+	docs := map[string][]string{
+		"own-1":   {"robotik", "nasional"},
+		"own-2":   {"robotik"},
+		"other-1": {"debat", "robotik"},
+	}
+	achievementRefRepo = &mockAchievementRefRepo{
+		MongoIDsByStatusesFn: func(ctx context.Context, statuses []string, sid *uuid.UUID, advisorID *uuid.UUID) ([]string, error) {
+			if sid == nil || *sid != studentID {
+				t.Fatalf("ids must be scoped to the caller's student, got %v", sid)
+			}
+			return []string{"own-1", "own-2"}, nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		TagCountsFn: func(ctx context.Context, ids []string) ([]model.TagCount, error) {
+			counts := map[string]int64{}
+			for _, id := range ids {
+				for _, tag := range docs[id] {
+					counts[tag]++
+				}
+			}
+			var out []model.TagCount
+			for _, tag := range []string{"robotik", "nasional", "debat"} {
+				if counts[tag] > 0 {
+					out = append(out, model.TagCount{Tag: tag, Count: counts[tag]})
+				}
+			}
+			return out, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/achievements/tags", func(c *fiber.Ctx) error {
+		c.Locals("role_id", "role-mhs")
+		c.Locals("student_uuid", studentID)
+		return GetAchievementTagsService(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/tags", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	var body struct {
+		Data []model.TagCount `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Data) != 2 || body.Data[0].Tag != "robotik" || body.Data[0].Count != 2 || body.Data[1].Tag != "nasional" {
+		t.Fatalf("unexpected tags: %+v", body.Data)
+	}
+	for _, tc := range body.Data {
+		if tc.Tag == "debat" {
+			t.Fatalf("out-of-scope tag leaked: %+v", body.Data)
+		}
+	}
+}
+
+func TestCombineByReferenceOrder_FlagsMalformedMongoID(t *testing.T) {
+	valid := bson.NewObjectID()
+	refs := []model.AchievementReference{
+		{ID: uuid.New(), MongoAchievementID: valid.Hex()},
+		{ID: uuid.New(), MongoAchievementID: "bukan-object-id"},
+	}
+	combined := combineByReferenceOrder(refs, []model.Achievement{{ID: valid, Title: "Juara 1"}})
+
+	if len(combined) != 2 {
+		t.Fatalf("every reference must be kept, got %d", len(combined))
+	}
+	if combined[0].MongoMissing || combined[0].Achievement.Title != "Juara 1" {
+		t.Fatalf("valid reference must carry its document: %+v", combined[0])
+	}
+	if !combined[1].MongoMissing {
+		t.Fatalf("malformed mongo id must be flagged: %+v", combined[1])
+	}
+}
+
+func TestGetAchievementPDFService(t *testing.T) {
+	studentID := uuid.New()
+	reviewerID := uuid.New()
+	mongoID := bson.NewObjectID()
+	verifiedAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Mahasiswa"}, nil
+		},
+	}
+	achievementUserRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			return &model.User{ID: id, FullName: "Dr. Siti"}, nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			return []model.Achievement{{
+				ID:              mongoID,
+				AchievementType: "competition",
+				Title:           "Juara 1 Lomba Robotik",
+				Details:         map[string]interface{}{"competitionLevel": "national"},
+				Attachments:     []model.Attachment{{FileName: "sertifikat.pdf"}},
+			}}, nil
+		},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		status string
+		want   int
+	}{
+		{"submitted ditolak", model.AchievementStatusSubmitted, http.StatusBadRequest},
+		{"verified", model.AchievementStatusVerified, http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			achievementRefRepo = &mockAchievementRefRepo{
+				GetByIDFn: func(ctx context.Context, id string) (*model.AchievementReference, error) {
+					ref := &model.AchievementReference{ID: uuid.New(), StudentID: studentID, MongoAchievementID: mongoID.Hex(), Status: tc.status}
+					if tc.status == model.AchievementStatusVerified {
+						ref.VerifiedAt = &verifiedAt
+						ref.VerifiedBy = &reviewerID
+					}
+					return ref, nil
+				},
+			}
+
+			app := fiber.New()
+			app.Get("/achievements/:id/pdf", func(c *fiber.Ctx) error {
+				c.Locals("role_id", "role-mhs")
+				c.Locals("student_uuid", studentID)
+				return GetAchievementPDFService(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/ref-1/pdf", nil), -1)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != tc.want {
+				t.Fatalf("status: got %d want %d", resp.StatusCode, tc.want)
+			}
+			if tc.want != http.StatusOK {
+				return
+			}
+			if ct := resp.Header.Get("Content-Type"); ct != "application/pdf" {
+				t.Fatalf("content type: %q", ct)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if len(body) == 0 || !bytes.HasPrefix(body, []byte("%PDF-")) {
+				t.Fatalf("expected a PDF body, got %d bytes", len(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+
+	"hello-fiber/app/model"
+	"hello-fiber/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var auditRepo repository.AuditLogRepository
+
+func InitAuditService(db *sql.DB) {
+	auditRepo = repository.NewAuditLogRepositoryPostgres(db)
+}
+
+// auditEntities entity yang boleh dipakai sebagai filter GET /v1/audit-logs.
+var auditEntities = map[string]bool{
+	model.AuditEntityUser:           true,
+	model.AuditEntityRole:           true,
+	model.AuditEntityPermission:     true,
+	model.AuditEntityRolePermission: true,
+}
+
+// recordAudit mencatat aksi admin yang sudah berhasil. Mutasi sudah tersimpan,
+// jadi kegagalan pencatatan hanya di-log agar response tidak ikut gagal.
+func recordAudit(c *fiber.Ctx, action, entity, entityID string) {
+	if auditRepo == nil {
+		return
+	}
+	entry := model.AuditLog{
+		Action:   action,
+		Entity:   entity,
+		EntityID: strings.Clone(entityID),
+		IP:       c.IP(),
+	}
+	if actorID, ok := c.Locals("user_id").(string); ok && actorID != "" {
+		entry.ActorID = &actorID
+	}
+	if err := auditRepo.Record(entry); err != nil {
+		log.Printf("[WARNING] gagal mencatat audit %s %s %s: %v", action, entity, entityID, err)
+	}
+}
+
+// GetAuditLogsService godoc
+// @Summary Daftar audit log aksi admin (Admin)
+// @Description Mengambil jejak create/update/delete user, role, permission, dan role_permission, terbaru lebih dulu
+// @Tags AuditLogs
+// @Accept json
+// @Produce json
+// @Param page query int false "Halaman (default: 1)"
+// @Param limit query int false "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)"
+// @Param entity query string false "Filter entity: user, role, permission, role_permission"
+// @Success 200 {object} map[string]interface{} "Audit log berhasil diambil"
+// @Failure 400 {object} model.ErrorResponse "Entity tidak valid"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 403 {object} model.ErrorResponse "Bukan admin"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/audit-logs [get]
+// @Security BearerAuth
+func GetAuditLogsService(c *fiber.Ctx) error {
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
+	}
+
+	entity := strings.ToLower(strings.TrimSpace(c.Query("entity")))
+	if entity != "" && !auditEntities[entity] {
+		return errorJSON(c, 400, "Entity harus salah satu dari: user, role, permission, role_permission")
+	}
+
+	logs, total, err := auditRepo.List(page, limit, entity)
+	if err != nil {
+		return errorWithDetail(c, 500, "Gagal mengambil audit log", err)
+	}
+	if logs == nil {
+		logs = []model.AuditLog{}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Audit log berhasil diambil",
+		"data":    logs,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hello-fiber/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type mockAuditRepo struct {
+	records []model.AuditLog
+	ListFn  func(page, limit int64, entity string) ([]model.AuditLog, int64, error)
+}
+
+func (m *mockAuditRepo) Record(entry model.AuditLog) error {
+	m.records = append(m.records, entry)
+	return nil
+}
+
+func (m *mockAuditRepo) List(page, limit int64, entity string) ([]model.AuditLog, int64, error) {
+	if m.ListFn != nil {
+		return m.ListFn(page, limit, entity)
+	}
+	return nil, 0, nil
+}
+
+func useMockAuditRepo(t *testing.T) *mockAuditRepo {
+	t.Helper()
+	mock := &mockAuditRepo{}
+	auditRepo = mock
+	t.Cleanup(func() { auditRepo = nil })
+	return mock
+}
+
+func TestDeleteUserService_RecordsAudit(t *testing.T) {
+	audit := useMockAuditRepo(t)
+	userRepo = &mockUserRepo{
+		DeleteUserFn: func(id string) error { return nil },
+	}
+
+	app := fiber.New()
+	app.Delete("/users/:id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "admin-1")
+		return DeleteUserService(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/users/u1", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	if len(audit.records) != 1 {
+		t.Fatalf("expected 1 audit row, got %d", len(audit.records))
+	}
+	got := audit.records[0]
+	if got.Action != model.AuditActionDelete || got.Entity != model.AuditEntityUser || got.EntityID != "u1" {
+		t.Fatalf("unexpected audit row: %+v", got)
+	}
+	if got.ActorID == nil || *got.ActorID != "admin-1" {
+		t.Fatalf("unexpected actor: %v", got.ActorID)
+	}
+}
+
+func TestDeleteUserService_FailedDeleteNotAudited(t *testing.T) {
+	audit := useMockAuditRepo(t)
+	userRepo = &mockUserRepo{
+		DeleteUserFn: func(id string) error { return errors.New("db error") },
+	}
+
+	app := fiber.New()
+	app.Delete("/users/:id", DeleteUserService)
+
+	if _, err := app.Test(httptest.NewRequest(http.MethodDelete, "/users/u1", nil)); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if len(audit.records) != 0 {
+		t.Fatalf("failed mutation must not be audited: %+v", audit.records)
+	}
+}
+
+func TestGetAuditLogsService_EntityFilter(t *testing.T) {
+	audit := useMockAuditRepo(t)
+	var gotEntity string
+	audit.ListFn = func(page, limit int64, entity string) ([]model.AuditLog, int64, error) {
+		gotEntity = entity
+		return []model.AuditLog{{ID: "a1", Action: model.AuditActionCreate, Entity: entity, EntityID: "r1"}}, 1, nil
+	}
+
+	app := fiber.New()
+	app.Get("/audit-logs", GetAuditLogsService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/audit-logs?entity=Role", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if gotEntity != model.AuditEntityRole {
+		t.Fatalf("entity filter: got %q", gotEntity)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/audit-logs?entity=achievement", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown entity, got %d", resp.StatusCode)
+	}
+}
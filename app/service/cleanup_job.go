@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"hello-fiber/utils"
+)
+
+const (
+	defaultCleanupInterval      = 24 * time.Hour
+	defaultCleanupRetentionDays = 30
+	cleanupRunTimeout           = 5 * time.Minute
+)
+
+// CleanupConfig pengaturan job pembersih achievement berstatus deleted.
+type CleanupConfig struct {
+	Interval  time.Duration
+	Retention time.Duration
+}
+
+// CleanupConfigFromEnv membaca ENABLE_CLEANUP (harus "true" agar job jalan), CLEANUP_INTERVAL
+// (durasi Go, default 24h) dan CLEANUP_RETENTION_DAYS (default 30). ok=false jika job dimatikan.
+func CleanupConfigFromEnv() (cfg CleanupConfig, ok bool) {
+	if !strings.EqualFold(strings.TrimSpace(utils.GetEnv("ENABLE_CLEANUP", "false")), "true") {
+		return CleanupConfig{}, false
+	}
+	cfg = CleanupConfig{
+		Interval:  defaultCleanupInterval,
+		Retention: defaultCleanupRetentionDays * 24 * time.Hour,
+	}
+	if d, err := time.ParseDuration(strings.TrimSpace(utils.GetEnv("CLEANUP_INTERVAL", ""))); err == nil && d > 0 {
+		cfg.Interval = d
+	}
+	if days, err := strconv.Atoi(strings.TrimSpace(utils.GetEnv("CLEANUP_RETENTION_DAYS", ""))); err == nil && days >= 0 {
+		cfg.Retention = time.Duration(days) * 24 * time.Hour
+	}
+	return cfg, true
+}
+
+// PurgeDeletedAchievements menghapus permanen semua reference deleted yang lebih tua dari retention
+// (Mongo, reference, dan file attachment). Kegagalan per item hanya di-log; mengembalikan jumlah yang terhapus.
+func PurgeDeletedAchievements(ctx context.Context, retention time.Duration) (int, error) {
+	refs, err := achievementRefRepo.ListDeletedOlderThan(ctx, retention)
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	for i := range refs {
+		if err := purgeAchievement(ctx, &refs[i]); err != nil {
+			log.Printf("[WARNING] Cleanup gagal hapus achievement %s: %v", refs[i].ID, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// StartAchievementCleanup menjalankan PurgeDeletedAchievements setiap cfg.Interval di goroutine terpisah.
+// Fungsi yang dikembalikan menghentikan job.
+func StartAchievementCleanup(cfg CleanupConfig) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(cfg.Interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), cleanupRunTimeout)
+				n, err := PurgeDeletedAchievements(ctx, cfg.Retention)
+				cancel()
+				if err != nil {
+					log.Printf("[WARNING] Cleanup achievement deleted gagal: %v", err)
+					continue
+				}
+				if n > 0 {
+					log.Printf("Cleanup: %d achievement deleted dihapus permanen", n)
+				}
+			}
+		}
+	}()
+	log.Printf("Cleanup achievement deleted aktif (interval %s, retensi %s)", cfg.Interval, cfg.Retention)
+	return func() { close(done) }
+}
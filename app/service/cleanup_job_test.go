@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hello-fiber/app/model"
+
+	"github.com/google/uuid"
+)
+
+func TestPurgeDeletedAchievements_RemovesEachAndSkipsFailures(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("UPLOAD_DIR", dir)
+	if err := os.WriteFile(filepath.Join(dir, "1-bukti.pdf"), []byte("x"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	okRef := model.AchievementReference{ID: uuid.New(), MongoAchievementID: "m-ok", Status: model.AchievementStatusDeleted}
+	badRef := model.AchievementReference{ID: uuid.New(), MongoAchievementID: "m-bad", Status: model.AchievementStatusDeleted}
+	goneRef := model.AchievementReference{ID: uuid.New(), MongoAchievementID: "m-gone", Status: model.AchievementStatusDeleted}
+	corruptRef := model.AchievementReference{ID: uuid.New(), MongoAchievementID: "m-corrupt", Status: model.AchievementStatusDeleted}
+
+	var gotRetention time.Duration
+	var hardDeleted []string
+	achievementRefRepo = &mockAchievementRefRepo{
+		ListDeletedOlderThanFn: func(ctx context.Context, olderThan time.Duration) ([]model.AchievementReference, error) {
+			gotRetention = olderThan
+			return []model.AchievementReference{badRef, okRef, goneRef, corruptRef}, nil
+		},
+		HardDeleteFn: func(ctx context.Context, refID string) error {
+			hardDeleted = append(hardDeleted, refID)
+			return nil
+		},
+	}
+	achievementMongoRepo = &mockAchievementMongoRepo{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]model.Achievement, error) {
+			return []model.Achievement{{Attachments: []model.Attachment{{FileURL: "/uploads/1-bukti.pdf"}}}}, nil
+		},
+		DeleteFn: func(ctx context.Context, id string) error {
+			switch id {
+			case "m-bad":
+				return errors.New("gagal menghapus achievement mongo: connection reset")
+			case "m-gone":
+				return errors.New("achievement mongo tidak ditemukan")
+			case "m-corrupt":
+				return errors.New("invalid mongo achievement id: the provided hex string is not a valid ObjectID")
+			}
+			return nil
+		},
+	}
+
+	n, err := PurgeDeletedAchievements(context.Background(), 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("PurgeDeletedAchievements: %v", err)
+	}
+	if n != 3 || gotRetention != 30*24*time.Hour {
+		t.Fatalf("unexpected result: purged=%d retention=%s", n, gotRetention)
+	}
+	// dokumen Mongo yang sudah hilang / id rusak tetap di-hard delete; hanya error sungguhan yang dilewati
+	if len(hardDeleted) != 3 || hardDeleted[0] != okRef.ID.String() || hardDeleted[1] != goneRef.ID.String() || hardDeleted[2] != corruptRef.ID.String() {
+		t.Fatalf("unexpected hard deletes: %v", hardDeleted)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "1-bukti.pdf")); !os.IsNotExist(err) {
+		t.Fatalf("attachment file should be removed, stat err=%v", err)
+	}
+}
+
+func TestCleanupConfigFromEnv(t *testing.T) {
+	t.Setenv("ENABLE_CLEANUP", "")
+	if _, ok := CleanupConfigFromEnv(); ok {
+		t.Fatalf("cleanup must be disabled by default")
+	}
+
+	t.Setenv("ENABLE_CLEANUP", "true")
+	t.Setenv("CLEANUP_INTERVAL", "")
+	t.Setenv("CLEANUP_RETENTION_DAYS", "")
+	cfg, ok := CleanupConfigFromEnv()
+	if !ok || cfg.Interval != defaultCleanupInterval || cfg.Retention != 30*24*time.Hour {
+		t.Fatalf("unexpected defaults: ok=%v cfg=%+v", ok, cfg)
+	}
+
+	t.Setenv("CLEANUP_INTERVAL", "1h")
+	t.Setenv("CLEANUP_RETENTION_DAYS", "7")
+	if cfg, _ := CleanupConfigFromEnv(); cfg.Interval != time.Hour || cfg.Retention != 7*24*time.Hour {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+}
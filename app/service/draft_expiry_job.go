@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"hello-fiber/app/model"
+	"hello-fiber/utils"
+)
+
+const (
+	defaultDraftExpiry         = 90 * 24 * time.Hour
+	defaultDraftExpiryInterval = 24 * time.Hour
+)
+
+// DraftExpiryConfig pengaturan job soft delete draft achievement yang tidak pernah disubmit.
+type DraftExpiryConfig struct {
+	Interval time.Duration
+	Expiry   time.Duration
+}
+
+// DraftExpiryConfigFromEnv membaca ENABLE_DRAFT_EXPIRY (harus "true" agar job jalan), DRAFT_EXPIRY
+// (durasi Go, default 2160h = 90 hari) dan DRAFT_EXPIRY_INTERVAL (default 24h). ok=false jika job dimatikan.
+func DraftExpiryConfigFromEnv() (cfg DraftExpiryConfig, ok bool) {
+	if !strings.EqualFold(strings.TrimSpace(utils.GetEnv("ENABLE_DRAFT_EXPIRY", "false")), "true") {
+		return DraftExpiryConfig{}, false
+	}
+	cfg = DraftExpiryConfig{
+		Interval: defaultDraftExpiryInterval,
+		Expiry:   defaultDraftExpiry,
+	}
+	if d, err := time.ParseDuration(strings.TrimSpace(utils.GetEnv("DRAFT_EXPIRY", ""))); err == nil && d > 0 {
+		cfg.Expiry = d
+	}
+	if d, err := time.ParseDuration(strings.TrimSpace(utils.GetEnv("DRAFT_EXPIRY_INTERVAL", ""))); err == nil && d > 0 {
+		cfg.Interval = d
+	}
+	return cfg, true
+}
+
+// ExpireOldDrafts soft delete (draft -> deleted) semua draft yang tidak diubah lebih lama dari expiry lewat
+// jalur DeleteByStudent, lalu memberi tahu mahasiswa pemiliknya. Kegagalan per item hanya di-log;
+// mengembalikan jumlah draft yang dihapus.
+func ExpireOldDrafts(ctx context.Context, expiry time.Duration) (int, error) {
+	refs, err := achievementRefRepo.ListDraftsOlderThan(ctx, expiry)
+	if err != nil {
+		return 0, err
+	}
+	note := fmt.Sprintf("Draft dihapus otomatis karena tidak disubmit selama %s", expiry)
+	expired := 0
+	for i := range refs {
+		refID := refs[i].ID.String()
+		if err := achievementRefRepo.DeleteByStudent(ctx, refID, refs[i].StudentID); err != nil {
+			log.Printf("[WARNING] Expiry draft gagal hapus achievement %s: %v", refID, err)
+			continue
+		}
+		expired++
+		notifyAchievementStatusChanged(refID, model.AchievementStatusDeleted, note)
+	}
+	return expired, nil
+}
+
+// StartDraftExpiry menjalankan ExpireOldDrafts setiap cfg.Interval di goroutine terpisah.
+// Fungsi yang dikembalikan menghentikan job.
+func StartDraftExpiry(cfg DraftExpiryConfig) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(cfg.Interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), cleanupRunTimeout)
+				n, err := ExpireOldDrafts(ctx, cfg.Expiry)
+				cancel()
+				if err != nil {
+					log.Printf("[WARNING] Expiry draft achievement gagal: %v", err)
+					continue
+				}
+				if n > 0 {
+					log.Printf("Expiry draft: %d draft achievement dihapus (soft delete)", n)
+				}
+			}
+		}
+	}()
+	log.Printf("Expiry draft achievement aktif (interval %s, expiry %s)", cfg.Interval, cfg.Expiry)
+	return func() { close(done) }
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseFieldsQuery membaca ?fields=a,b,c menjadi set nama field; nil berarti tanpa proyeksi.
+func parseFieldsQuery(c *fiber.Ctx) map[string]bool {
+	raw := strings.TrimSpace(c.Query("fields"))
+	if raw == "" {
+		return nil
+	}
+	fields := make(map[string]bool)
+	for _, f := range strings.Split(raw, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields[f] = true
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// projectFields mengembalikan hanya key JSON v yang ada di fields; nama yang tidak dikenal diabaikan.
+// Proyeksi dilakukan dari bentuk JSON response (mis. model.UserResponse), jadi field yang tidak pernah
+// diserialisasi seperti password_hash tidak bisa dipilih.
+func projectFields(v interface{}, fields map[string]bool) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var full map[string]interface{}
+	if err := json.Unmarshal(b, &full); err != nil {
+		return nil, err
+	}
+	out := make(map[string]interface{}, len(fields))
+	for k, val := range full {
+		if fields[k] {
+			out[k] = val
+		}
+	}
+	return out, nil
+}
@@ -37,17 +37,20 @@ func toLecturerResponse(l *model.Lecturer) *model.LecturerResponse {
 // @Accept json
 // @Produce json
 // @Param page query int false "Halaman (default: 1)"
-// @Param limit query int false "Jumlah data per halaman (default: 10)"
+// @Param limit query int false "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)"
 // @Success 200 {object} map[string]interface{} "Data lecturer berhasil diambil"
+// @Failure 400 {object} model.ErrorResponse "Parameter page/limit tidak valid"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/lecturers [get]
 // @Security BearerAuth
 func GetAllLecturersService(c *fiber.Ctx) error {
-	page := int64(c.QueryInt("page", 1))
-	limit := int64(c.QueryInt("limit", 10))
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
+	}
 
-	data, total, err := lecturerRepo.GetAllLecturers(page, limit)
+	data, total, err := lecturerRepo.GetAllLecturers(c.UserContext(), page, limit)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"success": false,
@@ -100,7 +103,7 @@ func GetLecturerByIDService(c *fiber.Ctx) error {
 		})
 	}
 
-	lec, err := lecturerRepo.GetLecturerByID(id)
+	lec, err := lecturerRepo.GetLecturerByID(c.UserContext(), id)
 	if err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
 			return c.Status(404).JSON(fiber.Map{
@@ -122,6 +125,89 @@ func GetLecturerByIDService(c *fiber.Ctx) error {
 	})
 }
 
+// GetMyLecturerService godoc
+// @Summary Dapatkan data lecturer milik user yang login (dosen wali)
+// @Description Resolve record lecturers dari user_id di token, tanpa perlu tahu UUID internal
+// @Tags Lecturers
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Data lecturer berhasil diambil"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 403 {object} model.ErrorResponse "Bukan dosen wali"
+// @Failure 404 {object} model.ErrorResponse "Lecturer tidak ditemukan"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/lecturers/me [get]
+// @Security BearerAuth
+func GetMyLecturerService(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || strings.TrimSpace(userID) == "" {
+		return errorJSON(c, fiber.StatusUnauthorized, "Unauthorized")
+	}
+	roleName, err := resolveRoleName(c)
+	if err != nil || roleName != "dosen wali" {
+		return errorJSON(c, fiber.StatusForbidden, "Hanya dosen wali yang dapat mengakses")
+	}
+
+	lec, err := lecturerRepo.GetLecturerByUserID(c.UserContext(), userID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return errorJSON(c, fiber.StatusNotFound, "Lecturer tidak ditemukan")
+		}
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data lecturer", err)
+	}
+	if lec == nil {
+		return errorJSON(c, fiber.StatusNotFound, "Lecturer tidak ditemukan")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Data lecturer berhasil diambil",
+		"data":    toLecturerResponse(lec),
+	})
+}
+
+// GetMyAdviseesSummaryService godoc
+// @Summary Ringkasan mahasiswa bimbingan dosen wali yang login
+// @Description Setiap mahasiswa bimbingan (advisor utama maupun co-advisor) beserta jumlah achievement submitted, verified, dan rejected
+// @Tags Lecturers
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Ringkasan mahasiswa bimbingan berhasil diambil"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 403 {object} model.ErrorResponse "Bukan dosen wali"
+// @Failure 404 {object} model.ErrorResponse "Lecturer tidak ditemukan"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/lecturers/me/advisees-summary [get]
+// @Security BearerAuth
+func GetMyAdviseesSummaryService(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || strings.TrimSpace(userID) == "" {
+		return errorJSON(c, fiber.StatusUnauthorized, "Unauthorized")
+	}
+	roleName, err := resolveRoleName(c)
+	if err != nil || roleName != "dosen wali" {
+		return errorJSON(c, fiber.StatusForbidden, "Hanya dosen wali yang dapat mengakses")
+	}
+
+	lec, err := lecturerRepo.GetLecturerByUserID(c.UserContext(), userID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return errorJSON(c, fiber.StatusNotFound, "Lecturer tidak ditemukan")
+		}
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data lecturer", err)
+	}
+	if lec == nil {
+		return errorJSON(c, fiber.StatusNotFound, "Lecturer tidak ditemukan")
+	}
+
+	advisees, err := lecturerRepo.GetAdviseesSummary(c.UserContext(), lec.ID.String())
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil ringkasan mahasiswa bimbingan", err)
+	}
+
+	return successJSON(c, fiber.StatusOK, "Ringkasan mahasiswa bimbingan berhasil diambil", advisees)
+}
+
 // CreateLecturerService godoc
 // @Summary Buat lecturer (Permission: user:manage)
 // @Description Membuat data lecturer baru
@@ -155,7 +241,7 @@ func CreateLecturerService(c *fiber.Ctx) error {
 		})
 	}
 
-	id, err := lecturerRepo.CreateLecturer(req)
+	id, err := lecturerRepo.CreateLecturer(c.UserContext(), req)
 	if err != nil {
 		l := strings.ToLower(err.Error())
 		if strings.Contains(l, "sudah digunakan") || strings.Contains(l, "tidak valid") {
@@ -224,7 +310,7 @@ func UpdateLecturerService(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := lecturerRepo.UpdateLecturer(id, req); err != nil {
+	if err := lecturerRepo.UpdateLecturer(c.UserContext(), id, req); err != nil {
 		l := strings.ToLower(err.Error())
 		if strings.Contains(l, "tidak ditemukan") {
 			return c.Status(404).JSON(fiber.Map{
@@ -282,7 +368,7 @@ func DeleteLecturerService(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := lecturerRepo.DeleteLecturer(id); err != nil {
+	if err := lecturerRepo.DeleteLecturer(c.UserContext(), id); err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
 			return c.Status(404).JSON(fiber.Map{
 				"success": false,
@@ -2,6 +2,7 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -19,47 +20,55 @@ type mockLecturerRepo struct {
 	GetAllLecturersFn     func(page, limit int64) ([]model.Lecturer, int64, error)
 	GetLecturerByIDFn     func(id string) (*model.Lecturer, error)
 	GetLecturerByUserIDFn func(userID string) (*model.Lecturer, error)
+	GetAdviseesSummaryFn  func(lecturerID string) ([]model.AdviseeSummary, error)
 	CreateLecturerFn      func(req model.CreateLecturerRequest) (string, error)
 	UpdateLecturerFn      func(id string, req model.UpdateLecturerRequest) error
 	DeleteLecturerFn      func(id string) error
 }
 
-func (m *mockLecturerRepo) GetAllLecturers(page, limit int64) ([]model.Lecturer, int64, error) {
+func (m *mockLecturerRepo) GetAllLecturers(ctx context.Context, page, limit int64) ([]model.Lecturer, int64, error) {
 	if m.GetAllLecturersFn != nil {
 		return m.GetAllLecturersFn(page, limit)
 	}
 	return nil, 0, nil
 }
 
-func (m *mockLecturerRepo) GetLecturerByID(id string) (*model.Lecturer, error) {
+func (m *mockLecturerRepo) GetLecturerByID(ctx context.Context, id string) (*model.Lecturer, error) {
 	if m.GetLecturerByIDFn != nil {
 		return m.GetLecturerByIDFn(id)
 	}
 	return nil, nil
 }
 
-func (m *mockLecturerRepo) GetLecturerByUserID(userID string) (*model.Lecturer, error) {
+func (m *mockLecturerRepo) GetLecturerByUserID(ctx context.Context, userID string) (*model.Lecturer, error) {
 	if m.GetLecturerByUserIDFn != nil {
 		return m.GetLecturerByUserIDFn(userID)
 	}
 	return nil, nil
 }
 
-func (m *mockLecturerRepo) CreateLecturer(req model.CreateLecturerRequest) (string, error) {
+func (m *mockLecturerRepo) GetAdviseesSummary(ctx context.Context, lecturerID string) ([]model.AdviseeSummary, error) {
+	if m.GetAdviseesSummaryFn != nil {
+		return m.GetAdviseesSummaryFn(lecturerID)
+	}
+	return []model.AdviseeSummary{}, nil
+}
+
+func (m *mockLecturerRepo) CreateLecturer(ctx context.Context, req model.CreateLecturerRequest) (string, error) {
 	if m.CreateLecturerFn != nil {
 		return m.CreateLecturerFn(req)
 	}
 	return "", nil
 }
 
-func (m *mockLecturerRepo) UpdateLecturer(id string, req model.UpdateLecturerRequest) error {
+func (m *mockLecturerRepo) UpdateLecturer(ctx context.Context, id string, req model.UpdateLecturerRequest) error {
 	if m.UpdateLecturerFn != nil {
 		return m.UpdateLecturerFn(id, req)
 	}
 	return nil
 }
 
-func (m *mockLecturerRepo) DeleteLecturer(id string) error {
+func (m *mockLecturerRepo) DeleteLecturer(ctx context.Context, id string) error {
 	if m.DeleteLecturerFn != nil {
 		return m.DeleteLecturerFn(id)
 	}
@@ -234,3 +243,118 @@ func TestDeleteLecturerService_NotFound(t *testing.T) {
 		t.Fatalf("unexpected message: %v", body["message"])
 	}
 }
+
+func TestGetMyLecturerService_DosenWali(t *testing.T) {
+	userID := uuid.New()
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Dosen Wali"}, nil
+		},
+	}
+	lecturerRepo = &mockLecturerRepo{
+		GetLecturerByUserIDFn: func(uid string) (*model.Lecturer, error) {
+			return &model.Lecturer{ID: uuid.New(), UserID: userID, LecturerID: "L-01"}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/lecturers/me", func(c *fiber.Ctx) error {
+		c.Locals("user_id", userID.String())
+		c.Locals("role_id", "role-dosen")
+		return c.Next()
+	}, GetMyLecturerService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/lecturers/me", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	body := decodeMapLecturer(t, resp)
+	if data, _ := body["data"].(map[string]any); data["lecturer_id"] != "L-01" {
+		t.Fatalf("unexpected data: %#v", body["data"])
+	}
+}
+
+func TestGetMyLecturerService_MahasiswaForbidden(t *testing.T) {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "mahasiswa"}, nil
+		},
+	}
+	lecturerRepo = &mockLecturerRepo{}
+
+	app := fiber.New()
+	app.Get("/lecturers/me", func(c *fiber.Ctx) error {
+		c.Locals("user_id", uuid.NewString())
+		c.Locals("role_id", "role-mhs")
+		return c.Next()
+	}, GetMyLecturerService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/lecturers/me", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestGetMyAdviseesSummaryService_RoleGuard(t *testing.T) {
+	lecturerID := uuid.New()
+	for _, tc := range []struct {
+		role string
+		want int
+	}{
+		{"mahasiswa", http.StatusForbidden},
+		{"admin", http.StatusForbidden},
+		{"Dosen Wali", http.StatusOK},
+	} {
+		t.Run(tc.role, func(t *testing.T) {
+			achievementRoleRepo = &mockRoleRepo{
+				GetRoleByIDFn: func(id string) (*model.Role, error) {
+					return &model.Role{ID: id, Name: tc.role}, nil
+				},
+			}
+			summaryCalled := false
+			lecturerRepo = &mockLecturerRepo{
+				GetLecturerByUserIDFn: func(uid string) (*model.Lecturer, error) {
+					return &model.Lecturer{ID: lecturerID, LecturerID: "L-01"}, nil
+				},
+				GetAdviseesSummaryFn: func(id string) ([]model.AdviseeSummary, error) {
+					summaryCalled = true
+					if id != lecturerID.String() {
+						t.Fatalf("unexpected lecturer id: %s", id)
+					}
+					return []model.AdviseeSummary{{StudentID: "S-01", Submitted: 2, Verified: 1}}, nil
+				},
+			}
+
+			app := fiber.New()
+			app.Get("/lecturers/me/advisees-summary", func(c *fiber.Ctx) error {
+				c.Locals("user_id", uuid.NewString())
+				c.Locals("role_id", "role-x")
+				return c.Next()
+			}, GetMyAdviseesSummaryService)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/lecturers/me/advisees-summary", nil))
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != tc.want {
+				t.Fatalf("expected %d, got %d", tc.want, resp.StatusCode)
+			}
+			if summaryCalled != (tc.want == http.StatusOK) {
+				t.Fatalf("GetAdviseesSummary called=%v", summaryCalled)
+			}
+			if tc.want == http.StatusOK {
+				body := decodeMapLecturer(t, resp)
+				data, _ := body["data"].([]any)
+				if len(data) != 1 || data[0].(map[string]any)["submitted"] != float64(2) {
+					t.Fatalf("unexpected data: %#v", body["data"])
+				}
+			}
+		})
+	}
+}
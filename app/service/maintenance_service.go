@@ -0,0 +1,42 @@
+package service
+
+import (
+	"hello-fiber/app/model"
+	"hello-fiber/middleware"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetMaintenanceModeService godoc
+// @Summary Nyalakan/matikan mode maintenance (Admin)
+// @Description Selama maintenance, request POST/PUT/PATCH/DELETE dijawab 503 dengan header Retry-After, sedangkan GET tetap dilayani.
+// @Description Status hanya disimpan di memori proses; setelah restart kembali mengikuti MAINTENANCE_MODE.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param body body model.MaintenanceRequest true "Status maintenance"
+// @Success 200 {object} map[string]interface{} "Mode maintenance berhasil diubah"
+// @Failure 400 {object} model.ErrorResponse "Request tidak valid"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 403 {object} model.ErrorResponse "Bukan admin"
+// @Router /v1/admin/maintenance [put]
+// @Security BearerAuth
+func SetMaintenanceModeService(c *fiber.Ctx) error {
+	var req model.MaintenanceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorWithDetail(c, 400, "Request body tidak valid", err)
+	}
+	if req.Enabled == nil {
+		return errorJSON(c, 400, "Field enabled harus diisi")
+	}
+
+	middleware.SetMaintenanceMode(*req.Enabled)
+	log.Printf("[INFO] mode maintenance diubah menjadi %t oleh user %v", *req.Enabled, c.Locals("user_id"))
+
+	message := "Mode maintenance dimatikan"
+	if *req.Enabled {
+		message = "Mode maintenance dinyalakan"
+	}
+	return successJSON(c, fiber.StatusOK, message, fiber.Map{"enabled": *req.Enabled})
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hello-fiber/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestSetMaintenanceModeService_Toggles(t *testing.T) {
+	t.Cleanup(func() { middleware.SetMaintenanceMode(false) })
+	app := fiber.New()
+	app.Put("/maintenance", SetMaintenanceModeService)
+
+	for _, tc := range []struct {
+		body string
+		want bool
+	}{
+		{`{"enabled":true}`, true},
+		{`{"enabled":false}`, false},
+	} {
+		req := httptest.NewRequest(http.MethodPut, "/maintenance", bytes.NewBufferString(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", tc.body, resp.StatusCode)
+		}
+		if got := middleware.MaintenanceModeEnabled(); got != tc.want {
+			t.Fatalf("%s: maintenance flag got %t", tc.body, got)
+		}
+	}
+}
+
+func TestSetMaintenanceModeService_RequiresEnabled(t *testing.T) {
+	app := fiber.New()
+	app.Put("/maintenance", SetMaintenanceModeService)
+
+	req := httptest.NewRequest(http.MethodPut, "/maintenance", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
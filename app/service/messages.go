@@ -0,0 +1,155 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Bahasa pesan response; dipilih dari header Accept-Language, default Indonesia.
+const (
+	langID = "id"
+	langEN = "en"
+)
+
+// Key katalog pesan. Baru handler auth yang memakai katalog; handler lain masih memakai teks langsung.
+const (
+	msgRequestBodyInvalid       = "request_body_invalid"
+	msgRegisterFieldsRequired   = "register_fields_required"
+	msgUsernameInvalid          = "username_invalid"
+	msgEmailInvalid             = "email_invalid"
+	msgPasswordWeak             = "password_weak"
+	msgUsernameCheckFailed      = "username_check_failed"
+	msgUsernameTaken            = "username_taken"
+	msgDefaultRoleUnavailable   = "default_role_unavailable"
+	msgRegisterFailed           = "register_failed"
+	msgRegisterSuccess          = "register_success"
+	msgLoginFieldsRequired      = "login_fields_required"
+	msgAccountStatusFailed      = "account_status_failed"
+	msgAccountLocked            = "account_locked"
+	msgAccountDisabled          = "account_disabled"
+	msgUserStatusUpdateFailed   = "user_status_update_failed"
+	msgPermissionsFailed        = "permissions_failed"
+	msgTokenCreateFailed        = "token_create_failed"
+	msgNewTokenFailed           = "new_token_failed"
+	msgRefreshTokenCreateFailed = "refresh_token_create_failed"
+	msgLoginSuccess             = "login_success"
+	msgTokenRequired            = "token_required"
+	msgTokenInvalid             = "token_invalid"
+	msgTokenClaimsInvalid       = "token_claims_invalid"
+	msgTokenRefreshed           = "token_refreshed"
+	msgTokenValid               = "token_valid"
+	msgUserNotFound             = "user_not_found"
+	msgUserFetchFailed          = "user_fetch_failed"
+	msgUserInvalid              = "user_invalid"
+	msgUserInactive             = "user_inactive"
+	msgRefreshTokenInvalid      = "refresh_token_invalid"
+	msgRefreshTokenExpired      = "refresh_token_expired"
+	msgRefreshTokenReused       = "refresh_token_reused"
+	msgRefreshTokenCheckFailed  = "refresh_token_check_failed"
+	msgRefreshTokenRotateFailed = "refresh_token_rotate_failed"
+	msgLogoutFailed             = "logout_failed"
+	msgLogoutSuccess            = "logout_success"
+)
+
+var messageCatalog = map[string]map[string]string{
+	langID: {
+		msgRequestBodyInvalid:       "Request body tidak valid",
+		msgRegisterFieldsRequired:   "Username, email, password, dan full_name harus diisi",
+		msgUsernameInvalid:          "Username harus 3-50 karakter, hanya alphanumeric dan underscore",
+		msgEmailInvalid:             "Format email tidak valid",
+		msgPasswordWeak:             "Password minimal 5 karakter dengan uppercase, lowercase, dan number",
+		msgUsernameCheckFailed:      "Gagal validasi username",
+		msgUsernameTaken:            "Username sudah terdaftar",
+		msgDefaultRoleUnavailable:   "Role default registrasi tidak tersedia, hubungi admin",
+		msgRegisterFailed:           "Gagal mendaftarkan user",
+		msgRegisterSuccess:          "User berhasil didaftarkan",
+		msgLoginFieldsRequired:      "Email dan password harus diisi",
+		msgAccountStatusFailed:      "Gagal cek status akun",
+		msgAccountLocked:            "Akun terkunci karena terlalu banyak login gagal, coba lagi setelah %s",
+		msgAccountDisabled:          "Akun dinonaktifkan oleh admin",
+		msgUserStatusUpdateFailed:   "Gagal update user status",
+		msgPermissionsFailed:        "Gagal mengambil permissions",
+		msgTokenCreateFailed:        "Gagal membuat token",
+		msgNewTokenFailed:           "Gagal membuat token baru",
+		msgRefreshTokenCreateFailed: "Gagal membuat refresh token",
+		msgLoginSuccess:             "Login berhasil",
+		msgTokenRequired:            "Token harus diisi",
+		msgTokenInvalid:             "Token tidak valid atau expired",
+		msgTokenClaimsInvalid:       "Token claims tidak valid",
+		msgTokenRefreshed:           "Token berhasil direfresh",
+		msgTokenValid:               "Token valid",
+		msgUserNotFound:             "User tidak ditemukan",
+		msgUserFetchFailed:          "Gagal mengambil data user",
+		msgUserInvalid:              "User tidak valid",
+		msgUserInactive:             "User tidak aktif",
+		msgRefreshTokenInvalid:      "Refresh token tidak valid",
+		msgRefreshTokenExpired:      "Refresh token sudah expired",
+		msgRefreshTokenReused:       "Refresh token sudah pernah digunakan, semua sesi terkait dicabut",
+		msgRefreshTokenCheckFailed:  "Gagal memeriksa refresh token",
+		msgRefreshTokenRotateFailed: "Gagal merotasi refresh token",
+		msgLogoutFailed:             "Gagal logout, error saat update user status",
+		msgLogoutSuccess:            "Logout berhasil, token sudah tidak aktif",
+	},
+	langEN: {
+		msgRequestBodyInvalid:       "Invalid request body",
+		msgRegisterFieldsRequired:   "Username, email, password, and full_name are required",
+		msgUsernameInvalid:          "Username must be 3-50 characters, alphanumeric and underscore only",
+		msgEmailInvalid:             "Invalid email format",
+		msgPasswordWeak:             "Password must be at least 5 characters with uppercase, lowercase, and a number",
+		msgUsernameCheckFailed:      "Failed to validate username",
+		msgUsernameTaken:            "Username is already registered",
+		msgDefaultRoleUnavailable:   "Default registration role is unavailable, contact an admin",
+		msgRegisterFailed:           "Failed to register user",
+		msgRegisterSuccess:          "User registered successfully",
+		msgLoginFieldsRequired:      "Email and password are required",
+		msgAccountStatusFailed:      "Failed to check account status",
+		msgAccountLocked:            "Account locked after too many failed logins, try again after %s",
+		msgAccountDisabled:          "Account has been disabled by an admin",
+		msgUserStatusUpdateFailed:   "Failed to update user status",
+		msgPermissionsFailed:        "Failed to load permissions",
+		msgTokenCreateFailed:        "Failed to create token",
+		msgNewTokenFailed:           "Failed to create new token",
+		msgRefreshTokenCreateFailed: "Failed to create refresh token",
+		msgLoginSuccess:             "Login successful",
+		msgTokenRequired:            "Token is required",
+		msgTokenInvalid:             "Token is invalid or expired",
+		msgTokenClaimsInvalid:       "Invalid token claims",
+		msgTokenRefreshed:           "Token refreshed successfully",
+		msgTokenValid:               "Token is valid",
+		msgUserNotFound:             "User not found",
+		msgUserFetchFailed:          "Failed to load user",
+		msgUserInvalid:              "Invalid user",
+		msgUserInactive:             "User is inactive",
+		msgRefreshTokenInvalid:      "Invalid refresh token",
+		msgRefreshTokenExpired:      "Refresh token has expired",
+		msgRefreshTokenReused:       "Refresh token was already used, all related sessions have been revoked",
+		msgRefreshTokenCheckFailed:  "Failed to check refresh token",
+		msgRefreshTokenRotateFailed: "Failed to rotate refresh token",
+		msgLogoutFailed:             "Logout failed while updating user status",
+		msgLogoutSuccess:            "Logout successful, token is no longer active",
+	},
+}
+
+// requestLang memilih bahasa dari Accept-Language (mis. "en-US,en;q=0.9"); tidak dikenal => Indonesia.
+func requestLang(c *fiber.Ctx) string {
+	if lang := c.AcceptsLanguages(langID, langEN); lang != "" {
+		return lang
+	}
+	return langID
+}
+
+// msg mengambil pesan key sesuai bahasa request; args diformat dengan fmt.Sprintf.
+// Key yang belum punya terjemahan jatuh ke teks Indonesia, lalu ke key itu sendiri.
+func msg(c *fiber.Ctx, key string, args ...interface{}) string {
+	text, ok := messageCatalog[requestLang(c)][key]
+	if !ok {
+		if text, ok = messageCatalog[langID][key]; !ok {
+			text = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hello-fiber/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fallbackPageLimit limit per halaman jika DEFAULT_PAGE_LIMIT tidak diset/tidak valid.
+const fallbackPageLimit = 10
+
+// defaultPageLimit limit yang dipakai list endpoint jika query limit tidak dikirim; diisi InitPagination.
+var defaultPageLimit int64 = fallbackPageLimit
+
+// InitPagination membaca DEFAULT_PAGE_LIMIT sekali saat startup.
+func InitPagination() {
+	defaultPageLimit = defaultPageLimitFromEnv()
+}
+
+func defaultPageLimitFromEnv() int64 {
+	n, err := strconv.ParseInt(strings.TrimSpace(utils.GetEnv("DEFAULT_PAGE_LIMIT", "")), 10, 64)
+	if err != nil || n < 1 {
+		return fallbackPageLimit
+	}
+	return n
+}
+
+// defaultMaxPageLimit batas limit per halaman jika PAGINATION_MAX_LIMIT tidak diset/tidak valid.
+const defaultMaxPageLimit = 100
+
+// maxPageLimit batas atas limit untuk semua list endpoint (PAGINATION_MAX_LIMIT).
+func maxPageLimit() int64 {
+	n, err := strconv.ParseInt(strings.TrimSpace(utils.GetEnv("PAGINATION_MAX_LIMIT", "")), 10, 64)
+	if err != nil || n < 1 {
+		return defaultMaxPageLimit
+	}
+	return n
+}
+
+// clampPagination memaksa page >= 1 dan 1 <= limit <= maxPageLimit(), agar client tidak bisa memicu query raksasa.
+func clampPagination(page, limit int64) (int64, int64) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	if max := maxPageLimit(); limit > max {
+		limit = max
+	}
+	return page, limit
+}
+
+// parseIntQuery membaca query integer key. Tidak dikirim/kosong berarti def; nilai yang ada tapi
+// bukan angka dikembalikan sebagai error (c.QueryInt diam-diam memakai default untuk kasus ini).
+func parseIntQuery(c *fiber.Ctx, key string, def int64) (int64, error) {
+	raw := strings.TrimSpace(c.Query(key))
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parameter %s tidak valid", key)
+	}
+	return n, nil
+}
+
+// parsePagination membaca page (default 1) dan limit (default defaultPageLimit) lalu di-clamp;
+// error berisi pesan 400 jika salah satunya bukan angka.
+func parsePagination(c *fiber.Ctx) (int64, int64, error) {
+	page, err := parseIntQuery(c, "page", 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	limit, err := parseIntQuery(c, "limit", defaultPageLimit)
+	if err != nil {
+		return 0, 0, err
+	}
+	page, limit = clampPagination(page, limit)
+	return page, limit, nil
+}
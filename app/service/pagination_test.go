@@ -0,0 +1,156 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hello-fiber/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestClampPagination(t *testing.T) {
+	t.Setenv("PAGINATION_MAX_LIMIT", "")
+
+	cases := []struct {
+		name                string
+		page, limit         int64
+		wantPage, wantLimit int64
+	}{
+		{"limit nol", 1, 0, 1, 1},
+		{"page negatif", -3, 10, 1, 10},
+		{"limit di atas max", 2, 1000000, 2, defaultMaxPageLimit},
+		{"nilai valid", 3, 25, 3, 25},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			page, limit := clampPagination(tc.page, tc.limit)
+			if page != tc.wantPage || limit != tc.wantLimit {
+				t.Fatalf("got (%d, %d) want (%d, %d)", page, limit, tc.wantPage, tc.wantLimit)
+			}
+		})
+	}
+}
+
+func TestClampPagination_ConfigurableMax(t *testing.T) {
+	t.Setenv("PAGINATION_MAX_LIMIT", "20")
+
+	if _, limit := clampPagination(1, 50); limit != 20 {
+		t.Fatalf("limit: got %d want 20", limit)
+	}
+}
+
+func TestGetAllUsersService_ReturnsClampedPagination(t *testing.T) {
+	t.Setenv("PAGINATION_MAX_LIMIT", "")
+	var gotPage, gotLimit int64
+	userRepo = &mockUserRepo{
+		GetAllUsersFn: func(page, limit int64, filter model.UserFilter) ([]model.User, int64, error) {
+			gotPage, gotLimit = page, limit
+			return []model.User{}, 0, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/users", GetAllUsersService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users?page=-1&limit=1000000", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if gotPage != 1 || gotLimit != defaultMaxPageLimit {
+		t.Fatalf("repo got (%d, %d)", gotPage, gotLimit)
+	}
+	body := decodeMap(t, resp)
+	if body["page"] != float64(1) || body["limit"] != float64(defaultMaxPageLimit) {
+		t.Fatalf("response pagination: page=%v limit=%v", body["page"], body["limit"])
+	}
+}
+
+func TestGetAllUsersService_NonNumericPaginationRejected(t *testing.T) {
+	called := false
+	userRepo = &mockUserRepo{
+		GetAllUsersFn: func(page, limit int64, filter model.UserFilter) ([]model.User, int64, error) {
+			called = true
+			return []model.User{}, 0, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/users", GetAllUsersService)
+
+	for _, query := range []string{"page=abc", "limit=10x"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users?"+query, nil), -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", query, resp.StatusCode)
+		}
+	}
+	if called {
+		t.Fatal("repo must not be called for invalid pagination")
+	}
+}
+
+func TestGetAllUsersService_OmittedPaginationUsesDefaults(t *testing.T) {
+	var gotPage, gotLimit int64
+	userRepo = &mockUserRepo{
+		GetAllUsersFn: func(page, limit int64, filter model.UserFilter) ([]model.User, int64, error) {
+			gotPage, gotLimit = page, limit
+			return []model.User{}, 0, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/users", GetAllUsersService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if gotPage != 1 || gotLimit != 10 {
+		t.Fatalf("repo got (%d, %d) want (1, 10)", gotPage, gotLimit)
+	}
+}
+
+func TestGetAllUsersService_DefaultPageLimitFromEnv(t *testing.T) {
+	t.Setenv("PAGINATION_MAX_LIMIT", "")
+	t.Setenv("DEFAULT_PAGE_LIMIT", "25")
+	InitPagination()
+	t.Cleanup(func() { defaultPageLimit = fallbackPageLimit })
+
+	var gotLimit int64
+	userRepo = &mockUserRepo{
+		GetAllUsersFn: func(page, limit int64, filter model.UserFilter) ([]model.User, int64, error) {
+			gotLimit = limit
+			return []model.User{}, 0, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/users", GetAllUsersService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if gotLimit != 25 {
+		t.Fatalf("repo limit: got %d want 25", gotLimit)
+	}
+	if body := decodeMap(t, resp); body["limit"] != float64(25) {
+		t.Fatalf("response limit: %v", body["limit"])
+	}
+}
+
+func TestDefaultPageLimitFromEnv_InvalidFallsBack(t *testing.T) {
+	for _, raw := range []string{"", "0", "-5", "banyak"} {
+		t.Setenv("DEFAULT_PAGE_LIMIT", raw)
+		if got := defaultPageLimitFromEnv(); got != fallbackPageLimit {
+			t.Fatalf("%q: got %d want %d", raw, got, fallbackPageLimit)
+		}
+	}
+}
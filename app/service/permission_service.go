@@ -28,29 +28,30 @@ func normalizePathParam(raw string) string {
 
 // GetAllPermissionsService godoc
 // @Summary Dapatkan semua permission (Permission: user:manage)
-// @Description Mengambil daftar semua permission dengan pagination
+// @Description Mengambil daftar semua permission dengan pagination, opsional difilter resource dan/atau action
 // @Tags Permissions
 // @Accept json
 // @Produce json
 // @Param page query int false "Halaman (default: 1)"
-// @Param limit query int false "Jumlah data per halaman (default: 10)"
+// @Param limit query int false "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)"
+// @Param resource query string false "Filter resource (mis. achievement)"
+// @Param action query string false "Filter action (mis. read)"
 // @Success 200 {object} map[string]interface{} "Data permission berhasil diambil"
+// @Failure 400 {object} model.ErrorResponse "Parameter page/limit tidak valid"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/permissions [get]
 // @Security BearerAuth
 func GetAllPermissionsService(c *fiber.Ctx) error {
-	page := int64(1)
-	limit := int64(10)
-
-	if p := c.Query("page"); p != "" {
-		page = int64(c.QueryInt("page", 1))
-	}
-	if l := c.Query("limit"); l != "" {
-		limit = int64(c.QueryInt("limit", 10))
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
 	}
 
-	permissions, total, err := permissionRepo.GetAllPermissions(page, limit)
+	resource := strings.TrimSpace(c.Query("resource"))
+	action := strings.TrimSpace(c.Query("action"))
+
+	permissions, total, err := permissionRepo.GetAllPermissions(c.UserContext(), page, limit, resource, action)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"success": false,
@@ -92,7 +93,7 @@ func GetPermissionByIDService(c *fiber.Ctx) error {
 		})
 	}
 
-	perm, err := permissionRepo.GetPermissionByID(id)
+	perm, err := permissionRepo.GetPermissionByID(c.UserContext(), id)
 	if err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
 			return c.Status(404).JSON(fiber.Map{
@@ -116,13 +117,13 @@ func GetPermissionByIDService(c *fiber.Ctx) error {
 
 // CreatePermissionService godoc
 // @Summary Buat permission baru (Permission: user:manage)
-// @Description Memerlukan permission user:manage untuk membuat permission baru
+// @Description Memerlukan permission user:manage untuk membuat permission baru. resource dan action wajib; name opsional, jika kosong diisi "resource:action".
 // @Tags Permissions
 // @Accept json
 // @Produce json
 // @Param body body model.CreatePermissionRequest true "Data permission yang akan dibuat"
 // @Success 201 {object} model.SuccessResponse "Permission berhasil dibuat"
-// @Failure 400 {object} model.ErrorResponse "Validasi gagal"
+// @Failure 400 {object} model.ErrorResponse "Resource dan action harus diisi / validasi gagal"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/permissions [post]
@@ -142,14 +143,38 @@ func CreatePermissionService(c *fiber.Ctx) error {
 	req.Action = strings.TrimSpace(req.Action)
 	req.Description = strings.TrimSpace(req.Description)
 
-	if req.Name == "" || req.Resource == "" || req.Action == "" {
+	if req.Resource == "" || req.Action == "" {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "Resource dan action harus diisi",
+		})
+	}
+	if descriptionTooLong(req.Description) {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "description terlalu panjang",
+		})
+	}
+	if req.Name == "" {
+		req.Name = req.Resource + ":" + req.Action
+	}
+
+	existing, err := permissionRepo.GetPermissionByName(c.UserContext(), req.Name)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal validasi nama permission",
+			"error":   err.Error(),
+		})
+	}
+	if existing != nil {
 		return c.Status(400).JSON(fiber.Map{
 			"success": false,
-			"message": "Name, resource, dan action harus diisi",
+			"message": "Permission dengan nama tersebut sudah ada",
 		})
 	}
 
-	id, err := permissionRepo.CreatePermission(req)
+	id, err := permissionRepo.CreatePermission(c.UserContext(), req)
 	if err != nil {
 		lower := strings.ToLower(err.Error())
 		if strings.Contains(lower, "sudah ada") || strings.Contains(lower, "duplicate") || strings.Contains(lower, "unique") {
@@ -166,6 +191,7 @@ func CreatePermissionService(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, model.AuditActionCreate, model.AuditEntityPermission, id)
 	return c.Status(201).JSON(fiber.Map{
 		"success": true,
 		"message": "Permission berhasil dibuat",
@@ -215,8 +241,14 @@ func UpdatePermissionService(c *fiber.Ctx) error {
 			"message": "Minimal satu field harus diisi untuk update",
 		})
 	}
+	if descriptionTooLong(strings.TrimSpace(req.Description)) {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "description terlalu panjang",
+		})
+	}
 
-	if err := permissionRepo.UpdatePermission(id, req); err != nil {
+	if err := permissionRepo.UpdatePermission(c.UserContext(), id, req); err != nil {
 		lower := strings.ToLower(err.Error())
 		if strings.Contains(lower, "tidak ditemukan") {
 			return c.Status(404).JSON(fiber.Map{
@@ -238,6 +270,7 @@ func UpdatePermissionService(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, model.AuditActionUpdate, model.AuditEntityPermission, id)
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "Permission berhasil diupdate",
@@ -246,15 +279,17 @@ func UpdatePermissionService(c *fiber.Ctx) error {
 
 // DeletePermissionService godoc
 // @Summary Hapus permission (Permission: user:manage)
-// @Description Memerlukan permission user:manage untuk menghapus permission berdasarkan ID
+// @Description Memerlukan permission user:manage untuk menghapus permission berdasarkan ID. Permission yang masih dipetakan ke role ditolak kecuali force=true.
 // @Tags Permissions
 // @Accept json
 // @Produce json
 // @Param id path string true "Permission ID (UUID)"
+// @Param force query bool false "Hapus mapping role_permissions lebih dulu"
 // @Success 200 {object} model.SuccessResponse "Permission berhasil dihapus"
 // @Failure 400 {object} model.ErrorResponse "Validasi gagal"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 404 {object} model.ErrorResponse "Permission tidak ditemukan"
+// @Failure 409 {object} model.ErrorResponse "Permission sedang digunakan"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/permissions/{id} [delete]
 // @Security BearerAuth
@@ -267,7 +302,26 @@ func DeletePermissionService(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := permissionRepo.DeletePermission(id); err != nil {
+	force := c.QueryBool("force", false)
+	if !force {
+		mappings, err := permissionRepo.CountPermissionUsage(c.UserContext(), id)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"success": false,
+				"message": "Gagal cek pemakaian permission",
+				"error":   err.Error(),
+			})
+		}
+		if mappings > 0 {
+			return c.Status(409).JSON(fiber.Map{
+				"success":          false,
+				"message":          "permission sedang digunakan",
+				"role_permissions": mappings,
+			})
+		}
+	}
+
+	if err := permissionRepo.DeletePermission(c.UserContext(), id, force); err != nil {
 		lower := strings.ToLower(err.Error())
 		if strings.Contains(lower, "tidak ditemukan") {
 			return c.Status(404).JSON(fiber.Map{
@@ -283,6 +337,7 @@ func DeletePermissionService(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, model.AuditActionDelete, model.AuditEntityPermission, id)
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "Permission berhasil dihapus",
@@ -2,10 +2,12 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"hello-fiber/app/model"
@@ -14,48 +16,64 @@ import (
 )
 
 type mockPermissionRepo struct {
-	GetAllPermissionsFn func(page, limit int64) ([]model.Permission, int64, error)
-	GetPermissionByIDFn func(id string) (*model.Permission, error)
-	CreatePermissionFn  func(req model.CreatePermissionRequest) (string, error)
-	UpdatePermissionFn  func(id string, req model.UpdatePermissionRequest) error
-	DeletePermissionFn  func(id string) error
+	GetAllPermissionsFn    func(page, limit int64, resource, action string) ([]model.Permission, int64, error)
+	GetPermissionByIDFn    func(id string) (*model.Permission, error)
+	GetPermissionByNameFn  func(name string) (*model.Permission, error)
+	CreatePermissionFn     func(req model.CreatePermissionRequest) (string, error)
+	UpdatePermissionFn     func(id string, req model.UpdatePermissionRequest) error
+	DeletePermissionFn     func(id string, force bool) error
+	CountPermissionUsageFn func(id string) (int64, error)
 }
 
-func (m *mockPermissionRepo) GetAllPermissions(page, limit int64) ([]model.Permission, int64, error) {
+func (m *mockPermissionRepo) GetAllPermissions(ctx context.Context, page, limit int64, resource, action string) ([]model.Permission, int64, error) {
 	if m.GetAllPermissionsFn != nil {
-		return m.GetAllPermissionsFn(page, limit)
+		return m.GetAllPermissionsFn(page, limit, resource, action)
 	}
 	return nil, 0, nil
 }
 
-func (m *mockPermissionRepo) GetPermissionByID(id string) (*model.Permission, error) {
+func (m *mockPermissionRepo) GetPermissionByID(ctx context.Context, id string) (*model.Permission, error) {
 	if m.GetPermissionByIDFn != nil {
 		return m.GetPermissionByIDFn(id)
 	}
 	return nil, nil
 }
 
-func (m *mockPermissionRepo) CreatePermission(req model.CreatePermissionRequest) (string, error) {
+func (m *mockPermissionRepo) GetPermissionByName(ctx context.Context, name string) (*model.Permission, error) {
+	if m.GetPermissionByNameFn != nil {
+		return m.GetPermissionByNameFn(name)
+	}
+	return nil, nil
+}
+
+func (m *mockPermissionRepo) CreatePermission(ctx context.Context, req model.CreatePermissionRequest) (string, error) {
 	if m.CreatePermissionFn != nil {
 		return m.CreatePermissionFn(req)
 	}
 	return "", nil
 }
 
-func (m *mockPermissionRepo) UpdatePermission(id string, req model.UpdatePermissionRequest) error {
+func (m *mockPermissionRepo) UpdatePermission(ctx context.Context, id string, req model.UpdatePermissionRequest) error {
 	if m.UpdatePermissionFn != nil {
 		return m.UpdatePermissionFn(id, req)
 	}
 	return nil
 }
 
-func (m *mockPermissionRepo) DeletePermission(id string) error {
+func (m *mockPermissionRepo) DeletePermission(ctx context.Context, id string, force bool) error {
 	if m.DeletePermissionFn != nil {
-		return m.DeletePermissionFn(id)
+		return m.DeletePermissionFn(id, force)
 	}
 	return nil
 }
 
+func (m *mockPermissionRepo) CountPermissionUsage(ctx context.Context, id string) (int64, error) {
+	if m.CountPermissionUsageFn != nil {
+		return m.CountPermissionUsageFn(id)
+	}
+	return 0, nil
+}
+
 func toJSONReaderPermission(t *testing.T, v any) *bytes.Reader {
 	t.Helper()
 	b, err := json.Marshal(v)
@@ -76,7 +94,7 @@ func decodeMapPermission(t *testing.T, resp *http.Response) map[string]any {
 
 func TestGetAllPermissionsService_Success(t *testing.T) {
 	permissionRepo = &mockPermissionRepo{
-		GetAllPermissionsFn: func(page, limit int64) ([]model.Permission, int64, error) {
+		GetAllPermissionsFn: func(page, limit int64, resource, action string) ([]model.Permission, int64, error) {
 			if page != 2 || limit != 5 {
 				t.Fatalf("expected page=2 limit=5, got page=%d limit=%d", page, limit)
 			}
@@ -110,9 +128,35 @@ func TestGetAllPermissionsService_Success(t *testing.T) {
 	}
 }
 
+func TestGetAllPermissionsService_ResourceActionFilter(t *testing.T) {
+	var gotResource, gotAction string
+	permissionRepo = &mockPermissionRepo{
+		GetAllPermissionsFn: func(page, limit int64, resource, action string) ([]model.Permission, int64, error) {
+			gotResource, gotAction = resource, action
+			return []model.Permission{{ID: "p2", Name: "achievement:read", Resource: "achievement", Action: "read"}}, 1, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/permissions", GetAllPermissionsService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/permissions?resource=achievement&action=read", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if gotResource != "achievement" || gotAction != "read" {
+		t.Fatalf("filters not forwarded: resource=%q action=%q", gotResource, gotAction)
+	}
+}
+
 func TestGetAllPermissionsService_DefaultPagination(t *testing.T) {
 	permissionRepo = &mockPermissionRepo{
-		GetAllPermissionsFn: func(page, limit int64) ([]model.Permission, int64, error) {
+		GetAllPermissionsFn: func(page, limit int64, resource, action string) ([]model.Permission, int64, error) {
 			if page != 1 || limit != 10 {
 				t.Fatalf("expected page=1 limit=10, got page=%d limit=%d", page, limit)
 			}
@@ -137,7 +181,7 @@ func TestGetAllPermissionsService_DefaultPagination(t *testing.T) {
 
 func TestGetAllPermissionsService_RepoError(t *testing.T) {
 	permissionRepo = &mockPermissionRepo{
-		GetAllPermissionsFn: func(page, limit int64) ([]model.Permission, int64, error) {
+		GetAllPermissionsFn: func(page, limit int64, resource, action string) ([]model.Permission, int64, error) {
 			return nil, 0, errors.New("db down")
 		},
 	}
@@ -286,7 +330,7 @@ func TestCreatePermissionService_MissingFields(t *testing.T) {
 	}
 
 	body := decodeMapPermission(t, resp)
-	if body["message"] != "Name, resource, dan action harus diisi" {
+	if body["message"] != "Resource dan action harus diisi" {
 		t.Fatalf("unexpected message: %#v", body["message"])
 	}
 }
@@ -331,6 +375,109 @@ func TestCreatePermissionService_Success(t *testing.T) {
 	}
 }
 
+func TestCreatePermissionService_DuplicateNameRejected(t *testing.T) {
+	permissionRepo = &mockPermissionRepo{
+		GetPermissionByNameFn: func(name string) (*model.Permission, error) {
+			if name != "achievement:read" {
+				t.Fatalf("unexpected lookup name: %q", name)
+			}
+			return &model.Permission{ID: "p1", Name: "achievement:read"}, nil
+		},
+		CreatePermissionFn: func(req model.CreatePermissionRequest) (string, error) {
+			t.Fatalf("CreatePermission must not be called for duplicate name")
+			return "", nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/permissions", CreatePermissionService)
+
+	req := httptest.NewRequest(http.MethodPost, "/permissions", toJSONReaderPermission(t, map[string]any{
+		"resource": "achievement",
+		"action":   "read",
+	}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	body := decodeMapPermission(t, resp)
+	if body["message"] != "Permission dengan nama tersebut sudah ada" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
+func TestCreatePermissionService_DerivesNameFromResourceAction(t *testing.T) {
+	var stored model.CreatePermissionRequest
+	permissionRepo = &mockPermissionRepo{
+		CreatePermissionFn: func(req model.CreatePermissionRequest) (string, error) {
+			stored = req
+			return "new-id", nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/permissions", CreatePermissionService)
+
+	req := httptest.NewRequest(http.MethodPost, "/permissions", toJSONReaderPermission(t, map[string]any{
+		"resource": " achievement ",
+		"action":   "verify",
+	}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	}
+	if stored.Name != "achievement:verify" {
+		t.Fatalf("expected derived name achievement:verify, got %q", stored.Name)
+	}
+}
+
+func TestCreatePermissionService_ExplicitNamePreserved(t *testing.T) {
+	var stored model.CreatePermissionRequest
+	permissionRepo = &mockPermissionRepo{
+		CreatePermissionFn: func(req model.CreatePermissionRequest) (string, error) {
+			stored = req
+			return "new-id", nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/permissions", CreatePermissionService)
+
+	req := httptest.NewRequest(http.MethodPost, "/permissions", toJSONReaderPermission(t, map[string]any{
+		"name":     "report:export-pdf",
+		"resource": "report",
+		"action":   "export",
+	}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	}
+	if stored.Name != "report:export-pdf" {
+		t.Fatalf("explicit name must be kept, got %q", stored.Name)
+	}
+}
+
 func TestUpdatePermissionService_NoFields(t *testing.T) {
 	permissionRepo = &mockPermissionRepo{}
 
@@ -395,7 +542,7 @@ func TestUpdatePermissionService_Success(t *testing.T) {
 
 func TestDeletePermissionService_Success(t *testing.T) {
 	permissionRepo = &mockPermissionRepo{
-		DeletePermissionFn: func(id string) error {
+		DeletePermissionFn: func(id string, force bool) error {
 			if id != "p1" {
 				t.Fatalf("expected id=p1, got %s", id)
 			}
@@ -422,3 +569,119 @@ func TestDeletePermissionService_Success(t *testing.T) {
 		t.Fatalf("unexpected message: %#v", body["message"])
 	}
 }
+
+func TestDeletePermissionService_InUseBlocked(t *testing.T) {
+	permissionRepo = &mockPermissionRepo{
+		CountPermissionUsageFn: func(id string) (int64, error) { return 2, nil },
+		DeletePermissionFn: func(id string, force bool) error {
+			t.Fatalf("DeletePermission must not be called for permission in use")
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Delete("/permissions/:id", DeletePermissionService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/permissions/p1", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", resp.StatusCode)
+	}
+	body := decodeMapPermission(t, resp)
+	if body["message"] != "permission sedang digunakan" || body["role_permissions"] != float64(2) {
+		t.Fatalf("unexpected body: %#v", body)
+	}
+}
+
+func TestDeletePermissionService_ForceDeletes(t *testing.T) {
+	var gotForce bool
+	permissionRepo = &mockPermissionRepo{
+		CountPermissionUsageFn: func(id string) (int64, error) {
+			t.Fatalf("usage check must be skipped with force=true")
+			return 0, nil
+		},
+		DeletePermissionFn: func(id string, force bool) error {
+			gotForce = force
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Delete("/permissions/:id", DeletePermissionService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/permissions/p1?force=true", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || !gotForce {
+		t.Fatalf("expected forced delete, status=%d force=%v", resp.StatusCode, gotForce)
+	}
+}
+
+func TestCreatePermissionService_DescriptionTooLong(t *testing.T) {
+	permissionRepo = &mockPermissionRepo{
+		CreatePermissionFn: func(req model.CreatePermissionRequest) (string, error) {
+			t.Fatalf("CreatePermission must not be called for an over-length description")
+			return "", nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/permissions", CreatePermissionService)
+
+	req := httptest.NewRequest(http.MethodPost, "/permissions", toJSONReaderPermission(t, map[string]any{
+		"resource":    "report",
+		"action":      "read",
+		"description": strings.Repeat("x", defaultMaxDescriptionLength+1),
+	}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	if body := decodeMapPermission(t, resp); body["message"] != "description terlalu panjang" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
+func TestUpdatePermissionService_DescriptionTooLong(t *testing.T) {
+	permissionRepo = &mockPermissionRepo{
+		UpdatePermissionFn: func(id string, req model.UpdatePermissionRequest) error {
+			t.Fatalf("UpdatePermission must not be called for an over-length description")
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/permissions/:id", UpdatePermissionService)
+
+	req := httptest.NewRequest(http.MethodPut, "/permissions/p1", toJSONReaderPermission(t, map[string]any{
+		"description": strings.Repeat("x", defaultMaxDescriptionLength+1),
+	}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	if body := decodeMapPermission(t, resp); body["message"] != "description terlalu panjang" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
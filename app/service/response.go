@@ -0,0 +1,99 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"hello-fiber/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// isProduction: APP_ENV=production menyembunyikan detail error internal dari response.
+func isProduction() bool {
+	return strings.EqualFold(strings.TrimSpace(utils.GetEnv("APP_ENV", "")), "production")
+}
+
+// errorJSON response error standar: {"success": false, "message": ...}.
+func errorJSON(c *fiber.Ctx, code int, message string) error {
+	return c.Status(code).JSON(fiber.Map{
+		"success": false,
+		"message": message,
+	})
+}
+
+// validationErrorJSON dipakai untuk body/param yang berhasil di-parse tetapi isinya tidak lolos
+// validasi field (wajib diisi, format email, kekuatan password): 422 Unprocessable Entity.
+// Konvensinya: 400 hanya untuk body/param yang tidak bisa di-parse sama sekali, 422 untuk
+// validasi field, sehingga client bisa membedakan request rusak dari input yang salah.
+func validationErrorJSON(c *fiber.Ctx, message string) error {
+	return errorJSON(c, fiber.StatusUnprocessableEntity, message)
+}
+
+// errorWithDetail sama seperti errorJSON, ditambah "error" berisi err.Error() kecuali di production.
+func errorWithDetail(c *fiber.Ctx, code int, message string, err error) error {
+	body := fiber.Map{
+		"success": false,
+		"message": message,
+	}
+	if err != nil && !isProduction() {
+		body["error"] = err.Error()
+	}
+	return c.Status(code).JSON(body)
+}
+
+// successJSON response sukses standar; "data" hanya disertakan jika tidak nil.
+func successJSON(c *fiber.Ctx, code int, message string, data interface{}) error {
+	body := fiber.Map{
+		"success": true,
+		"message": message,
+	}
+	if data != nil {
+		body["data"] = data
+	}
+	return c.Status(code).JSON(body)
+}
+
+// weakETag ETag lemah dari hash body JSON; berubah setiap kali isi response berubah.
+func weakETag(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches membandingkan If-None-Match (bisa berisi beberapa tag atau "*") secara weak.
+func etagMatches(ifNoneMatch, etag string) bool {
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// successJSONWithETag seperti successJSON (200) plus header ETag; jika If-None-Match cocok
+// dikembalikan 304 tanpa body agar client polling tidak mengunduh ulang data yang sama.
+func successJSONWithETag(c *fiber.Ctx, message string, data interface{}) error {
+	body := fiber.Map{
+		"success": true,
+		"message": message,
+	}
+	if data != nil {
+		body["data"] = data
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal menyusun response", err)
+	}
+
+	etag := weakETag(payload)
+	c.Set(fiber.HeaderETag, etag)
+	if inm := c.Get(fiber.HeaderIfNoneMatch); inm != "" && etagMatches(inm, etag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(fiber.StatusOK).Send(payload)
+}
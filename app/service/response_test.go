@@ -0,0 +1,168 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hello-fiber/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func callResponseHelper(t *testing.T, h fiber.Handler) (int, map[string]any) {
+	t.Helper()
+	app := fiber.New()
+	app.Get("/", h)
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode json: %v", err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestErrorJSON_Shape(t *testing.T) {
+	code, body := callResponseHelper(t, func(c *fiber.Ctx) error {
+		return errorJSON(c, fiber.StatusNotFound, "Data tidak ditemukan")
+	})
+	if code != fiber.StatusNotFound {
+		t.Fatalf("status: got %d want %d", code, fiber.StatusNotFound)
+	}
+	if len(body) != 2 || body["success"] != false || body["message"] != "Data tidak ditemukan" {
+		t.Fatalf("unexpected body: %v", body)
+	}
+}
+
+func TestErrorWithDetail_IncludesErrorOutsideProduction(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	code, body := callResponseHelper(t, func(c *fiber.Ctx) error {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal", errors.New("koneksi putus"))
+	})
+	if code != fiber.StatusInternalServerError {
+		t.Fatalf("status: got %d want %d", code, fiber.StatusInternalServerError)
+	}
+	if body["success"] != false || body["message"] != "Gagal" || body["error"] != "koneksi putus" {
+		t.Fatalf("unexpected body: %v", body)
+	}
+}
+
+func TestErrorWithDetail_HidesErrorInProduction(t *testing.T) {
+	t.Setenv("APP_ENV", "production")
+	_, body := callResponseHelper(t, func(c *fiber.Ctx) error {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal", errors.New("pq: password authentication failed"))
+	})
+	if _, ok := body["error"]; ok {
+		t.Fatalf("error detail should be hidden in production: %v", body)
+	}
+	if body["message"] != "Gagal" {
+		t.Fatalf("unexpected message: %v", body["message"])
+	}
+}
+
+func TestSuccessJSON_Shape(t *testing.T) {
+	code, body := callResponseHelper(t, func(c *fiber.Ctx) error {
+		return successJSON(c, fiber.StatusCreated, "Berhasil", fiber.Map{"id": "x"})
+	})
+	if code != fiber.StatusCreated {
+		t.Fatalf("status: got %d want %d", code, fiber.StatusCreated)
+	}
+	data, _ := body["data"].(map[string]any)
+	if body["success"] != true || body["message"] != "Berhasil" || data["id"] != "x" {
+		t.Fatalf("unexpected body: %v", body)
+	}
+
+	_, body = callResponseHelper(t, func(c *fiber.Ctx) error {
+		return successJSON(c, fiber.StatusOK, "Berhasil", nil)
+	})
+	if _, ok := body["data"]; ok {
+		t.Fatalf("data should be omitted when nil: %v", body)
+	}
+}
+
+// getWithETag memanggil GET path dengan If-None-Match opsional, mengembalikan status, ETag, dan body mentah.
+func getWithETag(t *testing.T, app *fiber.App, path, ifNoneMatch string) (int, string, []byte) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return resp.StatusCode, resp.Header.Get("ETag"), raw
+}
+
+func TestGetUserByIDService_ETag(t *testing.T) {
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			return &model.User{ID: id, Username: "user1", Email: "u1@mail.com", IsActive: true}, nil
+		},
+	}
+	app := fiber.New()
+	app.Get("/users/:id", GetUserByIDService)
+
+	status, etag, _ := getWithETag(t, app, "/users/u1", "")
+	if status != http.StatusOK || etag == "" {
+		t.Fatalf("first request: status=%d etag=%q", status, etag)
+	}
+
+	status, _, raw := getWithETag(t, app, "/users/u1", etag)
+	if status != http.StatusNotModified {
+		t.Fatalf("matching If-None-Match: expected 304, got %d", status)
+	}
+	if len(raw) != 0 {
+		t.Fatalf("304 must have empty body, got %q", raw)
+	}
+
+	status, _, raw = getWithETag(t, app, "/users/u1", `W/"stale"`)
+	if status != http.StatusOK || len(raw) == 0 {
+		t.Fatalf("non-matching If-None-Match: status=%d body=%q", status, raw)
+	}
+}
+
+func TestGetRoleByIDService_ETag(t *testing.T) {
+	role := &model.Role{ID: "r1", Name: "admin"}
+	roleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return role, nil
+		},
+	}
+	app := fiber.New()
+	app.Get("/roles/:id", GetRoleByIDService)
+
+	_, etag, _ := getWithETag(t, app, "/roles/r1", "")
+	if status, _, raw := getWithETag(t, app, "/roles/r1", etag); status != http.StatusNotModified || len(raw) != 0 {
+		t.Fatalf("expected empty 304, got %d %q", status, raw)
+	}
+
+	// data berubah => ETag lama tidak cocok lagi
+	role.Name = "administrator"
+	status, newETag, _ := getWithETag(t, app, "/roles/r1", etag)
+	if status != http.StatusOK {
+		t.Fatalf("changed role: expected 200, got %d", status)
+	}
+	if newETag == etag {
+		t.Fatalf("ETag must change when data changes")
+	}
+}
+
+func TestEtagMatches(t *testing.T) {
+	if !etagMatches(`"x", W/"abc"`, `W/"abc"`) || !etagMatches(`"abc"`, `W/"abc"`) || !etagMatches("*", `W/"abc"`) {
+		t.Fatalf("expected match")
+	}
+	if etagMatches(`W/"abd"`, `W/"abc"`) {
+		t.Fatalf("unexpected match")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"hello-fiber/app/repository"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 var rolePermissionRepo repository.RolePermissionRepository
@@ -27,26 +28,41 @@ func normParam(raw string) string {
 
 // GetAllRolePermissionsService godoc
 // @Summary Dapatkan semua role_permission (Permission: user:manage)
-// @Description Mengambil daftar mapping role_id dan permission_id dengan pagination dan filter opsional
+// @Description Mengambil daftar mapping role_id dan permission_id dengan pagination dan filter opsional; role_id dan permission_id boleh dikombinasikan
 // @Tags RolePermissions
 // @Accept json
 // @Produce json
 // @Param page query int false "Halaman (default: 1)"
-// @Param limit query int false "Jumlah data per halaman (default: 10)"
+// @Param limit query int false "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)"
 // @Param role_id query string false "Filter role_id (UUID)"
 // @Param permission_id query string false "Filter permission_id (UUID)"
 // @Success 200 {object} map[string]interface{} "Data role_permission berhasil diambil"
+// @Failure 400 {object} model.ErrorResponse "Format role_id atau permission_id tidak valid"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/role-permissions [get]
 // @Security BearerAuth
 func GetAllRolePermissionsService(c *fiber.Ctx) error {
-	page := int64(c.QueryInt("page", 1))
-	limit := int64(c.QueryInt("limit", 10))
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
+	}
 	roleID := strings.TrimSpace(c.Query("role_id"))
 	permissionID := strings.TrimSpace(c.Query("permission_id"))
 
-	data, total, err := rolePermissionRepo.GetAllRolePermissions(page, limit, roleID, permissionID)
+	// validasi format di sini agar UUID rusak tidak sampai ke DB sebagai type error
+	if roleID != "" {
+		if _, err := uuid.Parse(roleID); err != nil {
+			return errorJSON(c, 400, "Format role_id tidak valid")
+		}
+	}
+	if permissionID != "" {
+		if _, err := uuid.Parse(permissionID); err != nil {
+			return errorJSON(c, 400, "Format permission_id tidak valid")
+		}
+	}
+
+	data, total, err := rolePermissionRepo.GetAllRolePermissions(c.UserContext(), page, limit, roleID, permissionID)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"success": false,
@@ -115,11 +131,13 @@ func GetAllRolePermissionsService(c *fiber.Ctx) error {
 
 // GetPermissionsByRoleIDService godoc
 // @Summary Dapatkan daftar permissions milik role (Permission: user:manage)
-// @Description Mengambil list permission yang dimiliki oleh role tertentu
+// @Description Mengambil list permission yang dimiliki oleh role tertentu dengan pagination (urut nama)
 // @Tags RolePermissions
 // @Accept json
 // @Produce json
 // @Param role_id path string true "Role ID (UUID)"
+// @Param page query int false "Halaman (default: 1)"
+// @Param limit query int false "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)"
 // @Success 200 {object} map[string]interface{} "Data permissions milik role berhasil diambil"
 // @Failure 400 {object} model.ErrorResponse "Validasi gagal"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
@@ -135,7 +153,12 @@ func GetPermissionsByRoleIDService(c *fiber.Ctx) error {
 		})
 	}
 
-	perms, err := rolePermissionRepo.GetPermissionsByRoleID(roleID)
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
+	}
+
+	perms, total, err := rolePermissionRepo.ListPermissionsByRoleID(c.UserContext(), roleID, page, limit)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"success": false,
@@ -143,11 +166,17 @@ func GetPermissionsByRoleIDService(c *fiber.Ctx) error {
 			"error":   err.Error(),
 		})
 	}
+	if perms == nil {
+		perms = []model.Permission{}
+	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "Data permissions milik role berhasil diambil",
 		"data":    perms,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
 	})
 }
 
@@ -183,7 +212,7 @@ func CreateRolePermissionService(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := rolePermissionRepo.CreateRolePermission(req.RoleID, req.PermissionID); err != nil {
+	if err := rolePermissionRepo.CreateRolePermission(c.UserContext(), req.RoleID, req.PermissionID); err != nil {
 		l := strings.ToLower(err.Error())
 		code := 500
 		if strings.Contains(l, "sudah ada") || strings.Contains(l, "tidak valid") {
@@ -195,6 +224,7 @@ func CreateRolePermissionService(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, model.AuditActionCreate, model.AuditEntityRolePermission, req.RoleID+":"+req.PermissionID)
 	return c.Status(201).JSON(fiber.Map{
 		"success": true,
 		"message": "role_permission berhasil dibuat",
@@ -211,7 +241,7 @@ func CreateRolePermissionService(c *fiber.Ctx) error {
 // @Param permission_id path string true "Permission ID lama (UUID)"
 // @Param body body model.UpdateRolePermissionRequest true "Data role_permission baru"
 // @Success 200 {object} model.SuccessResponse "role_permission berhasil diupdate"
-// @Failure 400 {object} model.ErrorResponse "Validasi gagal / data sudah ada / foreign key tidak valid"
+// @Failure 400 {object} model.ErrorResponse "Validasi gagal / data sudah ada / new_role_id atau new_permission_id tidak ditemukan"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 404 {object} model.ErrorResponse "role_permission tidak ditemukan"
 // @Failure 500 {object} model.ErrorResponse "Error server"
@@ -246,6 +276,38 @@ func UpdateRolePermissionService(c *fiber.Ctx) error {
 			"message": "Minimal salah satu dari new_role_id atau new_permission_id harus diisi",
 		})
 	}
+	if newRoleID != "" {
+		if _, err := uuid.Parse(newRoleID); err != nil {
+			return errorJSON(c, 400, "Format new_role_id tidak valid")
+		}
+	}
+	if newPermissionID != "" {
+		if _, err := uuid.Parse(newPermissionID); err != nil {
+			return errorJSON(c, 400, "Format new_permission_id tidak valid")
+		}
+	}
+	// cek eksplisit agar pesan 400 menyebut entitas yang tidak ada, bukan mengandalkan pesan foreign key
+	if newRoleID != "" {
+		role, err := roleRepo.GetRoleByID(c.UserContext(), newRoleID)
+		found, err := entityExists(role != nil, err)
+		if err != nil {
+			return errorWithDetail(c, 500, "Gagal cek new_role_id", err)
+		}
+		if !found {
+			return errorJSON(c, 400, "new_role_id tidak ditemukan")
+		}
+	}
+	if newPermissionID != "" {
+		perm, err := permissionRepo.GetPermissionByID(c.UserContext(), newPermissionID)
+		found, err := entityExists(perm != nil, err)
+		if err != nil {
+			return errorWithDetail(c, 500, "Gagal cek new_permission_id", err)
+		}
+		if !found {
+			return errorJSON(c, 400, "new_permission_id tidak ditemukan")
+		}
+	}
+
 	if newRoleID == "" {
 		newRoleID = oldRoleID
 	}
@@ -253,7 +315,7 @@ func UpdateRolePermissionService(c *fiber.Ctx) error {
 		newPermissionID = oldPermissionID
 	}
 
-	if err := rolePermissionRepo.UpdateRolePermission(oldRoleID, oldPermissionID, newRoleID, newPermissionID); err != nil {
+	if err := rolePermissionRepo.UpdateRolePermission(c.UserContext(), oldRoleID, oldPermissionID, newRoleID, newPermissionID); err != nil {
 		l := strings.ToLower(err.Error())
 		if strings.Contains(l, "tidak ditemukan") {
 			return c.Status(404).JSON(fiber.Map{
@@ -274,12 +336,24 @@ func UpdateRolePermissionService(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, model.AuditActionUpdate, model.AuditEntityRolePermission, newRoleID+":"+newPermissionID)
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "role_permission berhasil diupdate",
 	})
 }
 
+// entityExists membedakan hasil lookup "tidak ditemukan" (false, nil) dari error lain.
+func entityExists(found bool, err error) (bool, error) {
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return false, nil
+		}
+		return false, err
+	}
+	return found, nil
+}
+
 // DeleteRolePermissionService godoc
 // @Summary Hapus role_permission (Permission: user:manage)
 // @Description Menghapus mapping role_id dan permission_id
@@ -306,7 +380,7 @@ func DeleteRolePermissionService(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := rolePermissionRepo.DeleteRolePermission(roleID, permissionID); err != nil {
+	if err := rolePermissionRepo.DeleteRolePermission(c.UserContext(), roleID, permissionID); err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
 			return c.Status(404).JSON(fiber.Map{
 				"success": false,
@@ -320,6 +394,7 @@ func DeleteRolePermissionService(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, model.AuditActionDelete, model.AuditEntityRolePermission, roleID+":"+permissionID)
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "role_permission berhasil dihapus",
@@ -2,6 +2,7 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -14,45 +15,52 @@ import (
 )
 
 type mockRolePermissionRepo struct {
-	GetAllRolePermissionsFn func(page, limit int64, roleID, permissionID string) ([]model.RolePermission, int64, error)
-	GetRolePermissionFn     func(roleID, permissionID string) (*model.RolePermission, error)
+	GetAllRolePermissionsFn  func(page, limit int64, roleID, permissionID string) ([]model.RolePermission, int64, error)
+	GetRolePermissionFn      func(roleID, permissionID string) (*model.RolePermission, error)
 	GetPermissionsByRoleIDFn func(roleID string) ([]model.Permission, error)
-	CreateRolePermissionFn  func(roleID, permissionID string) error
-	UpdateRolePermissionFn  func(oldRoleID, oldPermissionID, newRoleID, newPermissionID string) error
-	DeleteRolePermissionFn  func(roleID, permissionID string) error
+	ListPermissionsByRoleFn  func(roleID string, page, limit int64) ([]model.Permission, int64, error)
+	CreateRolePermissionFn   func(roleID, permissionID string) error
+	UpdateRolePermissionFn   func(oldRoleID, oldPermissionID, newRoleID, newPermissionID string) error
+	DeleteRolePermissionFn   func(roleID, permissionID string) error
 }
 
-func (m *mockRolePermissionRepo) GetAllRolePermissions(page, limit int64, roleID, permissionID string) ([]model.RolePermission, int64, error) {
+func (m *mockRolePermissionRepo) GetAllRolePermissions(ctx context.Context, page, limit int64, roleID, permissionID string) ([]model.RolePermission, int64, error) {
 	if m.GetAllRolePermissionsFn != nil {
 		return m.GetAllRolePermissionsFn(page, limit, roleID, permissionID)
 	}
 	return nil, 0, nil
 }
-func (m *mockRolePermissionRepo) GetRolePermission(roleID, permissionID string) (*model.RolePermission, error) {
+func (m *mockRolePermissionRepo) GetRolePermission(ctx context.Context, roleID, permissionID string) (*model.RolePermission, error) {
 	if m.GetRolePermissionFn != nil {
 		return m.GetRolePermissionFn(roleID, permissionID)
 	}
 	return nil, nil
 }
-func (m *mockRolePermissionRepo) GetPermissionsByRoleID(roleID string) ([]model.Permission, error) {
+func (m *mockRolePermissionRepo) GetPermissionsByRoleID(ctx context.Context, roleID string) ([]model.Permission, error) {
 	if m.GetPermissionsByRoleIDFn != nil {
 		return m.GetPermissionsByRoleIDFn(roleID)
 	}
 	return nil, nil
 }
-func (m *mockRolePermissionRepo) CreateRolePermission(roleID, permissionID string) error {
+func (m *mockRolePermissionRepo) ListPermissionsByRoleID(ctx context.Context, roleID string, page, limit int64) ([]model.Permission, int64, error) {
+	if m.ListPermissionsByRoleFn != nil {
+		return m.ListPermissionsByRoleFn(roleID, page, limit)
+	}
+	return nil, 0, nil
+}
+func (m *mockRolePermissionRepo) CreateRolePermission(ctx context.Context, roleID, permissionID string) error {
 	if m.CreateRolePermissionFn != nil {
 		return m.CreateRolePermissionFn(roleID, permissionID)
 	}
 	return nil
 }
-func (m *mockRolePermissionRepo) UpdateRolePermission(oldRoleID, oldPermissionID, newRoleID, newPermissionID string) error {
+func (m *mockRolePermissionRepo) UpdateRolePermission(ctx context.Context, oldRoleID, oldPermissionID, newRoleID, newPermissionID string) error {
 	if m.UpdateRolePermissionFn != nil {
 		return m.UpdateRolePermissionFn(oldRoleID, oldPermissionID, newRoleID, newPermissionID)
 	}
 	return nil
 }
-func (m *mockRolePermissionRepo) DeleteRolePermission(roleID, permissionID string) error {
+func (m *mockRolePermissionRepo) DeleteRolePermission(ctx context.Context, roleID, permissionID string) error {
 	if m.DeleteRolePermissionFn != nil {
 		return m.DeleteRolePermissionFn(roleID, permissionID)
 	}
@@ -77,23 +85,55 @@ func decodeMapRolePermission(t *testing.T, resp *http.Response) map[string]any {
 	return out
 }
 
+const (
+	testRoleUUID       = "3f2b6a1e-8c4d-4e7a-9b1f-2a6c5d8e9f01"
+	testPermissionUUID = "7d1e4c2b-5a6f-4b8e-8c3d-1f2e3a4b5c6d"
+)
+
+func TestGetAllRolePermissionsService_MalformedRoleID(t *testing.T) {
+	rolePermissionRepo = &mockRolePermissionRepo{
+		GetAllRolePermissionsFn: func(page, limit int64, roleID, permissionID string) ([]model.RolePermission, int64, error) {
+			t.Fatalf("repo must not be called with malformed role_id")
+			return nil, 0, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/role-permissions", GetAllRolePermissionsService)
+
+	req := httptest.NewRequest(http.MethodGet, "/role-permissions?role_id=bukan-uuid&permission_id="+testPermissionUUID, nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	body := decodeMapRolePermission(t, resp)
+	if body["message"] != "Format role_id tidak valid" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
 func TestGetAllRolePermissionsService_Success(t *testing.T) {
 	rolePermissionRepo = &mockRolePermissionRepo{
 		GetAllRolePermissionsFn: func(page, limit int64, roleID, permissionID string) ([]model.RolePermission, int64, error) {
 			if page != 2 || limit != 5 {
 				t.Fatalf("expected page=2 limit=5, got page=%d limit=%d", page, limit)
 			}
-			if roleID != "r1" || permissionID != "p1" {
+			if roleID != testRoleUUID || permissionID != testPermissionUUID {
 				t.Fatalf("unexpected filters: roleID=%q permissionID=%q", roleID, permissionID)
 			}
-			return []model.RolePermission{{RoleID: "r1", PermissionID: "p1"}}, 1, nil
+			return []model.RolePermission{{RoleID: roleID, PermissionID: permissionID}}, 1, nil
 		},
 	}
 
 	app := fiber.New()
 	app.Get("/role-permissions", GetAllRolePermissionsService)
 
-	req := httptest.NewRequest(http.MethodGet, "/role-permissions?page=2&limit=5&role_id=r1&permission_id=p1", nil)
+	req := httptest.NewRequest(http.MethodGet, "/role-permissions?page=2&limit=5&role_id="+testRoleUUID+"&permission_id="+testPermissionUUID, nil)
 	resp, err := app.Test(req)
 	if err != nil {
 		t.Fatalf("app.Test: %v", err)
@@ -303,13 +343,13 @@ func TestGetPermissionsByRoleIDService_EmptyRoleID(t *testing.T) {
 
 func TestGetPermissionsByRoleIDService_Success(t *testing.T) {
 	rolePermissionRepo = &mockRolePermissionRepo{
-		GetPermissionsByRoleIDFn: func(roleID string) ([]model.Permission, error) {
+		ListPermissionsByRoleFn: func(roleID string, page, limit int64) ([]model.Permission, int64, error) {
 			if roleID != "r1" {
 				t.Fatalf("expected roleID=r1 got %q", roleID)
 			}
 			return []model.Permission{
 				{ID: "p1", Name: "achievement:read", Resource: "achievement", Action: "read"},
-			}, nil
+			}, 1, nil
 		},
 	}
 
@@ -332,6 +372,40 @@ func TestGetPermissionsByRoleIDService_Success(t *testing.T) {
 	}
 }
 
+func TestGetPermissionsByRoleIDService_DefaultPaginationAndEmptyArray(t *testing.T) {
+	var gotPage, gotLimit int64
+	rolePermissionRepo = &mockRolePermissionRepo{
+		ListPermissionsByRoleFn: func(roleID string, page, limit int64) ([]model.Permission, int64, error) {
+			gotPage, gotLimit = page, limit
+			return nil, 0, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/roles/:role_id/permissions", GetPermissionsByRoleIDService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/roles/r1/permissions", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if gotPage != 1 || gotLimit != 10 {
+		t.Fatalf("expected default page=1 limit=10, got page=%d limit=%d", gotPage, gotLimit)
+	}
+	body := decodeMapRolePermission(t, resp)
+	data, ok := body["data"].([]any)
+	if !ok || len(data) != 0 {
+		t.Fatalf("expected empty array, got %#v", body["data"])
+	}
+	if body["total"] != float64(0) || body["page"] != float64(1) || body["limit"] != float64(10) {
+		t.Fatalf("unexpected envelope: %#v", body)
+	}
+}
+
 func TestCreateRolePermissionService_InvalidBody(t *testing.T) {
 	rolePermissionRepo = &mockRolePermissionRepo{}
 
@@ -518,12 +592,24 @@ func TestUpdateRolePermissionService_NoFields(t *testing.T) {
 }
 
 func TestUpdateRolePermissionService_Success_OnlyNewRoleID(t *testing.T) {
+	const newRoleUUID = "6f1c2a8e-3b4d-4c5e-9f60-7a8b9c0d1e2f"
+	roleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id}, nil
+		},
+	}
+	permissionRepo = &mockPermissionRepo{
+		GetPermissionByIDFn: func(id string) (*model.Permission, error) {
+			t.Fatal("new_permission_id kosong tidak perlu dicek")
+			return nil, nil
+		},
+	}
 	rolePermissionRepo = &mockRolePermissionRepo{
 		UpdateRolePermissionFn: func(oldRoleID, oldPermissionID, newRoleID, newPermissionID string) error {
 			if oldRoleID != "r1" || oldPermissionID != "p1" {
 				t.Fatalf("unexpected old ids: %s %s", oldRoleID, oldPermissionID)
 			}
-			if newRoleID != "r2" || newPermissionID != "p1" { // new_permission_id kosong -> pakai yg lama
+			if newRoleID != newRoleUUID || newPermissionID != "p1" { // new_permission_id kosong -> pakai yg lama
 				t.Fatalf("unexpected new ids: %s %s", newRoleID, newPermissionID)
 			}
 			return nil
@@ -534,7 +620,7 @@ func TestUpdateRolePermissionService_Success_OnlyNewRoleID(t *testing.T) {
 	app.Put("/role-permissions/:role_id/:permission_id", UpdateRolePermissionService)
 
 	req := httptest.NewRequest(http.MethodPut, "/role-permissions/r1/p1", toJSONReaderRolePermission(t, map[string]any{
-		"new_role_id": "r2",
+		"new_role_id": newRoleUUID,
 	}))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -554,6 +640,11 @@ func TestUpdateRolePermissionService_Success_OnlyNewRoleID(t *testing.T) {
 }
 
 func TestUpdateRolePermissionService_NotFound(t *testing.T) {
+	permissionRepo = &mockPermissionRepo{
+		GetPermissionByIDFn: func(id string) (*model.Permission, error) {
+			return &model.Permission{ID: id}, nil
+		},
+	}
 	rolePermissionRepo = &mockRolePermissionRepo{
 		UpdateRolePermissionFn: func(oldRoleID, oldPermissionID, newRoleID, newPermissionID string) error {
 			return errors.New("role_permission tidak ditemukan")
@@ -564,7 +655,7 @@ func TestUpdateRolePermissionService_NotFound(t *testing.T) {
 	app.Put("/role-permissions/:role_id/:permission_id", UpdateRolePermissionService)
 
 	req := httptest.NewRequest(http.MethodPut, "/role-permissions/r1/p1", toJSONReaderRolePermission(t, map[string]any{
-		"new_permission_id": "p2",
+		"new_permission_id": "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d",
 	}))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -583,6 +674,57 @@ func TestUpdateRolePermissionService_NotFound(t *testing.T) {
 	}
 }
 
+func TestUpdateRolePermissionService_UnknownTargets(t *testing.T) {
+	cases := []struct {
+		name    string
+		payload map[string]any
+		message string
+	}{
+		{"unknown new_role_id", map[string]any{"new_role_id": "00000000-0000-4000-8000-000000000404"}, "new_role_id tidak ditemukan"},
+		{"unknown new_permission_id", map[string]any{"new_permission_id": "00000000-0000-4000-8000-000000000405"}, "new_permission_id tidak ditemukan"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			roleRepo = &mockRoleRepo{
+				GetRoleByIDFn: func(id string) (*model.Role, error) {
+					return nil, errors.New("role tidak ditemukan")
+				},
+			}
+			permissionRepo = &mockPermissionRepo{
+				GetPermissionByIDFn: func(id string) (*model.Permission, error) {
+					return nil, errors.New("permission tidak ditemukan")
+				},
+			}
+			rolePermissionRepo = &mockRolePermissionRepo{
+				UpdateRolePermissionFn: func(oldRoleID, oldPermissionID, newRoleID, newPermissionID string) error {
+					t.Fatal("UpdateRolePermission must not be called for an unknown target")
+					return nil
+				},
+			}
+
+			app := fiber.New()
+			app.Put("/role-permissions/:role_id/:permission_id", UpdateRolePermissionService)
+
+			req := httptest.NewRequest(http.MethodPut, "/role-permissions/r1/p1", toJSONReaderRolePermission(t, tc.payload))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d", resp.StatusCode)
+			}
+			body := decodeMapRolePermission(t, resp)
+			if body["message"] != tc.message {
+				t.Fatalf("unexpected message: %#v", body["message"])
+			}
+		})
+	}
+}
+
 func TestDeleteRolePermissionService_Success(t *testing.T) {
 	rolePermissionRepo = &mockRolePermissionRepo{
 		DeleteRolePermissionFn: func(roleID, permissionID string) error {
@@ -611,3 +753,51 @@ func TestDeleteRolePermissionService_Success(t *testing.T) {
 		t.Fatalf("unexpected message: %#v", body["message"])
 	}
 }
+
+func TestUpdateRolePermissionService_MalformedTargetIDs(t *testing.T) {
+	cases := []struct {
+		name    string
+		payload map[string]any
+		message string
+	}{
+		{"malformed new_role_id", map[string]any{"new_role_id": "bukan-uuid"}, "Format new_role_id tidak valid"},
+		{"malformed new_permission_id", map[string]any{"new_permission_id": "bukan-uuid"}, "Format new_permission_id tidak valid"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			roleRepo = &mockRoleRepo{
+				GetRoleByIDFn: func(id string) (*model.Role, error) {
+					t.Fatal("GetRoleByID must not be called for a malformed id")
+					return nil, nil
+				},
+			}
+			permissionRepo = &mockPermissionRepo{
+				GetPermissionByIDFn: func(id string) (*model.Permission, error) {
+					t.Fatal("GetPermissionByID must not be called for a malformed id")
+					return nil, nil
+				},
+			}
+			rolePermissionRepo = &mockRolePermissionRepo{}
+
+			app := fiber.New()
+			app.Put("/role-permissions/:role_id/:permission_id", UpdateRolePermissionService)
+
+			req := httptest.NewRequest(http.MethodPut, "/role-permissions/r1/p1", toJSONReaderRolePermission(t, tc.payload))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d", resp.StatusCode)
+			}
+			body := decodeMapRolePermission(t, resp)
+			if body["message"] != tc.message {
+				t.Fatalf("unexpected message: %#v", body["message"])
+			}
+		})
+	}
+}
@@ -1,45 +1,57 @@
 package service
 
 import (
+	"fmt"
 	"strings"
 	"hello-fiber/app/repository"
 	"hello-fiber/app/model"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"database/sql"
 	"net/url"
 )
 
 var roleRepo repository.RoleRepository
 
+// minRoleSearchLen panjang minimal keyword q pada GetAllRolesService.
+const minRoleSearchLen = 2
+
 func InitRepoService(db *sql.DB) {
     roleRepo = repository.NewRoleRepositoryPostgres(db)
 }
 
 // GetAllRolesService godoc
 // @Summary Dapatkan semua role (Permission: user:manage)
-// @Description Mengambil daftar semua role dengan pagination
+// @Description Mengambil daftar semua role dengan pagination. Parameter q (min 2 karakter) mencari pada nama/deskripsi role.
 // @Tags Roles
 // @Accept json
 // @Produce json
+// @Param q query string false "Kata kunci nama/deskripsi role (min 2 karakter)"
 // @Param page query int false "Halaman (default: 1)"
-// @Param limit query int false "Jumlah data per halaman (default: 10)"
+// @Param limit query int false "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)"
 // @Success 200 {object} model.RoleListResponse "Role list berhasil diambil"
+// @Failure 400 {object} model.ErrorResponse "Parameter page/limit/q tidak valid"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/roles [get]
 // @Security BearerAuth
 func GetAllRolesService(c *fiber.Ctx) error {
-	page := int64(1)
-	limit := int64(10)
-
-	if p := c.Query("page"); p != "" {
-		page = int64(c.QueryInt("page", 1))
-	}
-	if l := c.Query("limit"); l != "" {
-		limit = int64(c.QueryInt("limit", 10))
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
 	}
 
-	roles, total, err := roleRepo.GetAllRoles(page, limit)
+	q := strings.TrimSpace(c.Query("q"))
+	var roles []model.Role
+	var total int64
+	switch {
+	case q == "":
+		roles, total, err = roleRepo.GetAllRoles(c.UserContext(), page, limit)
+	case len([]rune(q)) < minRoleSearchLen:
+		return errorJSON(c, 400, fmt.Sprintf("Parameter q minimal %d karakter", minRoleSearchLen))
+	default:
+		roles, total, err = roleRepo.SearchRoles(c.UserContext(), q, page, limit)
+	}
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"success": false,
@@ -70,12 +82,14 @@ func GetAllRolesService(c *fiber.Ctx) error {
 
 // GetRoleByIDService godoc
 // @Summary Dapatkan detail role (Permission: user:manage)
-// @Description Mengambil detail role berdasarkan Role ID
+// @Description Mengambil detail role berdasarkan Role ID. Mendukung ETag/If-None-Match (304 jika tidak berubah).
 // @Tags Roles
 // @Accept json
 // @Produce json
 // @Param id path string true "Role ID (UUID)"
+// @Param If-None-Match header string false "ETag dari response sebelumnya"
 // @Success 200 {object} model.RoleDetailResponse "Data role berhasil diambil"
+// @Success 304 "Data tidak berubah"
 // @Failure 400 {object} model.ErrorResponse "Validasi gagal"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 404 {object} model.ErrorResponse "Role tidak ditemukan"
@@ -95,7 +109,7 @@ func GetRoleByIDService(c *fiber.Ctx) error {
         })
     }
 
-    role, err := roleRepo.GetRoleByID(id)
+    role, err := roleRepo.GetRoleByID(c.UserContext(), id)
     if err != nil {
         if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
             return c.Status(404).JSON(fiber.Map{
@@ -117,18 +131,56 @@ func GetRoleByIDService(c *fiber.Ctx) error {
         })
     }
 
-    return c.JSON(fiber.Map{
-        "success": true,
-        "message": "Data role berhasil diambil",
-        "data": model.Role{
-            ID:          role.ID,
-            Name:        role.Name,
-            Description: role.Description,
-            CreatedAt:   role.CreatedAt,
-        },
+    return successJSONWithETag(c, "Data role berhasil diambil", model.Role{
+        ID:          role.ID,
+        Name:        role.Name,
+        Description: role.Description,
+        CreatedAt:   role.CreatedAt,
     })
 }
 
+// GetRoleUserCountService godoc
+// @Summary Jumlah user pemegang role (Permission: user:manage)
+// @Description Untuk dashboard admin; hanya menghitung tanpa mengambil daftar user.
+// @Tags Roles
+// @Produce json
+// @Param id path string true "Role ID (UUID)"
+// @Success 200 {object} model.RoleUserCount
+// @Failure 400 {object} model.ErrorResponse "Role ID kosong atau bukan UUID"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 404 {object} model.ErrorResponse "Role tidak ditemukan"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/roles/{id}/user-count [get]
+// @Security BearerAuth
+func GetRoleUserCountService(c *fiber.Ctx) error {
+	id, _ := url.PathUnescape(c.Params("id"))
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return errorJSON(c, fiber.StatusBadRequest, "Role ID harus diisi")
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return errorJSON(c, fiber.StatusBadRequest, "Format Role ID tidak valid")
+	}
+
+	role, err := roleRepo.GetRoleByID(c.UserContext(), id)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return errorJSON(c, fiber.StatusNotFound, "Role tidak ditemukan")
+		}
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data role", err)
+	}
+	if role == nil {
+		return errorJSON(c, fiber.StatusNotFound, "Role tidak ditemukan")
+	}
+
+	count, _, err := roleRepo.CountRoleUsage(c.UserContext(), role.ID)
+	if err != nil {
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal menghitung user role", err)
+	}
+
+	return successJSON(c, fiber.StatusOK, "Jumlah user role berhasil diambil", model.RoleUserCount{RoleID: role.ID, Count: count})
+}
+
 // GetRoleByNameService godoc
 // @Summary Dapatkan detail role by name (Permission: user:manage)
 // @Description Contoh: /roles/byname?name=Staff
@@ -193,15 +245,21 @@ func CreateRoleService(c *fiber.Ctx) error {
 			"message": "Nama role harus diisi",
 		})
 	}
+	if descriptionTooLong(req.Description) {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "description terlalu panjang",
+		})
+	}
 
-	if existing, err := roleRepo.GetRoleByName(req.Name); err == nil && existing != nil {
+	if existing, err := roleRepo.GetRoleByName(c.UserContext(), req.Name); err == nil && existing != nil {
 		return c.Status(400).JSON(fiber.Map{
 			"success": false,
 			"message": "Role dengan nama tersebut sudah ada",
 		})
 	}
 
-	id, err := roleRepo.CreateRole(req)
+	id, err := roleRepo.CreateRole(c.UserContext(), req)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"success": false,
@@ -210,6 +268,7 @@ func CreateRoleService(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, model.AuditActionCreate, model.AuditEntityRole, id)
 	return c.Status(201).JSON(fiber.Map{
 		"success": true,
 		"message": "Role berhasil dibuat",
@@ -257,8 +316,14 @@ func UpdateRoleService(c *fiber.Ctx) error {
 			"message": "Minimal ada satu field yang harus diupdate",
 		})
 	}
+	if descriptionTooLong(strings.TrimSpace(req.Description)) {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "description terlalu panjang",
+		})
+	}
 
-	if err := roleRepo.UpdateRole(roleID, req); err != nil {
+	if err := roleRepo.UpdateRole(c.UserContext(), roleID, req); err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
 			return c.Status(404).JSON(fiber.Map{
 				"success": false,
@@ -272,6 +337,7 @@ func UpdateRoleService(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, model.AuditActionUpdate, model.AuditEntityRole, roleID)
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "Role berhasil diupdate",
@@ -280,15 +346,17 @@ func UpdateRoleService(c *fiber.Ctx) error {
 
 // DeleteRoleService godoc
 // @Summary Hapus role (Permission: user:manage)
-// @Description Memerlukan permission user:manage untuk menghapus role berdasarkan ID
+// @Description Memerlukan permission user:manage untuk menghapus role berdasarkan ID. Role yang masih dipakai user/role_permissions ditolak kecuali force=true. Role admin tidak dapat dihapus.
 // @Tags Roles
 // @Accept json
 // @Produce json
 // @Param id path string true "Role ID (UUID)"
+// @Param force query bool false "Hapus mapping role_permissions dan lepas role dari user lebih dulu"
 // @Success 200 {object} model.SuccessResponse "Role berhasil dihapus"
 // @Failure 400 {object} model.ErrorResponse "Role ID tidak valid"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 404 {object} model.ErrorResponse "Role tidak ditemukan"
+// @Failure 409 {object} model.ErrorResponse "Role sedang digunakan / role admin tidak dapat dihapus"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/roles/{id} [delete]
 // @Security BearerAuth
@@ -301,7 +369,44 @@ func DeleteRoleService(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := roleRepo.DeleteRole(roleID); err != nil {
+	// role admin tidak boleh dihapus (termasuk force): force melepas role dari semua admin sekaligus
+	// sehingga tidak ada lagi yang bisa mengakses fungsi admin
+	role, err := roleRepo.GetRoleByID(c.UserContext(), roleID)
+	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+		return c.Status(500).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal mengambil data role",
+			"error":   err.Error(),
+		})
+	}
+	if role != nil && strings.EqualFold(strings.TrimSpace(role.Name), "admin") {
+		return c.Status(409).JSON(fiber.Map{
+			"success": false,
+			"message": "Role admin tidak dapat dihapus",
+		})
+	}
+
+	force := c.QueryBool("force", false)
+	if !force {
+		users, mappings, err := roleRepo.CountRoleUsage(c.UserContext(), roleID)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"success": false,
+				"message": "Gagal cek pemakaian role",
+				"error":   err.Error(),
+			})
+		}
+		if users > 0 || mappings > 0 {
+			return c.Status(409).JSON(fiber.Map{
+				"success":          false,
+				"message":          "role sedang digunakan",
+				"users":            users,
+				"role_permissions": mappings,
+			})
+		}
+	}
+
+	if err := roleRepo.DeleteRole(c.UserContext(), roleID, force); err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
 			return c.Status(404).JSON(fiber.Map{
 				"success": false,
@@ -315,6 +420,7 @@ func DeleteRoleService(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, model.AuditActionDelete, model.AuditEntityRole, roleID)
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "Role berhasil dihapus",
@@ -2,70 +2,91 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"hello-fiber/app/model"
+	"hello-fiber/middleware"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type mockRoleRepo struct {
 	GetAllRolesFn  func(page, limit int64) ([]model.Role, int64, error)
+	SearchRolesFn  func(q string, page, limit int64) ([]model.Role, int64, error)
 	GetRoleByIDFn  func(id string) (*model.Role, error)
 	GetRoleByNameFn func(name string) (*model.Role, error)
 
 	CreateRoleFn func(req model.CreateRoleRequest) (string, error)
 	UpdateRoleFn func(id string, req model.UpdateRoleRequest) error
-	DeleteRoleFn func(id string) error
+	DeleteRoleFn func(id string, force bool) error
+
+	CountRoleUsageFn func(id string) (int64, int64, error)
 }
 
-func (m *mockRoleRepo) GetAllRoles(page, limit int64) ([]model.Role, int64, error) {
+func (m *mockRoleRepo) GetAllRoles(ctx context.Context, page, limit int64) ([]model.Role, int64, error) {
 	if m.GetAllRolesFn != nil {
 		return m.GetAllRolesFn(page, limit)
 	}
 	return nil, 0, nil
 }
 
-func (m *mockRoleRepo) GetRoleByID(id string) (*model.Role, error) {
+func (m *mockRoleRepo) SearchRoles(ctx context.Context, q string, page, limit int64) ([]model.Role, int64, error) {
+	if m.SearchRolesFn != nil {
+		return m.SearchRolesFn(q, page, limit)
+	}
+	return nil, 0, nil
+}
+
+func (m *mockRoleRepo) GetRoleByID(ctx context.Context, id string) (*model.Role, error) {
 	if m.GetRoleByIDFn != nil {
 		return m.GetRoleByIDFn(id)
 	}
 	return nil, nil
 }
 
-func (m *mockRoleRepo) GetRoleByName(name string) (*model.Role, error) {
+func (m *mockRoleRepo) GetRoleByName(ctx context.Context, name string) (*model.Role, error) {
 	if m.GetRoleByNameFn != nil {
 		return m.GetRoleByNameFn(name)
 	}
 	return nil, nil
 }
 
-func (m *mockRoleRepo) CreateRole(req model.CreateRoleRequest) (string, error) {
+func (m *mockRoleRepo) CreateRole(ctx context.Context, req model.CreateRoleRequest) (string, error) {
 	if m.CreateRoleFn != nil {
 		return m.CreateRoleFn(req)
 	}
 	return "role-id-1", nil
 }
 
-func (m *mockRoleRepo) UpdateRole(id string, req model.UpdateRoleRequest) error {
+func (m *mockRoleRepo) UpdateRole(ctx context.Context, id string, req model.UpdateRoleRequest) error {
 	if m.UpdateRoleFn != nil {
 		return m.UpdateRoleFn(id, req)
 	}
 	return nil
 }
 
-func (m *mockRoleRepo) DeleteRole(id string) error {
+func (m *mockRoleRepo) DeleteRole(ctx context.Context, id string, force bool) error {
 	if m.DeleteRoleFn != nil {
-		return m.DeleteRoleFn(id)
+		return m.DeleteRoleFn(id, force)
 	}
 	return nil
 }
 
+func (m *mockRoleRepo) CountRoleUsage(ctx context.Context, id string) (int64, int64, error) {
+	if m.CountRoleUsageFn != nil {
+		return m.CountRoleUsageFn(id)
+	}
+	return 0, 0, nil
+}
+
 func jsonBodyRole(t *testing.T, v any) *bytes.Reader {
 	t.Helper()
 	b, err := json.Marshal(v)
@@ -123,6 +144,49 @@ func TestGetAllRolesService_Success(t *testing.T) {
 	}
 }
 
+func TestGetAllRolesService_SearchMinLength(t *testing.T) {
+	var searched []string
+	roleRepo = &mockRoleRepo{
+		GetAllRolesFn: func(page, limit int64) ([]model.Role, int64, error) {
+			t.Fatal("GetAllRoles must not be called when q is set")
+			return nil, 0, nil
+		},
+		SearchRolesFn: func(q string, page, limit int64) ([]model.Role, int64, error) {
+			searched = append(searched, q)
+			return []model.Role{{ID: "r1", Name: "Dosen Wali"}}, 1, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/roles", GetAllRolesService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/roles?q=%20d%20", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for 1-char q, got %d", resp.StatusCode)
+	}
+	if len(searched) != 0 {
+		t.Fatalf("repository must not be searched for short q: %v", searched)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/roles?q=%20wali%20&page=1&limit=5", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if len(searched) != 1 || searched[0] != "wali" {
+		t.Fatalf("expected trimmed search for wali, got %v", searched)
+	}
+	body := decodeMapRole(t, resp)
+	if body["total"].(float64) != 1 || body["limit"].(float64) != 5 {
+		t.Fatalf("unexpected envelope: %#v", body)
+	}
+}
+
 func TestGetAllRolesService_Error(t *testing.T) {
 	roleRepo = &mockRoleRepo{
 		GetAllRolesFn: func(page, limit int64) ([]model.Role, int64, error) {
@@ -198,6 +262,120 @@ func TestGetRoleByIDService_NotFound(t *testing.T) {
 	}
 }
 
+func TestGetAllRolesService_RepoDeadlineReturns503(t *testing.T) {
+	roleRepo = &mockRoleRepo{
+		GetAllRolesFn: func(page, limit int64) ([]model.Role, int64, error) {
+			time.Sleep(50 * time.Millisecond)
+			return nil, 0, fmt.Errorf("gagal query roles: %w", context.DeadlineExceeded)
+		},
+	}
+
+	app := fiber.New()
+	app.Use(middleware.Timeout(20 * time.Millisecond))
+	app.Get("/roles", GetAllRolesService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/roles", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", resp.StatusCode)
+	}
+	body := decodeMapRole(t, resp)
+	if body["message"] != "request timeout" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
+func TestGetRoleUserCountService_NotFound(t *testing.T) {
+	roleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return nil, errors.New("role tidak ditemukan")
+		},
+		CountRoleUsageFn: func(id string) (int64, int64, error) {
+			t.Fatal("must not count users of a missing role")
+			return 0, 0, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/roles/:id/user-count", GetRoleUserCountService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/roles/00000000-0000-4000-8000-000000000404/user-count", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", resp.StatusCode)
+	}
+	body := decodeMapRole(t, resp)
+	if body["message"] != "Role tidak ditemukan" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
+func TestGetRoleUserCountService_ReturnsCount(t *testing.T) {
+	const roleID = "3d2c1b0a-9f8e-4d7c-8b6a-5f4e3d2c1b0a"
+	roleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Mahasiswa"}, nil
+		},
+		CountRoleUsageFn: func(id string) (int64, int64, error) {
+			if id != roleID {
+				t.Fatalf("unexpected role id: %s", id)
+			}
+			return 12, 3, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/roles/:id/user-count", GetRoleUserCountService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/roles/"+roleID+"/user-count", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	data, _ := decodeMapRole(t, resp)["data"].(map[string]any)
+	if data["role_id"] != roleID || data["count"] != float64(12) {
+		t.Fatalf("unexpected data: %#v", data)
+	}
+}
+
+func TestGetRoleUserCountService_MalformedID(t *testing.T) {
+	roleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			t.Fatal("GetRoleByID must not be called for a malformed id")
+			return nil, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/roles/:id/user-count", GetRoleUserCountService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/roles/bukan-uuid/user-count", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	body := decodeMapRole(t, resp)
+	if body["message"] != "Format Role ID tidak valid" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
 // func TestGetRoleByNameService_EmptyName(t *testing.T) {
 // 	roleRepo = &mockRoleRepo{}
 
@@ -315,7 +493,7 @@ func TestUpdateRoleService_NotFound(t *testing.T) {
 
 func TestDeleteRoleService_Success(t *testing.T) {
 	roleRepo = &mockRoleRepo{
-		DeleteRoleFn: func(id string) error { return nil },
+		DeleteRoleFn: func(id string, force bool) error { return nil },
 	}
 
 	app := fiber.New()
@@ -336,3 +514,152 @@ func TestDeleteRoleService_Success(t *testing.T) {
 		t.Fatalf("unexpected message: %#v", body["message"])
 	}
 }
+
+func TestDeleteRoleService_InUseBlocked(t *testing.T) {
+	roleRepo = &mockRoleRepo{
+		CountRoleUsageFn: func(id string) (int64, int64, error) {
+			if id != "r1" {
+				t.Fatalf("unexpected role id: %s", id)
+			}
+			return 3, 2, nil
+		},
+		DeleteRoleFn: func(id string, force bool) error {
+			t.Fatalf("DeleteRole must not be called for role in use")
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Delete("/roles/:id", DeleteRoleService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/roles/r1", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", resp.StatusCode)
+	}
+	body := decodeMapRole(t, resp)
+	if body["message"] != "role sedang digunakan" || body["users"] != float64(3) || body["role_permissions"] != float64(2) {
+		t.Fatalf("unexpected body: %#v", body)
+	}
+}
+
+func TestDeleteRoleService_ForceSkipsUsageCheck(t *testing.T) {
+	var gotForce bool
+	roleRepo = &mockRoleRepo{
+		CountRoleUsageFn: func(id string) (int64, int64, error) {
+			t.Fatalf("usage check must be skipped with force=true")
+			return 0, 0, nil
+		},
+		DeleteRoleFn: func(id string, force bool) error {
+			gotForce = force
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Delete("/roles/:id", DeleteRoleService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/roles/r1?force=true", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || !gotForce {
+		t.Fatalf("expected forced delete, status=%d force=%v", resp.StatusCode, gotForce)
+	}
+}
+
+func TestDeleteRoleService_ForceAdminRoleBlocked(t *testing.T) {
+	roleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
+		},
+		DeleteRoleFn: func(id string, force bool) error {
+			t.Fatal("admin role must not be deleted")
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Delete("/roles/:id", DeleteRoleService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/roles/r-admin?force=true", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", resp.StatusCode)
+	}
+	body := decodeMapRole(t, resp)
+	if body["message"] != "Role admin tidak dapat dihapus" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
+func TestCreateRoleService_DescriptionTooLong(t *testing.T) {
+	roleRepo = &mockRoleRepo{
+		CreateRoleFn: func(req model.CreateRoleRequest) (string, error) {
+			t.Fatalf("CreateRole must not be called for an over-length description")
+			return "", nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/roles", CreateRoleService)
+
+	req := httptest.NewRequest(http.MethodPost, "/roles", jsonBodyRole(t, model.CreateRoleRequest{
+		Name:        "Staff",
+		Description: strings.Repeat("a", defaultMaxDescriptionLength+1),
+	}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	if body := decodeMapRole(t, resp); body["message"] != "description terlalu panjang" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
+func TestUpdateRoleService_DescriptionTooLong(t *testing.T) {
+	roleRepo = &mockRoleRepo{
+		UpdateRoleFn: func(id string, req model.UpdateRoleRequest) error {
+			t.Fatalf("UpdateRole must not be called for an over-length description")
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/roles/:id", UpdateRoleService)
+
+	req := httptest.NewRequest(http.MethodPut, "/roles/r1", jsonBodyRole(t, model.UpdateRoleRequest{
+		Description: strings.Repeat("a", defaultMaxDescriptionLength+1),
+	}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	if body := decodeMapRole(t, resp); body["message"] != "description terlalu panjang" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"database/sql"
+	"strings"
+
+	"hello-fiber/app/model"
+	"hello-fiber/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var searchRepo repository.SearchRepository
+
+// maxSearchLimit batas item per section agar satu query tidak menarik seluruh tabel.
+const maxSearchLimit = 50
+
+func InitSearchService(db *sql.DB) {
+	searchRepo = repository.NewSearchRepositoryPostgres(db)
+}
+
+// GlobalSearchService godoc
+// @Summary Pencarian global users, students, dan lecturers (Admin)
+// @Description Mencari users (username/email/nama), students (NIM/nama), dan lecturers (NIP/department/nama). Page dan limit berlaku per section (limit maks 50).
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param q query string true "Kata kunci pencarian"
+// @Param page query int false "Halaman per section (default: 1)"
+// @Param limit query int false "Jumlah data per section (default: 5, maks: 50)"
+// @Success 200 {object} model.SearchResult
+// @Failure 400 {object} model.ErrorResponse "Kata kunci kosong"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/search [get]
+// @Security BearerAuth
+func GlobalSearchService(c *fiber.Ctx) error {
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "Parameter q harus diisi",
+		})
+	}
+
+	page, err := parseIntQuery(c, "page", 1)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	limit, err := parseIntQuery(c, "limit", 5)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if limit < 1 {
+		limit = 5
+	}
+	page, limit = clampPagination(page, limit)
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	users, usersTotal, err := searchRepo.SearchUsers(q, page, limit)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal mencari users", "error": err.Error()})
+	}
+	students, studentsTotal, err := searchRepo.SearchStudents(q, page, limit)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal mencari students", "error": err.Error()})
+	}
+	lecturers, lecturersTotal, err := searchRepo.SearchLecturers(q, page, limit)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal mencari lecturers", "error": err.Error()})
+	}
+
+	result := model.SearchResult{
+		Query: q,
+		Page:  page,
+		Limit: limit,
+		Users: model.UserSearchSection{
+			Items: []model.UserResponse{},
+			Total: usersTotal,
+		},
+		Students: model.StudentSearchSection{
+			Items: students,
+			Total: studentsTotal,
+		},
+		Lecturers: model.LecturerSearchSection{
+			Items: lecturers,
+			Total: lecturersTotal,
+		},
+	}
+	for i := range users {
+		result.Users.Items = append(result.Users.Items, *toUserResponse(&users[i]))
+	}
+	if result.Students.Items == nil {
+		result.Students.Items = []model.StudentSearchItem{}
+	}
+	if result.Lecturers.Items == nil {
+		result.Lecturers.Items = []model.LecturerSearchItem{}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Hasil pencarian berhasil diambil",
+		"data":    result,
+	})
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hello-fiber/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type mockSearchRepo struct {
+	SearchUsersFn     func(q string, page, limit int64) ([]model.User, int64, error)
+	SearchStudentsFn  func(q string, page, limit int64) ([]model.StudentSearchItem, int64, error)
+	SearchLecturersFn func(q string, page, limit int64) ([]model.LecturerSearchItem, int64, error)
+}
+
+func (m *mockSearchRepo) SearchUsers(q string, page, limit int64) ([]model.User, int64, error) {
+	if m.SearchUsersFn != nil {
+		return m.SearchUsersFn(q, page, limit)
+	}
+	return nil, 0, nil
+}
+
+func (m *mockSearchRepo) SearchStudents(q string, page, limit int64) ([]model.StudentSearchItem, int64, error) {
+	if m.SearchStudentsFn != nil {
+		return m.SearchStudentsFn(q, page, limit)
+	}
+	return nil, 0, nil
+}
+
+func (m *mockSearchRepo) SearchLecturers(q string, page, limit int64) ([]model.LecturerSearchItem, int64, error) {
+	if m.SearchLecturersFn != nil {
+		return m.SearchLecturersFn(q, page, limit)
+	}
+	return nil, 0, nil
+}
+
+func TestGlobalSearchService_Sections(t *testing.T) {
+	searchRepo = &mockSearchRepo{
+		SearchUsersFn: func(q string, page, limit int64) ([]model.User, int64, error) {
+			if q != "budi" {
+				t.Fatalf("unexpected q: %q", q)
+			}
+			if limit != maxSearchLimit {
+				t.Fatalf("limit not capped: %d", limit)
+			}
+			return []model.User{{ID: "u1", Username: "budi"}}, 1, nil
+		},
+		SearchStudentsFn: func(q string, page, limit int64) ([]model.StudentSearchItem, int64, error) {
+			item := model.StudentSearchItem{FullName: "Budi Santoso"}
+			item.StudentID = "2101001"
+			return []model.StudentSearchItem{item}, 1, nil
+		},
+		SearchLecturersFn: func(q string, page, limit int64) ([]model.LecturerSearchItem, int64, error) {
+			return nil, 0, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/search", GlobalSearchService)
+
+	req := httptest.NewRequest(http.MethodGet, "/search?q=budi&limit=500", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data model.SearchResult `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Data.Users.Items) != 1 || body.Data.Users.Items[0].ID != "u1" {
+		t.Fatalf("unexpected users section: %#v", body.Data.Users)
+	}
+	if len(body.Data.Students.Items) != 1 || body.Data.Students.Items[0].StudentID != "2101001" || body.Data.Students.Items[0].FullName != "Budi Santoso" {
+		t.Fatalf("unexpected students section: %#v", body.Data.Students)
+	}
+	if len(body.Data.Lecturers.Items) != 0 || body.Data.Lecturers.Total != 0 {
+		t.Fatalf("unexpected lecturers section: %#v", body.Data.Lecturers)
+	}
+}
+
+func TestGlobalSearchService_EmptyQuery(t *testing.T) {
+	searchRepo = &mockSearchRepo{}
+
+	app := fiber.New()
+	app.Get("/search", GlobalSearchService)
+
+	req := httptest.NewRequest(http.MethodGet, "/search?q=%20", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
@@ -1,7 +1,14 @@
 package service
 
 import (
+	"context"
 	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"strings"
 
 	"hello-fiber/app/model"
@@ -12,9 +19,103 @@ import (
 )
 
 var studentRepo repository.StudentRepository
+var studentAdvisorRepo repository.StudentAdvisorRepository
+var advisorHistoryRepo repository.AdvisorHistoryRepository
+var onboardingRepo repository.OnboardingRepository
+var studentLecturerRepo repository.LecturerRepository
 
 func InitStudentService(db *sql.DB) {
 	studentRepo = repository.NewStudentRepositoryPostgres(db)
+	studentAdvisorRepo = repository.NewStudentAdvisorRepositoryPostgres(db)
+	advisorHistoryRepo = repository.NewAdvisorHistoryRepositoryPostgres(db)
+	onboardingRepo = repository.NewOnboardingRepositoryPostgres(db)
+	studentLecturerRepo = repository.NewLecturerRepositoryPostgres(db)
+}
+
+var errInvalidAdvisor = errors.New("advisor_id bukan lecturer valid")
+
+// checkAdvisorLecturer memastikan advisor_id menunjuk ke baris di tabel lecturers.
+// nil / uuid.Nil lolos karena berarti advisor tidak diisi atau dikosongkan.
+func checkAdvisorLecturer(ctx context.Context, advisorID *uuid.UUID) error {
+	if advisorID == nil || *advisorID == uuid.Nil || studentLecturerRepo == nil {
+		return nil
+	}
+	lecturer, err := studentLecturerRepo.GetLecturerByID(ctx, advisorID.String())
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return errInvalidAdvisor
+		}
+		return err
+	}
+	if lecturer == nil {
+		return errInvalidAdvisor
+	}
+	return nil
+}
+
+// advisorCheckResponse menulis response untuk error dari checkAdvisorLecturer.
+func advisorCheckResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errInvalidAdvisor) {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+	return c.Status(500).JSON(fiber.Map{
+		"success": false,
+		"message": "Gagal memvalidasi advisor",
+		"error":   err.Error(),
+	})
+}
+
+func sameAdvisor(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// recordAdvisorChange mencatat riwayat advisor. Perubahan advisor sudah tersimpan,
+// jadi kegagalan pencatatan hanya di-log.
+func recordAdvisorChange(c *fiber.Ctx, studentID uuid.UUID, changeType string, prev, next *uuid.UUID) {
+	entry := model.AdvisorHistory{
+		StudentID:         studentID,
+		PreviousAdvisorID: prev,
+		NewAdvisorID:      next,
+		ChangeType:        changeType,
+	}
+	if userID, ok := c.Locals("user_id").(string); ok {
+		if uid, err := uuid.Parse(userID); err == nil {
+			entry.ChangedBy = &uid
+		}
+	}
+	if err := advisorHistoryRepo.RecordChange(entry); err != nil {
+		log.Printf("[WARNING] gagal mencatat riwayat advisor student %s: %v", studentID, err)
+	}
+}
+
+// canViewStudentAdvisors: admin, mahasiswa pemilik, atau dosen wali yang membimbing student.
+func canViewStudentAdvisors(c *fiber.Ctx, studentID uuid.UUID) (bool, error) {
+	roleName, err := resolveRoleName(c)
+	if err != nil {
+		return false, err
+	}
+	switch roleName {
+	case "admin":
+		return true, nil
+	case "mahasiswa", "dosen wali":
+		_, studentFilter, advisorFilter, err := allowedStatusesByRole(c, roleName, true)
+		if err != nil {
+			return false, err
+		}
+		if studentFilter != nil {
+			return *studentFilter == studentID, nil
+		}
+		if advisorFilter != nil {
+			return studentAdvisorRepo.IsAdvisor(studentID.String(), advisorFilter.String())
+		}
+	}
+	return false, nil
 }
 
 func toStudentResponse(s *model.Student) *model.StudentResponse {
@@ -39,17 +140,20 @@ func toStudentResponse(s *model.Student) *model.StudentResponse {
 // @Accept json
 // @Produce json
 // @Param page query int false "Halaman (default: 1)"
-// @Param limit query int false "Jumlah data per halaman (default: 10)"
+// @Param limit query int false "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)"
 // @Success 200 {object} map[string]interface{} "Data student berhasil diambil"
+// @Failure 400 {object} model.ErrorResponse "Parameter page/limit tidak valid"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/students [get]
 // @Security BearerAuth
 func GetAllStudentsService(c *fiber.Ctx) error {
-	page := int64(c.QueryInt("page", 1))
-	limit := int64(c.QueryInt("limit", 10))
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
+	}
 
-	data, total, err := studentRepo.GetAllStudents(page, limit)
+	data, total, err := studentRepo.GetAllStudents(c.UserContext(), page, limit)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"success": false,
@@ -102,7 +206,7 @@ func GetStudentByIDService(c *fiber.Ctx) error {
 		})
 	}
 
-	st, err := studentRepo.GetStudentByID(id)
+	st, err := studentRepo.GetStudentByID(c.UserContext(), id)
 	if err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
 			return c.Status(404).JSON(fiber.Map{
@@ -124,6 +228,47 @@ func GetStudentByIDService(c *fiber.Ctx) error {
 	})
 }
 
+// GetMyStudentService godoc
+// @Summary Dapatkan data student milik user yang login (mahasiswa)
+// @Description Resolve record students dari user_id di token, tanpa perlu tahu UUID internal
+// @Tags Students
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Data student berhasil diambil"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 403 {object} model.ErrorResponse "Bukan mahasiswa"
+// @Failure 404 {object} model.ErrorResponse "Student tidak ditemukan"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/students/me [get]
+// @Security BearerAuth
+func GetMyStudentService(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || strings.TrimSpace(userID) == "" {
+		return errorJSON(c, fiber.StatusUnauthorized, "Unauthorized")
+	}
+	roleName, err := resolveRoleName(c)
+	if err != nil || roleName != "mahasiswa" {
+		return errorJSON(c, fiber.StatusForbidden, "Hanya mahasiswa yang dapat mengakses")
+	}
+
+	st, err := studentRepo.GetStudentByUserID(c.UserContext(), userID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return errorJSON(c, fiber.StatusNotFound, "Student tidak ditemukan")
+		}
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data student", err)
+	}
+	if st == nil {
+		return errorJSON(c, fiber.StatusNotFound, "Student tidak ditemukan")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Data student berhasil diambil",
+		"data":    toStudentResponse(st),
+	})
+}
+
 // CreateStudentService godoc
 // @Summary Buat students (Permission: user:manage)
 // @Description Membuat data students baru
@@ -132,8 +277,9 @@ func GetStudentByIDService(c *fiber.Ctx) error {
 // @Produce json
 // @Param body body model.CreateStudentRequest true "Data student"
 // @Success 201 {object} model.SuccessResponse "Student berhasil dibuat"
-// @Failure 400 {object} model.ErrorResponse "Validasi gagal"
+// @Failure 400 {object} model.ErrorResponse "Request body tidak valid / advisor_id bukan lecturer valid / user sudah terdaftar sebagai student"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 422 {object} model.ErrorResponse "user_id dan student_id harus diisi"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/students [post]
 // @Security BearerAuth
@@ -152,16 +298,31 @@ func CreateStudentService(c *fiber.Ctx) error {
 	req.AcademicYear = strings.TrimSpace(req.AcademicYear)
 
 	if req.UserID == uuid.Nil || req.StudentID == "" {
-		return c.Status(400).JSON(fiber.Map{
+		return validationErrorJSON(c, "user_id dan student_id harus diisi")
+	}
+
+	if err := ensureNotStudent(c.UserContext(), req.UserID.String()); err != nil {
+		if errors.Is(err, errAlreadyStudent) {
+			return c.Status(400).JSON(fiber.Map{
+				"success": false,
+				"message": err.Error(),
+			})
+		}
+		return c.Status(500).JSON(fiber.Map{
 			"success": false,
-			"message": "user_id dan student_id harus diisi",
+			"message": "Gagal membuat student",
+			"error":   err.Error(),
 		})
 	}
 
-	id, err := studentRepo.CreateStudent(req)
+	if err := checkAdvisorLecturer(c.UserContext(), req.AdvisorID); err != nil {
+		return advisorCheckResponse(c, err)
+	}
+
+	id, err := studentRepo.CreateStudent(c.UserContext(), req)
 	if err != nil {
 		l := strings.ToLower(err.Error())
-		if strings.Contains(l, "sudah digunakan") || strings.Contains(l, "tidak valid") {
+		if strings.Contains(l, "sudah digunakan") || strings.Contains(l, "sudah terdaftar") || strings.Contains(l, "tidak valid") {
 			return c.Status(400).JSON(fiber.Map{
 				"success": false,
 				"message": err.Error(),
@@ -181,16 +342,295 @@ func CreateStudentService(c *fiber.Ctx) error {
 	})
 }
 
+// OnboardStudentService godoc
+// @Summary Onboarding student: buat user + student sekaligus (Permission: user:manage)
+// @Description Membuat user (role mahasiswa) dan data students dalam satu transaksi. Jika salah satu gagal, tidak ada data yang tersimpan.
+// @Tags Students
+// @Accept json
+// @Produce json
+// @Param body body model.OnboardStudentRequest true "Data user dan student"
+// @Success 201 {object} map[string]interface{} "Onboarding student berhasil"
+// @Failure 400 {object} model.ErrorResponse "Validasi gagal / data sudah terdaftar"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/onboarding/student [post]
+// @Security BearerAuth
+func OnboardStudentService(c *fiber.Ctx) error {
+	var req model.OnboardStudentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "Request body tidak valid",
+			"error":   err.Error(),
+		})
+	}
+
+	req.Username = strings.TrimSpace(req.Username)
+	req.Email = strings.TrimSpace(req.Email)
+	req.FullName = strings.TrimSpace(req.FullName)
+	req.StudentID = strings.TrimSpace(req.StudentID)
+
+	// validasi semua field di depan agar transaksi tidak dibuka untuk data yang pasti ditolak
+	var message string
+	switch {
+	case req.Username == "" || req.Email == "" || req.Password == "" || req.FullName == "" || req.StudentID == "":
+		message = "Username, email, password, full_name, dan student_id harus diisi"
+	case !isValidUsername(req.Username):
+		message = "Username harus 3-50 karakter, hanya alphanumeric dan underscore"
+	case !isValidEmail(req.Email):
+		message = "Format email tidak valid"
+	case !isValidPassword(req.Password):
+		message = "Password minimal 5 karakter dengan uppercase, lowercase, dan number"
+	}
+	if message != "" {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": message,
+		})
+	}
+
+	result, err := onboardingRepo.OnboardStudent(req)
+	if err != nil {
+		l := strings.ToLower(err.Error())
+		if strings.Contains(l, "sudah terdaftar") || strings.Contains(l, "sudah digunakan") || strings.Contains(l, "tidak valid") {
+			return c.Status(400).JSON(fiber.Map{
+				"success": false,
+				"message": err.Error(),
+			})
+		}
+		return c.Status(500).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal onboarding student",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"message": "Onboarding student berhasil",
+		"data":    result,
+	})
+}
+
+// maxStudentImportRows batas baris data per file import agar satu request tidak berjalan terlalu lama.
+const maxStudentImportRows = 1000
+
+// studentImportColumns kolom CSV yang dikenali; user_id dan student_id wajib ada di header.
+var studentImportColumns = []string{"user_id", "student_id", "program_study", "academic_year", "advisor_id"}
+
+// parseStudentImportRow memvalidasi satu baris CSV menjadi CreateStudentRequest.
+func parseStudentImportRow(record []string, index map[string]int) (model.CreateStudentRequest, error) {
+	field := func(name string) string {
+		if i, ok := index[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var req model.CreateStudentRequest
+	uid, err := uuid.Parse(field("user_id"))
+	if err != nil {
+		return req, errors.New("user_id tidak valid")
+	}
+	req.UserID = uid
+	req.StudentID = field("student_id")
+	if req.StudentID == "" {
+		return req, errors.New("student_id harus diisi")
+	}
+	req.ProgramStudy = field("program_study")
+	req.AcademicYear = field("academic_year")
+	if raw := field("advisor_id"); raw != "" {
+		aid, err := uuid.Parse(raw)
+		if err != nil {
+			return req, errors.New("advisor_id tidak valid")
+		}
+		req.AdvisorID = &aid
+	}
+	return req, nil
+}
+
+// checkStudentImportTarget memastikan user ada dan belum punya data student.
+func checkStudentImportTarget(ctx context.Context, userID string) error {
+	if _, err := userRepo.GetUserByID(ctx, userID); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return errors.New("user tidak ditemukan")
+		}
+		return err
+	}
+	return ensureNotStudent(ctx, userID)
+}
+
+var errAlreadyStudent = errors.New("user sudah terdaftar sebagai student")
+
+// ensureNotStudent mengembalikan errAlreadyStudent jika user sudah punya data student, agar
+// GetStudentByUserID tidak ambigu (satu user satu student).
+func ensureNotStudent(ctx context.Context, userID string) error {
+	existing, err := studentRepo.GetStudentByUserID(ctx, userID)
+	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+		return err
+	}
+	if existing != nil {
+		return errAlreadyStudent
+	}
+	return nil
+}
+
+// ImportStudentsService godoc
+// @Summary Import students dari CSV (Permission: user:manage)
+// @Description Upload CSV dengan header user_id,student_id[,program_study,academic_year,advisor_id]. Setiap baris divalidasi (format, duplikat di file, user ada, user belum jadi student, advisor_id adalah lecturer yang ada). Dengan dry_run=true tidak ada data yang ditulis; hasil per baris berstatus would_create.
+// @Tags Students
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "File CSV"
+// @Param dry_run query bool false "Validasi saja tanpa menyimpan (default false)"
+// @Success 200 {object} model.StudentImportSummary "Ringkasan import per baris"
+// @Failure 400 {object} model.ErrorResponse "File/header CSV tidak valid"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Router /v1/students/import [post]
+// @Security BearerAuth
+func ImportStudentsService(c *fiber.Ctx) error {
+	dryRun := c.QueryBool("dry_run", false)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "File CSV harus diupload pada field 'file'",
+		})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "File CSV tidak dapat dibaca",
+			"error":   err.Error(),
+		})
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "Header CSV tidak valid",
+		})
+	}
+	index := make(map[string]int)
+	for i, col := range header {
+		index[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+	for _, required := range studentImportColumns[:2] {
+		if _, ok := index[required]; !ok {
+			return c.Status(400).JSON(fiber.Map{
+				"success": false,
+				"message": fmt.Sprintf("Header CSV harus memuat kolom %s", required),
+			})
+		}
+	}
+
+	summary := model.StudentImportSummary{DryRun: dryRun, Rows: []model.StudentImportRowResult{}}
+	seenStudentIDs := make(map[string]int)
+	seenUserIDs := make(map[uuid.UUID]int)
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if summary.Total >= maxStudentImportRows {
+			return c.Status(400).JSON(fiber.Map{
+				"success": false,
+				"message": fmt.Sprintf("Maksimal %d baris per import", maxStudentImportRows),
+			})
+		}
+		summary.Total++
+
+		result := model.StudentImportRowResult{Row: line}
+		fail := func(msg string) {
+			result.Status = model.StudentImportFailed
+			result.Error = msg
+			summary.Failed++
+			summary.Rows = append(summary.Rows, result)
+		}
+		if err != nil {
+			fail("baris CSV tidak valid")
+			continue
+		}
+
+		req, err := parseStudentImportRow(record, index)
+		result.StudentID = req.StudentID
+		if err != nil {
+			fail(err.Error())
+			continue
+		}
+		if prev, dup := seenStudentIDs[req.StudentID]; dup {
+			fail(fmt.Sprintf("student_id duplikat dengan baris %d", prev))
+			continue
+		}
+		if prev, dup := seenUserIDs[req.UserID]; dup {
+			fail(fmt.Sprintf("user_id duplikat dengan baris %d", prev))
+			continue
+		}
+		seenStudentIDs[req.StudentID] = line
+		seenUserIDs[req.UserID] = line
+
+		if err := checkStudentImportTarget(c.UserContext(), req.UserID.String()); err != nil {
+			fail(err.Error())
+			continue
+		}
+		if err := checkAdvisorLecturer(c.UserContext(), req.AdvisorID); err != nil {
+			if errors.Is(err, errInvalidAdvisor) {
+				fail(err.Error())
+			} else {
+				fail("gagal validasi advisor_id")
+			}
+			continue
+		}
+
+		// dry run: semua validasi sudah jalan, penulisan dilewati
+		if dryRun {
+			result.Status = model.StudentImportWouldCreate
+			summary.WouldCreate++
+			summary.Rows = append(summary.Rows, result)
+			continue
+		}
+
+		id, err := studentRepo.CreateStudent(c.UserContext(), req)
+		if err != nil {
+			fail(err.Error())
+			continue
+		}
+		result.Status = model.StudentImportCreated
+		result.ID = id
+		summary.Created++
+		summary.Rows = append(summary.Rows, result)
+	}
+
+	message := "Import student selesai"
+	if dryRun {
+		message = "Dry run import student selesai, tidak ada data yang disimpan"
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": message,
+		"data":    summary,
+	})
+}
+
 // UpdateStudentService godoc
 // @Summary Update students (Permission: user:manage)
-// @Description Update students by id (partial update). Untuk hapus advisor_id, kirim advisor_id = "00000000-0000-0000-0000-000000000000"
+// @Description Update students by id (partial update). Untuk set/hapus advisor gunakan PUT /v1/students/{id}/advisor; advisor_id = "00000000-0000-0000-0000-000000000000" masih didukung untuk kompatibilitas.
 // @Tags Students
 // @Accept json
 // @Produce json
 // @Param id path string true "Student ID (UUID)"
 // @Param body body model.UpdateStudentRequest true "Field yang ingin diupdate"
 // @Success 200 {object} model.SuccessResponse "Student berhasil diupdate"
-// @Failure 400 {object} model.ErrorResponse "Validasi gagal"
+// @Failure 400 {object} model.ErrorResponse "Validasi gagal / advisor_id bukan lecturer valid"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 404 {object} model.ErrorResponse "Student tidak ditemukan"
 // @Failure 500 {object} model.ErrorResponse "Error server"
@@ -227,7 +667,32 @@ func UpdateStudentService(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := studentRepo.UpdateStudent(id, req); err != nil {
+	if err := checkAdvisorLecturer(c.UserContext(), req.AdvisorID); err != nil {
+		return advisorCheckResponse(c, err)
+	}
+
+	var prevAdvisor *uuid.UUID
+	if req.AdvisorID != nil {
+		current, err := studentRepo.GetStudentByID(c.UserContext(), id)
+		if err != nil {
+			if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+				return c.Status(404).JSON(fiber.Map{
+					"success": false,
+					"message": "Student tidak ditemukan",
+				})
+			}
+			return c.Status(500).JSON(fiber.Map{
+				"success": false,
+				"message": "Gagal mengambil data student",
+				"error":   err.Error(),
+			})
+		}
+		if current != nil {
+			prevAdvisor = current.AdvisorID
+		}
+	}
+
+	if err := studentRepo.UpdateStudent(c.UserContext(), id, req); err != nil {
 		l := strings.ToLower(err.Error())
 		if strings.Contains(l, "tidak ditemukan") {
 			return c.Status(404).JSON(fiber.Map{
@@ -251,12 +716,130 @@ func UpdateStudentService(c *fiber.Ctx) error {
 		})
 	}
 
+	if req.AdvisorID != nil {
+		var nextAdvisor *uuid.UUID
+		if *req.AdvisorID != uuid.Nil {
+			nextAdvisor = req.AdvisorID
+		}
+		if !sameAdvisor(prevAdvisor, nextAdvisor) {
+			recordAdvisorChange(c, uuid.MustParse(id), model.AdvisorChangePrimary, prevAdvisor, nextAdvisor)
+		}
+	}
+
 	return c.JSON(model.SuccessResponse{
 		Success: true,
 		Message: "Student berhasil diupdate",
 	})
 }
 
+// SetStudentAdvisorService godoc
+// @Summary Set atau hapus dosen wali utama student (Permission: user:manage)
+// @Description Mengisi advisor_id dengan lecturer yang valid, atau mengosongkannya dengan advisor_id = null. Field advisor_id wajib ada di body.
+// @Tags Students
+// @Accept json
+// @Produce json
+// @Param id path string true "Student ID (UUID)"
+// @Param body body model.SetStudentAdvisorRequest true "advisor_id (uuid lecturer atau null)"
+// @Success 200 {object} model.SuccessResponse "Advisor student berhasil diupdate / dihapus"
+// @Failure 400 {object} model.ErrorResponse "Validasi gagal / advisor_id bukan lecturer valid"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 404 {object} model.ErrorResponse "Student tidak ditemukan"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/students/{id}/advisor [put]
+// @Security BearerAuth
+func SetStudentAdvisorService(c *fiber.Ctx) error {
+	id := normParam(c.Params("id"))
+	if _, err := uuid.Parse(id); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "Format Student ID tidak valid",
+		})
+	}
+
+	// advisor_id wajib dikirim eksplisit agar body kosong tidak diam-diam menghapus advisor.
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(c.Body(), &raw); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "Request body tidak valid",
+			"error":   err.Error(),
+		})
+	}
+	if _, ok := raw["advisor_id"]; !ok {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "advisor_id harus diisi (uuid lecturer atau null)",
+		})
+	}
+	var req model.SetStudentAdvisorRequest
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "advisor_id tidak valid",
+		})
+	}
+	if req.AdvisorID != nil && *req.AdvisorID == uuid.Nil {
+		req.AdvisorID = nil
+	}
+
+	if err := checkAdvisorLecturer(c.UserContext(), req.AdvisorID); err != nil {
+		return advisorCheckResponse(c, err)
+	}
+
+	current, err := studentRepo.GetStudentByID(c.UserContext(), id)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return c.Status(404).JSON(fiber.Map{
+				"success": false,
+				"message": "Student tidak ditemukan",
+			})
+		}
+		return c.Status(500).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal mengambil data student",
+			"error":   err.Error(),
+		})
+	}
+	var prevAdvisor *uuid.UUID
+	if current != nil {
+		prevAdvisor = current.AdvisorID
+	}
+
+	if err := studentRepo.SetAdvisor(c.UserContext(), id, req.AdvisorID); err != nil {
+		l := strings.ToLower(err.Error())
+		if strings.Contains(l, "tidak ditemukan") {
+			return c.Status(404).JSON(fiber.Map{
+				"success": false,
+				"message": "Student tidak ditemukan",
+			})
+		}
+		if strings.Contains(l, "tidak valid") {
+			return c.Status(400).JSON(fiber.Map{
+				"success": false,
+				"message": err.Error(),
+			})
+		}
+		return c.Status(500).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal mengupdate advisor student",
+			"error":   err.Error(),
+		})
+	}
+
+	if !sameAdvisor(prevAdvisor, req.AdvisorID) {
+		recordAdvisorChange(c, uuid.MustParse(id), model.AdvisorChangePrimary, prevAdvisor, req.AdvisorID)
+	}
+
+	message := "Advisor student berhasil diupdate"
+	if req.AdvisorID == nil {
+		message = "Advisor student berhasil dihapus"
+	}
+	return c.JSON(model.SuccessResponse{
+		Success: true,
+		Message: message,
+	})
+}
+
 // DeleteStudentService godoc
 // @Summary Hapus students (Permission: user:manage)
 // @Description Menghapus students by id
@@ -286,7 +869,7 @@ func DeleteStudentService(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := studentRepo.DeleteStudent(id); err != nil {
+	if err := studentRepo.DeleteStudent(c.UserContext(), id); err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
 			return c.Status(404).JSON(fiber.Map{
 				"success": false,
@@ -305,3 +888,226 @@ func DeleteStudentService(c *fiber.Ctx) error {
 		Message: "Student berhasil dihapus",
 	})
 }
+
+// GetStudentAdvisorsService godoc
+// @Summary Daftar advisor student (Permission: user:manage)
+// @Description Mengembalikan advisor utama (advisor_id) dan co-advisor student
+// @Tags Students
+// @Accept json
+// @Produce json
+// @Param id path string true "Student ID (UUID)"
+// @Success 200 {object} map[string]interface{} "Data advisor berhasil diambil"
+// @Failure 400 {object} model.ErrorResponse "Validasi gagal"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/students/{id}/advisors [get]
+// @Security BearerAuth
+func GetStudentAdvisorsService(c *fiber.Ctx) error {
+	id := normParam(c.Params("id"))
+	if _, err := uuid.Parse(id); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "Format Student ID tidak valid",
+		})
+	}
+
+	data, err := studentAdvisorRepo.ListAdvisors(id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal mengambil data advisor",
+			"error":   err.Error(),
+		})
+	}
+
+	resp := []model.LecturerResponse{}
+	for i := range data {
+		resp = append(resp, *toLecturerResponse(&data[i]))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Data advisor berhasil diambil",
+		"data":    resp,
+	})
+}
+
+// AddStudentAdvisorService godoc
+// @Summary Tambah co-advisor student (Permission: user:manage)
+// @Description Menambahkan lecturer sebagai advisor tambahan; advisor_id utama tidak berubah
+// @Tags Students
+// @Accept json
+// @Produce json
+// @Param id path string true "Student ID (UUID)"
+// @Param body body model.AddStudentAdvisorRequest true "Lecturer yang ditambahkan"
+// @Success 201 {object} model.SuccessResponse "Advisor berhasil ditambahkan"
+// @Failure 400 {object} model.ErrorResponse "Validasi gagal"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 404 {object} model.ErrorResponse "Student atau lecturer tidak ditemukan"
+// @Failure 409 {object} model.ErrorResponse "Advisor sudah terdaftar"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/students/{id}/advisors [post]
+// @Security BearerAuth
+func AddStudentAdvisorService(c *fiber.Ctx) error {
+	id := normParam(c.Params("id"))
+	if _, err := uuid.Parse(id); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "Format Student ID tidak valid",
+		})
+	}
+
+	var req model.AddStudentAdvisorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "Request body tidak valid",
+			"error":   err.Error(),
+		})
+	}
+	if req.LecturerID == uuid.Nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "lecturer_id harus diisi",
+		})
+	}
+
+	if err := studentAdvisorRepo.AddAdvisor(id, req.LecturerID.String()); err != nil {
+		l := strings.ToLower(err.Error())
+		if strings.Contains(l, "tidak ditemukan") {
+			return c.Status(404).JSON(fiber.Map{
+				"success": false,
+				"message": err.Error(),
+			})
+		}
+		if strings.Contains(l, "sudah terdaftar") {
+			return c.Status(409).JSON(fiber.Map{
+				"success": false,
+				"message": err.Error(),
+			})
+		}
+		return c.Status(500).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal menambah advisor",
+			"error":   err.Error(),
+		})
+	}
+
+	recordAdvisorChange(c, uuid.MustParse(id), model.AdvisorChangeCoAdvisorAdded, nil, &req.LecturerID)
+
+	return c.Status(201).JSON(model.SuccessResponse{
+		Success: true,
+		Message: "Advisor berhasil ditambahkan",
+	})
+}
+
+// RemoveStudentAdvisorService godoc
+// @Summary Hapus co-advisor student (Permission: user:manage)
+// @Description Menghapus lecturer dari daftar advisor tambahan student
+// @Tags Students
+// @Accept json
+// @Produce json
+// @Param id path string true "Student ID (UUID)"
+// @Param lecturer_id path string true "Lecturer ID (UUID)"
+// @Success 200 {object} model.SuccessResponse "Advisor berhasil dihapus"
+// @Failure 400 {object} model.ErrorResponse "Validasi gagal"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 404 {object} model.ErrorResponse "Advisor tidak ditemukan"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/students/{id}/advisors/{lecturer_id} [delete]
+// @Security BearerAuth
+func RemoveStudentAdvisorService(c *fiber.Ctx) error {
+	id := normParam(c.Params("id"))
+	lecturerID := normParam(c.Params("lecturer_id"))
+	if _, err := uuid.Parse(id); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "Format Student ID tidak valid",
+		})
+	}
+	if _, err := uuid.Parse(lecturerID); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "Format Lecturer ID tidak valid",
+		})
+	}
+
+	if err := studentAdvisorRepo.RemoveAdvisor(id, lecturerID); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return c.Status(404).JSON(fiber.Map{
+				"success": false,
+				"message": err.Error(),
+			})
+		}
+		return c.Status(500).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal menghapus advisor",
+			"error":   err.Error(),
+		})
+	}
+
+	removed := uuid.MustParse(lecturerID)
+	recordAdvisorChange(c, uuid.MustParse(id), model.AdvisorChangeCoAdvisorRemoved, &removed, nil)
+
+	return c.JSON(model.SuccessResponse{
+		Success: true,
+		Message: "Advisor berhasil dihapus",
+	})
+}
+
+// GetStudentAdvisorHistoryService godoc
+// @Summary Riwayat advisor student (Admin, mahasiswa pemilik, dosen wali)
+// @Description Mengembalikan riwayat perubahan advisor utama dan co-advisor student, terbaru lebih dulu
+// @Tags Students
+// @Accept json
+// @Produce json
+// @Param id path string true "Student ID (UUID)"
+// @Success 200 {object} map[string]interface{} "Riwayat advisor berhasil diambil"
+// @Failure 400 {object} model.ErrorResponse "Validasi gagal"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 403 {object} model.ErrorResponse "Tidak berhak melihat riwayat advisor"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/students/{id}/advisor-history [get]
+// @Security BearerAuth
+func GetStudentAdvisorHistoryService(c *fiber.Ctx) error {
+	id := normParam(c.Params("id"))
+	studentID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"message": "Format Student ID tidak valid",
+		})
+	}
+
+	allowed, err := canViewStudentAdvisors(c, studentID)
+	if err != nil {
+		return c.Status(403).JSON(fiber.Map{
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{
+			"success": false,
+			"message": "Tidak berhak melihat riwayat advisor student ini",
+		})
+	}
+
+	data, err := advisorHistoryRepo.ListByStudent(id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal mengambil riwayat advisor",
+			"error":   err.Error(),
+		})
+	}
+	if data == nil {
+		data = []model.AdvisorHistory{}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Riwayat advisor berhasil diambil",
+		"data":    data,
+	})
+}
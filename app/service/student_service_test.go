@@ -2,8 +2,10 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -16,56 +18,72 @@ import (
 )
 
 type mockStudentRepoStd struct {
-	GetAllStudentsFn     func(page, limit int64) ([]model.Student, int64, error)
-	GetStudentByIDFn     func(id string) (*model.Student, error)
-	GetStudentByUserIDFn func(userID string) (*model.Student, error)
-	CreateStudentFn      func(req model.CreateStudentRequest) (string, error)
-	UpdateStudentFn      func(id string, req model.UpdateStudentRequest) error
-	DeleteStudentFn      func(id string) error
+	GetAllStudentsFn      func(page, limit int64) ([]model.Student, int64, error)
+	GetStudentByIDFn      func(id string) (*model.Student, error)
+	GetStudentByUserIDFn  func(userID string) (*model.Student, error)
+	CreateStudentFn       func(req model.CreateStudentRequest) (string, error)
+	UpdateStudentFn       func(id string, req model.UpdateStudentRequest) error
+	DeleteStudentFn       func(id string) error
+	GetStudentSummariesFn func(ids []uuid.UUID) (map[uuid.UUID]model.StudentSummary, error)
+	SetAdvisorFn          func(id string, advisorID *uuid.UUID) error
 }
 
-func (m *mockStudentRepoStd) GetAllStudents(page, limit int64) ([]model.Student, int64, error) {
+func (m *mockStudentRepoStd) GetAllStudents(ctx context.Context, page, limit int64) ([]model.Student, int64, error) {
 	if m.GetAllStudentsFn != nil {
 		return m.GetAllStudentsFn(page, limit)
 	}
 	return nil, 0, nil
 }
 
-func (m *mockStudentRepoStd) GetStudentByID(id string) (*model.Student, error) {
+func (m *mockStudentRepoStd) GetStudentByID(ctx context.Context, id string) (*model.Student, error) {
 	if m.GetStudentByIDFn != nil {
 		return m.GetStudentByIDFn(id)
 	}
 	return nil, nil
 }
 
-func (m *mockStudentRepoStd) GetStudentByUserID(userID string) (*model.Student, error) {
+func (m *mockStudentRepoStd) GetStudentByUserID(ctx context.Context, userID string) (*model.Student, error) {
 	if m.GetStudentByUserIDFn != nil {
 		return m.GetStudentByUserIDFn(userID)
 	}
 	return nil, nil
 }
 
-func (m *mockStudentRepoStd) CreateStudent(req model.CreateStudentRequest) (string, error) {
+func (m *mockStudentRepoStd) CreateStudent(ctx context.Context, req model.CreateStudentRequest) (string, error) {
 	if m.CreateStudentFn != nil {
 		return m.CreateStudentFn(req)
 	}
 	return "", nil
 }
 
-func (m *mockStudentRepoStd) UpdateStudent(id string, req model.UpdateStudentRequest) error {
+func (m *mockStudentRepoStd) UpdateStudent(ctx context.Context, id string, req model.UpdateStudentRequest) error {
 	if m.UpdateStudentFn != nil {
 		return m.UpdateStudentFn(id, req)
 	}
 	return nil
 }
 
-func (m *mockStudentRepoStd) DeleteStudent(id string) error {
+func (m *mockStudentRepoStd) SetAdvisor(ctx context.Context, id string, advisorID *uuid.UUID) error {
+	if m.SetAdvisorFn != nil {
+		return m.SetAdvisorFn(id, advisorID)
+	}
+	return nil
+}
+
+func (m *mockStudentRepoStd) DeleteStudent(ctx context.Context, id string) error {
 	if m.DeleteStudentFn != nil {
 		return m.DeleteStudentFn(id)
 	}
 	return nil
 }
 
+func (m *mockStudentRepoStd) GetStudentSummaries(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]model.StudentSummary, error) {
+	if m.GetStudentSummariesFn != nil {
+		return m.GetStudentSummariesFn(ids)
+	}
+	return map[uuid.UUID]model.StudentSummary{}, nil
+}
+
 func jsonBodyStudent(t *testing.T, v any) *bytes.Reader {
 	t.Helper()
 	b, err := json.Marshal(v)
@@ -84,6 +102,60 @@ func decodeMapStudent(t *testing.T, resp *http.Response) map[string]any {
 	return out
 }
 
+type mockStudentAdvisorRepo struct {
+	AddAdvisorFn    func(studentID, lecturerID string) error
+	RemoveAdvisorFn func(studentID, lecturerID string) error
+	ListAdvisorsFn  func(studentID string) ([]model.Lecturer, error)
+	IsAdvisorFn     func(studentID, lecturerID string) (bool, error)
+}
+
+func (m *mockStudentAdvisorRepo) AddAdvisor(studentID, lecturerID string) error {
+	if m.AddAdvisorFn != nil {
+		return m.AddAdvisorFn(studentID, lecturerID)
+	}
+	return nil
+}
+
+func (m *mockStudentAdvisorRepo) RemoveAdvisor(studentID, lecturerID string) error {
+	if m.RemoveAdvisorFn != nil {
+		return m.RemoveAdvisorFn(studentID, lecturerID)
+	}
+	return nil
+}
+
+func (m *mockStudentAdvisorRepo) ListAdvisors(studentID string) ([]model.Lecturer, error) {
+	if m.ListAdvisorsFn != nil {
+		return m.ListAdvisorsFn(studentID)
+	}
+	return nil, nil
+}
+
+func (m *mockStudentAdvisorRepo) IsAdvisor(studentID, lecturerID string) (bool, error) {
+	if m.IsAdvisorFn != nil {
+		return m.IsAdvisorFn(studentID, lecturerID)
+	}
+	return false, nil
+}
+
+type mockAdvisorHistoryRepo struct {
+	RecordChangeFn  func(entry model.AdvisorHistory) error
+	ListByStudentFn func(studentID string) ([]model.AdvisorHistory, error)
+}
+
+func (m *mockAdvisorHistoryRepo) RecordChange(entry model.AdvisorHistory) error {
+	if m.RecordChangeFn != nil {
+		return m.RecordChangeFn(entry)
+	}
+	return nil
+}
+
+func (m *mockAdvisorHistoryRepo) ListByStudent(studentID string) ([]model.AdvisorHistory, error) {
+	if m.ListByStudentFn != nil {
+		return m.ListByStudentFn(studentID)
+	}
+	return nil, nil
+}
+
 func TestGetAllStudentsService_Success(t *testing.T) {
 	studentRepo = &mockStudentRepoStd{
 		GetAllStudentsFn: func(page, limit int64) ([]model.Student, int64, error) {
@@ -132,6 +204,17 @@ func TestGetStudentByIDService_InvalidUUID(t *testing.T) {
 	}
 }
 
+type mockOnboardingRepo struct {
+	OnboardStudentFn func(req model.OnboardStudentRequest) (*model.OnboardStudentResult, error)
+}
+
+func (m *mockOnboardingRepo) OnboardStudent(req model.OnboardStudentRequest) (*model.OnboardStudentResult, error) {
+	if m.OnboardStudentFn != nil {
+		return m.OnboardStudentFn(req)
+	}
+	return nil, nil
+}
+
 func TestCreateStudentService_Validation(t *testing.T) {
 	app := fiber.New()
 	app.Post("/students", CreateStudentService)
@@ -142,8 +225,8 @@ func TestCreateStudentService_Validation(t *testing.T) {
 	if err != nil {
 		t.Fatalf("app.Test: %v", err)
 	}
-	if resp.StatusCode != http.StatusBadRequest {
-		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusUnprocessableEntity)
 	}
 	body := decodeMapStudent(t, resp)
 	if body["message"] != "user_id dan student_id harus diisi" {
@@ -190,6 +273,43 @@ func TestCreateStudentService_Success(t *testing.T) {
 	}
 }
 
+func TestCreateStudentService_UserAlreadyStudent(t *testing.T) {
+	uid := uuid.New()
+	studentRepo = &mockStudentRepoStd{
+		GetStudentByUserIDFn: func(userID string) (*model.Student, error) {
+			if userID != uid.String() {
+				t.Fatalf("unexpected user_id lookup: %s", userID)
+			}
+			return &model.Student{ID: uuid.New(), UserID: uid, StudentID: "S001"}, nil
+		},
+		CreateStudentFn: func(req model.CreateStudentRequest) (string, error) {
+			t.Fatal("CreateStudent must not be called for a user that is already a student")
+			return "", nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/students", CreateStudentService)
+
+	req := httptest.NewRequest(http.MethodPost, "/students", jsonBodyStudent(t, map[string]any{
+		"user_id":    uid.String(),
+		"student_id": "S999",
+	}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	body := decodeMapStudent(t, resp)
+	if body["message"] != "user sudah terdaftar sebagai student" {
+		t.Fatalf("unexpected message: %v", body["message"])
+	}
+}
+
 func TestUpdateStudentService_NoFields(t *testing.T) {
 	app := fiber.New()
 	app.Put("/students/:id", UpdateStudentService)
@@ -234,3 +354,559 @@ func TestDeleteStudentService_NotFound(t *testing.T) {
 		t.Fatalf("unexpected message: %v", body["message"])
 	}
 }
+
+func TestAddStudentAdvisorService_TwoAdvisors(t *testing.T) {
+	studentID := uuid.New().String()
+	advisors := map[string]bool{}
+	studentAdvisorRepo = &mockStudentAdvisorRepo{
+		AddAdvisorFn: func(sID, lecturerID string) error {
+			if sID != studentID {
+				t.Fatalf("unexpected student id: %s", sID)
+			}
+			if advisors[lecturerID] {
+				return errors.New("advisor sudah terdaftar untuk student ini")
+			}
+			advisors[lecturerID] = true
+			return nil
+		},
+	}
+	advisorHistoryRepo = &mockAdvisorHistoryRepo{}
+
+	app := fiber.New()
+	app.Post("/students/:id/advisors", AddStudentAdvisorService)
+
+	first, second := uuid.New().String(), uuid.New().String()
+	for _, lecturerID := range []string{first, second} {
+		req := httptest.NewRequest(http.MethodPost, "/students/"+studentID+"/advisors", jsonBodyStudent(t, map[string]any{"lecturer_id": lecturerID}))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusCreated)
+		}
+	}
+	if len(advisors) != 2 {
+		t.Fatalf("expected 2 advisors, got %d", len(advisors))
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/students/"+studentID+"/advisors", jsonBodyStudent(t, map[string]any{"lecturer_id": first}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("duplicate status: got %d want %d", resp.StatusCode, http.StatusConflict)
+	}
+}
+
+func TestUpdateStudentService_AdvisorChangedTwiceRecordsHistory(t *testing.T) {
+	studentID := uuid.New()
+	var currentAdvisor *uuid.UUID
+	studentRepo = &mockStudentRepoStd{
+		GetStudentByIDFn: func(id string) (*model.Student, error) {
+			return &model.Student{ID: studentID, AdvisorID: currentAdvisor}, nil
+		},
+		UpdateStudentFn: func(id string, req model.UpdateStudentRequest) error {
+			adv := *req.AdvisorID
+			currentAdvisor = &adv
+			return nil
+		},
+	}
+	var history []model.AdvisorHistory
+	advisorHistoryRepo = &mockAdvisorHistoryRepo{
+		RecordChangeFn: func(entry model.AdvisorHistory) error {
+			history = append(history, entry)
+			return nil
+		},
+		ListByStudentFn: func(id string) ([]model.AdvisorHistory, error) {
+			if id != studentID.String() {
+				t.Fatalf("unexpected student id: %s", id)
+			}
+			return history, nil
+		},
+	}
+	adminRoleID := uuid.New().String()
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{Name: "Admin"}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("role_id", adminRoleID)
+		return c.Next()
+	})
+	app.Put("/students/:id", UpdateStudentService)
+	app.Get("/students/:id/advisor-history", GetStudentAdvisorHistoryService)
+
+	first, second := uuid.New(), uuid.New()
+	for _, advisorID := range []uuid.UUID{first, second} {
+		req := httptest.NewRequest(http.MethodPut, "/students/"+studentID.String(), jsonBodyStudent(t, map[string]any{"advisor_id": advisorID}))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+		}
+	}
+
+	if len(history) != 2 {
+		t.Fatalf("expected 2 history rows, got %d", len(history))
+	}
+	if history[0].PreviousAdvisorID != nil || *history[0].NewAdvisorID != first {
+		t.Fatalf("unexpected first history row: %+v", history[0])
+	}
+	if *history[1].PreviousAdvisorID != first || *history[1].NewAdvisorID != second {
+		t.Fatalf("unexpected second history row: %+v", history[1])
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/students/"+studentID.String()+"/advisor-history", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("history status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	body := decodeMapStudent(t, resp)
+	data, _ := body["data"].([]any)
+	if len(data) != 2 {
+		t.Fatalf("expected 2 history rows in response, got %d", len(data))
+	}
+}
+
+func TestOnboardStudentService_ValidatesBeforeTransaction(t *testing.T) {
+	onboardingRepo = &mockOnboardingRepo{
+		OnboardStudentFn: func(req model.OnboardStudentRequest) (*model.OnboardStudentResult, error) {
+			t.Fatalf("repo should not be called for invalid input")
+			return nil, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/onboarding/student", OnboardStudentService)
+
+	payload := map[string]any{
+		"username":   "budi_s",
+		"email":      "bukan-email",
+		"password":   "Secret1",
+		"full_name":  "Budi",
+		"student_id": "S123",
+	}
+	req := httptest.NewRequest(http.MethodPost, "/onboarding/student", jsonBodyStudent(t, payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	body := decodeMapStudent(t, resp)
+	if body["message"] != "Format email tidak valid" {
+		t.Fatalf("unexpected message: %v", body["message"])
+	}
+}
+
+func TestOnboardStudentService_DuplicateStudentID(t *testing.T) {
+	onboardingRepo = &mockOnboardingRepo{
+		OnboardStudentFn: func(req model.OnboardStudentRequest) (*model.OnboardStudentResult, error) {
+			return nil, errors.New("student_id sudah digunakan")
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/onboarding/student", OnboardStudentService)
+
+	payload := map[string]any{
+		"username":   "budi_s",
+		"email":      "budi@mail.com",
+		"password":   "Secret1",
+		"full_name":  "Budi",
+		"student_id": "S123",
+	}
+	req := httptest.NewRequest(http.MethodPost, "/onboarding/student", jsonBodyStudent(t, payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	body := decodeMapStudent(t, resp)
+	if body["message"] != "student_id sudah digunakan" {
+		t.Fatalf("unexpected message: %v", body["message"])
+	}
+}
+
+func postStudentImport(t *testing.T, query, csvBody string) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	fw, err := w.CreateFormFile("file", "students.csv")
+	if err != nil {
+		t.Fatalf("CreateFormFile: %v", err)
+	}
+	fw.Write([]byte(csvBody))
+	w.Close()
+
+	app := fiber.New()
+	app.Post("/students/import", ImportStudentsService)
+
+	req := httptest.NewRequest(http.MethodPost, "/students/import"+query, &buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	body := decodeMapStudent(t, resp)
+	data, ok := body["data"].(map[string]any)
+	if !ok {
+		t.Fatalf("missing data: %#v", body)
+	}
+	return data
+}
+
+func TestImportStudentsService_DryRunValidatesWithoutWriting(t *testing.T) {
+	known := uuid.New()
+	existing := uuid.New()
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			if id == known.String() || id == existing.String() {
+				return &model.User{ID: id}, nil
+			}
+			return nil, errors.New("user tidak ditemukan")
+		},
+	}
+	studentRepo = &mockStudentRepoStd{
+		GetStudentByUserIDFn: func(userID string) (*model.Student, error) {
+			if userID == existing.String() {
+				return &model.Student{UserID: existing}, nil
+			}
+			return nil, errors.New("student tidak ditemukan")
+		},
+		CreateStudentFn: func(req model.CreateStudentRequest) (string, error) {
+			t.Fatalf("dry run must not create students")
+			return "", nil
+		},
+	}
+
+	csvBody := "user_id,student_id,program_study\n" +
+		known.String() + ",S1,TI\n" +
+		"bukan-uuid,S2,TI\n" +
+		uuid.NewString() + ",S3,TI\n" +
+		existing.String() + ",S4,TI\n" +
+		known.String() + ",S1,TI\n"
+
+	data := postStudentImport(t, "?dry_run=true", csvBody)
+	if data["dry_run"] != true || data["total"] != float64(5) || data["would_create"] != float64(1) || data["created"] != float64(0) || data["failed"] != float64(4) {
+		t.Fatalf("unexpected summary: %#v", data)
+	}
+
+	rows := data["rows"].([]any)
+	wantErrors := map[float64]string{
+		3: "user_id tidak valid",
+		4: "user tidak ditemukan",
+		5: "user sudah terdaftar sebagai student",
+		6: "student_id duplikat dengan baris 2",
+	}
+	for _, r := range rows {
+		row := r.(map[string]any)
+		if row["row"] == float64(2) {
+			if row["status"] != model.StudentImportWouldCreate {
+				t.Fatalf("row 2 should be would_create: %#v", row)
+			}
+			continue
+		}
+		if want := wantErrors[row["row"].(float64)]; row["status"] != model.StudentImportFailed || row["error"] != want {
+			t.Fatalf("row %v: got %#v want error %q", row["row"], row, want)
+		}
+	}
+}
+
+func TestImportStudentsService_CreatesValidRows(t *testing.T) {
+	uid := uuid.New()
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) { return &model.User{ID: id}, nil },
+	}
+	created := 0
+	studentRepo = &mockStudentRepoStd{
+		GetStudentByUserIDFn: func(userID string) (*model.Student, error) {
+			return nil, errors.New("student tidak ditemukan")
+		},
+		CreateStudentFn: func(req model.CreateStudentRequest) (string, error) {
+			created++
+			if req.UserID != uid || req.StudentID != "S1" || req.AcademicYear != "2024" {
+				t.Fatalf("unexpected request: %+v", req)
+			}
+			return "stud-1", nil
+		},
+	}
+
+	data := postStudentImport(t, "", "student_id,user_id,academic_year\nS1,"+uid.String()+",2024\n")
+	if created != 1 || data["created"] != float64(1) || data["would_create"] != float64(0) {
+		t.Fatalf("unexpected summary: %#v (created=%d)", data, created)
+	}
+}
+
+func TestImportStudentsService_ValidatesAdvisorInBothModes(t *testing.T) {
+	lecturer, bogus := uuid.New(), uuid.New()
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) { return &model.User{ID: id}, nil },
+	}
+	studentLecturerRepo = &mockLecturerRepo{
+		GetLecturerByIDFn: func(id string) (*model.Lecturer, error) {
+			if id == lecturer.String() {
+				return &model.Lecturer{ID: lecturer}, nil
+			}
+			return nil, errors.New("lecturer tidak ditemukan")
+		},
+	}
+	t.Cleanup(func() { studentLecturerRepo = nil })
+	var created []string
+	studentRepo = &mockStudentRepoStd{
+		GetStudentByUserIDFn: func(userID string) (*model.Student, error) {
+			return nil, errors.New("student tidak ditemukan")
+		},
+		CreateStudentFn: func(req model.CreateStudentRequest) (string, error) {
+			created = append(created, req.StudentID)
+			return "stud-" + req.StudentID, nil
+		},
+	}
+
+	csvBody := "user_id,student_id,advisor_id\n" +
+		uuid.NewString() + ",S1," + lecturer.String() + "\n" +
+		uuid.NewString() + ",S2," + bogus.String() + "\n"
+
+	for _, query := range []string{"?dry_run=true", ""} {
+		created = nil
+		data := postStudentImport(t, query, csvBody)
+		if data["failed"] != float64(1) {
+			t.Fatalf("%q: expected bogus advisor row to fail: %#v", query, data)
+		}
+		row := data["rows"].([]any)[1].(map[string]any)
+		if row["status"] != model.StudentImportFailed || row["error"] != errInvalidAdvisor.Error() {
+			t.Fatalf("%q: unexpected row: %#v", query, row)
+		}
+		if query == "" && (len(created) != 1 || created[0] != "S1") {
+			t.Fatalf("only the valid advisor row should be created, got %v", created)
+		}
+	}
+}
+
+func newStudentMeApp(userID, roleName string) *fiber.App {
+	achievementRoleRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: roleName}, nil
+		},
+	}
+	app := fiber.New()
+	app.Get("/students/me", func(c *fiber.Ctx) error {
+		// meniru JWTAuthMiddleware
+		c.Locals("user_id", userID)
+		c.Locals("role_id", "role-"+roleName)
+		return c.Next()
+	}, GetMyStudentService)
+	return app
+}
+
+func TestGetMyStudentService_Mahasiswa(t *testing.T) {
+	userID := uuid.New()
+	studentID := uuid.New()
+	studentRepo = &mockStudentRepoStd{
+		GetStudentByUserIDFn: func(uid string) (*model.Student, error) {
+			if uid != userID.String() {
+				t.Fatalf("unexpected user_id lookup: %s", uid)
+			}
+			return &model.Student{ID: studentID, UserID: userID, StudentID: "2201001"}, nil
+		},
+	}
+
+	resp, err := newStudentMeApp(userID.String(), "Mahasiswa").Test(httptest.NewRequest(http.MethodGet, "/students/me", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	body := decodeMapStudent(t, resp)
+	data, _ := body["data"].(map[string]any)
+	if data["id"] != studentID.String() || data["student_id"] != "2201001" {
+		t.Fatalf("unexpected data: %#v", body["data"])
+	}
+}
+
+func TestGetMyStudentService_NonStudentForbidden(t *testing.T) {
+	studentRepo = &mockStudentRepoStd{
+		GetStudentByUserIDFn: func(uid string) (*model.Student, error) {
+			t.Fatalf("student lookup must not run for non-student role")
+			return nil, nil
+		},
+	}
+
+	resp, err := newStudentMeApp(uuid.NewString(), "dosen wali").Test(httptest.NewRequest(http.MethodGet, "/students/me", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestGetMyStudentService_NoRecord(t *testing.T) {
+	studentRepo = &mockStudentRepoStd{
+		GetStudentByUserIDFn: func(uid string) (*model.Student, error) {
+			return nil, errors.New("student tidak ditemukan")
+		},
+	}
+
+	resp, err := newStudentMeApp(uuid.NewString(), "mahasiswa").Test(httptest.NewRequest(http.MethodGet, "/students/me", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestStudentService_BogusAdvisorRejected(t *testing.T) {
+	bogus := uuid.New()
+	studentLecturerRepo = &mockLecturerRepo{
+		GetLecturerByIDFn: func(id string) (*model.Lecturer, error) {
+			if id != bogus.String() {
+				t.Fatalf("unexpected lecturer id: %s", id)
+			}
+			return nil, errors.New("lecturer tidak ditemukan")
+		},
+	}
+	t.Cleanup(func() { studentLecturerRepo = nil })
+	studentRepo = &mockStudentRepoStd{
+		CreateStudentFn: func(req model.CreateStudentRequest) (string, error) {
+			t.Fatal("CreateStudent should not be called with a bogus advisor")
+			return "", nil
+		},
+		UpdateStudentFn: func(id string, req model.UpdateStudentRequest) error {
+			t.Fatal("UpdateStudent should not be called with a bogus advisor")
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/students", CreateStudentService)
+	app.Put("/students/:id", UpdateStudentService)
+
+	cases := []struct {
+		name    string
+		method  string
+		target  string
+		payload map[string]any
+	}{
+		{"create", http.MethodPost, "/students", map[string]any{
+			"user_id":    uuid.New().String(),
+			"student_id": "S123",
+			"advisor_id": bogus.String(),
+		}},
+		{"update", http.MethodPut, "/students/" + uuid.New().String(), map[string]any{
+			"advisor_id": bogus.String(),
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, jsonBodyStudent(t, tc.payload))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
+			}
+			body := decodeMapStudent(t, resp)
+			if body["message"] != "advisor_id bukan lecturer valid" {
+				t.Fatalf("unexpected message: %v", body["message"])
+			}
+		})
+	}
+}
+
+func TestSetStudentAdvisorService_SetAndClear(t *testing.T) {
+	studentID := uuid.New()
+	lecturerID := uuid.New()
+	var currentAdvisor *uuid.UUID
+	studentRepo = &mockStudentRepoStd{
+		GetStudentByIDFn: func(id string) (*model.Student, error) {
+			return &model.Student{ID: studentID, AdvisorID: currentAdvisor}, nil
+		},
+		SetAdvisorFn: func(id string, advisorID *uuid.UUID) error {
+			if id != studentID.String() {
+				t.Fatalf("unexpected student id: %s", id)
+			}
+			currentAdvisor = advisorID
+			return nil
+		},
+	}
+	studentLecturerRepo = &mockLecturerRepo{
+		GetLecturerByIDFn: func(id string) (*model.Lecturer, error) {
+			if id != lecturerID.String() {
+				return nil, errors.New("lecturer tidak ditemukan")
+			}
+			return &model.Lecturer{ID: lecturerID}, nil
+		},
+	}
+	t.Cleanup(func() { studentLecturerRepo = nil })
+	var history []model.AdvisorHistory
+	advisorHistoryRepo = &mockAdvisorHistoryRepo{
+		RecordChangeFn: func(entry model.AdvisorHistory) error {
+			history = append(history, entry)
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/students/:id/advisor", SetStudentAdvisorService)
+
+	put := func(body string) (int, map[string]any) {
+		req := httptest.NewRequest(http.MethodPut, "/students/"+studentID.String()+"/advisor", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		return resp.StatusCode, decodeMapStudent(t, resp)
+	}
+
+	status, body := put(`{"advisor_id":"` + lecturerID.String() + `"}`)
+	if status != http.StatusOK || body["message"] != "Advisor student berhasil diupdate" {
+		t.Fatalf("set: got %d %v", status, body["message"])
+	}
+	if currentAdvisor == nil || *currentAdvisor != lecturerID {
+		t.Fatalf("advisor not set: %v", currentAdvisor)
+	}
+
+	status, body = put(`{"advisor_id":null}`)
+	if status != http.StatusOK || body["message"] != "Advisor student berhasil dihapus" {
+		t.Fatalf("clear: got %d %v", status, body["message"])
+	}
+	if currentAdvisor != nil {
+		t.Fatalf("advisor not cleared: %v", *currentAdvisor)
+	}
+
+	if len(history) != 2 || history[1].PreviousAdvisorID == nil || history[1].NewAdvisorID != nil {
+		t.Fatalf("unexpected history: %+v", history)
+	}
+
+	status, body = put(`{}`)
+	if status != http.StatusBadRequest {
+		t.Fatalf("missing advisor_id: got %d %v", status, body["message"])
+	}
+}
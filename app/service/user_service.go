@@ -1,24 +1,34 @@
 package service
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"hello-fiber/app/model"
 	"hello-fiber/app/repository"
 	"hello-fiber/utils"
+	"log"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 var userRepo repository.UserRepository
 var rolesRepo repository.RoleRepository
 
+// refreshTokenRepo nil berarti refresh token tidak diterbitkan (Login/Refresh hanya mengembalikan access token).
+var refreshTokenRepo repository.RefreshTokenRepository
+
 func InitUserService(db *sql.DB) {
 	userRepo = repository.NewUserRepositoryPostgres(db)
 	rolesRepo = repository.NewRoleRepositoryPostgres(db)
+	refreshTokenRepo = repository.NewRefreshTokenRepositoryPostgres(db)
 }
 
 func isValidEmail(email string) bool {
@@ -64,6 +74,52 @@ func isValidPassword(password string) bool {
 	return hasUpper && hasLower && hasNumber
 }
 
+// isSelfTarget true jika id target sama dengan user_id pemanggil (dari JWT).
+func isSelfTarget(c *fiber.Ctx, targetID string) bool {
+	callerID, _ := c.Locals("user_id").(string)
+	return callerID != "" && strings.TrimSpace(targetID) == callerID
+}
+
+// isLastAdmin true jika user adalah admin aktif dan tidak ada admin aktif lain yang tersisa.
+func isLastAdmin(ctx context.Context, user *model.User) (bool, error) {
+	if user == nil || !user.IsActive || strings.TrimSpace(user.RoleID) == "" {
+		return false, nil
+	}
+	role, err := rolesRepo.GetRoleByID(ctx, user.RoleID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return false, nil
+		}
+		return false, err
+	}
+	if role == nil || strings.ToLower(strings.TrimSpace(role.Name)) != "admin" {
+		return false, nil
+	}
+	total, err := userRepo.CountUsersByRoleName(ctx, "admin")
+	if err != nil {
+		return false, err
+	}
+	return total <= 1, nil
+}
+
+// loginMaxAttempts jumlah login gagal sebelum akun dikunci (env LOGIN_MAX_ATTEMPTS, default 5; 0 = nonaktif).
+func loginMaxAttempts() int {
+	n, err := strconv.Atoi(strings.TrimSpace(utils.GetEnv("LOGIN_MAX_ATTEMPTS", "5")))
+	if err != nil || n < 0 {
+		return 5
+	}
+	return n
+}
+
+// loginLockoutDuration lama akun terkunci (env LOGIN_LOCKOUT_MINUTES, default 15).
+func loginLockoutDuration() time.Duration {
+	n, err := strconv.Atoi(strings.TrimSpace(utils.GetEnv("LOGIN_LOCKOUT_MINUTES", "15")))
+	if err != nil || n <= 0 {
+		n = 15
+	}
+	return time.Duration(n) * time.Minute
+}
+
 func toUserResponse(user *model.User) *model.UserResponse {
 	return &model.UserResponse{
 		ID:        user.ID,
@@ -79,51 +135,65 @@ func toUserResponse(user *model.User) *model.UserResponse {
 
 // Register godoc
 // @Summary Daftar users baru
-// @Description Membuat users baru dengan validasi email, username, password, dan full_name
+// @Description Membuat users baru dengan validasi email, username, password, dan full_name. Role awal diambil dari DEFAULT_REGISTER_ROLE jika diset
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Param body body model.RegisterRequest true "Data registrasi"
+// @Param Accept-Language header string false "Bahasa pesan: id (default) atau en"
 // @Success 201 {object} model.SuccessResponse "User berhasil terdaftar"
-// @Failure 400 {object} model.ErrorResponse "Validasi gagal"
+// @Failure 400 {object} model.ErrorResponse "Request body tidak valid / username sudah terdaftar"
+// @Failure 422 {object} model.ErrorResponse "Validasi field gagal"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/auth/register [post]
 func Register(c *fiber.Ctx, db *sql.DB) error {
 	var req model.RegisterRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Request body tidak valid", "error": err.Error()})
+		return errorWithDetail(c, 400, msg(c, msgRequestBodyInvalid), err)
 	}
 
 	if req.Username == "" || req.Email == "" || req.Password == "" || req.FullName == "" {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Username, email, password, dan full_name harus diisi"})
+		return validationErrorJSON(c, msg(c, msgRegisterFieldsRequired))
 	}
 
 	if !isValidUsername(req.Username) {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Username harus 3-50 karakter, hanya alphanumeric dan underscore"})
+		return validationErrorJSON(c, msg(c, msgUsernameInvalid))
 	}
 
 	if !isValidEmail(req.Email) {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Format email tidak valid"})
+		return validationErrorJSON(c, msg(c, msgEmailInvalid))
 	}
 
 	if !isValidPassword(req.Password) {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Password minimal 5 karakter dengan uppercase, lowercase, dan number"})
+		return validationErrorJSON(c, msg(c, msgPasswordWeak))
 	}
 
-	existingUser, err := userRepo.GetUserByUsername(req.Username)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal validasi username", "error": err.Error()})
+	existingUser, err := userRepo.GetUserByUsername(c.UserContext(), req.Username)
+	if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+		return errorWithDetail(c, 500, msg(c, msgUsernameCheckFailed), err)
 	}
 	if existingUser != nil {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Username sudah terdaftar"})
+		return errorJSON(c, 400, msg(c, msgUsernameTaken))
 	}
 
-	id, err := userRepo.Register(req)
+	// DEFAULT_REGISTER_ROLE opsional: tanpa env user terdaftar tanpa role seperti sebelumnya
+	req.RoleID = ""
+	if roleName := strings.TrimSpace(utils.GetEnv("DEFAULT_REGISTER_ROLE", "")); roleName != "" {
+		role, err := rolesRepo.GetRoleByName(c.UserContext(), roleName)
+		if err != nil || role == nil {
+			// salah konfigurasi server, bukan kesalahan client
+			log.Printf("[ERROR] DEFAULT_REGISTER_ROLE %q tidak dapat dipakai: %v", roleName, err)
+			return errorJSON(c, 500, msg(c, msgDefaultRoleUnavailable))
+		}
+		req.RoleID = role.ID
+	}
+
+	id, err := userRepo.Register(c.UserContext(), req)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal mendaftarkan user", "error": err.Error()})
+		return errorWithDetail(c, 500, msg(c, msgRegisterFailed), err)
 	}
 
-	return c.Status(201).JSON(fiber.Map{"success": true, "message": "User berhasil didaftarkan", "id": id})
+	return c.Status(201).JSON(fiber.Map{"success": true, "message": msg(c, msgRegisterSuccess), "id": id})
 }
 
 // Login godoc
@@ -133,59 +203,99 @@ func Register(c *fiber.Ctx, db *sql.DB) error {
 // @Accept json
 // @Produce json
 // @Param body body model.LoginRequest true "Email dan password"
+// @Param Accept-Language header string false "Bahasa pesan: id (default) atau en"
 // @Success 200 {object} model.LoginResponse "Login berhasil"
 // @Failure 400 {object} model.ErrorResponse "Validasi gagal"
 // @Failure 401 {object} model.ErrorResponse "Email atau password salah"
+// @Failure 403 {object} model.ErrorResponse "Akun dinonaktifkan oleh admin"
+// @Failure 423 {object} model.ErrorResponse "Akun terkunci sementara (LOGIN_MAX_ATTEMPTS)"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/auth/login [post]
 func Login(c *fiber.Ctx, db *sql.DB) error {
 	var req model.LoginRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Request body tidak valid", "error": err.Error()})
+		return errorWithDetail(c, 400, msg(c, msgRequestBodyInvalid), err)
 	}
 
 	if req.Email == "" || req.Password == "" {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Email dan password harus diisi"})
+		return errorJSON(c, 400, msg(c, msgLoginFieldsRequired))
 	}
 
-	user, err := userRepo.Login(strings.ToLower(strings.TrimSpace(req.Email)), req.Password)
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	maxAttempts := loginMaxAttempts()
+	if maxAttempts > 0 {
+		lockedUntil, err := userRepo.GetLockedUntil(c.UserContext(), email)
+		if err != nil {
+			return errorWithDetail(c, 500, msg(c, msgAccountStatusFailed), err)
+		}
+		if lockedUntil != nil && lockedUntil.After(time.Now()) {
+			return errorJSON(c, 423, msg(c, msgAccountLocked, lockedUntil.Format(time.RFC3339)))
+		}
+	}
+
+	user, err := userRepo.Login(c.UserContext(), email, req.Password)
 	if err != nil {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": err.Error()})
+		if maxAttempts > 0 && strings.Contains(strings.ToLower(err.Error()), "password salah") {
+			if recErr := userRepo.RecordFailedLogin(c.UserContext(), email, maxAttempts, loginLockoutDuration()); recErr != nil {
+				log.Printf("[WARNING] %v", recErr)
+			}
+		}
+		return errorJSON(c, 401, err.Error())
+	}
+	// is_active di-reset setiap login/logout, jadi penonaktifan admin dicek lewat disabled_at.
+	disabled, err := userRepo.IsUserDisabled(c.UserContext(), user.ID)
+	if err != nil {
+		return errorWithDetail(c, 500, msg(c, msgAccountStatusFailed), err)
+	}
+	if disabled {
+		return errorJSON(c, 403, msg(c, msgAccountDisabled))
+	}
+	if maxAttempts > 0 {
+		if err := userRepo.ResetLoginAttempts(c.UserContext(), user.ID); err != nil {
+			log.Printf("[WARNING] %v", err)
+		}
 	}
 
 	isActive := true
 	updateReq := model.UpdateUserRequest{
 		IsActive: &isActive,
 	}
-	if err := userRepo.UpdateUser(user.ID, updateReq); err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal update user status", "error": err.Error()})
+	if err := userRepo.UpdateUser(c.UserContext(), user.ID, updateReq); err != nil {
+		return errorWithDetail(c, 500, msg(c, msgUserStatusUpdateFailed), err)
 	}
 
-	perms, err := userRepo.GetUserPermissions(user.ID)
+	perms, err := userRepo.GetUserPermissions(c.UserContext(), user.ID)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal mengambil permissions", "error": err.Error()})
+		return errorWithDetail(c, 500, msg(c, msgPermissionsFailed), err)
 	}
 	var permNames []string
 	for _, p := range perms {
 		permNames = append(permNames, p.Name)
 	}
 
-	token, err := utils.GenerateJWTPostgres(user, permNames...)
+	token, expiresAt, err := utils.IssueAccessToken(user, permNames...)
+	if err != nil {
+		return errorWithDetail(c, 500, msg(c, msgTokenCreateFailed), err)
+	}
+
+	refreshToken, err := issueRefreshToken(user.ID, uuid.NewString())
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal membuat token", "error": err.Error()})
+		return errorWithDetail(c, 500, msg(c, msgRefreshTokenCreateFailed), err)
 	}
 
-	return c.JSON(fiber.Map{"success": true, "message": "Login berhasil", "token": token, "user": toUserResponse(user)})
+	return c.JSON(tokenResponse(msg(c, msgLoginSuccess), token, expiresAt, refreshToken, user))
 }
 
 // Refresh godoc
 // @Summary Refresh JWT token
-// @Description Membuat token JWT baru dari token lama yang masih valid
+// @Description Menukar refresh_token dengan access token dan refresh_token baru (rotasi); refresh_token lama tidak berlaku lagi.
+// @Description Jika refresh_token yang sudah dipakai dikirim ulang, seluruh family token dicabut dan dikembalikan 401.
+// @Description Untuk client lama, field token (access JWT yang masih valid) tetap diterima, tetapi hanya mengembalikan access token baru tanpa refresh_token.
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Security Bearer
-// @Param body body model.RefreshTokenRequest true "Token yang akan direfresh"
+// @Param body body model.RefreshTokenRequest true "refresh_token hasil login/refresh sebelumnya"
 // @Success 200 {object} model.LoginResponse "Token berhasil direfresh"
 // @Failure 400 {object} model.ErrorResponse "Request tidak valid"
 // @Failure 401 {object} model.ErrorResponse "Token tidak valid atau expired"
@@ -194,43 +304,45 @@ func Login(c *fiber.Ctx, db *sql.DB) error {
 func Refresh(c *fiber.Ctx, db *sql.DB) error {
 	var req model.RefreshTokenRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Request body tidak valid", "error": err.Error()})
+		return errorWithDetail(c, 400, msg(c, msgRequestBodyInvalid), err)
+	}
+
+	if req.RefreshToken != "" {
+		return rotateRefreshToken(c, req.RefreshToken)
 	}
 
 	if req.Token == "" {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Token harus diisi"})
+		return errorJSON(c, 400, msg(c, msgTokenRequired))
 	}
 
 	// Parse dan validate token signature menggunakan JWT secret
-	token, err := jwt.ParseWithClaims(req.Token, &utils.Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrTokenUnverifiable
-		}
-		return utils.GetJWTSecret(), nil
-	})
+	token, err := utils.ParseJWT(req.Token)
 
 	if err != nil {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid atau expired", "error": err.Error()})
+		return errorWithDetail(c, 401, msg(c, msgTokenInvalid), err)
 	}
 
 	claims, ok := token.Claims.(*utils.Claims)
 	if !ok || !token.Valid {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token claims tidak valid"})
+		return errorJSON(c, 401, msg(c, msgTokenClaimsInvalid))
 	}
 
 	// Tidak perlu menyimpan token di database, hanya check user status
-	user, err := userRepo.GetUserByID(claims.UserID)
+	user, err := userRepo.GetUserByID(c.UserContext(), claims.UserID)
 	if err != nil {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "User tidak ditemukan"})
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return errorJSON(c, 401, msg(c, msgUserNotFound))
+		}
+		return errorWithDetail(c, 500, msg(c, msgUserFetchFailed), err)
 	}
 
 	if user == nil {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "User tidak valid"})
+		return errorJSON(c, 401, msg(c, msgUserInvalid))
 	}
 
-	perms, err := userRepo.GetUserPermissions(user.ID)
+	perms, err := userRepo.GetUserPermissions(c.UserContext(), user.ID)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal mengambil permissions", "error": err.Error()})
+		return errorWithDetail(c, 500, msg(c, msgPermissionsFailed), err)
 	}
 	var permNames []string
 	for _, p := range perms {
@@ -238,12 +350,192 @@ func Refresh(c *fiber.Ctx, db *sql.DB) error {
 	}
 
 	// Generate token JWT baru dengan claims baru
-	newToken, err := utils.GenerateJWTPostgres(user, permNames...)
+	newToken, expiresAt, err := utils.IssueAccessToken(user, permNames...)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal membuat token baru", "error": err.Error()})
+		return errorWithDetail(c, 500, msg(c, msgNewTokenFailed), err)
 	}
 
-	return c.JSON(fiber.Map{"success": true, "message": "Token berhasil direfresh", "token": newToken, "user": toUserResponse(user)})
+	// jalur lama hanya memperpanjang access token; refresh token tidak diterbitkan dari access token
+	// agar token berumur pendek tidak bisa ditukar menjadi family refresh token di luar rotasi
+	return c.JSON(tokenResponse(msg(c, msgTokenRefreshed), newToken, expiresAt, "", user))
+}
+
+// VerifyTokenService godoc
+// @Summary Verifikasi JWT tanpa refresh
+// @Description Memvalidasi signature dan masa berlaku token, memastikan user masih ada dan aktif, lalu mengembalikan claims-nya tanpa menerbitkan token baru
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param body body model.VerifyTokenRequest true "Token yang akan diverifikasi"
+// @Success 200 {object} model.VerifyTokenResponse "Token valid"
+// @Failure 400 {object} model.ErrorResponse "Request tidak valid"
+// @Failure 401 {object} model.ErrorResponse "Token tidak valid, expired, atau user tidak aktif"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/auth/verify [post]
+func VerifyTokenService(c *fiber.Ctx) error {
+	var req model.VerifyTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorWithDetail(c, 400, msg(c, msgRequestBodyInvalid), err)
+	}
+	if strings.TrimSpace(req.Token) == "" {
+		return errorJSON(c, 400, msg(c, msgTokenRequired))
+	}
+
+	token, err := utils.ParseJWT(strings.TrimSpace(req.Token))
+	if err != nil {
+		return errorWithDetail(c, 401, msg(c, msgTokenInvalid), err)
+	}
+	claims, ok := token.Claims.(*utils.Claims)
+	if !ok || !token.Valid {
+		return errorJSON(c, 401, msg(c, msgTokenClaimsInvalid))
+	}
+
+	user, err := userRepo.GetUserByID(c.UserContext(), claims.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return errorJSON(c, 401, msg(c, msgUserNotFound))
+		}
+		return errorWithDetail(c, 500, msg(c, msgUserFetchFailed), err)
+	}
+	if user == nil {
+		return errorJSON(c, 401, msg(c, msgUserNotFound))
+	}
+	if !user.IsActive {
+		return errorJSON(c, 401, msg(c, msgUserInactive))
+	}
+
+	permissions := claims.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+	var expiresAt string
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	return c.JSON(model.VerifyTokenResponse{
+		Success:     true,
+		Message:     msg(c, msgTokenValid),
+		Valid:       true,
+		UserID:      claims.UserID,
+		RoleID:      claims.RoleID,
+		Permissions: permissions,
+		ExpiresAt:   expiresAt,
+	})
+}
+
+// rotateRefreshToken menukar refresh token yang valid dengan pasangan token baru dalam family yang sama.
+// Token yang sudah used/revoked dianggap dicuri: seluruh family dicabut agar token curian maupun
+// token milik pemilik sah tidak bisa dipakai lagi.
+func rotateRefreshToken(c *fiber.Ctx, raw string) error {
+	if refreshTokenRepo == nil {
+		return errorJSON(c, 401, msg(c, msgRefreshTokenInvalid))
+	}
+
+	stored, err := refreshTokenRepo.GetByHash(utils.HashRefreshToken(raw))
+	if err != nil {
+		return errorWithDetail(c, 500, msg(c, msgRefreshTokenCheckFailed), err)
+	}
+	if stored == nil {
+		return errorJSON(c, 401, msg(c, msgRefreshTokenInvalid))
+	}
+	if stored.Used || stored.Revoked {
+		revokeRefreshFamily(stored.FamilyID)
+		return errorJSON(c, 401, msg(c, msgRefreshTokenReused))
+	}
+	if !stored.ExpiresAt.After(time.Now()) {
+		return errorJSON(c, 401, msg(c, msgRefreshTokenExpired))
+	}
+
+	user, err := userRepo.GetUserByID(c.UserContext(), stored.UserID)
+	if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+		return errorWithDetail(c, 500, msg(c, msgUserFetchFailed), err)
+	}
+	if user == nil {
+		return errorJSON(c, 401, msg(c, msgUserNotFound))
+	}
+	if !user.IsActive {
+		return errorJSON(c, 401, msg(c, msgUserInactive))
+	}
+
+	perms, err := userRepo.GetUserPermissions(c.UserContext(), user.ID)
+	if err != nil {
+		return errorWithDetail(c, 500, msg(c, msgPermissionsFailed), err)
+	}
+	var permNames []string
+	for _, p := range perms {
+		permNames = append(permNames, p.Name)
+	}
+
+	newToken, expiresAt, err := utils.IssueAccessToken(user, permNames...)
+	if err != nil {
+		return errorWithDetail(c, 500, msg(c, msgNewTokenFailed), err)
+	}
+
+	newRefresh, newHash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return errorWithDetail(c, 500, msg(c, msgRefreshTokenCreateFailed), err)
+	}
+	next := model.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  stored.FamilyID,
+		TokenHash: newHash,
+		ExpiresAt: time.Now().Add(utils.RefreshTokenTTL()),
+	}
+	if err := refreshTokenRepo.Rotate(stored.ID, next); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenReused) {
+			// Kalah balapan dengan refresh lain memakai token yang sama: perlakukan sebagai reuse
+			revokeRefreshFamily(stored.FamilyID)
+			return errorJSON(c, 401, msg(c, msgRefreshTokenReused))
+		}
+		return errorWithDetail(c, 500, msg(c, msgRefreshTokenRotateFailed), err)
+	}
+
+	return c.JSON(tokenResponse(msg(c, msgTokenRefreshed), newToken, expiresAt, newRefresh, user))
+}
+
+// issueRefreshToken menerbitkan refresh token baru dalam family familyID; "" jika refresh token tidak aktif.
+func issueRefreshToken(userID, familyID string) (string, error) {
+	if refreshTokenRepo == nil {
+		return "", nil
+	}
+	token, hash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return "", err
+	}
+	err = refreshTokenRepo.Create(model.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(utils.RefreshTokenTTL()),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func revokeRefreshFamily(familyID string) {
+	if err := refreshTokenRepo.RevokeFamily(familyID); err != nil {
+		log.Printf("[WARNING] gagal mencabut refresh token family %s: %v", familyID, err)
+	}
+}
+
+// tokenResponse body sukses Login/Refresh beserta expires_at (RFC3339), expires_in (detik),
+// dan refresh_token jika diterbitkan.
+func tokenResponse(message, token string, expiresAt time.Time, refreshToken string, user *model.User) fiber.Map {
+	resp := fiber.Map{
+		"success":    true,
+		"message":    message,
+		"token":      token,
+		"expires_at": expiresAt.UTC().Format(time.RFC3339),
+		"expires_in": int64(time.Until(expiresAt).Round(time.Second).Seconds()),
+		"user":       toUserResponse(user),
+	}
+	if refreshToken != "" {
+		resp["refresh_token"] = refreshToken
+	}
+	return resp
 }
 
 // GetUserByEmailService godoc
@@ -299,12 +591,15 @@ func Refresh(c *fiber.Ctx, db *sql.DB) error {
 
 // GetUserByIDService godoc
 // @Summary Dapatkan detail user (Admin)
-// @Description Mengambil detail user berdasarkan User ID
+// @Description Mengambil detail user berdasarkan User ID. Mendukung ETag/If-None-Match (304 jika tidak berubah).
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param id path string true "User ID (UUID)"
+// @Param If-None-Match header string false "ETag dari response sebelumnya"
+// @Param fields query string false "Field yang dikembalikan, dipisah koma (mis. id,username,email); field tidak dikenal diabaikan"
 // @Success 200 {object} model.UserDetailResponse "Data user berhasil diambil"
+// @Success 304 "Data tidak berubah"
 // @Failure 400 {object} model.ErrorResponse "Validasi gagal"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 404 {object} model.ErrorResponse "User tidak ditemukan"
@@ -314,33 +609,27 @@ func Refresh(c *fiber.Ctx, db *sql.DB) error {
 func GetUserByIDService(c *fiber.Ctx) error {
 	id := strings.TrimSpace(c.Params("id"))
 	if id == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"success": false,
-			"message": "User ID harus diisi",
-		})
+		return errorJSON(c, 400, "User ID harus diisi")
 	}
 
-	user, err := userRepo.GetUserByID(id)
+	user, err := userRepo.GetUserByID(c.UserContext(), id)
 	if err != nil {
-		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
-			return c.Status(404).JSON(fiber.Map{
-				"success": false,
-				"message": "User tidak ditemukan",
-			})
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return errorJSON(c, 404, "User tidak ditemukan")
 		}
 
-		return c.Status(500).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengambil data user",
-			"error":   err.Error(),
-		})
+		return errorWithDetail(c, 500, "Gagal mengambil data user", err)
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Data user berhasil diambil",
-		"data":    toUserResponse(user),
-	})
+	if fields := parseFieldsQuery(c); fields != nil {
+		projected, err := projectFields(toUserResponse(user), fields)
+		if err != nil {
+			return errorWithDetail(c, 500, "Gagal memproses field user", err)
+		}
+		return successJSONWithETag(c, "Data user berhasil diambil", projected)
+	}
+
+	return successJSONWithETag(c, "Data user berhasil diambil", toUserResponse(user))
 }
 
 // GetAllUsersService godoc
@@ -350,26 +639,41 @@ func GetUserByIDService(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param page query int false "Halaman (default: 1)"
-// @Param limit query int false "Jumlah data per halaman (default: 10)"
+// @Param limit query int false "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)"
+// @Param exclude_self query bool false "Sembunyikan akun admin yang sedang login dari daftar"
+// @Param is_active query bool false "Filter status aktif (true/false); kosong = semua"
+// @Param fields query string false "Field yang dikembalikan, dipisah koma (mis. id,username,email); field tidak dikenal diabaikan"
 // @Success 200 {object} model.UserListResponse "User list berhasil diambil"
+// @Failure 400 {object} model.ErrorResponse "Parameter is_active tidak valid"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/users [get]
 // @Security BearerAuth
 func GetAllUsersService(c *fiber.Ctx) error {
-	page := int64(1)
-	limit := int64(10)
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
+	}
 
-	if p := c.Query("page"); p != "" {
-		page = int64(c.QueryInt("page", 1))
+	// is_active opsional: hanya "true"/"false" yang diterima; kosong berarti semua user
+	var filter model.UserFilter
+	switch raw := strings.TrimSpace(c.Query("is_active")); raw {
+	case "":
+	case "true", "false":
+		isActive := raw == "true"
+		filter.IsActive = &isActive
+	default:
+		return errorJSON(c, 400, "Parameter is_active harus true atau false")
 	}
-	if l := c.Query("limit"); l != "" {
-		limit = int64(c.QueryInt("limit", 10))
+
+	// exclude_self=true: akun pemanggil tidak ikut terpilih di aksi massal
+	if callerID, _ := c.Locals("user_id").(string); c.QueryBool("exclude_self", false) && callerID != "" {
+		filter.ExcludeID = callerID
 	}
 
-	users, total, err := userRepo.GetAllUsers(page, limit)
+	users, total, err := userRepo.GetAllUsers(c.UserContext(), page, limit, filter)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal mengambil data user", "error": err.Error()})
+		return errorWithDetail(c, 500, "Gagal mengambil data user", err)
 	}
 
 	var userResponses []model.UserResponse
@@ -377,10 +681,23 @@ func GetAllUsersService(c *fiber.Ctx) error {
 		userResponses = append(userResponses, *toUserResponse(&user))
 	}
 
+	var data interface{} = userResponses
+	if fields := parseFieldsQuery(c); fields != nil {
+		projected := make([]map[string]interface{}, 0, len(userResponses))
+		for _, resp := range userResponses {
+			item, err := projectFields(resp, fields)
+			if err != nil {
+				return errorWithDetail(c, 500, "Gagal memproses field user", err)
+			}
+			projected = append(projected, item)
+		}
+		data = projected
+	}
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "Data user berhasil diambil",
-		"data":    userResponses,
+		"data":    data,
 		"total":   total,
 		"page":    page,
 		"limit":   limit,
@@ -446,7 +763,7 @@ func GetAllUsersService(c *fiber.Ctx) error {
 // @Produce json
 // @Param name query string true "Nama role (contoh: admin)"
 // @Param page query int false "Halaman (default: 1)"
-// @Param limit query int false "Jumlah data per halaman (default: 10)"
+// @Param limit query int false "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)"
 // @Success 200 {object} model.UserListResponse "User list berhasil diambil"
 // @Failure 400 {object} model.ErrorResponse "Validasi gagal"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
@@ -454,116 +771,113 @@ func GetAllUsersService(c *fiber.Ctx) error {
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/users/byrole [get]
 // @Security BearerAuth
-// func GetUsersByRoleNameService(c *fiber.Ctx) error {
-// 	roleName := strings.TrimSpace(c.Query("name"))
-// 	if roleName == "" {
-// 		return c.Status(400).JSON(fiber.Map{
-// 			"success": false,
-// 			"message": "Nama role harus diisi",
-// 		})
-// 	}
-
-// 	page := int64(1)
-// 	limit := int64(10)
-
-// 	if p := c.Query("page"); p != "" {
-// 		page = int64(c.QueryInt("page", 1))
-// 	}
-// 	if l := c.Query("limit"); l != "" {
-// 		limit = int64(c.QueryInt("limit", 10))
-// 	}
+func GetUsersByRoleNameService(c *fiber.Ctx) error {
+	roleName := strings.TrimSpace(c.Query("name"))
+	if roleName == "" {
+		return errorJSON(c, 400, "Nama role harus diisi")
+	}
 
-// 	users, total, err := userRepo.GetUsersByRoleName(roleName, page, limit)
-// 	if err != nil {
-// 		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
-// 			return c.Status(404).JSON(fiber.Map{
-// 				"success": false,
-// 				"message": "Role tidak ditemukan",
-// 			})
-// 		}
-// 		if strings.Contains(strings.ToLower(err.Error()), "harus diisi") {
-// 			return c.Status(400).JSON(fiber.Map{
-// 				"success": false,
-// 				"message": err.Error(),
-// 			})
-// 		}
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
+	}
 
-// 		return c.Status(500).JSON(fiber.Map{
-// 			"success": false,
-// 			"message": "Gagal mengambil data user",
-// 			"error":   err.Error(),
-// 		})
-// 	}
+	users, total, err := userRepo.GetUsersByRoleName(c.UserContext(), roleName, page, limit)
+	if err != nil {
+		msg := strings.ToLower(err.Error())
+		if strings.Contains(msg, "tidak ditemukan") {
+			return errorJSON(c, 404, "Role tidak ditemukan")
+		}
+		if strings.Contains(msg, "harus diisi") {
+			return errorJSON(c, 400, err.Error())
+		}
+		return errorWithDetail(c, 500, "Gagal mengambil data user", err)
+	}
 
-// 	var userResponses []model.UserResponse
-// 	for _, u := range users {
-// 		userResponses = append(userResponses, *toUserResponse(&u))
-// 	}
+	// role ada tapi belum punya user => data [] (bukan null)
+	userResponses := make([]model.UserResponse, 0, len(users))
+	for _, u := range users {
+		userResponses = append(userResponses, *toUserResponse(&u))
+	}
 
-// 	return c.JSON(fiber.Map{
-// 		"success": true,
-// 		"message": "Data user berhasil diambil",
-// 		"data":    userResponses,
-// 		"total":   total,
-// 		"page":    page,
-// 		"limit":   limit,
-// 	})
-// }
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Data user berhasil diambil",
+		"data":    userResponses,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
 
 // CreateUserAdmin godoc
 // @Summary Buat users baru (Admin)
-// @Description Admin membuat users baru dengan validasi lengkap
+// @Description Admin membuat users baru dengan validasi lengkap. role_name opsional untuk langsung mengisi role user.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param body body model.CreateUserRequest true "Data user baru"
 // @Success 201 {object} model.SuccessResponse "User berhasil dibuat"
-// @Failure 400 {object} model.ErrorResponse "Validasi gagal"
+// @Failure 400 {object} model.ErrorResponse "Request body tidak valid / username sudah terdaftar / role_name tidak ditemukan"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 422 {object} model.ErrorResponse "Validasi field gagal"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/users [post]
 // @Security BearerAuth
 func CreateUserAdmin(c *fiber.Ctx) error {
 	var req model.CreateUserRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Request body tidak valid", "error": err.Error()})
+		return errorWithDetail(c, 400, "Request body tidak valid", err)
 	}
 
 	if req.Username == "" || req.Email == "" || req.Password == "" || req.FullName == "" {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Username, email, password, dan full_name harus diisi"})
+		return validationErrorJSON(c, "Username, email, password, dan full_name harus diisi")
 	}
 
 	if !isValidUsername(req.Username) {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Username harus 3-50 karakter, hanya alphanumeric dan underscore"})
+		return validationErrorJSON(c, "Username harus 3-50 karakter, hanya alphanumeric dan underscore")
 	}
 
 	if !isValidEmail(req.Email) {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Format email tidak valid"})
+		return validationErrorJSON(c, "Format email tidak valid")
 	}
 
 	if !isValidPassword(req.Password) {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Password minimal 5 karakter dengan uppercase, lowercase, dan number"})
+		return validationErrorJSON(c, "Password minimal 5 karakter dengan uppercase, lowercase, dan number")
 	}
 
-	existingUser, err := userRepo.GetUserByUsername(req.Username)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal validasi username", "error": err.Error()})
+	existingUser, err := userRepo.GetUserByUsername(c.UserContext(), req.Username)
+	if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+		return errorWithDetail(c, 500, "Gagal validasi username", err)
 	}
 	if existingUser != nil {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Username sudah terdaftar"})
+		return errorJSON(c, 400, "Username sudah terdaftar")
+	}
+
+	// role_name opsional: tanpa role_name user dibuat tanpa role seperti sebelumnya
+	if roleName := strings.TrimSpace(req.RoleName); roleName != "" {
+		role, err := rolesRepo.GetRoleByName(c.UserContext(), roleName)
+		if err != nil && !strings.Contains(err.Error(), "tidak ditemukan") {
+			return errorWithDetail(c, 500, "Gagal validasi role", err)
+		}
+		if err != nil || role == nil {
+			return errorJSON(c, 400, fmt.Sprintf("Role '%s' tidak ditemukan", roleName))
+		}
+		req.RoleID = role.ID
 	}
 
-	id, err := userRepo.CreateUser(req)
+	id, err := userRepo.CreateUser(c.UserContext(), req)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal membuat user", "error": err.Error()})
+		return errorWithDetail(c, 500, "Gagal membuat user", err)
 	}
 
+	recordAudit(c, model.AuditActionCreate, model.AuditEntityUser, id)
 	return c.Status(201).JSON(fiber.Map{"success": true, "message": "User berhasil dibuat", "id": id})
 }
 
 // UpdateUserService godoc
 // @Summary Update data users (Admin)
-// @Description Admin dapat update username, email, password, role, atau is_active
+// @Description Admin dapat update username, email, password, role, atau is_active. role_id kosong berarti role tidak diubah; clear_role=true mengosongkan role (user kehilangan semua permission).
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -573,6 +887,7 @@ func CreateUserAdmin(c *fiber.Ctx) error {
 // @Failure 400 {object} model.ErrorResponse "Validasi gagal"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 404 {object} model.ErrorResponse "User tidak ditemukan"
+// @Failure 409 {object} model.ErrorResponse "Admin terakhir tidak dapat diturunkan atau data telah diubah oleh proses lain"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/users/{id} [put]
 // @Security BearerAuth
@@ -581,41 +896,139 @@ func UpdateUserService(c *fiber.Ctx) error {
 	var req model.UpdateUserRequest
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Request body tidak valid", "error": err.Error()})
+		return errorWithDetail(c, 400, "Request body tidak valid", err)
 	}
 
-	hasUpdate := req.Username != "" || req.Email != "" || req.Password != "" || req.RoleID != "" || req.FullName != "" || req.IsActive != nil
+	hasUpdate := req.Username != "" || req.Email != "" || req.Password != "" || req.RoleID != "" || req.FullName != "" || req.IsActive != nil || req.ClearRole
 	if !hasUpdate {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Minimal ada satu field yang harus diupdate"})
+		return errorJSON(c, 400, "Minimal ada satu field yang harus diupdate")
+	}
+	if req.ClearRole && req.RoleID != "" {
+		return errorJSON(c, 400, "role_id dan clear_role tidak boleh diisi bersamaan")
 	}
 
-	if req.Username != "" && !isValidUsername(req.Username) {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Username harus 3-50 karakter, hanya alphanumeric dan underscore"})
+	// validasi dijalankan dalam bentuk patch: field kosong di PUT berarti tidak diubah
+	patch := model.PatchUserRequest{IsActive: req.IsActive}
+	if req.Username != "" {
+		patch.Username = &req.Username
+	}
+	if req.Email != "" {
+		patch.Email = &req.Email
+	}
+	if req.Password != "" {
+		patch.Password = &req.Password
+	}
+	if req.RoleID != "" || req.ClearRole {
+		patch.RoleID = &req.RoleID
+	}
+	if code, message, err := validateUserChanges(c, userID, patch); code != 0 {
+		return errorWithDetail(c, code, message, err)
 	}
 
-	if req.Email != "" && !isValidEmail(req.Email) {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Format email tidak valid"})
+	if err := userRepo.UpdateUser(c.UserContext(), userID, req); err != nil {
+		if errors.Is(err, repository.ErrConcurrentUpdate) {
+			return errorJSON(c, 409, err.Error())
+		}
+		return errorWithDetail(c, 500, "Gagal update user", err)
 	}
+	recordAudit(c, model.AuditActionUpdate, model.AuditEntityUser, userID)
+
+	return successJSON(c, fiber.StatusOK, "User berhasil diupdate", nil)
+}
 
-	if req.Password != "" && !isValidPassword(req.Password) {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Password minimal 5 karakter dengan uppercase, lowercase, dan number"})
+// PatchUserService godoc
+// @Summary Update sebagian data users (Admin)
+// @Description Semantik PATCH: field yang tidak dikirim tidak diubah, field yang dikirim di-set apa adanya. full_name boleh string kosong; role_id "" mengosongkan role. Validasi sama dengan PUT.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID (UUID)"
+// @Param body body model.PatchUserRequest true "Field yang diubah"
+// @Success 200 {object} model.SuccessResponse "User berhasil diupdate"
+// @Failure 400 {object} model.ErrorResponse "Validasi gagal"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 404 {object} model.ErrorResponse "User tidak ditemukan"
+// @Failure 409 {object} model.ErrorResponse "Admin terakhir tidak dapat diturunkan atau data telah diubah oleh proses lain"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/users/{id} [patch]
+// @Security BearerAuth
+func PatchUserService(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	var req model.PatchUserRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return errorWithDetail(c, 400, "Request body tidak valid", err)
 	}
 
-	if req.Username != "" {
-		existingUser, err := userRepo.GetUserByUsername(req.Username)
-		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal validasi username", "error": err.Error()})
+	if req.Username == nil && req.Email == nil && req.Password == nil && req.FullName == nil && req.RoleID == nil && req.IsActive == nil {
+		return errorJSON(c, 400, "Minimal ada satu field yang harus diupdate")
+	}
+	if code, message, err := validateUserChanges(c, userID, req); code != 0 {
+		return errorWithDetail(c, code, message, err)
+	}
+
+	if err := userRepo.PatchUser(c.UserContext(), userID, req); err != nil {
+		if errors.Is(err, repository.ErrConcurrentUpdate) {
+			return errorJSON(c, 409, err.Error())
 		}
-		if existingUser != nil && existingUser.ID != userID {
-			return c.Status(400).JSON(fiber.Map{"success": false, "message": "Username sudah terdaftar"})
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return errorJSON(c, 404, "User tidak ditemukan")
 		}
+		return errorWithDetail(c, 500, "Gagal update user", err)
+	}
+	recordAudit(c, model.AuditActionUpdate, model.AuditEntityUser, userID)
+
+	return successJSON(c, fiber.StatusOK, "User berhasil diupdate", nil)
+}
+
+// validateUserChanges validasi bersama PUT dan PATCH /v1/users/{id} untuk field yang akan di-set.
+// code 0 berarti lolos; selain itu code/message (dan err untuk detail 500) siap dikirim ke client.
+func validateUserChanges(c *fiber.Ctx, userID string, p model.PatchUserRequest) (int, string, error) {
+	if p.Username != nil && !isValidUsername(*p.Username) {
+		return 400, "Username harus 3-50 karakter, hanya alphanumeric dan underscore", nil
+	}
+	if p.Email != nil && !isValidEmail(*p.Email) {
+		return 400, "Format email tidak valid", nil
+	}
+	if p.Password != nil && !isValidPassword(*p.Password) {
+		return 400, "Password minimal 5 karakter dengan uppercase, lowercase, dan number", nil
+	}
+	if p.IsActive != nil && !*p.IsActive && isSelfTarget(c, userID) {
+		return 400, "Tidak dapat menonaktifkan/menghapus akun sendiri", nil
 	}
 
-	if err := userRepo.UpdateUser(userID, req); err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal update user", "error": err.Error()})
+	if p.Username != nil {
+		existingUser, err := userRepo.GetUserByUsername(c.UserContext(), *p.Username)
+		if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+			return 500, "Gagal validasi username", err
+		}
+		if existingUser != nil && existingUser.ID != userID {
+			return 400, "Username sudah terdaftar", nil
+		}
 	}
 
-	return c.JSON(fiber.Map{"success": true, "message": "User berhasil diupdate"})
+	roleChanged := p.RoleID != nil
+	deactivated := p.IsActive != nil && !*p.IsActive
+	if roleChanged || deactivated {
+		// error selain not-found tidak boleh melewati guard admin terakhir (fail closed)
+		target, err := userRepo.GetUserByID(c.UserContext(), userID)
+		if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+			return 500, "Gagal mengambil data user", err
+		}
+		if target != nil && (deactivated || target.RoleID != *p.RoleID) {
+			lastAdmin, err := isLastAdmin(c.UserContext(), target)
+			if err != nil {
+				return 500, "Gagal validasi admin", err
+			}
+			if lastAdmin && deactivated {
+				return 409, "Tidak dapat menonaktifkan admin terakhir", nil
+			}
+			if lastAdmin {
+				return 409, "Tidak dapat menghapus admin terakhir", nil
+			}
+		}
+	}
+	return 0, "", nil
 }
 
 // DeleteUserService godoc
@@ -626,22 +1039,42 @@ func UpdateUserService(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path string true "User ID (UUID)"
 // @Success 200 {object} model.SuccessResponse "User berhasil dihapus"
-// @Failure 400 {object} model.ErrorResponse "User ID tidak valid"
+// @Failure 400 {object} model.ErrorResponse "User ID tidak valid / menghapus akun sendiri"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 409 {object} model.ErrorResponse "Admin terakhir tidak dapat dihapus"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/users/{id} [delete]
 // @Security BearerAuth
 func DeleteUserService(c *fiber.Ctx) error {
 	userID := c.Params("id")
 	if userID == "" {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "User ID harus diisi"})
+		return errorJSON(c, 400, "User ID harus diisi")
+	}
+
+	if isSelfTarget(c, userID) {
+		return errorJSON(c, 400, "Tidak dapat menonaktifkan/menghapus akun sendiri")
 	}
 
-	if err := userRepo.DeleteUser(userID); err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal delete user", "error": err.Error()})
+	target, err := userRepo.GetUserByID(c.UserContext(), userID)
+	if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+		return errorWithDetail(c, 500, "Gagal mengambil data user", err)
+	}
+	if target != nil {
+		lastAdmin, err := isLastAdmin(c.UserContext(), target)
+		if err != nil {
+			return errorWithDetail(c, 500, "Gagal validasi admin", err)
+		}
+		if lastAdmin {
+			return errorJSON(c, 409, "Tidak dapat menghapus admin terakhir")
+		}
 	}
 
-	return c.JSON(fiber.Map{"success": true, "message": "User berhasil dihapus"})
+	if err := userRepo.DeleteUser(c.UserContext(), userID); err != nil {
+		return errorWithDetail(c, 500, "Gagal delete user", err)
+	}
+	recordAudit(c, model.AuditActionDelete, model.AuditEntityUser, userID)
+
+	return successJSON(c, fiber.StatusOK, "User berhasil dihapus", nil)
 }
 
 // Logout godoc
@@ -660,38 +1093,33 @@ func DeleteUserService(c *fiber.Ctx) error {
 func Logout(c *fiber.Ctx, db *sql.DB) error {
 	var req model.LogoutRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Request body tidak valid", "error": err.Error()})
+		return errorWithDetail(c, 400, msg(c, msgRequestBodyInvalid), err)
 	}
 
 	if req.Token == "" {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Token harus diisi"})
+		return errorJSON(c, 400, msg(c, msgTokenRequired))
 	}
 
 	// Parse dan validate token signature
-	token, err := jwt.ParseWithClaims(req.Token, &utils.Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrTokenUnverifiable
-		}
-		return utils.GetJWTSecret(), nil
-	})
+	token, err := utils.ParseJWT(req.Token)
 
 	if err != nil {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid atau expired", "error": err.Error()})
+		return errorWithDetail(c, 401, msg(c, msgTokenInvalid), err)
 	}
 
 	claims, ok := token.Claims.(*utils.Claims)
 	if !ok || !token.Valid {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token claims tidak valid"})
+		return errorJSON(c, 401, msg(c, msgTokenClaimsInvalid))
 	}
 
 	// Verify user exists
-	user, err := userRepo.GetUserByID(claims.UserID)
+	user, err := userRepo.GetUserByID(c.UserContext(), claims.UserID)
 	if err != nil {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "User tidak ditemukan"})
+		return errorJSON(c, 401, msg(c, msgUserNotFound))
 	}
 
 	if user == nil {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "User tidak valid"})
+		return errorJSON(c, 401, msg(c, msgUserInvalid))
 	}
 
 	isActiveFalse := false
@@ -699,11 +1127,11 @@ func Logout(c *fiber.Ctx, db *sql.DB) error {
 		IsActive: &isActiveFalse,
 	}
 
-	if err := userRepo.UpdateUser(claims.UserID, updateReq); err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal logout, error saat update user status", "error": err.Error()})
+	if err := userRepo.UpdateUser(c.UserContext(), claims.UserID, updateReq); err != nil {
+		return errorWithDetail(c, 500, msg(c, msgLogoutFailed), err)
 	}
 
-	return c.JSON(fiber.Map{"success": true, "message": "Logout berhasil, token sudah tidak aktif"})
+	return successJSON(c, fiber.StatusOK, msg(c, msgLogoutSuccess), nil)
 }
 
 // GetProfileService godoc
@@ -720,41 +1148,24 @@ func Logout(c *fiber.Ctx, db *sql.DB) error {
 func GetProfileService(c *fiber.Ctx) error {
 	userIDVal := c.Locals("user_id")
 	if userIDVal == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "User ID tidak ditemukan dalam token",
-		})
+		return errorJSON(c, fiber.StatusUnauthorized, "User ID tidak ditemukan dalam token")
 	}
 
 	userID, ok := userIDVal.(string)
 	if !ok || userID == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "User ID tidak valid",
-		})
+		return errorJSON(c, fiber.StatusUnauthorized, "User ID tidak valid")
 	}
 
 	// Get user data from database
-	user, err := userRepo.GetUserByID(userID)
+	user, err := userRepo.GetUserByID(c.UserContext(), userID)
 	if err != nil {
-		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "User tidak ditemukan",
-			})
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return errorJSON(c, fiber.StatusNotFound, "User tidak ditemukan")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengambil data profil",
-			"error":   err.Error(),
-		})
+		return errorWithDetail(c, fiber.StatusInternalServerError, "Gagal mengambil data profil", err)
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Profil user berhasil diambil",
-		"data":    toUserResponse(user),
-	})
+	return successJSON(c, fiber.StatusOK, "Profil user berhasil diambil", toUserResponse(user))
 }
 
 // UpdateUserRoleByNameService godoc
@@ -769,64 +1180,51 @@ func GetProfileService(c *fiber.Ctx) error {
 // @Failure 400 {object} model.ErrorResponse "Validasi gagal"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 404 {object} model.ErrorResponse "User atau Role tidak ditemukan"
+// @Failure 409 {object} model.ErrorResponse "Admin terakhir tidak dapat diturunkan"
 // @Failure 500 {object} model.ErrorResponse "Error server"
 // @Router /v1/users/{id}/role [put]
 // @Security BearerAuth
 func UpdateUserRoleByNameService(c *fiber.Ctx) error {
 	userID := c.Params("id")
 	if userID == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"success": false,
-			"message": "User ID harus diisi",
-		})
+		return errorJSON(c, 400, "User ID harus diisi")
 	}
 
 	var req model.UpdateUserRoleByNameRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"success": false,
-			"message": "Request body tidak valid",
-			"error":   err.Error(),
-		})
+		return errorWithDetail(c, 400, "Request body tidak valid", err)
 	}
 
 	roleName := strings.TrimSpace(req.RoleName)
 	if roleName == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"success": false,
-			"message": "Nama role harus diisi",
-		})
+		return errorJSON(c, 400, "Nama role harus diisi")
 	}
 
 	// Check if user exists
-	user, err := userRepo.GetUserByID(userID)
+	user, err := userRepo.GetUserByID(c.UserContext(), userID)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{
-			"success": false,
-			"message": "User tidak ditemukan",
-			"error":   err.Error(),
-		})
+		return errorWithDetail(c, 404, "User tidak ditemukan", err)
 	}
 	if user == nil {
-		return c.Status(404).JSON(fiber.Map{
-			"success": false,
-			"message": "User tidak ditemukan",
-		})
+		return errorJSON(c, 404, "User tidak ditemukan")
 	}
 
-	role, err := rolesRepo.GetRoleByName(roleName)
+	role, err := rolesRepo.GetRoleByName(c.UserContext(), roleName)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{
-			"success": false,
-			"message": "Role tidak ditemukan",
-			"error":   err.Error(),
-		})
+		return errorWithDetail(c, 404, "Role tidak ditemukan", err)
 	}
 	if role == nil {
-		return c.Status(404).JSON(fiber.Map{
-			"success": false,
-			"message": "Role tidak ditemukan",
-		})
+		return errorJSON(c, 404, "Role tidak ditemukan")
+	}
+
+	if role.ID != user.RoleID {
+		lastAdmin, err := isLastAdmin(c.UserContext(), user)
+		if err != nil {
+			return errorWithDetail(c, 500, "Gagal validasi admin", err)
+		}
+		if lastAdmin {
+			return errorJSON(c, 409, "Tidak dapat menghapus admin terakhir")
+		}
 	}
 
 	// Update user role with role ID
@@ -834,21 +1232,198 @@ func UpdateUserRoleByNameService(c *fiber.Ctx) error {
 		RoleID: role.ID,
 	}
 
-	if err := userRepo.UpdateUser(userID, updateReq); err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengupdate role user",
-			"error":   err.Error(),
-		})
+	if err := userRepo.UpdateUser(c.UserContext(), userID, updateReq); err != nil {
+		return errorWithDetail(c, 500, "Gagal mengupdate role user", err)
+	}
+	recordAudit(c, model.AuditActionUpdate, model.AuditEntityUser, userID)
+
+	return successJSON(c, fiber.StatusOK, "Role user berhasil diupdate", fiber.Map{
+		"user_id":   userID,
+		"role_name": role.Name,
+		"role_id":   role.ID,
+	})
+}
+
+// SetUserActiveService godoc
+// @Summary Aktifkan/nonaktifkan user (Admin)
+// @Description Mengisi/mengosongkan disabled_at dan menyamakan is_active. User yang dinonaktifkan ditolak saat login (403) sampai diaktifkan kembali. Admin tidak dapat menonaktifkan akun sendiri maupun admin terakhir.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID (UUID)"
+// @Param body body model.SetUserActiveRequest true "Status aktif baru"
+// @Success 200 {object} model.SuccessResponse "Status aktif user berhasil diupdate"
+// @Failure 400 {object} model.ErrorResponse "Validasi gagal"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 404 {object} model.ErrorResponse "User tidak ditemukan"
+// @Failure 409 {object} model.ErrorResponse "Admin terakhir tidak dapat dinonaktifkan"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/users/{id}/active [put]
+// @Security BearerAuth
+func SetUserActiveService(c *fiber.Ctx) error {
+	userID := strings.TrimSpace(c.Params("id"))
+	if userID == "" {
+		return errorJSON(c, 400, "User ID harus diisi")
+	}
+
+	var req model.SetUserActiveRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errorWithDetail(c, 400, "Request body tidak valid", err)
+	}
+	if req.IsActive == nil {
+		return errorJSON(c, 400, "is_active harus diisi")
+	}
+	if !*req.IsActive && isSelfTarget(c, userID) {
+		return errorJSON(c, 400, "Tidak dapat menonaktifkan/menghapus akun sendiri")
+	}
+
+	user, err := userRepo.GetUserByID(c.UserContext(), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return errorJSON(c, 404, "User tidak ditemukan")
+		}
+		return errorWithDetail(c, 500, "Gagal mengambil data user", err)
+	}
+	if user == nil {
+		return errorJSON(c, 404, "User tidak ditemukan")
+	}
+
+	if !*req.IsActive {
+		lastAdmin, err := isLastAdmin(c.UserContext(), user)
+		if err != nil {
+			return errorWithDetail(c, 500, "Gagal validasi admin", err)
+		}
+		if lastAdmin {
+			return errorJSON(c, 409, "Tidak dapat menonaktifkan admin terakhir")
+		}
+	}
+
+	if err := userRepo.SetUserDisabled(c.UserContext(), userID, !*req.IsActive); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return errorJSON(c, 404, "User tidak ditemukan")
+		}
+		return errorWithDetail(c, 500, "Gagal mengupdate status aktif user", err)
+	}
+	recordAudit(c, model.AuditActionUpdate, model.AuditEntityUser, userID)
+
+	message := "User berhasil dinonaktifkan"
+	if *req.IsActive {
+		message = "User berhasil diaktifkan"
+	}
+	return successJSON(c, fiber.StatusOK, message, fiber.Map{
+		"user_id":   userID,
+		"is_active": *req.IsActive,
+	})
+}
+
+// GetLockedUsersService godoc
+// @Summary Daftar user yang sedang terkunci (Admin)
+// @Description Mengambil user dengan locked_until di masa depan akibat login gagal berulang
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param page query int false "Halaman (default: 1)"
+// @Param limit query int false "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)"
+// @Success 200 {object} map[string]interface{} "Data user terkunci berhasil diambil"
+// @Failure 400 {object} model.ErrorResponse "Parameter page/limit tidak valid"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/users/locked [get]
+// @Security BearerAuth
+func GetLockedUsersService(c *fiber.Ctx) error {
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
+	}
+
+	users, total, err := userRepo.GetLockedUsers(c.UserContext(), page, limit)
+	if err != nil {
+		return errorWithDetail(c, 500, "Gagal mengambil data user terkunci", err)
+	}
+	if users == nil {
+		users = []model.LockedUser{}
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Role user berhasil diupdate",
-		"data": fiber.Map{
-			"user_id":   userID,
-			"role_name": role.Name,
-			"role_id":   role.ID,
-		},
+		"message": "Data user terkunci berhasil diambil",
+		"data":    users,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
 	})
 }
+
+// GetUnassignedUsersService godoc
+// @Summary Daftar user tanpa role (Admin)
+// @Description Mengambil user dengan role_id kosong; akun ini tidak bisa melakukan apa pun sampai role diisi
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param page query int false "Halaman (default: 1)"
+// @Param limit query int false "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)"
+// @Success 200 {object} map[string]interface{} "Data user tanpa role berhasil diambil"
+// @Failure 400 {object} model.ErrorResponse "Parameter page/limit tidak valid"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/users/unassigned [get]
+// @Security BearerAuth
+func GetUnassignedUsersService(c *fiber.Ctx) error {
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return errorJSON(c, 400, err.Error())
+	}
+
+	users, total, err := userRepo.GetUsersWithoutRole(c.UserContext(), page, limit)
+	if err != nil {
+		return errorWithDetail(c, 500, "Gagal mengambil data user tanpa role", err)
+	}
+
+	userResponses := make([]model.UserResponse, 0, len(users))
+	for _, u := range users {
+		userResponses = append(userResponses, *toUserResponse(&u))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Data user tanpa role berhasil diambil",
+		"data":    userResponses,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+// UnlockUserService godoc
+// @Summary Buka kunci akun user (Admin)
+// @Description Mengosongkan counter login gagal dan locked_until sehingga user bisa login lagi
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} model.SuccessResponse "Akun user berhasil dibuka"
+// @Failure 400 {object} model.ErrorResponse "Validasi gagal"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 404 {object} model.ErrorResponse "User tidak ditemukan"
+// @Failure 500 {object} model.ErrorResponse "Error server"
+// @Router /v1/users/{id}/unlock [post]
+// @Security BearerAuth
+func UnlockUserService(c *fiber.Ctx) error {
+	id := strings.TrimSpace(c.Params("id"))
+	if id == "" {
+		return errorJSON(c, 400, "User ID harus diisi")
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return errorJSON(c, 400, "Format User ID tidak valid")
+	}
+
+	if err := userRepo.ResetLoginAttempts(c.UserContext(), id); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "tidak ditemukan") {
+			return errorJSON(c, 404, "User tidak ditemukan")
+		}
+		return errorWithDetail(c, 500, "Gagal membuka kunci user", err)
+	}
+	recordAudit(c, model.AuditActionUpdate, model.AuditEntityUser, id)
+
+	return successJSON(c, fiber.StatusOK, "Akun user berhasil dibuka", nil)
+}
@@ -2,14 +2,18 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"hello-fiber/app/model"
+	"hello-fiber/app/repository"
 	"hello-fiber/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -22,25 +26,35 @@ type mockUserRepo struct {
 	LoginFn             func(email, password string) (*model.User, error)
 	RefreshTokenFn      func(userID string) (*model.User, error)
 
-	GetUserByEmailFn     func(email string) (*model.User, error)
-	GetUserByIDFn        func(id string) (*model.User, error)
-	GetAllUsersFn        func(page, limit int64) ([]model.User, int64, error)
-	GetUsersByRoleNameFn func(roleName string, page, limit int64) ([]model.User, int64, error)
-	CreateUserFn         func(req model.CreateUserRequest) (string, error)
-	UpdateUserFn         func(id string, req model.UpdateUserRequest) error
-	DeleteUserFn         func(id string) error
+	GetUserByEmailFn       func(email string) (*model.User, error)
+	GetUserByIDFn          func(id string) (*model.User, error)
+	GetAllUsersFn          func(page, limit int64, filter model.UserFilter) ([]model.User, int64, error)
+	GetUsersByRoleNameFn   func(roleName string, page, limit int64) ([]model.User, int64, error)
+	GetUsersWithoutRoleFn  func(page, limit int64) ([]model.User, int64, error)
+	CountUsersByRoleNameFn func(roleName string) (int64, error)
+	CreateUserFn           func(req model.CreateUserRequest) (string, error)
+	UpdateUserFn           func(id string, req model.UpdateUserRequest) error
+	PatchUserFn            func(id string, req model.PatchUserRequest) error
+	DeleteUserFn           func(id string) error
 
 	GetAllRolesFn        func(page, limit int64) ([]model.Role, int64, error)
 	GetRoleByIDFn        func(id string) (*model.Role, error)
 	GetRoleByNameFn      func(name string) (*model.Role, error)
 	GetUserPermissionsFn func(userID string) ([]model.Permission, error)
 
+	GetLockedUntilFn     func(email string) (*time.Time, error)
+	RecordFailedLoginFn  func(email string, maxAttempts int, lockFor time.Duration) error
+	ResetLoginAttemptsFn func(userID string) error
+	GetLockedUsersFn     func(page, limit int64) ([]model.LockedUser, int64, error)
+	IsUserDisabledFn     func(userID string) (bool, error)
+	SetUserDisabledFn    func(userID string, disabled bool) error
+
 	LastLoginEmail    string
 	LastLoginPassword string
 	LastRegisterReq   *model.RegisterRequest
 }
 
-func (m *mockUserRepo) Register(req model.RegisterRequest) (string, error) {
+func (m *mockUserRepo) Register(ctx context.Context, req model.RegisterRequest) (string, error) {
 	m.LastRegisterReq = &req
 	if m.RegisterFn != nil {
 		return m.RegisterFn(req)
@@ -48,7 +62,7 @@ func (m *mockUserRepo) Register(req model.RegisterRequest) (string, error) {
 	return "mock-id", nil
 }
 
-func (m *mockUserRepo) Login(email, password string) (*model.User, error) {
+func (m *mockUserRepo) Login(ctx context.Context, email, password string) (*model.User, error) {
 	m.LastLoginEmail = email
 	m.LastLoginPassword = password
 	if m.LoginFn != nil {
@@ -57,75 +71,143 @@ func (m *mockUserRepo) Login(email, password string) (*model.User, error) {
 	return &model.User{ID: "u1", Email: email, RoleID: "user", IsActive: true}, nil
 }
 
-func (m *mockUserRepo) RefreshToken(userID string) (*model.User, error) {
+func (m *mockUserRepo) RefreshToken(ctx context.Context, userID string) (*model.User, error) {
 	if m.RefreshTokenFn != nil {
 		return m.RefreshTokenFn(userID)
 	}
 	return nil, nil
 }
 
-func (m *mockUserRepo) GetUserByUsername(username string) (*model.User, error) {
+func (m *mockUserRepo) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
 	if m.GetUserByUsernameFn != nil {
 		return m.GetUserByUsernameFn(username)
 	}
 	return nil, nil
 }
 
-func (m *mockUserRepo) GetUserByEmail(email string) (*model.User, error) {
+func (m *mockUserRepo) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
 	if m.GetUserByEmailFn != nil {
 		return m.GetUserByEmailFn(email)
 	}
 	return nil, nil
 }
 
-func (m *mockUserRepo) GetUserByID(id string) (*model.User, error) {
+func (m *mockUserRepo) GetUserByID(ctx context.Context, id string) (*model.User, error) {
 	if m.GetUserByIDFn != nil {
 		return m.GetUserByIDFn(id)
 	}
 	return nil, nil
 }
 
-func (m *mockUserRepo) GetAllUsers(page, limit int64) ([]model.User, int64, error) {
+func (m *mockUserRepo) GetAllUsers(ctx context.Context, page, limit int64, filter model.UserFilter) ([]model.User, int64, error) {
 	if m.GetAllUsersFn != nil {
-		return m.GetAllUsersFn(page, limit)
+		return m.GetAllUsersFn(page, limit, filter)
 	}
 	return nil, 0, nil
 }
 
-func (m *mockUserRepo) GetUsersByRoleName(roleName string, page, limit int64) ([]model.User, int64, error) {
+func (m *mockUserRepo) GetUsersByRoleName(ctx context.Context, roleName string, page, limit int64) ([]model.User, int64, error) {
 	if m.GetUsersByRoleNameFn != nil {
 		return m.GetUsersByRoleNameFn(roleName, page, limit)
 	}
 	return nil, 0, nil
 }
 
-func (m *mockUserRepo) CreateUser(req model.CreateUserRequest) (string, error) {
+func (m *mockUserRepo) GetUsersWithoutRole(ctx context.Context, page, limit int64) ([]model.User, int64, error) {
+	if m.GetUsersWithoutRoleFn != nil {
+		return m.GetUsersWithoutRoleFn(page, limit)
+	}
+	return nil, 0, nil
+}
+
+func (m *mockUserRepo) GetLockedUntil(ctx context.Context, email string) (*time.Time, error) {
+	if m.GetLockedUntilFn != nil {
+		return m.GetLockedUntilFn(email)
+	}
+	return nil, nil
+}
+
+func (m *mockUserRepo) RecordFailedLogin(ctx context.Context, email string, maxAttempts int, lockFor time.Duration) error {
+	if m.RecordFailedLoginFn != nil {
+		return m.RecordFailedLoginFn(email, maxAttempts, lockFor)
+	}
+	return nil
+}
+
+func (m *mockUserRepo) ResetLoginAttempts(ctx context.Context, userID string) error {
+	if m.ResetLoginAttemptsFn != nil {
+		return m.ResetLoginAttemptsFn(userID)
+	}
+	return nil
+}
+
+func (m *mockUserRepo) GetLockedUsers(ctx context.Context, page, limit int64) ([]model.LockedUser, int64, error) {
+	if m.GetLockedUsersFn != nil {
+		return m.GetLockedUsersFn(page, limit)
+	}
+	return nil, 0, nil
+}
+
+func (m *mockUserRepo) IsUserDisabled(ctx context.Context, userID string) (bool, error) {
+	if m.IsUserDisabledFn != nil {
+		return m.IsUserDisabledFn(userID)
+	}
+	return false, nil
+}
+
+func (m *mockUserRepo) SetUserDisabled(ctx context.Context, userID string, disabled bool) error {
+	if m.SetUserDisabledFn != nil {
+		return m.SetUserDisabledFn(userID, disabled)
+	}
+	return nil
+}
+
+func (m *mockUserRepo) CountUsersByRoleName(ctx context.Context, roleName string) (int64, error) {
+	if m.CountUsersByRoleNameFn != nil {
+		return m.CountUsersByRoleNameFn(roleName)
+	}
+	return 0, nil
+}
+
+func (m *mockUserRepo) CreateUser(ctx context.Context, req model.CreateUserRequest) (string, error) {
 	if m.CreateUserFn != nil {
 		return m.CreateUserFn(req)
 	}
 	return "", nil
 }
 
-func (m *mockUserRepo) UpdateUser(id string, req model.UpdateUserRequest) error {
+func (m *mockUserRepo) UpdateUser(ctx context.Context, id string, req model.UpdateUserRequest) error {
 	if m.UpdateUserFn != nil {
 		return m.UpdateUserFn(id, req)
 	}
 	return nil
 }
 
-func (m *mockUserRepo) DeleteUser(id string) error {
+func (m *mockUserRepo) PatchUser(ctx context.Context, id string, req model.PatchUserRequest) error {
+	if m.PatchUserFn != nil {
+		return m.PatchUserFn(id, req)
+	}
+	return nil
+}
+
+func (m *mockUserRepo) DeleteUser(ctx context.Context, id string) error {
 	if m.DeleteUserFn != nil {
 		return m.DeleteUserFn(id)
 	}
 	return nil
 }
 
-func (m *mockUserRepo) GetAllRoles(page, limit int64) ([]model.Role, int64, error) {
+func (m *mockUserRepo) GetAllRoles(ctx context.Context, page, limit int64) ([]model.Role, int64, error) {
 	return nil, 0, nil
 }
-func (m *mockUserRepo) GetRoleByID(id string) (*model.Role, error)                   { return nil, nil }
-func (m *mockUserRepo) GetRoleByName(name string) (*model.Role, error)               { return nil, nil }
-func (m *mockUserRepo) GetUserPermissions(userID string) ([]model.Permission, error) { return nil, nil }
+func (m *mockUserRepo) GetRoleByID(ctx context.Context, id string) (*model.Role, error)                   { return nil, nil }
+func (m *mockUserRepo) GetRoleByName(ctx context.Context, name string) (*model.Role, error)               { return nil, nil }
+func (m *mockUserRepo) GetUserPermissions(ctx context.Context, userID string) ([]model.Permission, error) {
+	if m.GetUserPermissionsFn != nil {
+		return m.GetUserPermissionsFn(userID)
+	}
+	return nil, nil
+}
 
 func jsonBody(t *testing.T, v any) *bytes.Reader {
 	t.Helper()
@@ -243,8 +325,8 @@ func TestRegister_InvalidEmail(t *testing.T) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusBadRequest {
-		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", resp.StatusCode)
 	}
 	body := decodeMap(t, resp)
 	if body["message"] != "Format email tidak valid" {
@@ -338,8 +420,8 @@ func TestRegister_EmptyPassword(t *testing.T) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusBadRequest {
-		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", resp.StatusCode)
 	}
 
 	body := decodeMap(t, resp)
@@ -368,8 +450,8 @@ func TestRegister_EmptyFullName(t *testing.T) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusBadRequest {
-		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", resp.StatusCode)
 	}
 
 	body := decodeMap(t, resp)
@@ -398,8 +480,8 @@ func TestRegister_EmptyEmail(t *testing.T) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusBadRequest {
-		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", resp.StatusCode)
 	}
 
 	body := decodeMap(t, resp)
@@ -462,6 +544,53 @@ func TestLogin_Success(t *testing.T) {
 	}
 }
 
+
+func TestLogin_MessageFollowsAcceptLanguage(t *testing.T) {
+	userRepo = &mockUserRepo{
+		LoginFn: func(email, password string) (*model.User, error) {
+			return &model.User{ID: "u1", Email: email, Username: "user_1", IsActive: true}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/login", func(c *fiber.Ctx) error { return Login(c, nil) })
+
+	for _, tc := range []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"", "Login berhasil"},
+		{"id", "Login berhasil"},
+		{"en-US,en;q=0.9", "Login successful"},
+		{"fr", "Login berhasil"},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/login", jsonBody(t, model.LoginRequest{Email: "a@mail.com", Password: "x"}))
+		req.Header.Set("Content-Type", "application/json")
+		if tc.acceptLanguage != "" {
+			req.Header.Set("Accept-Language", tc.acceptLanguage)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if body := decodeMap(t, resp); body["message"] != tc.want {
+			t.Fatalf("Accept-Language %q: got %#v want %q", tc.acceptLanguage, body["message"], tc.want)
+		}
+	}
+
+	// pesan error auth juga diterjemahkan
+	req := httptest.NewRequest(http.MethodPost, "/login", jsonBody(t, model.LoginRequest{}))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "en")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if body := decodeMap(t, resp); body["message"] != "Email and password are required" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
 func TestLogin_MissingFields(t *testing.T) {
 	userRepo = &mockUserRepo{}
 
@@ -524,7 +653,7 @@ func TestLogin_UnauthorizedFromRepo(t *testing.T) {
 //GET ALL USERS Test
 func TestGetAllUsersService_Success_DefaultPagination(t *testing.T) {
 	mock := &mockUserRepo{
-		GetAllUsersFn: func(page, limit int64) ([]model.User, int64, error) {
+		GetAllUsersFn: func(page, limit int64, filter model.UserFilter) ([]model.User, int64, error) {
 			if page != 1 || limit != 10 {
 				t.Fatalf("expected default page=1 limit=10, got page=%d limit=%d", page, limit)
 			}
@@ -583,7 +712,7 @@ func TestGetAllUsersService_Success_DefaultPagination(t *testing.T) {
 
 func TestGetAllUsersService_RepoError(t *testing.T) {
 	mock := &mockUserRepo{
-		GetAllUsersFn: func(page, limit int64) ([]model.User, int64, error) {
+		GetAllUsersFn: func(page, limit int64, filter model.UserFilter) ([]model.User, int64, error) {
 			return nil, 0, errors.New("db error")
 		},
 	}
@@ -609,6 +738,97 @@ func TestGetAllUsersService_RepoError(t *testing.T) {
 	}
 }
 
+func TestGetAllUsersService_ExcludeSelf(t *testing.T) {
+	mock := &mockUserRepo{
+		GetAllUsersFn: func(page, limit int64, filter model.UserFilter) ([]model.User, int64, error) {
+			if filter.ExcludeID != "admin-1" {
+				t.Fatalf("caller must be excluded in the query, got %q", filter.ExcludeID)
+			}
+			if page != 1 || limit != 2 {
+				t.Fatalf("unexpected pagination: %d %d", page, limit)
+			}
+			return []model.User{
+				{ID: "u2", Username: "user2", Email: "u2@mail.com", IsActive: true},
+				{ID: "u3", Username: "user3", Email: "u3@mail.com", IsActive: true},
+			}, 4, nil
+		},
+	}
+	userRepo = mock
+
+	app := fiber.New()
+	app.Get("/users", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "admin-1")
+		return GetAllUsersService(c)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users?exclude_self=true&limit=2", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	body := decodeMap(t, resp)
+	data, ok := body["data"].([]any)
+	if !ok || len(data) != 2 {
+		t.Fatalf("expected a full page of 2 users, got %#v", body["data"])
+	}
+	if body["total"] != float64(4) {
+		t.Fatalf("total must come from the filtered count: %#v", body["total"])
+	}
+}
+
+func TestGetAllUsersService_IsActiveParam(t *testing.T) {
+	var gotFilter model.UserFilter
+	called := false
+	userRepo = &mockUserRepo{
+		GetAllUsersFn: func(page, limit int64, filter model.UserFilter) ([]model.User, int64, error) {
+			called = true
+			gotFilter = filter
+			return []model.User{}, 0, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/users", GetAllUsersService)
+
+	for _, bad := range []string{"yes", "1", "TRUE"} {
+		called = false
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users?is_active="+bad, nil))
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("is_active=%s: expected 400, got %d", bad, resp.StatusCode)
+		}
+		if called {
+			t.Fatalf("is_active=%s: repo should not be called", bad)
+		}
+	}
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users?is_active=false", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if gotFilter.IsActive == nil || *gotFilter.IsActive {
+		t.Fatalf("expected is_active=false filter, got %+v", gotFilter)
+	}
+
+	if _, err := app.Test(httptest.NewRequest(http.MethodGet, "/users", nil)); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if gotFilter.IsActive != nil {
+		t.Fatalf("expected no filter when param absent, got %+v", gotFilter)
+	}
+}
+
 func TestGetUserByIDService_Success(t *testing.T) {
 	mock := &mockUserRepo{
 		GetUserByIDFn: func(id string) (*model.User, error) {
@@ -657,10 +877,71 @@ func TestGetUserByIDService_Success(t *testing.T) {
 	}
 }
 
+func TestUserServices_FieldsProjection(t *testing.T) {
+	user := model.User{
+		ID:           "u1",
+		Username:     "user1",
+		Email:        "u1@mail.com",
+		FullName:     "User One",
+		PasswordHash: "secret-hash",
+		IsActive:     true,
+	}
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			return &user, nil
+		},
+		GetAllUsersFn: func(page, limit int64, filter model.UserFilter) ([]model.User, int64, error) {
+			return []model.User{user}, 1, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/users", GetAllUsersService)
+	app.Get("/users/:id", GetUserByIDService)
+
+	want := map[string]bool{"id": true, "username": true, "email": true}
+	assertKeys := func(t *testing.T, item map[string]any) {
+		t.Helper()
+		if len(item) != len(want) {
+			t.Fatalf("expected keys %v, got %#v", want, item)
+		}
+		for k := range item {
+			if !want[k] {
+				t.Fatalf("unexpected key %q in %#v", k, item)
+			}
+		}
+	}
+
+	// "nope" dan password_hash tidak ada di UserResponse sehingga diabaikan
+	const query = "?fields=id,username,email,nope,password_hash"
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users/u1"+query, nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	body := decodeMap(t, resp)
+	data, ok := body["data"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected object data, got %#v", body["data"])
+	}
+	assertKeys(t, data)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/users"+query, nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	body = decodeMap(t, resp)
+	list, ok := body["data"].([]any)
+	if !ok || len(list) != 1 {
+		t.Fatalf("expected one item, got %#v", body["data"])
+	}
+	assertKeys(t, list[0].(map[string]any))
+}
+
 func TestGetUserByIDService_NotFound(t *testing.T) {
 	mock := &mockUserRepo{
 		GetUserByIDFn: func(id string) (*model.User, error) {
-			return nil, errors.New("user tidak ditemukan")
+			return nil, repository.ErrUserNotFound
 		},
 	}
 	userRepo = mock
@@ -938,162 +1219,351 @@ func TestGetUserByIDService_RepoError(t *testing.T) {
 // 	}
 // }
 
-// func TestGetUsersByRoleNameService_MissingName(t *testing.T) {
-// 	userRepo = &mockUserRepo{}
-
-// 	app := fiber.New()
-// 	app.Get("/users/byrole", GetUsersByRoleNameService)
-
-// 	req := httptest.NewRequest(http.MethodGet, "/users/byrole", nil)
-// 	resp, err := app.Test(req)
-// 	if err != nil {
-// 		t.Fatalf("app.Test: %v", err)
-// 	}
-// 	defer resp.Body.Close()
-
-// 	if resp.StatusCode != http.StatusBadRequest {
-// 		t.Fatalf("expected 400, got %d", resp.StatusCode)
-// 	}
-// }
-
-// func TestGetUsersByRoleNameService_Success(t *testing.T) {
-// 	mock := &mockUserRepo{
-// 		GetUsersByRoleNameFn: func(roleName string, page, limit int64) ([]model.User, int64, error) {
-// 			if roleName != "admin" {
-// 				t.Fatalf("expected roleName=admin, got %q", roleName)
-// 			}
-// 			return []model.User{
-// 				{ID: "u1", Username: "user1", Email: "u1@mail.com", FullName: "User One", RoleID: "role-admin", IsActive: true},
-// 			}, 1, nil
-// 		},
-// 	}
-// 	userRepo = mock
-
-// 	app := fiber.New()
-// 	app.Get("/users/byrole", GetUsersByRoleNameService)
-
-// 	req := httptest.NewRequest(http.MethodGet, "/users/byrole?name=admin&page=1&limit=10", nil)
-// 	resp, err := app.Test(req)
-// 	if err != nil {
-// 		t.Fatalf("app.Test: %v", err)
-// 	}
-// 	defer resp.Body.Close()
-
-// 	if resp.StatusCode != http.StatusOK {
-// 		t.Fatalf("expected 200, got %d", resp.StatusCode)
-// 	}
-
-// 	body := decodeMap(t, resp)
-// 	if body["success"] != true {
-// 		t.Fatalf("expected success=true, got %#v", body["success"])
-// 	}
-// }
-
-// func TestGetUsersByRoleNameService_RoleNotFound(t *testing.T) {
-// 	mock := &mockUserRepo{
-// 		GetUsersByRoleNameFn: func(roleName string, page, limit int64) ([]model.User, int64, error) {
-// 			return nil, 0, errors.New("role tidak ditemukan")
-// 		},
-// 	}
-// 	userRepo = mock
+func TestGetUsersByRoleNameService_MissingName(t *testing.T) {
+	userRepo = &mockUserRepo{}
 
-// 	app := fiber.New()
-// 	app.Get("/users/byrole", GetUsersByRoleNameService)
+	app := fiber.New()
+	app.Get("/users/byrole", GetUsersByRoleNameService)
 
-// 	req := httptest.NewRequest(http.MethodGet, "/users/byrole?name=unknown", nil)
-// 	resp, err := app.Test(req)
-// 	if err != nil {
-// 		t.Fatalf("app.Test: %v", err)
-// 	}
-// 	defer resp.Body.Close()
+	req := httptest.NewRequest(http.MethodGet, "/users/byrole", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
 
-// 	if resp.StatusCode != http.StatusNotFound {
-// 		t.Fatalf("expected 404, got %d", resp.StatusCode)
-// 	}
-// }
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
 
-//CREATE USER ADMIN Test
-func TestCreateUserAdmin_Success(t *testing.T) {
+func TestGetUsersByRoleNameService_Success(t *testing.T) {
 	mock := &mockUserRepo{
-		GetUserByUsernameFn: func(username string) (*model.User, error) {
-			return nil, nil
-		},
-		CreateUserFn: func(req model.CreateUserRequest) (string, error) {
-			if req.Username != "user1" {
-				t.Fatalf("unexpected username: %q", req.Username)
+		GetUsersByRoleNameFn: func(roleName string, page, limit int64) ([]model.User, int64, error) {
+			if roleName != "admin" {
+				t.Fatalf("expected roleName=admin, got %q", roleName)
 			}
-			return "new-id-123", nil
+			return []model.User{
+				{ID: "u1", Username: "user1", Email: "u1@mail.com", FullName: "User One", RoleID: "role-admin", IsActive: true},
+			}, 1, nil
 		},
 	}
 	userRepo = mock
 
 	app := fiber.New()
-	app.Post("/users", CreateUserAdmin)
-
-	reqBody := model.CreateUserRequest{
-		Username: "user1",
-		Email:    "user1@mail.com",
-		Password: "Abcd1",
-		FullName: "User One",
-	}
-
-	req := httptest.NewRequest(http.MethodPost, "/users", jsonBody(t, reqBody))
-	req.Header.Set("Content-Type", "application/json")
+	app.Get("/users/byrole", GetUsersByRoleNameService)
 
+	req := httptest.NewRequest(http.MethodGet, "/users/byrole?name=admin&page=1&limit=10", nil)
 	resp, err := app.Test(req)
 	if err != nil {
 		t.Fatalf("app.Test: %v", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
-		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
 	}
+
 	body := decodeMap(t, resp)
 	if body["success"] != true {
 		t.Fatalf("expected success=true, got %#v", body["success"])
 	}
-	if body["message"] != "User berhasil dibuat" {
-		t.Fatalf("unexpected message: %#v", body["message"])
-	}
-	if body["id"] != "new-id-123" {
-		t.Fatalf("unexpected id: %#v", body["id"])
-	}
 }
 
-func TestCreateUserAdmin_UsernameAlreadyExists(t *testing.T) {
+func TestGetUsersByRoleNameService_RoleNotFound(t *testing.T) {
 	mock := &mockUserRepo{
-		GetUserByUsernameFn: func(username string) (*model.User, error) {
-			return &model.User{ID: "exists"}, nil
+		GetUsersByRoleNameFn: func(roleName string, page, limit int64) ([]model.User, int64, error) {
+			return nil, 0, errors.New("role tidak ditemukan")
 		},
 	}
 	userRepo = mock
 
 	app := fiber.New()
-	app.Post("/users", CreateUserAdmin)
-
-	reqBody := model.CreateUserRequest{
-		Username: "user1",
-		Email:    "user1@mail.com",
-		Password: "Abcd1",
-		FullName: "User One",
-	}
-
-	req := httptest.NewRequest(http.MethodPost, "/users", jsonBody(t, reqBody))
-	req.Header.Set("Content-Type", "application/json")
+	app.Get("/users/byrole", GetUsersByRoleNameService)
 
+	req := httptest.NewRequest(http.MethodGet, "/users/byrole?name=unknown", nil)
 	resp, err := app.Test(req)
 	if err != nil {
 		t.Fatalf("app.Test: %v", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusBadRequest {
-		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", resp.StatusCode)
 	}
-	body := decodeMap(t, resp)
-	if body["message"] != "Username sudah terdaftar" {
-		t.Fatalf("unexpected message: %#v", body["message"])
+}
+
+func TestGetUsersByRoleNameService_EmptyRoleReturnsEmptyArray(t *testing.T) {
+	var gotPage, gotLimit int64
+	userRepo = &mockUserRepo{
+		GetUsersByRoleNameFn: func(roleName string, page, limit int64) ([]model.User, int64, error) {
+			gotPage, gotLimit = page, limit
+			return nil, 0, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/users/byrole", GetUsersByRoleNameService)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/byrole?name=dosen&page=0&limit=100000", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if gotPage != 1 || gotLimit != defaultMaxPageLimit {
+		t.Fatalf("pagination not clamped: page=%d limit=%d", gotPage, gotLimit)
+	}
+
+	body := decodeMap(t, resp)
+	data, ok := body["data"].([]interface{})
+	if !ok || len(data) != 0 {
+		t.Fatalf("expected empty data array, got %#v", body["data"])
+	}
+}
+
+func TestGetUsersByRoleNameService_RepoError(t *testing.T) {
+	userRepo = &mockUserRepo{
+		GetUsersByRoleNameFn: func(roleName string, page, limit int64) ([]model.User, int64, error) {
+			return nil, 0, errors.New("gagal query users by role: connection refused")
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/users/byrole", GetUsersByRoleNameService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users/byrole?name=admin", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", resp.StatusCode)
+	}
+}
+
+//CREATE USER ADMIN Test
+func TestCreateUserAdmin_Success(t *testing.T) {
+	mock := &mockUserRepo{
+		GetUserByUsernameFn: func(username string) (*model.User, error) {
+			return nil, nil
+		},
+		CreateUserFn: func(req model.CreateUserRequest) (string, error) {
+			if req.Username != "user1" {
+				t.Fatalf("unexpected username: %q", req.Username)
+			}
+			return "new-id-123", nil
+		},
+	}
+	userRepo = mock
+
+	app := fiber.New()
+	app.Post("/users", CreateUserAdmin)
+
+	reqBody := model.CreateUserRequest{
+		Username: "user1",
+		Email:    "user1@mail.com",
+		Password: "Abcd1",
+		FullName: "User One",
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/users", jsonBody(t, reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	}
+	body := decodeMap(t, resp)
+	if body["success"] != true {
+		t.Fatalf("expected success=true, got %#v", body["success"])
+	}
+	if body["message"] != "User berhasil dibuat" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+	if body["id"] != "new-id-123" {
+		t.Fatalf("unexpected id: %#v", body["id"])
+	}
+}
+
+func postCreateUser(t *testing.T, payload map[string]any) *http.Response {
+	t.Helper()
+	app := fiber.New()
+	app.Post("/users", CreateUserAdmin)
+
+	req := httptest.NewRequest(http.MethodPost, "/users", jsonBody(t, payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	return resp
+}
+
+func TestCreateUserAdmin_MalformedVsInvalidFields(t *testing.T) {
+	userRepo = &mockUserRepo{}
+
+	app := fiber.New()
+	app.Post("/users", CreateUserAdmin)
+	app.Post("/register", func(c *fiber.Ctx) error { return Register(c, nil) })
+
+	for _, path := range []string{"/users", "/register"} {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(`{"username":`)))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("%s malformed json: expected 400, got %d", path, resp.StatusCode)
+		}
+	}
+
+	resp := postCreateUser(t, map[string]any{
+		"username":  "user_1",
+		"email":     "bukan-email",
+		"password":  "Secret1",
+		"full_name": "User One",
+	})
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("invalid email: expected 422, got %d", resp.StatusCode)
+	}
+	if body := decodeMap(t, resp); body["message"] != "Format email tidak valid" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+
+	resp = postCreateUser(t, map[string]any{"username": "user_1"})
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("missing fields: expected 422, got %d", resp.StatusCode)
+	}
+}
+
+func TestCreateUserAdmin_WithRoleName(t *testing.T) {
+	var created model.CreateUserRequest
+	userRepo = &mockUserRepo{
+		GetUserByUsernameFn: func(username string) (*model.User, error) { return nil, nil },
+		CreateUserFn: func(req model.CreateUserRequest) (string, error) {
+			created = req
+			return "new-id", nil
+		},
+	}
+	rolesRepo = &mockRoleRepo{
+		GetRoleByNameFn: func(name string) (*model.Role, error) {
+			if name != "dosen wali" {
+				t.Fatalf("unexpected role name: %q", name)
+			}
+			return &model.Role{ID: "role-dosen", Name: "Dosen Wali"}, nil
+		},
+	}
+
+	resp := postCreateUser(t, map[string]any{
+		"username": "user1", "email": "user1@mail.com", "password": "Abcd1", "full_name": "User One",
+		"role_name": " dosen wali ",
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	}
+	if created.RoleID != "role-dosen" {
+		t.Fatalf("role_id should be resolved from role_name, got %q", created.RoleID)
+	}
+}
+
+func TestCreateUserAdmin_UnknownRoleName(t *testing.T) {
+	userRepo = &mockUserRepo{
+		GetUserByUsernameFn: func(username string) (*model.User, error) { return nil, nil },
+		CreateUserFn: func(req model.CreateUserRequest) (string, error) {
+			t.Fatalf("CreateUser should not be called for unknown role")
+			return "", nil
+		},
+	}
+	rolesRepo = &mockRoleRepo{
+		GetRoleByNameFn: func(name string) (*model.Role, error) {
+			return nil, errors.New("role tidak ditemukan")
+		},
+	}
+
+	resp := postCreateUser(t, map[string]any{
+		"username": "user1", "email": "user1@mail.com", "password": "Abcd1", "full_name": "User One",
+		"role_name": "superhero",
+	})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	if body := decodeMap(t, resp); body["message"] != "Role 'superhero' tidak ditemukan" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
+func TestCreateUserAdmin_WithoutRoleName(t *testing.T) {
+	var created model.CreateUserRequest
+	userRepo = &mockUserRepo{
+		GetUserByUsernameFn: func(username string) (*model.User, error) { return nil, nil },
+		CreateUserFn: func(req model.CreateUserRequest) (string, error) {
+			created = req
+			return "new-id", nil
+		},
+	}
+	rolesRepo = &mockRoleRepo{
+		GetRoleByNameFn: func(name string) (*model.Role, error) {
+			t.Fatalf("role lookup should be skipped when role_name is omitted")
+			return nil, nil
+		},
+	}
+
+	resp := postCreateUser(t, map[string]any{
+		"username": "user1", "email": "user1@mail.com", "password": "Abcd1", "full_name": "User One",
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	}
+	if created.RoleID != "" {
+		t.Fatalf("role_id should stay empty, got %q", created.RoleID)
+	}
+}
+
+func TestCreateUserAdmin_UsernameAlreadyExists(t *testing.T) {
+	mock := &mockUserRepo{
+		GetUserByUsernameFn: func(username string) (*model.User, error) {
+			return &model.User{ID: "exists"}, nil
+		},
+	}
+	userRepo = mock
+
+	app := fiber.New()
+	app.Post("/users", CreateUserAdmin)
+
+	reqBody := model.CreateUserRequest{
+		Username: "user1",
+		Email:    "user1@mail.com",
+		Password: "Abcd1",
+		FullName: "User One",
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/users", jsonBody(t, reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	body := decodeMap(t, resp)
+	if body["message"] != "Username sudah terdaftar" {
+		t.Fatalf("unexpected message: %#v", body["message"])
 	}
 }
 
@@ -1227,6 +1697,39 @@ func TestUpdateUserService_UpdateError(t *testing.T) {
 	}
 }
 
+func TestUpdateUserService_StaleUpdatedAtConflict(t *testing.T) {
+	stale := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	mock := &mockUserRepo{
+		UpdateUserFn: func(id string, req model.UpdateUserRequest) error {
+			if req.UpdatedAt == nil || !req.UpdatedAt.Equal(stale) {
+				t.Fatalf("updated_at not forwarded: %v", req.UpdatedAt)
+			}
+			return repository.ErrConcurrentUpdate
+		},
+	}
+	userRepo = mock
+
+	app := fiber.New()
+	app.Put("/users/:id", UpdateUserService)
+
+	req := httptest.NewRequest(http.MethodPut, "/users/u1", jsonBody(t, model.UpdateUserRequest{FullName: "Nama Baru", UpdatedAt: &stale}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", resp.StatusCode)
+	}
+	body := decodeMap(t, resp)
+	if body["message"] != "data telah diubah oleh proses lain" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
 //DELETE USER Test
 func TestDeleteUserService_Success(t *testing.T) {
 	mock := &mockUserRepo{
@@ -1285,62 +1788,334 @@ func TestDeleteUserService_Error(t *testing.T) {
 	}
 }
 
-//REFRESH TOKEN Tests
-func TestRefresh_Success(t *testing.T) {
-	user := &model.User{
-		ID:       "user-123",
-		Username: "testuser",
-		Email:    "test@example.com",
-		FullName: "Test User",
-		RoleID:   "user-role",
-		IsActive: true,
-	}
-
-	// Generate a valid token
-	validToken, err := utils.GenerateJWTPostgres(user)
-	if err != nil {
-		t.Fatalf("failed to generate test token: %v", err)
-	}
-
-	mock := &mockUserRepo{
+func TestDeleteUserService_LastAdminRejected(t *testing.T) {
+	userRepo = &mockUserRepo{
 		GetUserByIDFn: func(id string) (*model.User, error) {
-			if id != "user-123" {
-				t.Fatalf("expected user id 'user-123', got %q", id)
+			return &model.User{ID: id, RoleID: "role-admin", IsActive: true}, nil
+		},
+		CountUsersByRoleNameFn: func(roleName string) (int64, error) {
+			if roleName != "admin" {
+				t.Fatalf("unexpected role name: %q", roleName)
 			}
-			return user, nil
+			return 1, nil
+		},
+		DeleteUserFn: func(id string) error {
+			t.Fatalf("DeleteUser should not be called for last admin")
+			return nil
+		},
+	}
+	rolesRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "Admin"}, nil
 		},
 	}
-	userRepo = mock
 
 	app := fiber.New()
-	app.Post("/refresh", func(c *fiber.Ctx) error { return Refresh(c, nil) })
-
-	req := httptest.NewRequest(http.MethodPost, "/refresh", jsonBody(t, model.RefreshTokenRequest{
-		Token: validToken,
-	}))
-	req.Header.Set("Content-Type", "application/json")
+	app.Delete("/users/:id", DeleteUserService)
 
+	req := httptest.NewRequest(http.MethodDelete, "/users/admin-2", nil)
 	resp, err := app.Test(req)
 	if err != nil {
 		t.Fatalf("app.Test: %v", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", resp.StatusCode)
 	}
-
 	body := decodeMap(t, resp)
-	if body["success"] != true {
-		t.Fatalf("expected success=true, got %#v", body["success"])
-	}
-	if body["message"] != "Token berhasil direfresh" {
+	if body["message"] != "Tidak dapat menghapus admin terakhir" {
 		t.Fatalf("unexpected message: %#v", body["message"])
 	}
-	if tok, _ := body["token"].(string); tok == "" {
-		t.Fatalf("expected non-empty new token")
-	}
-	if body["user"] == nil {
+}
+
+func TestDeleteUserService_NotLastAdmin(t *testing.T) {
+	deleted := false
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			return &model.User{ID: id, RoleID: "role-admin", IsActive: true}, nil
+		},
+		CountUsersByRoleNameFn: func(roleName string) (int64, error) {
+			return 2, nil
+		},
+		DeleteUserFn: func(id string) error {
+			deleted = true
+			return nil
+		},
+	}
+	rolesRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "admin"}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Delete("/users/:id", DeleteUserService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/users/admin-2", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if !deleted {
+		t.Fatalf("expected DeleteUser to be called")
+	}
+}
+
+func TestUpdateUserRoleByNameService_LastAdminDemoteRejected(t *testing.T) {
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			return &model.User{ID: id, RoleID: "role-admin", IsActive: true}, nil
+		},
+		CountUsersByRoleNameFn: func(roleName string) (int64, error) {
+			return 1, nil
+		},
+		UpdateUserFn: func(id string, req model.UpdateUserRequest) error {
+			t.Fatalf("UpdateUser should not be called when demoting last admin")
+			return nil
+		},
+	}
+	rolesRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "admin"}, nil
+		},
+		GetRoleByNameFn: func(name string) (*model.Role, error) {
+			return &model.Role{ID: "role-staff", Name: "staff"}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/users/:id/role", UpdateUserRoleByNameService)
+
+	req := httptest.NewRequest(http.MethodPut, "/users/admin-2/role", jsonBody(t, model.UpdateUserRoleByNameRequest{RoleName: "staff"}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", resp.StatusCode)
+	}
+	body := decodeMap(t, resp)
+	if body["message"] != "Tidak dapat menghapus admin terakhir" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
+func TestDeleteUserService_SelfRejected(t *testing.T) {
+	mock := &mockUserRepo{
+		DeleteUserFn: func(id string) error {
+			t.Fatalf("DeleteUser should not be called for own account")
+			return nil
+		},
+	}
+	userRepo = mock
+
+	app := fiber.New()
+	app.Delete("/users/:id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "admin-1")
+		return DeleteUserService(c)
+	})
+
+	req := httptest.NewRequest(http.MethodDelete, "/users/admin-1", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	body := decodeMap(t, resp)
+	if body["message"] != "Tidak dapat menonaktifkan/menghapus akun sendiri" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
+func TestUpdateUserService_SelfDeactivateRejected(t *testing.T) {
+	mock := &mockUserRepo{
+		UpdateUserFn: func(id string, req model.UpdateUserRequest) error {
+			t.Fatalf("UpdateUser should not be called when deactivating own account")
+			return nil
+		},
+	}
+	userRepo = mock
+
+	app := fiber.New()
+	app.Put("/users/:id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "admin-1")
+		return UpdateUserService(c)
+	})
+
+	isActive := false
+	req := httptest.NewRequest(http.MethodPut, "/users/admin-1", jsonBody(t, model.UpdateUserRequest{
+		IsActive: &isActive,
+	}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	body := decodeMap(t, resp)
+	if body["message"] != "Tidak dapat menonaktifkan/menghapus akun sendiri" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
+func TestUpdateUserService_DeactivateLastAdminRejected(t *testing.T) {
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			return &model.User{ID: id, RoleID: "role-admin", IsActive: true}, nil
+		},
+		CountUsersByRoleNameFn: func(roleName string) (int64, error) {
+			return 1, nil
+		},
+		UpdateUserFn: func(id string, req model.UpdateUserRequest) error {
+			t.Fatalf("UpdateUser should not be called when deactivating last admin")
+			return nil
+		},
+	}
+	rolesRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) {
+			return &model.Role{ID: id, Name: "admin"}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Put("/users/:id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "admin-1")
+		return UpdateUserService(c)
+	})
+
+	isActive := false
+	req := httptest.NewRequest(http.MethodPut, "/users/admin-2", jsonBody(t, model.UpdateUserRequest{
+		IsActive: &isActive,
+	}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", resp.StatusCode)
+	}
+	body := decodeMap(t, resp)
+	if body["message"] != "Tidak dapat menonaktifkan admin terakhir" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
+func TestLastAdminGuard_LookupErrorFailsClosed(t *testing.T) {
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			return nil, errors.New("connection refused")
+		},
+		DeleteUserFn: func(id string) error {
+			t.Fatalf("DeleteUser must not run when the admin guard cannot be evaluated")
+			return nil
+		},
+		UpdateUserFn: func(id string, req model.UpdateUserRequest) error {
+			t.Fatalf("UpdateUser must not run when the admin guard cannot be evaluated")
+			return nil
+		},
+	}
+	rolesRepo = &mockRoleRepo{}
+
+	app := fiber.New()
+	app.Delete("/users/:id", DeleteUserService)
+	app.Put("/users/:id", UpdateUserService)
+
+	isActive := false
+	reqs := []*http.Request{
+		httptest.NewRequest(http.MethodDelete, "/users/admin-2", nil),
+		httptest.NewRequest(http.MethodPut, "/users/admin-2", jsonBody(t, model.UpdateUserRequest{IsActive: &isActive})),
+	}
+	for _, req := range reqs {
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("%s: expected 500, got %d", req.Method, resp.StatusCode)
+		}
+	}
+}
+
+//REFRESH TOKEN Tests
+func TestRefresh_Success(t *testing.T) {
+	user := &model.User{
+		ID:       "user-123",
+		Username: "testuser",
+		Email:    "test@example.com",
+		FullName: "Test User",
+		RoleID:   "user-role",
+		IsActive: true,
+	}
+
+	// Generate a valid token
+	validToken, err := utils.GenerateJWTPostgres(user)
+	if err != nil {
+		t.Fatalf("failed to generate test token: %v", err)
+	}
+
+	mock := &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			if id != "user-123" {
+				t.Fatalf("expected user id 'user-123', got %q", id)
+			}
+			return user, nil
+		},
+	}
+	userRepo = mock
+
+	app := fiber.New()
+	app.Post("/refresh", func(c *fiber.Ctx) error { return Refresh(c, nil) })
+
+	req := httptest.NewRequest(http.MethodPost, "/refresh", jsonBody(t, model.RefreshTokenRequest{
+		Token: validToken,
+	}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	body := decodeMap(t, resp)
+	if body["success"] != true {
+		t.Fatalf("expected success=true, got %#v", body["success"])
+	}
+	if body["message"] != "Token berhasil direfresh" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+	if tok, _ := body["token"].(string); tok == "" {
+		t.Fatalf("expected non-empty new token")
+	}
+	if body["user"] == nil {
 		t.Fatalf("expected user object in response")
 	}
 }
@@ -1467,7 +2242,7 @@ func TestRefresh_UserNotFound(t *testing.T) {
 
 	mock := &mockUserRepo{
 		GetUserByIDFn: func(id string) (*model.User, error) {
-			return nil, errors.New("user tidak ditemukan")
+			return nil, repository.ErrUserNotFound
 		},
 	}
 	userRepo = mock
@@ -1743,11 +2518,12 @@ func TestRefresh_RepositoryError(t *testing.T) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusUnauthorized {
-		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	// error DB bukan "user tidak ditemukan", jadi 500 bukan 401
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", resp.StatusCode)
 	}
 	body := decodeMap(t, resp)
-	if body["message"] != "User tidak ditemukan" {
+	if body["message"] != "Gagal mengambil data user" {
 		t.Fatalf("unexpected message: %#v", body["message"])
 	}
 }
@@ -1811,3 +2587,770 @@ func TestRefresh_ResponseIncludesUserData(t *testing.T) {
 		t.Fatalf("expected role_id 'admin', got %#v", userResp["role_id"])
 	}
 }
+
+func TestUnlockUserService_UnlocksLockedAccount(t *testing.T) {
+	userID := "5b0e7c9a-1f2d-4e3c-9a8b-7c6d5e4f3a2b"
+	lockedUntil := time.Now().Add(10 * time.Minute)
+	locked := map[string]*time.Time{userID: &lockedUntil}
+	userRepo = &mockUserRepo{
+		ResetLoginAttemptsFn: func(id string) error {
+			if _, ok := locked[id]; !ok {
+				return errors.New("user tidak ditemukan")
+			}
+			delete(locked, id)
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/users/:id/unlock", UnlockUserService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/users/"+userID+"/unlock", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	if _, still := locked[userID]; still {
+		t.Fatalf("user should be unlocked")
+	}
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodPost, "/users/00000000-0000-4000-8000-000000000404/unlock", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("unknown user status: got %d want %d", resp.StatusCode, http.StatusNotFound)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodPost, "/users/bukan-uuid/unlock", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("malformed id status: got %d want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestSetUserActiveService(t *testing.T) {
+	users := map[string]*model.User{
+		"u1": {ID: "u1", Username: "budi", IsActive: false},
+		"u2": {ID: "u2", Username: "sari", IsActive: true},
+	}
+	disabled := map[string]bool{}
+	updates := 0
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			if u, ok := users[id]; ok {
+				return u, nil
+			}
+			return nil, repository.ErrUserNotFound
+		},
+		UpdateUserFn: func(id string, req model.UpdateUserRequest) error {
+			t.Fatalf("SetUserActiveService must go through SetUserDisabled, got UpdateUser %+v", req)
+			return nil
+		},
+		SetUserDisabledFn: func(id string, d bool) error {
+			updates++
+			disabled[id] = d
+			users[id].IsActive = !d
+			return nil
+		},
+	}
+	rolesRepo = &mockRoleRepo{}
+
+	app := fiber.New()
+	app.Put("/users/:id/active", SetUserActiveService)
+	put := func(id, body string) *http.Response {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPut, "/users/"+id+"/active", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		return resp
+	}
+
+	if resp := put("u1", `{"is_active":true}`); resp.StatusCode != http.StatusOK {
+		t.Fatalf("activate: got %d want 200", resp.StatusCode)
+	}
+	if !users["u1"].IsActive {
+		t.Fatal("u1 should be active")
+	}
+
+	if resp := put("u2", `{"is_active":false}`); resp.StatusCode != http.StatusOK {
+		t.Fatalf("deactivate: got %d want 200", resp.StatusCode)
+	}
+	if users["u2"].IsActive {
+		t.Fatal("u2 should be inactive")
+	}
+
+	if disabled["u1"] || !disabled["u2"] {
+		t.Fatalf("disabled flags: got %+v", disabled)
+	}
+
+	if resp := put("missing", `{"is_active":true}`); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("not found: got %d want 404", resp.StatusCode)
+	}
+	if resp := put("u1", `{}`); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("missing is_active: got %d want 400", resp.StatusCode)
+	}
+	if updates != 2 {
+		t.Fatalf("expected 2 updates, got %d", updates)
+	}
+}
+
+func TestGetLockedUsersService_ListsLockedAccounts(t *testing.T) {
+	lockedUntil := time.Now().Add(10 * time.Minute)
+	userRepo = &mockUserRepo{
+		GetLockedUsersFn: func(page, limit int64) ([]model.LockedUser, int64, error) {
+			return []model.LockedUser{
+				{ID: "u1", Email: "a@example.com", LockedUntil: lockedUntil},
+				{ID: "u2", Email: "b@example.com", LockedUntil: lockedUntil},
+			}, 2, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/users/locked", GetLockedUsersService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users/locked", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+	var body struct {
+		Data  []model.LockedUser `json:"data"`
+		Total int64              `json:"total"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Total != 2 || len(body.Data) != 2 || body.Data[0].Email != "a@example.com" {
+		t.Fatalf("unexpected body: %+v", body)
+	}
+}
+
+func TestLogin_LockedAccountRejected(t *testing.T) {
+	lockedUntil := time.Now().Add(5 * time.Minute)
+	userRepo = &mockUserRepo{
+		GetLockedUntilFn: func(email string) (*time.Time, error) {
+			return &lockedUntil, nil
+		},
+		LoginFn: func(email, password string) (*model.User, error) {
+			t.Fatalf("Login should not be called for locked account")
+			return nil, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/login", func(c *fiber.Ctx) error { return Login(c, nil) })
+
+	req := httptest.NewRequest(http.MethodPost, "/login", jsonBody(t, map[string]any{"email": "a@example.com", "password": "Secret1"}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusLocked {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusLocked)
+	}
+}
+
+func TestLogin_DisabledAccountRejected(t *testing.T) {
+	userRepo = &mockUserRepo{
+		LoginFn: func(email, password string) (*model.User, error) {
+			return &model.User{ID: "u1", Email: email, IsActive: false}, nil
+		},
+		IsUserDisabledFn: func(userID string) (bool, error) {
+			return userID == "u1", nil
+		},
+		UpdateUserFn: func(id string, req model.UpdateUserRequest) error {
+			t.Fatalf("disabled account must not be reactivated by login, got %+v", req)
+			return nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/login", func(c *fiber.Ctx) error { return Login(c, nil) })
+
+	req := httptest.NewRequest(http.MethodPost, "/login", jsonBody(t, map[string]any{"email": "a@example.com", "password": "Secret1"}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusForbidden)
+	}
+}
+
+func assertTokenExpiry(t *testing.T, body map[string]any, ttl time.Duration) {
+	t.Helper()
+	expiresIn, ok := body["expires_in"].(float64)
+	if !ok {
+		t.Fatalf("expires_in missing: %#v", body["expires_in"])
+	}
+	if expiresIn > ttl.Seconds() || expiresIn < ttl.Seconds()-2 {
+		t.Fatalf("expires_in %v not consistent with TTL %s", expiresIn, ttl)
+	}
+	raw, _ := body["expires_at"].(string)
+	expiresAt, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		t.Fatalf("expires_at must be RFC3339, got %q: %v", raw, err)
+	}
+	if d := time.Until(expiresAt); d > ttl || d < ttl-3*time.Second {
+		t.Fatalf("expires_at %s not consistent with TTL %s", raw, ttl)
+	}
+
+	token, err := utils.ParseJWT(body["token"].(string))
+	if err != nil {
+		t.Fatalf("ParseJWT: %v", err)
+	}
+	if exp := token.Claims.(*utils.Claims).ExpiresAt.Time; !exp.Equal(expiresAt) {
+		t.Fatalf("expires_at %s differs from token exp %s", expiresAt, exp)
+	}
+}
+
+func TestLogin_IncludesTokenExpiry(t *testing.T) {
+	t.Setenv("JWT_ACCESS_TTL", "15m")
+	userRepo = &mockUserRepo{
+		LoginFn: func(email, password string) (*model.User, error) {
+			return &model.User{ID: "u1", Email: email, Username: "user_1", IsActive: true}, nil
+		},
+	}
+
+	app := fiber.New()
+	app.Post("/login", func(c *fiber.Ctx) error { return Login(c, nil) })
+
+	req := httptest.NewRequest(http.MethodPost, "/login", jsonBody(t, model.LoginRequest{Email: "a@b.com", Password: "whatever"}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	assertTokenExpiry(t, decodeMap(t, resp), 15*time.Minute)
+}
+
+func TestRefresh_IncludesTokenExpiry(t *testing.T) {
+	user := &model.User{ID: "user-123", Email: "test@example.com", IsActive: true}
+	validToken, err := utils.GenerateJWTPostgres(user)
+	if err != nil {
+		t.Fatalf("failed to generate test token: %v", err)
+	}
+
+	t.Setenv("JWT_ACCESS_TTL", "2h")
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) { return user, nil },
+	}
+
+	app := fiber.New()
+	app.Post("/refresh", func(c *fiber.Ctx) error { return Refresh(c, nil) })
+
+	req := httptest.NewRequest(http.MethodPost, "/refresh", jsonBody(t, model.RefreshTokenRequest{Token: validToken}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	body := decodeMap(t, resp)
+	assertTokenExpiry(t, body, 2*time.Hour)
+	if _, ok := body["refresh_token"]; ok {
+		t.Fatalf("legacy access-token refresh must not mint a refresh_token: %#v", body)
+	}
+}
+
+// memRefreshTokenRepo RefreshTokenRepository in-memory dengan semantik rotasi yang sama seperti Postgres.
+type memRefreshTokenRepo struct {
+	tokens []*model.RefreshToken
+}
+
+func (m *memRefreshTokenRepo) Create(token model.RefreshToken) error {
+	token.ID = fmt.Sprintf("rt-%d", len(m.tokens)+1)
+	m.tokens = append(m.tokens, &token)
+	return nil
+}
+
+func (m *memRefreshTokenRepo) GetByHash(tokenHash string) (*model.RefreshToken, error) {
+	for _, t := range m.tokens {
+		if t.TokenHash == tokenHash {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memRefreshTokenRepo) Rotate(usedID string, next model.RefreshToken) error {
+	for _, t := range m.tokens {
+		if t.ID == usedID {
+			if t.Used || t.Revoked {
+				return repository.ErrRefreshTokenReused
+			}
+			t.Used = true
+			return m.Create(next)
+		}
+	}
+	return repository.ErrRefreshTokenReused
+}
+
+func (m *memRefreshTokenRepo) RevokeFamily(familyID string) error {
+	for _, t := range m.tokens {
+		if t.FamilyID == familyID {
+			t.Revoked = true
+		}
+	}
+	return nil
+}
+
+// setupRefreshRotationTest login sekali dan mengembalikan app beserta refresh token pertama.
+func setupRefreshRotationTest(t *testing.T) (*fiber.App, *memRefreshTokenRepo, string) {
+	t.Helper()
+	user := &model.User{ID: "u1", Email: "a@b.com", Username: "user_1", IsActive: true}
+	userRepo = &mockUserRepo{
+		LoginFn:       func(email, password string) (*model.User, error) { return user, nil },
+		GetUserByIDFn: func(id string) (*model.User, error) { return user, nil },
+	}
+	store := &memRefreshTokenRepo{}
+	refreshTokenRepo = store
+	t.Cleanup(func() { refreshTokenRepo = nil })
+
+	app := fiber.New()
+	app.Post("/login", func(c *fiber.Ctx) error { return Login(c, nil) })
+	app.Post("/refresh", func(c *fiber.Ctx) error { return Refresh(c, nil) })
+
+	req := httptest.NewRequest(http.MethodPost, "/login", jsonBody(t, model.LoginRequest{Email: "a@b.com", Password: "whatever"}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+	body := decodeMap(t, resp)
+	refreshToken, _ := body["refresh_token"].(string)
+	if resp.StatusCode != http.StatusOK || refreshToken == "" {
+		t.Fatalf("login must return refresh_token, got %d %#v", resp.StatusCode, body)
+	}
+	return app, store, refreshToken
+}
+
+func postRefreshToken(t *testing.T, app *fiber.App, refreshToken string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/refresh", jsonBody(t, model.RefreshTokenRequest{RefreshToken: refreshToken}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, decodeMap(t, resp)
+}
+
+func TestRefresh_RotatesRefreshToken(t *testing.T) {
+	app, store, first := setupRefreshRotationTest(t)
+
+	status, body := postRefreshToken(t, app, first)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d %#v", status, body)
+	}
+	second, _ := body["refresh_token"].(string)
+	if second == "" || second == first {
+		t.Fatalf("expected a new refresh_token, got %q", second)
+	}
+	if _, err := utils.ParseJWT(body["token"].(string)); err != nil {
+		t.Fatalf("access token invalid: %v", err)
+	}
+
+	if len(store.tokens) != 2 {
+		t.Fatalf("expected 2 stored tokens, got %d", len(store.tokens))
+	}
+	old, next := store.tokens[0], store.tokens[1]
+	if !old.Used || next.Used {
+		t.Fatalf("old token must be used and new one fresh: old=%+v next=%+v", old, next)
+	}
+	if old.FamilyID != next.FamilyID {
+		t.Fatalf("rotated token must stay in family %s, got %s", old.FamilyID, next.FamilyID)
+	}
+	if next.TokenHash != utils.HashRefreshToken(second) || next.TokenHash == second {
+		t.Fatal("only the hash of the refresh token may be stored")
+	}
+}
+
+func TestRefresh_ReuseRevokesFamily(t *testing.T) {
+	app, store, first := setupRefreshRotationTest(t)
+
+	status, body := postRefreshToken(t, app, first)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	second := body["refresh_token"].(string)
+
+	status, body = postRefreshToken(t, app, first)
+	if status != http.StatusUnauthorized {
+		t.Fatalf("reused token must be rejected with 401, got %d %#v", status, body)
+	}
+	for _, tok := range store.tokens {
+		if !tok.Revoked {
+			t.Fatalf("whole family must be revoked, got %+v", tok)
+		}
+	}
+
+	if status, _ := postRefreshToken(t, app, second); status != http.StatusUnauthorized {
+		t.Fatalf("token from revoked family must be rejected, got %d", status)
+	}
+}
+
+func TestRefresh_RotatedTokenKeepsWorking(t *testing.T) {
+	app, _, current := setupRefreshRotationTest(t)
+
+	for i := 0; i < 3; i++ {
+		status, body := postRefreshToken(t, app, current)
+		if status != http.StatusOK {
+			t.Fatalf("rotation %d: expected 200, got %d %#v", i, status, body)
+		}
+		current = body["refresh_token"].(string)
+	}
+
+	if status, _ := postRefreshToken(t, app, "tidak-terdaftar"); status != http.StatusUnauthorized {
+		t.Fatalf("unknown refresh token must be 401, got %d", status)
+	}
+}
+
+func TestRefresh_InactiveUserRejected(t *testing.T) {
+	app, _, first := setupRefreshRotationTest(t)
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			return &model.User{ID: id, Email: "a@b.com", IsActive: false}, nil
+		},
+	}
+
+	status, body := postRefreshToken(t, app, first)
+	if status != http.StatusUnauthorized {
+		t.Fatalf("inactive user must be rejected with 401, got %d %#v", status, body)
+	}
+	if _, ok := body["refresh_token"]; ok {
+		t.Fatalf("no refresh_token expected for inactive user: %#v", body)
+	}
+}
+
+func postVerifyToken(t *testing.T, token string) (int, map[string]any) {
+	t.Helper()
+	app := fiber.New()
+	app.Post("/verify", VerifyTokenService)
+
+	req := httptest.NewRequest(http.MethodPost, "/verify", jsonBody(t, model.VerifyTokenRequest{Token: token}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, decodeMap(t, resp)
+}
+
+func signTestClaims(t *testing.T, user *model.User, expiresAt time.Time, secret []byte) string {
+	t.Helper()
+	claims := utils.Claims{
+		UserID: user.ID,
+		Email:  user.Email,
+		RoleID: user.RoleID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Subject:   user.ID,
+		},
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+func TestVerifyTokenService_ValidToken(t *testing.T) {
+	user := &model.User{ID: "user-123", Email: "test@example.com", RoleID: "role-1", IsActive: true}
+	token, expiresAt, err := utils.IssueAccessToken(user, "achievement:read", "achievement:create")
+	if err != nil {
+		t.Fatalf("IssueAccessToken: %v", err)
+	}
+	permLookups := 0
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			if id != user.ID {
+				t.Fatalf("unexpected user id %s", id)
+			}
+			return user, nil
+		},
+		GetUserPermissionsFn: func(userID string) ([]model.Permission, error) {
+			permLookups++
+			return nil, nil
+		},
+	}
+
+	status, body := postVerifyToken(t, token)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d %#v", status, body)
+	}
+	if body["valid"] != true || body["user_id"] != user.ID || body["role_id"] != "role-1" {
+		t.Fatalf("unexpected claims: %#v", body)
+	}
+	perms, _ := body["permissions"].([]any)
+	if len(perms) != 2 || perms[0] != "achievement:read" {
+		t.Fatalf("unexpected permissions: %#v", body["permissions"])
+	}
+	if body["expires_at"] != expiresAt.UTC().Format(time.RFC3339) {
+		t.Fatalf("expires_at: got %v want %s", body["expires_at"], expiresAt.UTC().Format(time.RFC3339))
+	}
+	if _, ok := body["token"]; ok || permLookups != 0 {
+		t.Fatal("verify must not mint a new token")
+	}
+}
+
+func TestVerifyTokenService_ExpiredToken(t *testing.T) {
+	user := &model.User{ID: "user-123", Email: "test@example.com", IsActive: true}
+	userRepo = &mockUserRepo{}
+
+	status, body := postVerifyToken(t, signTestClaims(t, user, time.Now().Add(-time.Hour), utils.GetJWTSecret()))
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", status)
+	}
+	if body["message"] != "Token tidak valid atau expired" {
+		t.Fatalf("unexpected message: %#v", body["message"])
+	}
+}
+
+func TestVerifyTokenService_WrongSignature(t *testing.T) {
+	user := &model.User{ID: "user-123", Email: "test@example.com", IsActive: true}
+	userRepo = &mockUserRepo{}
+
+	status, _ := postVerifyToken(t, signTestClaims(t, user, time.Now().Add(time.Hour), []byte("wrong-secret-key")))
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", status)
+	}
+}
+
+func TestVerifyTokenService_InactiveUser(t *testing.T) {
+	user := &model.User{ID: "user-123", Email: "test@example.com", IsActive: false}
+	token, err := utils.GenerateJWTPostgres(user)
+	if err != nil {
+		t.Fatalf("GenerateJWTPostgres: %v", err)
+	}
+	userRepo = &mockUserRepo{
+		GetUserByIDFn: func(id string) (*model.User, error) { return user, nil },
+	}
+
+	status, body := postVerifyToken(t, token)
+	if status != http.StatusUnauthorized || body["message"] != "User tidak aktif" {
+		t.Fatalf("expected 401 User tidak aktif, got %d %#v", status, body["message"])
+	}
+}
+
+func postRegister(t *testing.T) int {
+	t.Helper()
+	app := fiber.New()
+	app.Post("/register", func(c *fiber.Ctx) error { return Register(c, nil) })
+
+	req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewBufferString(
+		`{"username":"user_1","email":"test@example.com","password":"Abcd1","full_name":"User One","role_id":"role-admin"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestRegister_AssignsDefaultRoleWhenConfigured(t *testing.T) {
+	t.Setenv("DEFAULT_REGISTER_ROLE", "mahasiswa")
+	var got model.RegisterRequest
+	userRepo = &mockUserRepo{
+		RegisterFn: func(req model.RegisterRequest) (string, error) {
+			got = req
+			return "user-id-123", nil
+		},
+	}
+	rolesRepo = &mockRoleRepo{
+		GetRoleByNameFn: func(name string) (*model.Role, error) {
+			if name != "mahasiswa" {
+				t.Fatalf("unexpected role lookup %q", name)
+			}
+			return &model.Role{ID: "role-mhs", Name: "Mahasiswa"}, nil
+		},
+	}
+
+	if status := postRegister(t); status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	if got.RoleID != "role-mhs" {
+		t.Fatalf("expected default role id, got %q", got.RoleID)
+	}
+}
+
+func TestRegister_NoDefaultRoleWhenUnset(t *testing.T) {
+	t.Setenv("DEFAULT_REGISTER_ROLE", "")
+	got := model.RegisterRequest{RoleID: "sentinel"}
+	userRepo = &mockUserRepo{
+		RegisterFn: func(req model.RegisterRequest) (string, error) {
+			got = req
+			return "user-id-123", nil
+		},
+	}
+	rolesRepo = &mockRoleRepo{
+		GetRoleByNameFn: func(name string) (*model.Role, error) {
+			t.Fatalf("role must not be looked up when DEFAULT_REGISTER_ROLE is unset")
+			return nil, nil
+		},
+	}
+
+	if status := postRegister(t); status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	// role_id dari body request tidak boleh ikut terbaca
+	if got.RoleID != "" {
+		t.Fatalf("expected no role, got %q", got.RoleID)
+	}
+}
+
+func TestRegister_MissingDefaultRoleFails(t *testing.T) {
+	t.Setenv("DEFAULT_REGISTER_ROLE", "tidak-ada")
+	userRepo = &mockUserRepo{
+		RegisterFn: func(req model.RegisterRequest) (string, error) {
+			t.Fatalf("user must not be created when the default role is missing")
+			return "", nil
+		},
+	}
+	rolesRepo = &mockRoleRepo{
+		GetRoleByNameFn: func(name string) (*model.Role, error) {
+			return nil, errors.New("role tidak ditemukan")
+		},
+	}
+
+	if status := postRegister(t); status != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", status)
+	}
+}
+
+func TestGetUnassignedUsersService_OnlyRoleless(t *testing.T) {
+	all := []model.User{
+		{ID: "u1", Username: "admin1", RoleID: "role-admin"},
+		{ID: "u2", Username: "yatim", RoleID: ""},
+		{ID: "u3", Username: "mhs1", RoleID: "role-mhs"},
+		{ID: "u4", Username: "yatim2", RoleID: ""},
+	}
+	userRepo = &mockUserRepo{
+		GetUsersWithoutRoleFn: func(page, limit int64) ([]model.User, int64, error) {
+			if page != 1 || limit != 10 {
+				t.Fatalf("unexpected pagination: page=%d limit=%d", page, limit)
+			}
+			// meniru WHERE role_id IS NULL
+			var out []model.User
+			for _, u := range all {
+				if u.RoleID == "" {
+					out = append(out, u)
+				}
+			}
+			return out, int64(len(out)), nil
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/users/unassigned", GetUnassignedUsersService)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users/unassigned", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	body := decodeMap(t, resp)
+	data, _ := body["data"].([]any)
+	if len(data) != 2 || body["total"] != float64(2) {
+		t.Fatalf("expected 2 role-less users, got %#v", body)
+	}
+	for _, d := range data {
+		u := d.(map[string]any)
+		if u["id"] != "u2" && u["id"] != "u4" {
+			t.Fatalf("unexpected user in unassigned list: %#v", u)
+		}
+	}
+}
+
+func TestPatchUserService_OmittedVsExplicit(t *testing.T) {
+	var got model.PatchUserRequest
+	called := false
+	userRepo = &mockUserRepo{
+		PatchUserFn: func(id string, req model.PatchUserRequest) error {
+			called = true
+			got = req
+			return nil
+		},
+		GetUserByIDFn: func(id string) (*model.User, error) {
+			return &model.User{ID: id, RoleID: "role-mhs"}, nil
+		},
+	}
+	rolesRepo = &mockRoleRepo{
+		GetRoleByIDFn: func(id string) (*model.Role, error) { return &model.Role{ID: id, Name: "Mahasiswa"}, nil },
+	}
+
+	app := fiber.New()
+	app.Patch("/users/:id", PatchUserService)
+
+	send := func(raw string) *http.Response {
+		t.Helper()
+		called, got = false, model.PatchUserRequest{}
+		req := httptest.NewRequest(http.MethodPatch, "/users/u1", strings.NewReader(raw))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		return resp
+	}
+
+	// full_name "" dikirim eksplisit: di-set kosong; field lain tidak disentuh
+	if resp := send(`{"full_name":""}`); resp.StatusCode != http.StatusOK {
+		t.Fatalf("explicit empty full_name: expected 200, got %d", resp.StatusCode)
+	}
+	if !called || got.FullName == nil || *got.FullName != "" {
+		t.Fatalf("full_name must be set to empty, got %+v", got)
+	}
+	if got.Username != nil || got.Email != nil || got.Password != nil || got.RoleID != nil || got.IsActive != nil {
+		t.Fatalf("omitted fields must stay nil: %+v", got)
+	}
+
+	// role_id "" mengosongkan role; full_name tidak dikirim tetap nil
+	if resp := send(`{"role_id":""}`); resp.StatusCode != http.StatusOK {
+		t.Fatalf("clear role: expected 200, got %d", resp.StatusCode)
+	}
+	if got.RoleID == nil || *got.RoleID != "" || got.FullName != nil {
+		t.Fatalf("unexpected patch for role clear: %+v", got)
+	}
+
+	// username "" eksplisit tetap divalidasi seperti PUT
+	if resp := send(`{"username":""}`); resp.StatusCode != http.StatusBadRequest || called {
+		t.Fatalf("explicit empty username: expected 400 without update, got %d (called=%v)", resp.StatusCode, called)
+	}
+
+	// body tanpa field sama sekali
+	if resp := send(`{}`); resp.StatusCode != http.StatusBadRequest || called {
+		t.Fatalf("empty patch: expected 400 without update, got %d (called=%v)", resp.StatusCode, called)
+	}
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"hello-fiber/utils"
+)
+
+const defaultMaxDescriptionLength = 2000
+
+// maxDescriptionLength batas panjang description (dalam karakter) dari MAX_DESCRIPTION_LENGTH,
+// default 2000.
+func maxDescriptionLength() int {
+	n, err := strconv.Atoi(utils.GetEnv("MAX_DESCRIPTION_LENGTH", strconv.Itoa(defaultMaxDescriptionLength)))
+	if err != nil || n < 1 {
+		return defaultMaxDescriptionLength
+	}
+	return n
+}
+
+// descriptionTooLong dihitung per rune, bukan byte, agar teks non-ASCII tidak terpotong lebih awal.
+func descriptionTooLong(description string) bool {
+	return utf8.RuneCountInString(description) > maxDescriptionLength()
+}
@@ -29,9 +29,15 @@ package config
 import (
 	// "database/sql"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
+	fiberSwagger "github.com/swaggo/fiber-swagger"
 	"hello-fiber/database"
 	"hello-fiber/middleware"
 	"hello-fiber/route"
+	"hello-fiber/utils"
+	"strconv"
+	"strings"
+	"time"
 )
 
 func NewApp() *fiber.App {
@@ -39,17 +45,110 @@ func NewApp() *fiber.App {
 	database.ConnectMongoDB()
 	db := database.ConnectDB()
 
+	// Limit body: BODY_LIMIT_MB untuk endpoint JSON, UPLOAD_BODY_LIMIT_MB untuk endpoint upload multipart
+	bodyLimit := utils.BodyLimitBytes("BODY_LIMIT_MB", utils.DefaultBodyLimitMB)
+	uploadLimit := utils.BodyLimitBytes("UPLOAD_BODY_LIMIT_MB", utils.DefaultUploadBodyLimitMB)
+
 	// Initialize the Fiber application
-	app := fiber.New()
+	cfg := fiber.Config{
+		BodyLimit: max(bodyLimit, uploadLimit),
+	}
+	// TRUSTED_PROXIES (IP/CIDR dipisah koma): c.IP() memakai X-Forwarded-For hanya jika request
+	// datang dari proxy tersebut, agar rate limit dan audit log mencatat IP client asli
+	applyTrustedProxies(&cfg, utils.GetEnv("TRUSTED_PROXIES", ""))
+	// ROUTING_CASE_SENSITIVE / ROUTING_STRICT (default false): /v1/Users dan /v1/users/ tetap cocok
+	// dengan /v1/users kecuali diaktifkan
+	applyRouting(&cfg, utils.GetEnv("ROUTING_CASE_SENSITIVE", ""), utils.GetEnv("ROUTING_STRICT", ""))
+	app := fiber.New(cfg)
 
 	// Middleware
 	app.Use(middleware.LoggerMiddleware)
+	// REQUEST_TIMEOUT (durasi Go, default 30s): request yang melewati batas dijawab 503
+	requestTimeout, err := time.ParseDuration(strings.TrimSpace(utils.GetEnv("REQUEST_TIMEOUT", "")))
+	if err != nil || requestTimeout <= 0 {
+		requestTimeout = middleware.DefaultRequestTimeout
+	}
+	app.Use(middleware.Timeout(requestTimeout))
+	// gzip/deflate/brotli sesuai Accept-Encoding; COMPRESSION_LEVEL: balanced (default), speed, best, off
+	if cfg, ok := compressionConfig(utils.GetEnv("COMPRESSION_LEVEL", "")); ok {
+		app.Use(compress.New(cfg))
+	}
+	app.Use(middleware.BodyLimit(bodyLimit, map[string]int{
+		fiber.MethodPost + " /api/v1/achievements": uploadLimit,
+	}))
+
+	// Mode maintenance: MAINTENANCE_MODE=true menolak request mutasi dengan 503; bisa di-toggle admin
+	// via PUT /api/v1/admin/maintenance. Login, refresh, verify token, dan endpoint toggle tetap dibuka.
+	middleware.SetMaintenanceMode(strings.EqualFold(strings.TrimSpace(utils.GetEnv("MAINTENANCE_MODE", "false")), "true"))
+	retryAfter, err := strconv.Atoi(strings.TrimSpace(utils.GetEnv("MAINTENANCE_RETRY_AFTER", "")))
+	if err != nil || retryAfter <= 0 {
+		retryAfter = middleware.DefaultMaintenanceRetryAfter
+	}
+	app.Use(middleware.Maintenance(retryAfter,
+		fiber.MethodPost+" /api/v1/auth/login",
+		fiber.MethodPost+" /api/v1/auth/refresh",
+		fiber.MethodPost+" /api/v1/auth/verify",
+		fiber.MethodPut+" /api/v1/admin/maintenance",
+	))
+
+	// Metrik Prometheus; sengaja tanpa auth agar bisa di-scrape
+	app.Get("/metrics", middleware.MetricsHandler())
 
 	// Serve uploaded files
-	app.Static("/uploads", "./uploads")
+	app.Static("/uploads", utils.GetEnv("UPLOAD_DIR", "./uploads"))
 
 	// Set up routes, passing db as a dependency to the route handler
 	route.SetupRoutes(app, db)
 
+	// Swagger UI; dilindungi jika SWAGGER_PROTECTED=true
+	app.Get("/swagger/*", middleware.SwaggerAuthMiddleware(db), fiberSwagger.WrapHandler)
+
 	return app
 }
+
+// applyTrustedProxies mengaktifkan ProxyHeader X-Forwarded-For dengan pengecekan trusted proxy.
+// raw kosong membiarkan cfg apa adanya sehingga c.IP() tetap IP koneksi langsung; header dari
+// sumber yang tidak dipercaya tidak pernah dipakai karena bisa dipalsukan client.
+func applyTrustedProxies(cfg *fiber.Config, raw string) {
+	var proxies []string
+	for _, p := range strings.Split(raw, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	if len(proxies) == 0 {
+		return
+	}
+	cfg.ProxyHeader = fiber.HeaderXForwardedFor
+	cfg.EnableTrustedProxyCheck = true
+	cfg.TrustedProxies = proxies
+	// ambil IP valid pertama dari daftar "client, proxy1, proxy2"
+	cfg.EnableIPValidation = true
+}
+
+// applyRouting mengatur CaseSensitive dan StrictRouting fiber; hanya "true" yang mengaktifkan,
+// nilai lain (termasuk kosong) memakai routing yang longgar.
+func applyRouting(cfg *fiber.Config, caseSensitive, strict string) {
+	cfg.CaseSensitive = strings.EqualFold(strings.TrimSpace(caseSensitive), "true")
+	cfg.StrictRouting = strings.EqualFold(strings.TrimSpace(strict), "true")
+}
+
+// compressionConfig menerjemahkan COMPRESSION_LEVEL ke konfigurasi compress; ok=false jika "off".
+// Download attachment dan file /uploads dilewati karena PDF sudah terkompresi.
+func compressionConfig(raw string) (compress.Config, bool) {
+	cfg := compress.Config{
+		Level: compress.LevelDefault,
+		Next: func(c *fiber.Ctx) bool {
+			return strings.HasPrefix(c.Path(), "/uploads/") || strings.Contains(c.Path(), "/attachments/")
+		},
+	}
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "off", "disabled", "none":
+		return compress.Config{}, false
+	case "speed":
+		cfg.Level = compress.LevelBestSpeed
+	case "best":
+		cfg.Level = compress.LevelBestCompression
+	}
+	return cfg, true
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
+)
+
+func resolvedIP(t *testing.T, trusted string) string {
+	t.Helper()
+	var cfg fiber.Config
+	applyTrustedProxies(&cfg, trusted)
+	app := fiber.New(cfg)
+	app.Get("/ip", func(c *fiber.Ctx) error {
+		return c.SendString(c.IP())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+	req.Header.Set(fiber.HeaderXForwardedFor, "203.0.113.7, 10.0.0.2")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return string(body)
+}
+
+func TestApplyTrustedProxies(t *testing.T) {
+	// app.Test memakai koneksi dengan remote address 0.0.0.0
+	if ip := resolvedIP(t, "0.0.0.0/8, 10.0.0.0/8"); ip != "203.0.113.7" {
+		t.Fatalf("trusted proxy: got %q want client IP from X-Forwarded-For", ip)
+	}
+	if ip := resolvedIP(t, "192.168.1.1"); ip != "0.0.0.0" {
+		t.Fatalf("untrusted source: got %q want remote IP", ip)
+	}
+	if ip := resolvedIP(t, ""); ip != "0.0.0.0" {
+		t.Fatalf("unset TRUSTED_PROXIES: got %q want remote IP", ip)
+	}
+}
+
+func TestCompressionConfig_GzipsListResponses(t *testing.T) {
+	cfg, ok := compressionConfig("")
+	if !ok || cfg.Level != compress.LevelDefault {
+		t.Fatalf("default must be enabled at balanced level, got ok=%v level=%d", ok, cfg.Level)
+	}
+	if _, ok := compressionConfig("off"); ok {
+		t.Fatal("off must disable compression")
+	}
+
+	app := fiber.New()
+	app.Use(compress.New(cfg))
+	items := strings.Repeat(`{"id":"r1","name":"Mahasiswa"},`, 200)
+	list := func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.SendString(`{"success":true,"data":[` + strings.TrimSuffix(items, ",") + `]}`)
+	}
+	app.Get("/api/v1/roles", list)
+	app.Get("/api/v1/achievements/:id/attachments/:index", list)
+
+	for _, tc := range []struct {
+		path, want string
+	}{
+		{"/api/v1/roles", "gzip"},
+		{"/api/v1/achievements/a1/attachments/0", ""},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		req.Header.Set(fiber.HeaderAcceptEncoding, "gzip")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if got := resp.Header.Get(fiber.HeaderContentEncoding); got != tc.want {
+			t.Fatalf("%s: Content-Encoding got %q want %q", tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestApplyRouting_LenientByDefault(t *testing.T) {
+	for _, tc := range []struct {
+		name                  string
+		caseSensitive, strict string
+		path                  string
+		want                  int
+	}{
+		{"default trailing slash + case", "", "", "/api/v1/Users/", http.StatusOK},
+		{"default exact", "", "", "/api/v1/users", http.StatusOK},
+		{"case sensitive", "true", "", "/api/v1/Users", http.StatusNotFound},
+		{"strict trailing slash", "", "true", "/api/v1/users/", http.StatusNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg fiber.Config
+			applyRouting(&cfg, tc.caseSensitive, tc.strict)
+			app := fiber.New(cfg)
+			app.Get("/api/v1/users", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tc.path, nil), -1)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != tc.want {
+				t.Fatalf("%s: got %d want %d", tc.path, resp.StatusCode, tc.want)
+			}
+		})
+	}
+}
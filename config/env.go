@@ -1 +1,25 @@
-package config
\ No newline at end of file
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// requiredEnvKeys env yang wajib terisi sebelum aplikasi mencoba koneksi apa pun.
+var requiredEnvKeys = []string{"JWT_SECRET", "DB_DSN", "MONGO_URI"}
+
+// ValidateConfig memastikan semua env wajib ada dan tidak kosong. Error yang dikembalikan
+// menyebutkan setiap key yang hilang sekaligus, bukan hanya yang pertama.
+func ValidateConfig() error {
+	var missing []string
+	for _, key := range requiredEnvKeys {
+		if strings.TrimSpace(os.Getenv(key)) == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("konfigurasi tidak lengkap, env wajib belum diisi: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
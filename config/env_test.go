@@ -0,0 +1,31 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateConfig_ListsEveryMissingKey(t *testing.T) {
+	t.Setenv("JWT_SECRET", "rahasia")
+	t.Setenv("DB_DSN", "")
+	t.Setenv("MONGO_URI", "   ")
+
+	err := ValidateConfig()
+	if err == nil {
+		t.Fatal("expected error for missing keys")
+	}
+	for _, key := range []string{"DB_DSN", "MONGO_URI"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Fatalf("error %q does not mention %s", err, key)
+		}
+	}
+	if strings.Contains(err.Error(), "JWT_SECRET") {
+		t.Fatalf("error %q mentions a key that is set", err)
+	}
+
+	t.Setenv("DB_DSN", "postgres://localhost/db")
+	t.Setenv("MONGO_URI", "mongodb://localhost:27017")
+	if err := ValidateConfig(); err != nil {
+		t.Fatalf("expected complete config to pass, got %v", err)
+	}
+}
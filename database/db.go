@@ -1,14 +1,74 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"os"
-	_ "github.com/lib/pq" // PostgreSQL driver
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq" // PostgreSQL driver
 )
 
 var DB *sql.DB
 
+const (
+	defaultDBConnectMaxAttempts = 5
+	defaultDBConnectBackoff     = time.Second
+	maxDBConnectBackoff         = 30 * time.Second
+	dbPingTimeout               = 5 * time.Second
+)
+
+// retryConfig pengaturan retry koneksi awal; sleep bisa diganti di test.
+type retryConfig struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	sleep       func(time.Duration)
+}
+
+// dbRetryConfigFromEnv membaca DB_CONNECT_MAX_ATTEMPTS (default 5) dan DB_CONNECT_BACKOFF_MS (default 1000).
+func dbRetryConfigFromEnv() retryConfig {
+	cfg := retryConfig{
+		MaxAttempts: defaultDBConnectMaxAttempts,
+		Backoff:     defaultDBConnectBackoff,
+		sleep:       time.Sleep,
+	}
+	if n, err := strconv.Atoi(strings.TrimSpace(os.Getenv("DB_CONNECT_MAX_ATTEMPTS"))); err == nil && n > 0 {
+		cfg.MaxAttempts = n
+	}
+	if ms, err := strconv.Atoi(strings.TrimSpace(os.Getenv("DB_CONNECT_BACKOFF_MS"))); err == nil && ms >= 0 {
+		cfg.Backoff = time.Duration(ms) * time.Millisecond
+	}
+	return cfg
+}
+
+// pingWithRetry mencoba ping sampai berhasil atau MaxAttempts habis, dengan backoff eksponensial
+// (dibatasi maxDBConnectBackoff). Mengembalikan jumlah percobaan dan error terakhir.
+func pingWithRetry(db *sql.DB, cfg retryConfig) (int, error) {
+	backoff := cfg.Backoff
+	var err error
+	for attempt := 1; attempt <= cfg.MaxAttempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), dbPingTimeout)
+		err = db.PingContext(ctx)
+		cancel()
+		if err == nil {
+			return attempt, nil
+		}
+		if attempt == cfg.MaxAttempts {
+			break
+		}
+		log.Printf("[WARNING] Postgres belum tersedia (percobaan %d/%d): %v; retry dalam %s", attempt, cfg.MaxAttempts, err, backoff)
+		cfg.sleep(backoff)
+		backoff *= 2
+		if backoff > maxDBConnectBackoff {
+			backoff = maxDBConnectBackoff
+		}
+	}
+	return cfg.MaxAttempts, err
+}
+
 func ConnectDB() *sql.DB {
 	dsn := os.Getenv("DB_DSN") // Ambil nilai dari .env
 	if dsn == "" {
@@ -16,10 +76,23 @@ func ConnectDB() *sql.DB {
 	}
 
 	// Jangan tambahkan sslmode=disable lagi di sini!
-	db, err := sql.Open("postgres", dsn)
+	connector, err := pq.NewConnector(dsn)
 	if err != nil {
 		log.Fatal("Error connecting to database: ", err)
 	}
+	// query/exec yang melewati SLOW_QUERY_THRESHOLD (default 500ms, 0 = mati) di-log tanpa argumennya
+	var db *sql.DB
+	if threshold := slowQueryThresholdFromEnv(); threshold > 0 {
+		db = sql.OpenDB(newSlowQueryConnector(connector, threshold))
+	} else {
+		db = sql.OpenDB(connector)
+	}
+
+	// tunggu Postgres siap (mis. container DB yang start bersamaan)
+	cfg := dbRetryConfigFromEnv()
+	if attempts, err := pingWithRetry(db, cfg); err != nil {
+		log.Fatalf("Error connecting to database setelah %d percobaan: %v", attempts, err)
+	}
 	log.Println("Connected to database successfully")
 	return db
 }
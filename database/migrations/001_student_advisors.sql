@@ -0,0 +1,10 @@
+-- Dosen wali tambahan (co-advisor) per mahasiswa.
+-- students.advisor_id tetap dipakai sebagai advisor utama untuk kompatibilitas.
+CREATE TABLE IF NOT EXISTS student_advisors (
+    student_id  UUID        NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    lecturer_id UUID        NOT NULL REFERENCES lecturers(id) ON DELETE CASCADE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (student_id, lecturer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_student_advisors_lecturer ON student_advisors (lecturer_id);
@@ -0,0 +1,13 @@
+-- Riwayat perubahan dosen wali per mahasiswa.
+-- change_type: primary (students.advisor_id), co_advisor_added, co_advisor_removed.
+CREATE TABLE IF NOT EXISTS advisor_history (
+    id                  UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    student_id          UUID        NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    previous_advisor_id UUID        REFERENCES lecturers(id) ON DELETE SET NULL,
+    new_advisor_id      UUID        REFERENCES lecturers(id) ON DELETE SET NULL,
+    change_type         VARCHAR(32) NOT NULL,
+    changed_by          UUID        REFERENCES users(id) ON DELETE SET NULL,
+    changed_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_advisor_history_student ON advisor_history (student_id, changed_at DESC);
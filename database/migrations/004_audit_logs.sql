@@ -0,0 +1,14 @@
+-- Jejak audit aksi admin (create/update/delete user, role, permission, role_permission).
+-- entity_id disimpan sebagai teks karena role_permission memakai id komposit "role_id:permission_id".
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id   UUID        REFERENCES users(id) ON DELETE SET NULL,
+    action     VARCHAR(32) NOT NULL,
+    entity     VARCHAR(64) NOT NULL,
+    entity_id  TEXT        NOT NULL,
+    ip         VARCHAR(64),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs (entity, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created ON audit_logs (created_at DESC);
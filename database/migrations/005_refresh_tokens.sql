@@ -0,0 +1,16 @@
+-- Refresh token berotasi: setiap refresh menerbitkan token baru dalam family yang sama dan
+-- menandai token lama used. Token yang dipakai ulang mencabut seluruh family (indikasi token dicuri).
+-- Hanya hash SHA-256 token yang disimpan, bukan token mentahnya.
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id  UUID        NOT NULL,
+    token_hash CHAR(64)    NOT NULL UNIQUE,
+    used       BOOLEAN     NOT NULL DEFAULT FALSE,
+    revoked    BOOLEAN     NOT NULL DEFAULT FALSE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user ON refresh_tokens (user_id);
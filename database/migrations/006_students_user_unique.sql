@@ -0,0 +1,3 @@
+-- Satu user hanya boleh punya satu data student (GetStudentByUserID memakai LIMIT 1).
+-- Bersihkan duplikat yang sudah ada sebelum menjalankan migration ini.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_students_user_id_unique ON students (user_id);
@@ -0,0 +1,3 @@
+-- Penonaktifan akun oleh admin (PUT /users/{id}/active), terpisah dari is_active yang juga dipakai logout.
+-- Login menolak user dengan disabled_at terisi.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ NULL;
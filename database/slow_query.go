@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultSlowQueryThreshold batas query lambat jika SLOW_QUERY_THRESHOLD tidak diset.
+const defaultSlowQueryThreshold = 500 * time.Millisecond
+
+// slowQueryThresholdFromEnv membaca SLOW_QUERY_THRESHOLD (durasi Go, mis. "200ms"); "0" mematikan log.
+func slowQueryThresholdFromEnv() time.Duration {
+	raw := strings.TrimSpace(os.Getenv("SLOW_QUERY_THRESHOLD"))
+	if raw == "" {
+		return defaultSlowQueryThreshold
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return defaultSlowQueryThreshold
+	}
+	return d
+}
+
+// slowQueryConnector membungkus connector driver sehingga setiap QueryContext/ExecContext yang lebih lama
+// dari threshold di-log. Semua repository otomatis ikut karena memakai *sql.DB yang sama.
+type slowQueryConnector struct {
+	driver.Connector
+	threshold time.Duration
+	logf      func(format string, args ...interface{})
+}
+
+func newSlowQueryConnector(inner driver.Connector, threshold time.Duration) driver.Connector {
+	return &slowQueryConnector{Connector: inner, threshold: threshold, logf: log.Printf}
+}
+
+func (c *slowQueryConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &slowQueryConn{Conn: conn, connector: c}, nil
+}
+
+// observe hanya mencatat statement dan durasi; argumen tidak pernah di-log karena bisa berisi
+// password hash, token, atau data pribadi.
+func (c *slowQueryConnector) observe(kind, query string, start time.Time) {
+	if d := time.Since(start); d >= c.threshold {
+		c.logf("[WARNING] slow %s (%s): %s", kind, d.Round(time.Microsecond), strings.Join(strings.Fields(query), " "))
+	}
+}
+
+// slowQueryConn meneruskan semua interface opsional driver ke koneksi asli.
+type slowQueryConn struct {
+	driver.Conn
+	connector *slowQueryConnector
+}
+
+func (c *slowQueryConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := q.QueryContext(ctx, query, args)
+	if err != driver.ErrSkip {
+		c.connector.observe("query", query, start)
+	}
+	return rows, err
+}
+
+func (c *slowQueryConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	res, err := e.ExecContext(ctx, query, args)
+	if err != driver.ErrSkip {
+		c.connector.observe("exec", query, start)
+	}
+	return res, err
+}
+
+func (c *slowQueryConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *slowQueryConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *slowQueryConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *slowQueryConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *slowQueryConn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
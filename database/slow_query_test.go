@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stubConnector driver minimal: query mengembalikan nol baris, exec selalu sukses.
+type stubConnector struct{}
+
+func (stubConnector) Connect(context.Context) (driver.Conn, error) { return stubConn{}, nil }
+func (stubConnector) Driver() driver.Driver                        { return nil }
+
+type stubConn struct{}
+
+func (stubConn) Prepare(string) (driver.Stmt, error) { return nil, fmt.Errorf("prepare not supported") }
+func (stubConn) Close() error                        { return nil }
+func (stubConn) Begin() (driver.Tx, error)           { return nil, fmt.Errorf("tx not supported") }
+func (stubConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return stubRows{}, nil
+}
+func (stubConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+type stubRows struct{}
+
+func (stubRows) Columns() []string         { return []string{"id"} }
+func (stubRows) Close() error              { return nil }
+func (stubRows) Next([]driver.Value) error { return io.EOF }
+
+func TestSlowQueryConnector_LogsStatementWithoutArgs(t *testing.T) {
+	var logs []string
+	connector := newSlowQueryConnector(stubConnector{}, time.Nanosecond).(*slowQueryConnector)
+	connector.logf = func(format string, args ...interface{}) {
+		logs = append(logs, fmt.Sprintf(format, args...))
+	}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	rows, err := db.QueryContext(context.Background(), "SELECT id\n\t\tFROM users WHERE email = $1", "rahasia@example.com")
+	if err != nil {
+		t.Fatalf("QueryContext: %v", err)
+	}
+	rows.Close()
+	if _, err := db.ExecContext(context.Background(), "UPDATE users SET password_hash = $1", "hash-rahasia"); err != nil {
+		t.Fatalf("ExecContext: %v", err)
+	}
+
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 slow query logs, got %d: %v", len(logs), logs)
+	}
+	if !strings.Contains(logs[0], "slow query") || !strings.Contains(logs[0], "SELECT id FROM users WHERE email = $1") {
+		t.Fatalf("unexpected query log: %s", logs[0])
+	}
+	if !strings.Contains(logs[1], "slow exec") {
+		t.Fatalf("unexpected exec log: %s", logs[1])
+	}
+	for _, l := range logs {
+		if strings.Contains(l, "rahasia") {
+			t.Fatalf("args must not be logged: %s", l)
+		}
+	}
+}
+
+func TestSlowQueryThresholdFromEnv(t *testing.T) {
+	for _, tc := range []struct {
+		raw  string
+		want time.Duration
+	}{
+		{"", defaultSlowQueryThreshold},
+		{"200ms", 200 * time.Millisecond},
+		{"0", 0},
+		{"lama", defaultSlowQueryThreshold},
+	} {
+		t.Setenv("SLOW_QUERY_THRESHOLD", tc.raw)
+		if got := slowQueryThresholdFromEnv(); got != tc.want {
+			t.Fatalf("%q: got %s want %s", tc.raw, got, tc.want)
+		}
+	}
+}
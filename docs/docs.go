@@ -47,9 +47,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Jumlah per halaman (default 10)",
+                        "description": "Jumlah per halaman (default DEFAULT_PAGE_LIMIT, 10)",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Awal rentang verified_at (RFC3339), wajib bersama verified_to",
+                        "name": "verified_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Akhir rentang verified_at (RFC3339), wajib bersama verified_from",
+                        "name": "verified_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -60,6 +72,12 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Rentang verified_from/verified_to tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -81,13 +99,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/achievements": {
-            "get": {
+        "/v1/achievement-references/statuses": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Mengembalikan map mongo_id -\u003e status; id yang tidak ada atau di luar scope role tidak disertakan",
                 "consumes": [
                     "application/json"
                 ],
@@ -97,19 +116,16 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Daftar semua achievements (Mongo)",
+                "summary": "Batch status reference berdasarkan mongo id",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Halaman (default 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah per halaman (default 10)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Daftar mongo achievement id (maks 500)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReferenceStatusesRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -120,6 +136,12 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -139,8 +161,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/achievements": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -155,28 +179,37 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Mahasiswa membuat achievement (Mongo) + reference draft (Postgres)",
+                "summary": "Daftar semua achievements (Mongo)",
                 "parameters": [
                     {
-                        "description": "Data achievement (details mengikuti achievement_type). oneOf examples: competition {competitionName, competitionLevel, rank}, publication {publicationType, publicationTitle, authors, publisher, issn}, organization {organizationName, position, periodStart, periodEnd}, certification {certificationName, issuedBy, certificationNumber, validUntil}, academic {description, score}.",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreateAchievementRequest"
-                        }
+                        "type": "integer",
+                        "description": "Halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (default DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "student: sertakan nama, NIM, dan program studi student (plus nama advisor untuk dosen wali)",
+                        "name": "expand",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Parameter page/limit tidak valid",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -200,15 +233,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/achievements/{id}/delete": {
-            "delete": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Upload multipart: default (strict=true) satu attachment tidak valid menggagalkan create. Dengan strict=false file tidak valid dilewati dan dilaporkan di data.rejected_attachments. competitionLevel harus salah satu level di ACHIEVEMENT_COMPETITION_LEVELS (default local, regional, national, international); jika points kosong, points default level dipakai.",
                 "consumes": [
                     "application/json"
                 ],
@@ -218,21 +250,30 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Hard delete achievement (hapus permanen Mongo + reference) untuk status deleted",
+                "summary": "Mahasiswa membuat achievement (Mongo) + reference draft (Postgres)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Achievement reference ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "false = lewati attachment tidak valid (default: true)",
+                        "name": "strict",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Data achievement (details mengikuti achievement_type). Field wajib: competition {competitionName, rank}, publication {publicationTitle, publisher}, organization {organizationName, position}, certification {certificationName, issuedBy}, academic {score}. oneOf examples: competition {competitionName, competitionLevel, rank}, publication {publicationType, publicationTitle, authors, publisher, issn}, organization {organizationName, position, periodStart, periodEnd}, certification {certificationName, issuedBy, certificationNumber, validUntil}, academic {description, score}.",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateAchievementRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -253,8 +294,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "413": {
+                        "description": "Body melebihi UPLOAD_BODY_LIMIT_MB",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -268,13 +309,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/achievements/{id}/review": {
+        "/v1/achievements/bulk-review": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Setiap id diproses dengan otorisasi dan guard status yang sama seperti review tunggal; tiap id atomik dan independen, hasil dikembalikan per id. Maks 100 id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -284,30 +326,24 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Dosen review achievement (submitted -\u003e verified/rejected)",
+                "summary": "Review banyak achievement sekaligus (submitted -\u003e verified/rejected)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Achievement reference ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Status baru",
+                        "description": "Daftar id reference, status, dan rejection_note",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateAchievementStatusRequest"
+                            "$ref": "#/definitions/model.BulkReviewAchievementRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "data: {results, succeeded, failed}",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -328,12 +364,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -343,43 +373,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/achievements/{id}/soft-delete": {
-            "put": {
+        "/v1/achievements/count": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Hanya mengembalikan total (scope sama dengan GET /v1/achievements) tanpa mengambil data, untuk badge dashboard.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Mahasiswa menghapus (soft delete) draft achievement reference (draft -\u003e deleted)",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Achievement reference ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "Jumlah achievements dalam scope pemanggil",
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "{success, message, total}",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "401": {
@@ -394,12 +408,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -409,13 +417,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/achievements/{id}/submit": {
-            "put": {
+        "/v1/achievements/funnel": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Default rentang 30 hari terakhir. Tanggal format YYYY-MM-DD, to bersifat inklusif.",
                 "consumes": [
                     "application/json"
                 ],
@@ -425,21 +434,26 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Mahasiswa submit achievement (draft -\u003e submitted)",
+                "summary": "Funnel achievement (created/submitted/verified/rejected) dalam rentang tanggal (Admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement reference ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Tanggal awal (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
+                            "$ref": "#/definitions/model.AchievementFunnel"
                         }
                     },
                     "400": {
@@ -454,18 +468,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -475,9 +477,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/login": {
-            "post": {
-                "description": "Authenticate users dengan email dan password, return JWT token",
+        "/v1/achievements/overdue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "SLA diatur lewat env ACHIEVEMENT_REVIEW_SLA_DAYS (default 7 hari). Dosen wali hanya melihat mahasiswa bimbingannya.",
                 "consumes": [
                     "application/json"
                 ],
@@ -485,41 +492,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Achievements"
                 ],
-                "summary": "Login users",
+                "summary": "Daftar achievement submitted yang melewati SLA review",
                 "parameters": [
                     {
-                        "description": "Email dan password",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.LoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "Halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (default DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login berhasil",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.LoginResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Parameter page/limit tidak valid",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Email atau password salah",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -527,14 +544,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/logout": {
-            "post": {
+        "/v1/achievements/review-queue": {
+            "get": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Deactivate user account to invalidate all JWT tokens",
+                "description": "Achievement berstatus submitted milik mahasiswa bimbingan pemanggil (advisor utama maupun co-advisor), terbaru dulu. Hanya untuk dosen wali.",
                 "consumes": [
                     "application/json"
                 ],
@@ -542,41 +559,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Achievements"
                 ],
-                "summary": "Logout user",
+                "summary": "Antrian review dosen wali",
                 "parameters": [
                     {
-                        "description": "Token to be logged out",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.LogoutRequest"
-                        }
+                        "type": "integer",
+                        "description": "Halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (default DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Logout berhasil",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Parameter page/limit tidak valid",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Token tidak valid",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Error server",
+                    "403": {
+                        "description": "Bukan dosen wali",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -584,39 +611,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/profile": {
+        "/v1/achievements/tags": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil detail profil user berdasarkan token JWT yang dikirim",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Daftar tag unik beserta jumlah achievement, terbanyak dulu, untuk filter tag di UI. Scope sama dengan GET /v1/achievements.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Achievements"
                 ],
-                "summary": "Dapatkan profil user yang sedang login",
+                "summary": "Tag yang dipakai achievements dalam scope pemanggil",
                 "responses": {
                     "200": {
-                        "description": "Profil user berhasil diambil",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserDetailResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TagCount"
+                            }
                         }
                     },
                     "401": {
-                        "description": "Unauthorized atau token tidak valid",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -624,56 +657,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/refresh": {
-            "post": {
+        "/v1/achievements/timeline": {
+            "get": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Membuat token JWT baru dari token lama yang masih valid",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Untuk grafik tren: count = achievement dibuat per bulan (created_at). include_verified=true menambahkan verified = achievement diverifikasi per bulan (verified_at). Scope sama dengan GET /v1/achievements.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Achievements"
                 ],
-                "summary": "Refresh JWT token",
+                "summary": "Jumlah achievements per bulan dalam scope pemanggil",
                 "parameters": [
                     {
-                        "description": "Token yang akan direfresh",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.RefreshTokenRequest"
-                        }
+                        "type": "string",
+                        "description": "Pengelompokan, saat ini hanya month (default: month)",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Batasi ke tahun tertentu, mis. 2025",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan jumlah verified per bulan",
+                        "name": "include_verified",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Token berhasil direfresh",
+                        "description": "data: [{period, count, verified}] urut period",
                         "schema": {
-                            "$ref": "#/definitions/model.LoginResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Request tidak valid",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Token tidak valid atau expired",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -681,9 +727,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/register": {
-            "post": {
-                "description": "Membuat users baru dengan validasi email, username, password, dan full_name",
+        "/v1/achievements/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan dokumen achievement beserta reference dan metadata review. Akses mengikuti scope role pemanggil.",
                 "consumes": [
                     "application/json"
                 ],
@@ -691,35 +742,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Achievements"
                 ],
-                "summary": "Daftar users baru",
+                "summary": "Detail achievement (Mongo + reference Postgres)",
                 "parameters": [
                     {
-                        "description": "Data registrasi",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.RegisterRequest"
-                        }
+                        "type": "string",
+                        "description": "Achievement reference ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User berhasil terdaftar",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
+                            "$ref": "#/definitions/model.AchievementWithReference"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -727,14 +794,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/lecturers": {
+        "/v1/achievements/{id}/actions": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil daftar semua lecturer dengan pagination",
+                "description": "Mengembalikan daftar aksi (submit, soft_delete, reopen, verify, reject, admin_soft_delete, hard_delete, reassign) yang boleh dilakukan pemanggil berdasarkan role dan status reference saat ini",
                 "consumes": [
                     "application/json"
                 ],
@@ -742,52 +809,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Lecturers"
+                    "Achievements"
                 ],
-                "summary": "Dapatkan semua lecturer (Permission: user:manage)",
+                "summary": "Aksi yang diizinkan untuk achievement",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Halaman (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (default: 10)",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Achievement reference ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data lecturer berhasil diambil",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Error server",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/achievements/{id}/admin-soft-delete": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Membuat data lecturer baru",
                 "consumes": [
                     "application/json"
                 ],
@@ -795,29 +870,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Lecturers"
+                    "Achievements"
                 ],
-                "summary": "Buat lecturer (Permission: user:manage)",
+                "summary": "Admin menghapus (soft delete) achievement reference status apa pun (-\u003e deleted)",
                 "parameters": [
                     {
-                        "description": "Data lecturer",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreateLecturerRequest"
-                        }
+                        "type": "string",
+                        "description": "Achievement reference ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Lecturer berhasil dibuat",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -828,8 +901,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -837,43 +922,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/lecturers/{id}": {
+        "/v1/achievements/{id}/attachments/{index}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil detail lecturer berdasarkan id (UUID)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "File hanya dikirim jika pemanggil berhak melihat achievement (scope sama dengan detail achievement).",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Lecturers"
+                    "Achievements"
                 ],
-                "summary": "Dapatkan lecturer by ID (Permission: user:manage)",
+                "summary": "Download attachment achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lecturer ID (UUID)",
+                        "description": "Achievement reference ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Index attachment (mulai dari 0)",
+                        "name": "index",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data lecturer berhasil diambil",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -884,27 +972,34 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Lecturer tidak ditemukan",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/achievements/{id}/delete": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update lecturer by id (partial update)",
                 "consumes": [
                     "application/json"
                 ],
@@ -912,36 +1007,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Lecturers"
+                    "Achievements"
                 ],
-                "summary": "Update lecturer (Permission: user:manage)",
+                "summary": "Hard delete achievement (hapus permanen Mongo + reference + file attachment) untuk status deleted",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lecturer ID (UUID)",
+                        "description": "Achievement reference ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Field yang ingin diupdate",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdateLecturerRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Lecturer berhasil diupdate",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -952,41 +1038,46 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Lecturer tidak ditemukan",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/achievements/{id}/pdf": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus lecturer by id",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Ringkasan achievement (judul, jenis, detail, tanggal verifikasi, reviewer) dalam PDF siap cetak. Hanya untuk achievement verified yang boleh dilihat pemanggil; nama file lampiran dicantumkan sebagai referensi.",
                 "produces": [
-                    "application/json"
+                    "application/pdf"
                 ],
                 "tags": [
-                    "Lecturers"
+                    "Achievements"
                 ],
-                "summary": "Hapus lecturer (Permission: user:manage)",
+                "summary": "Export achievement terverifikasi sebagai PDF",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lecturer ID (UUID)",
+                        "description": "Achievement reference ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -994,13 +1085,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Lecturer berhasil dihapus",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Achievement belum verified",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1011,14 +1102,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Lecturer tidak ditemukan",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1026,14 +1123,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/permissions": {
-            "get": {
+        "/v1/achievements/{id}/reassign": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil daftar semua permission dengan pagination",
+                "description": "Update student_id di reference (Postgres) dan studentId di dokumen Mongo; reference di-rollback jika update Mongo gagal.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1041,29 +1138,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permissions"
+                    "Achievements"
                 ],
-                "summary": "Dapatkan semua permission (Permission: user:manage)",
+                "summary": "Admin memindahkan kepemilikan achievement ke student lain",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Halaman (default: 1)",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Achievement reference ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (default: 10)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Student tujuan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReassignAchievementRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data permission berhasil diambil",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1072,21 +1178,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/achievements/{id}/reopen": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memerlukan permission user:manage untuk membuat permission baru",
+                "description": "Achievement rejected milik sendiri dikembalikan ke draft (rejection_note dikosongkan) agar bisa direvisi lalu di-submit ulang lewat /submit",
                 "consumes": [
                     "application/json"
                 ],
@@ -1094,29 +1214,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permissions"
+                    "Achievements"
                 ],
-                "summary": "Buat permission baru (Permission: user:manage)",
+                "summary": "Mahasiswa membuka kembali achievement rejected (rejected -\u003e draft)",
                 "parameters": [
                     {
-                        "description": "Data permission yang akan dibuat",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreatePermissionRequest"
-                        }
+                        "type": "string",
+                        "description": "Achievement reference ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Permission berhasil dibuat",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1127,8 +1245,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Status bukan rejected",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1136,14 +1272,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/permissions/{id}": {
-            "get": {
+        "/v1/achievements/{id}/review": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil detail permission berdasarkan ID",
+                "description": "points opsional: saat verified, points achievement di Mongo diganti nilai tersebut (harus \u003e= 0); diabaikan untuk rejected.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1151,28 +1287,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permissions"
+                    "Achievements"
                 ],
-                "summary": "Dapatkan detail permission (Permission: user:manage)",
+                "summary": "Dosen review achievement (submitted -\u003e verified/rejected)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Permission ID (UUID)",
+                        "description": "Achievement reference ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Status baru",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateAchievementStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data permission berhasil diambil",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1183,27 +1327,40 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Dosen wali bukan advisor mahasiswa ini",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Permission tidak ditemukan",
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Sudah diproses",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/achievements/{id}/soft-delete": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memerlukan permission user:manage untuk mengupdate data permission berdasarkan ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1211,36 +1368,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permissions"
+                    "Achievements"
                 ],
-                "summary": "Update permission (Permission: user:manage)",
+                "summary": "Mahasiswa menghapus (soft delete) draft achievement reference (draft -\u003e deleted)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Permission ID (UUID)",
+                        "description": "Achievement reference ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Data permission yang akan diupdate",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdatePermissionRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Permission berhasil diupdate",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1251,27 +1399,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Permission tidak ditemukan",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/achievements/{id}/submit": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memerlukan permission user:manage untuk menghapus permission berdasarkan ID",
+                "description": "Tipe yang requires_attachment (competition, certification) wajib punya attachment; nonaktifkan dengan ACHIEVEMENT_STRICT_EVIDENCE=false",
                 "consumes": [
                     "application/json"
                 ],
@@ -1279,13 +1435,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permissions"
+                    "Achievements"
                 ],
-                "summary": "Hapus permission (Permission: user:manage)",
+                "summary": "Mahasiswa submit achievement (draft -\u003e submitted)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Permission ID (UUID)",
+                        "description": "Achievement reference ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1293,13 +1449,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Permission berhasil dihapus",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1310,14 +1466,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Permission tidak ditemukan",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1325,14 +1487,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/role-permissions": {
+        "/v1/achievements/{id}/verify-public": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Mengambil daftar mapping role_id dan permission_id dengan pagination dan filter opsional",
+                "description": "Untuk link verifikasi di sertifikat. Hanya achievement verified yang dikembalikan; selain itu 404. Payload minimal tanpa data pribadi mahasiswa.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1340,64 +1497,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "RolePermissions"
+                    "Achievements"
                 ],
-                "summary": "Dapatkan semua role_permission (Permission: user:manage)",
+                "summary": "Verifikasi publik achievement (tanpa auth)",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Halaman (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (default: 10)",
-                        "name": "limit",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Filter role_id (UUID)",
-                        "name": "role_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter permission_id (UUID)",
-                        "name": "permission_id",
-                        "in": "query"
+                        "description": "Achievement reference ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data role_permission berhasil diambil",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.AchievementPublicVerification"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error server",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/admin/maintenance": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Membuat mapping role_id dan permission_id",
+                "description": "Selama maintenance, request POST/PUT/PATCH/DELETE dijawab 503 dengan header Retry-After, sedangkan GET tetap dilayani.\nStatus hanya disimpan di memori proses; setelah restart kembali mengikuti MAINTENANCE_MODE.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1405,29 +1546,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "RolePermissions"
+                    "Admin"
                 ],
-                "summary": "Tambah role_permission (Permission: user:manage)",
+                "summary": "Nyalakan/matikan mode maintenance (Admin)",
                 "parameters": [
                     {
-                        "description": "Data role_permission",
+                        "description": "Status maintenance",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateRolePermissionRequest"
+                            "$ref": "#/definitions/model.MaintenanceRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "role_permission berhasil dibuat",
+                    "200": {
+                        "description": "Mode maintenance berhasil diubah",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal / data sudah ada / foreign key tidak valid",
+                        "description": "Request tidak valid",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1438,8 +1580,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Error server",
+                    "403": {
+                        "description": "Bukan admin",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1447,14 +1589,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/role-permissions/byrole/{role_id}": {
+        "/v1/audit-logs": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil list permission yang dimiliki oleh role tertentu",
+                "description": "Mengambil jejak create/update/delete user, role, permission, dan role_permission, terbaru lebih dulu",
                 "consumes": [
                     "application/json"
                 ],
@@ -1462,28 +1604,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "RolePermissions"
+                    "AuditLogs"
                 ],
-                "summary": "Dapatkan daftar permissions milik role (Permission: user:manage)",
+                "summary": "Daftar audit log aksi admin (Admin)",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Role ID (UUID)",
-                        "name": "role_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter entity: user, role, permission, role_permission",
+                        "name": "entity",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data permissions milik role berhasil diambil",
+                        "description": "Audit log berhasil diambil",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Entity tidak valid",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1494,6 +1647,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Bukan admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Error server",
                         "schema": {
@@ -1503,14 +1662,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/role-permissions/{role_id}/{permission_id}": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update composite key mapping (role_id, permission_id) menjadi (new_role_id, new_permission_id)",
+        "/v1/auth/login": {
+            "post": {
+                "description": "Authenticate users dengan email dan password, return JWT token",
                 "consumes": [
                     "application/json"
                 ],
@@ -1518,55 +1672,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "RolePermissions"
+                    "Authentication"
                 ],
-                "summary": "Update role_permission (Permission: user:manage)",
+                "summary": "Login users",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Role ID lama (UUID)",
-                        "name": "role_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Permission ID lama (UUID)",
-                        "name": "permission_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Data role_permission baru",
+                        "description": "Email dan password",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateRolePermissionRequest"
+                            "$ref": "#/definitions/model.LoginRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bahasa pesan: id (default) atau en",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "role_permission berhasil diupdate",
+                        "description": "Login berhasil",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
+                            "$ref": "#/definitions/model.LoginResponse"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal / data sudah ada / foreign key tidak valid",
+                        "description": "Validasi gagal",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Email atau password salah",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "role_permission tidak ditemukan",
+                    "403": {
+                        "description": "Akun dinonaktifkan oleh admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Akun terkunci sementara (LOGIN_MAX_ATTEMPTS)",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1578,14 +1730,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/auth/logout": {
+            "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "Bearer": []
                     }
                 ],
-                "description": "Menghapus mapping role_id dan permission_id",
+                "description": "Deactivate user account to invalidate all JWT tokens",
                 "consumes": [
                     "application/json"
                 ],
@@ -1593,28 +1747,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "RolePermissions"
+                    "Authentication"
                 ],
-                "summary": "Hapus role_permission (Permission: user:manage)",
+                "summary": "Logout user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Role ID (UUID)",
-                        "name": "role_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Permission ID (UUID)",
-                        "name": "permission_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Token to be logged out",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LogoutRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "role_permission berhasil dihapus",
+                        "description": "Logout berhasil",
                         "schema": {
                             "$ref": "#/definitions/model.SuccessResponse"
                         }
@@ -1626,13 +1775,7 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "role_permission tidak ditemukan",
+                        "description": "Token tidak valid",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1646,14 +1789,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/roles": {
+        "/v1/auth/profile": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil daftar semua role dengan pagination",
+                "description": "Mengambil detail profil user berdasarkan token JWT yang dikirim",
                 "consumes": [
                     "application/json"
                 ],
@@ -1661,32 +1804,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
-                ],
-                "summary": "Dapatkan semua role (Permission: user:manage)",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Halaman (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (default: 10)",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "Authentication"
                 ],
+                "summary": "Dapatkan profil user yang sedang login",
                 "responses": {
                     "200": {
-                        "description": "Role list berhasil diambil",
+                        "description": "Profil user berhasil diambil",
                         "schema": {
-                            "$ref": "#/definitions/model.RoleListResponse"
+                            "$ref": "#/definitions/model.UserDetailResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized atau token tidak valid",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1698,14 +1827,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/auth/refresh": {
             "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "Bearer": []
                     }
                 ],
-                "description": "Memerlukan permission user:manage untuk membuat role baru",
+                "description": "Menukar refresh_token dengan access token dan refresh_token baru (rotasi); refresh_token lama tidak berlaku lagi.\nJika refresh_token yang sudah dipakai dikirim ulang, seluruh family token dicabut dan dikembalikan 401.\nUntuk client lama, field token (access JWT yang masih valid) tetap diterima, tetapi hanya mengembalikan access token baru tanpa refresh_token.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1713,35 +1844,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Authentication"
                 ],
-                "summary": "Buat role baru (Permission: user:manage)",
+                "summary": "Refresh JWT token",
                 "parameters": [
                     {
-                        "description": "Data role baru",
+                        "description": "refresh_token hasil login/refresh sebelumnya",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateRoleRequest"
+                            "$ref": "#/definitions/model.RefreshTokenRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Role berhasil dibuat",
+                    "200": {
+                        "description": "Token berhasil direfresh",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
+                            "$ref": "#/definitions/model.LoginResponse"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Request tidak valid",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Token tidak valid atau expired",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1755,14 +1886,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/roles/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Mengambil detail role berdasarkan Role ID",
+        "/v1/auth/register": {
+            "post": {
+                "description": "Membuat users baru dengan validasi email, username, password, dan full_name. Role awal diambil dari DEFAULT_REGISTER_ROLE jika diset",
                 "consumes": [
                     "application/json"
                 ],
@@ -1770,39 +1896,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Authentication"
                 ],
-                "summary": "Dapatkan detail role (Permission: user:manage)",
+                "summary": "Daftar users baru",
                 "parameters": [
+                    {
+                        "description": "Data registrasi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RegisterRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Role ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Bahasa pesan: id (default) atau en",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Data role berhasil diambil",
+                    "201": {
+                        "description": "User berhasil terdaftar",
                         "schema": {
-                            "$ref": "#/definitions/model.RoleDetailResponse"
+                            "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Request body tidak valid / username sudah terdaftar",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Role tidak ditemukan",
+                    "422": {
+                        "description": "Validasi field gagal",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1814,14 +1942,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Memerlukan permission user:manage untuk mengupdate data role berdasarkan ID",
+            }
+        },
+        "/v1/auth/verify": {
+            "post": {
+                "description": "Memvalidasi signature dan masa berlaku token, memastikan user masih ada dan aktif, lalu mengembalikan claims-nya tanpa menerbitkan token baru",
                 "consumes": [
                     "application/json"
                 ],
@@ -1829,48 +1954,96 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Authentication"
                 ],
-                "summary": "Update role (Permission: user:manage)",
+                "summary": "Verifikasi JWT tanpa refresh",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Role ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Data role yang akan diupdate",
+                        "description": "Token yang akan diverifikasi",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateRoleRequest"
+                            "$ref": "#/definitions/model.VerifyTokenRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Role berhasil diupdate",
+                        "description": "Token valid",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
+                            "$ref": "#/definitions/model.VerifyTokenResponse"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Request tidak valid",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Token tidak valid, expired, atau user tidak aktif",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Role tidak ditemukan",
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/lecturers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar semua lecturer dengan pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturers"
+                ],
+                "summary": "Dapatkan semua lecturer (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data lecturer berhasil diambil",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter page/limit tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1883,13 +2056,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memerlukan permission user:manage untuk menghapus role berdasarkan ID",
+                "description": "Membuat data lecturer baru",
                 "consumes": [
                     "application/json"
                 ],
@@ -1897,27 +2070,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Lecturers"
                 ],
-                "summary": "Hapus role (Permission: user:manage)",
+                "summary": "Buat lecturer (Permission: user:manage)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Role ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Data lecturer",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateLecturerRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Role berhasil dihapus",
+                    "201": {
+                        "description": "Lecturer berhasil dibuat",
                         "schema": {
                             "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Role ID tidak valid",
+                        "description": "Validasi gagal",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1928,12 +2103,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Role tidak ditemukan",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Error server",
                         "schema": {
@@ -1943,14 +2112,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/students": {
+        "/v1/lecturers/me": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil daftar semua students dengan pagination",
+                "description": "Resolve record lecturers dari user_id di token, tanpa perlu tahu UUID internal",
                 "consumes": [
                     "application/json"
                 ],
@@ -1958,26 +2127,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
-                ],
-                "summary": "Dapatkan semua students (Permission: user:manage)",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Halaman (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (default: 10)",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "Lecturers"
                 ],
+                "summary": "Dapatkan data lecturer milik user yang login (dosen wali)",
                 "responses": {
                     "200": {
-                        "description": "Data student berhasil diambil",
+                        "description": "Data lecturer berhasil diambil",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1989,6 +2144,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Bukan dosen wali",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Lecturer tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Error server",
                         "schema": {
@@ -1996,14 +2163,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/lecturers/me/advisees-summary": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Membuat data students baru",
+                "description": "Setiap mahasiswa bimbingan (advisor utama maupun co-advisor) beserta jumlah achievement submitted, verified, dan rejected",
                 "consumes": [
                     "application/json"
                 ],
@@ -2011,35 +2180,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Lecturers"
                 ],
-                "summary": "Buat students (Permission: user:manage)",
-                "parameters": [
-                    {
-                        "description": "Data student",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
+                "summary": "Ringkasan mahasiswa bimbingan dosen wali yang login",
+                "responses": {
+                    "200": {
+                        "description": "Ringkasan mahasiswa bimbingan berhasil diambil",
                         "schema": {
-                            "$ref": "#/definitions/model.CreateStudentRequest"
+                            "type": "object",
+                            "additionalProperties": true
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Student berhasil dibuat",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Validasi gagal",
+                    "403": {
+                        "description": "Bukan dosen wali",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Lecturer tidak ditemukan",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -2053,14 +2218,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/students/{id}": {
+        "/v1/lecturers/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil detail students berdasarkan id (UUID)",
+                "description": "Mengambil detail lecturer berdasarkan id (UUID)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2068,13 +2233,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Lecturers"
                 ],
-                "summary": "Dapatkan students by ID (Permission: user:manage)",
+                "summary": "Dapatkan lecturer by ID (Permission: user:manage)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID (UUID)",
+                        "description": "Lecturer ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2082,7 +2247,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data student berhasil diambil",
+                        "description": "Data lecturer berhasil diambil",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -2101,7 +2266,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Student tidak ditemukan",
+                        "description": "Lecturer tidak ditemukan",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -2120,7 +2285,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update students by id (partial update). Untuk hapus advisor_id, kirim advisor_id = \"00000000-0000-0000-0000-000000000000\"",
+                "description": "Update lecturer by id (partial update)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2128,13 +2293,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Lecturers"
                 ],
-                "summary": "Update students (Permission: user:manage)",
+                "summary": "Update lecturer (Permission: user:manage)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID (UUID)",
+                        "description": "Lecturer ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2145,13 +2310,13 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateStudentRequest"
+                            "$ref": "#/definitions/model.UpdateLecturerRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Student berhasil diupdate",
+                        "description": "Lecturer berhasil diupdate",
                         "schema": {
                             "$ref": "#/definitions/model.SuccessResponse"
                         }
@@ -2169,7 +2334,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Student tidak ditemukan",
+                        "description": "Lecturer tidak ditemukan",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -2188,7 +2353,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus students by id",
+                "description": "Menghapus lecturer by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -2196,13 +2361,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Lecturers"
                 ],
-                "summary": "Hapus students (Permission: user:manage)",
+                "summary": "Hapus lecturer (Permission: user:manage)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID (UUID)",
+                        "description": "Lecturer ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2210,7 +2375,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Student berhasil dihapus",
+                        "description": "Lecturer berhasil dihapus",
                         "schema": {
                             "$ref": "#/definitions/model.SuccessResponse"
                         }
@@ -2228,7 +2393,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Student tidak ditemukan",
+                        "description": "Lecturer tidak ditemukan",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -2242,14 +2407,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users": {
-            "get": {
+        "/v1/onboarding/student": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil daftar semua user dengan pagination",
+                "description": "Membuat user (role mahasiswa) dan data students dalam satu transaksi. Jika salah satu gagal, tidak ada data yang tersimpan.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2257,28 +2422,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Students"
                 ],
-                "summary": "Dapatkan semua user (Admin)",
+                "summary": "Onboarding student: buat user + student sekaligus (Permission: user:manage)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Halaman (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (default: 10)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Data user dan student",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.OnboardStudentRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "User list berhasil diambil",
+                    "201": {
+                        "description": "Onboarding student berhasil",
                         "schema": {
-                            "$ref": "#/definitions/model.UserListResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal / data sudah terdaftar",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "401": {
@@ -2294,14 +2463,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/permissions": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin membuat users baru dengan validasi lengkap",
+                "description": "Mengambil daftar semua permission dengan pagination, opsional difilter resource dan/atau action",
                 "consumes": [
                     "application/json"
                 ],
@@ -2309,29 +2480,100 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Permissions"
                 ],
-                "summary": "Buat users baru (Admin)",
+                "summary": "Dapatkan semua permission (Permission: user:manage)",
                 "parameters": [
                     {
-                        "description": "Data user baru",
+                        "type": "integer",
+                        "description": "Halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter resource (mis. achievement)",
+                        "name": "resource",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter action (mis. read)",
+                        "name": "action",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data permission berhasil diambil",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter page/limit tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memerlukan permission user:manage untuk membuat permission baru. resource dan action wajib; name opsional, jika kosong diisi \"resource:action\".",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Permissions"
+                ],
+                "summary": "Buat permission baru (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "description": "Data permission yang akan dibuat",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateUserRequest"
+                            "$ref": "#/definitions/model.CreatePermissionRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "User berhasil dibuat",
+                        "description": "Permission berhasil dibuat",
                         "schema": {
                             "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Resource dan action harus diisi / validasi gagal",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -2351,14 +2593,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users/{id}": {
+        "/v1/permissions/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil detail user berdasarkan User ID",
+                "description": "Mengambil detail permission berdasarkan ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2366,13 +2608,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Permissions"
                 ],
-                "summary": "Dapatkan detail user (Admin)",
+                "summary": "Dapatkan detail permission (Permission: user:manage)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
+                        "description": "Permission ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2380,9 +2622,10 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data user berhasil diambil",
+                        "description": "Data permission berhasil diambil",
                         "schema": {
-                            "$ref": "#/definitions/model.UserDetailResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -2398,7 +2641,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "User tidak ditemukan",
+                        "description": "Permission tidak ditemukan",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -2417,7 +2660,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin dapat update username, email, password, role, atau is_active",
+                "description": "Memerlukan permission user:manage untuk mengupdate data permission berdasarkan ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2425,30 +2668,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Permissions"
                 ],
-                "summary": "Update data users (Admin)",
+                "summary": "Update permission (Permission: user:manage)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
+                        "description": "Permission ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Data user yang diupdate",
+                        "description": "Data permission yang akan diupdate",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateUserRequest"
+                            "$ref": "#/definitions/model.UpdatePermissionRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User berhasil diupdate",
+                        "description": "Permission berhasil diupdate",
                         "schema": {
                             "$ref": "#/definitions/model.SuccessResponse"
                         }
@@ -2466,7 +2709,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "User tidak ditemukan",
+                        "description": "Permission tidak ditemukan",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -2485,7 +2728,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin dapat menghapus users berdasarkan ID",
+                "description": "Memerlukan permission user:manage untuk menghapus permission berdasarkan ID. Permission yang masih dipetakan ke role ditolak kecuali force=true.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2493,27 +2736,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Permissions"
                 ],
-                "summary": "Hapus users (Admin)",
+                "summary": "Hapus permission (Permission: user:manage)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
+                        "description": "Permission ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hapus mapping role_permissions lebih dulu",
+                        "name": "force",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User berhasil dihapus",
+                        "description": "Permission berhasil dihapus",
                         "schema": {
                             "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "User ID tidak valid",
+                        "description": "Validasi gagal",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -2524,6 +2773,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Permission tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Permission sedang digunakan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Error server",
                         "schema": {
@@ -2533,14 +2794,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users/{id}/role": {
-            "put": {
+        "/v1/role-permissions": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin dapat mengupdate role user berdasarkan nama role (bukan ID)",
+                "description": "Mengambil daftar mapping role_id dan permission_id dengan pagination dan filter opsional; role_id dan permission_id boleh dikombinasikan",
                 "consumes": [
                     "application/json"
                 ],
@@ -2548,36 +2809,100 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "RolePermissions"
                 ],
-                "summary": "Update user role by role name (Admin)",
+                "summary": "Dapatkan semua role_permission (Permission: user:manage)",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter role_id (UUID)",
+                        "name": "role_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Data role baru berdasarkan nama",
+                        "type": "string",
+                        "description": "Filter permission_id (UUID)",
+                        "name": "permission_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data role_permission berhasil diambil",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Format role_id atau permission_id tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat mapping role_id dan permission_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RolePermissions"
+                ],
+                "summary": "Tambah role_permission (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "description": "Data role_permission",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateUserRoleByNameRequest"
+                            "$ref": "#/definitions/model.CreateRolePermissionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Role user berhasil diupdate",
+                    "201": {
+                        "description": "role_permission berhasil dibuat",
                         "schema": {
                             "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validasi gagal",
+                        "description": "Validasi gagal / data sudah ada / foreign key tidak valid",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -2588,12 +2913,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "User atau Role tidak ditemukan",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Error server",
                         "schema": {
@@ -2602,44 +2921,2530 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.Attachment": {
-            "type": "object",
-            "properties": {
-                "file_name": {
-                    "type": "string"
-                },
-                "file_type": {
-                    "type": "string"
-                },
-                "file_url": {
-                    "type": "string"
-                },
-                "uploaded_at": {
-                    "type": "string"
-                }
-            }
         },
-        "model.CreateAchievementRequest": {
-            "type": "object",
-            "required": [
-                "achievement_type",
-                "description",
-                "details",
-                "title"
-            ],
-            "properties": {
-                "achievement_type": {
-                    "type": "string",
-                    "enum": [
-                        "academic",
-                        "competition",
+        "/v1/role-permissions/byrole/{role_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil list permission yang dimiliki oleh role tertentu dengan pagination (urut nama)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RolePermissions"
+                ],
+                "summary": "Dapatkan daftar permissions milik role (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID (UUID)",
+                        "name": "role_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data permissions milik role berhasil diambil",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/role-permissions/{role_id}/{permission_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update composite key mapping (role_id, permission_id) menjadi (new_role_id, new_permission_id)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RolePermissions"
+                ],
+                "summary": "Update role_permission (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID lama (UUID)",
+                        "name": "role_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Permission ID lama (UUID)",
+                        "name": "permission_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data role_permission baru",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateRolePermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "role_permission berhasil diupdate",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal / data sudah ada / new_role_id atau new_permission_id tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "role_permission tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus mapping role_id dan permission_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RolePermissions"
+                ],
+                "summary": "Hapus role_permission (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID (UUID)",
+                        "name": "role_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Permission ID (UUID)",
+                        "name": "permission_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "role_permission berhasil dihapus",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "role_permission tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar semua role dengan pagination. Parameter q (min 2 karakter) mencari pada nama/deskripsi role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Dapatkan semua role (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kata kunci nama/deskripsi role (min 2 karakter)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role list berhasil diambil",
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter page/limit/q tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memerlukan permission user:manage untuk membuat role baru",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Buat role baru (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "description": "Data role baru",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Role berhasil dibuat",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/roles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil detail role berdasarkan Role ID. Mendukung ETag/If-None-Match (304 jika tidak berubah).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Dapatkan detail role (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari response sebelumnya",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data role berhasil diambil",
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleDetailResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Data tidak berubah"
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memerlukan permission user:manage untuk mengupdate data role berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Update role (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data role yang akan diupdate",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role berhasil diupdate",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memerlukan permission user:manage untuk menghapus role berdasarkan ID. Role yang masih dipakai user/role_permissions ditolak kecuali force=true. Role admin tidak dapat dihapus.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Hapus role (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hapus mapping role_permissions dan lepas role dari user lebih dulu",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role berhasil dihapus",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Role ID tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Role sedang digunakan / role admin tidak dapat dihapus",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/roles/{id}/user-count": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Untuk dashboard admin; hanya menghitung tanpa mengambil daftar user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Jumlah user pemegang role (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleUserCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Role ID kosong atau bukan UUID",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencari users (username/email/nama), students (NIM/nama), dan lecturers (NIP/department/nama). Page dan limit berlaku per section (limit maks 50).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Pencarian global users, students, dan lecturers (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman per section (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per section (default: 5, maks: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SearchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Kata kunci kosong",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/students": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar semua students dengan pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Dapatkan semua students (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data student berhasil diambil",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter page/limit tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat data students baru",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Buat students (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "description": "Data student",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateStudentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Student berhasil dibuat",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Request body tidak valid / advisor_id bukan lecturer valid / user sudah terdaftar sebagai student",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "user_id dan student_id harus diisi",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/students/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload CSV dengan header user_id,student_id[,program_study,academic_year,advisor_id]. Setiap baris divalidasi (format, duplikat di file, user ada, user belum jadi student, advisor_id adalah lecturer yang ada). Dengan dry_run=true tidak ada data yang ditulis; hasil per baris berstatus would_create.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Import students dari CSV (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File CSV",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validasi saja tanpa menyimpan (default false)",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ringkasan import per baris",
+                        "schema": {
+                            "$ref": "#/definitions/model.StudentImportSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "File/header CSV tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/students/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Resolve record students dari user_id di token, tanpa perlu tahu UUID internal",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Dapatkan data student milik user yang login (mahasiswa)",
+                "responses": {
+                    "200": {
+                        "description": "Data student berhasil diambil",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Bukan mahasiswa",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/students/me/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan achievement berstatus verified tanpa id internal beserta total poin, untuk halaman profil yang bisa dibagikan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Ringkasan prestasi terverifikasi milik mahasiswa (public-safe)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StudentAchievementSummary"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/students/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil detail students berdasarkan id (UUID)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Dapatkan students by ID (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data student berhasil diambil",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update students by id (partial update). Untuk set/hapus advisor gunakan PUT /v1/students/{id}/advisor; advisor_id = \"00000000-0000-0000-0000-000000000000\" masih didukung untuk kompatibilitas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Update students (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Field yang ingin diupdate",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateStudentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student berhasil diupdate",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal / advisor_id bukan lecturer valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus students by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Hapus students (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student berhasil dihapus",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/students/{id}/advisor": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengisi advisor_id dengan lecturer yang valid, atau mengosongkannya dengan advisor_id = null. Field advisor_id wajib ada di body.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Set atau hapus dosen wali utama student (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "advisor_id (uuid lecturer atau null)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SetStudentAdvisorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Advisor student berhasil diupdate / dihapus",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal / advisor_id bukan lecturer valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/students/{id}/advisor-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan riwayat perubahan advisor utama dan co-advisor student, terbaru lebih dulu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Riwayat advisor student (Admin, mahasiswa pemilik, dosen wali)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Riwayat advisor berhasil diambil",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Tidak berhak melihat riwayat advisor",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/students/{id}/advisors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan advisor utama (advisor_id) dan co-advisor student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Daftar advisor student (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data advisor berhasil diambil",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan lecturer sebagai advisor tambahan; advisor_id utama tidak berubah",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Tambah co-advisor student (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lecturer yang ditambahkan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AddStudentAdvisorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Advisor berhasil ditambahkan",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student atau lecturer tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Advisor sudah terdaftar",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/students/{id}/advisors/{lecturer_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus lecturer dari daftar advisor tambahan student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Hapus co-advisor student (Permission: user:manage)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID (UUID)",
+                        "name": "lecturer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Advisor berhasil dihapus",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Advisor tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar semua user dengan pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Dapatkan semua user (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sembunyikan akun admin yang sedang login dari daftar",
+                        "name": "exclude_self",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter status aktif (true/false); kosong = semua",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field yang dikembalikan, dipisah koma (mis. id,username,email); field tidak dikenal diabaikan",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User list berhasil diambil",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter is_active tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin membuat users baru dengan validasi lengkap. role_name opsional untuk langsung mengisi role user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Buat users baru (Admin)",
+                "parameters": [
+                    {
+                        "description": "Data user baru",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User berhasil dibuat",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Request body tidak valid / username sudah terdaftar / role_name tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validasi field gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/byrole": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar user berdasarkan nama role dengan pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Dapatkan user berdasarkan nama role (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nama role (contoh: admin)",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User list berhasil diambil",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/locked": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil user dengan locked_until di masa depan akibat login gagal berulang",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Daftar user yang sedang terkunci (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data user terkunci berhasil diambil",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter page/limit tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/unassigned": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil user dengan role_id kosong; akun ini tidak bisa melakukan apa pun sampai role diisi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Daftar user tanpa role (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default: DEFAULT_PAGE_LIMIT, 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data user tanpa role berhasil diambil",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter page/limit tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil detail user berdasarkan User ID. Mendukung ETag/If-None-Match (304 jika tidak berubah).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Dapatkan detail user (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari response sebelumnya",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field yang dikembalikan, dipisah koma (mis. id,username,email); field tidak dikenal diabaikan",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data user berhasil diambil",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserDetailResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Data tidak berubah"
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin dapat update username, email, password, role, atau is_active. role_id kosong berarti role tidak diubah; clear_role=true mengosongkan role (user kehilangan semua permission).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update data users (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data user yang diupdate",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User berhasil diupdate",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Admin terakhir tidak dapat diturunkan atau data telah diubah oleh proses lain",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin dapat menghapus users berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Hapus users (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User berhasil dihapus",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "User ID tidak valid / menghapus akun sendiri",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Admin terakhir tidak dapat dihapus",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Semantik PATCH: field yang tidak dikirim tidak diubah, field yang dikirim di-set apa adanya. full_name boleh string kosong; role_id \"\" mengosongkan role. Validasi sama dengan PUT.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update sebagian data users (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Field yang diubah",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PatchUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User berhasil diupdate",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Admin terakhir tidak dapat diturunkan atau data telah diubah oleh proses lain",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/active": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengisi/mengosongkan disabled_at dan menyamakan is_active. User yang dinonaktifkan ditolak saat login (403) sampai diaktifkan kembali. Admin tidak dapat menonaktifkan akun sendiri maupun admin terakhir.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Aktifkan/nonaktifkan user (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status aktif baru",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SetUserActiveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status aktif user berhasil diupdate",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Admin terakhir tidak dapat dinonaktifkan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin dapat mengupdate role user berdasarkan nama role (bukan ID)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user role by role name (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data role baru berdasarkan nama",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateUserRoleByNameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role user berhasil diupdate",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User atau Role tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Admin terakhir tidak dapat diturunkan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengosongkan counter login gagal dan locked_until sehingga user bisa login lagi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Buka kunci akun user (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Akun user berhasil dibuka",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error server",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.Achievement": {
+            "type": "object",
+            "properties": {
+                "achievement_type": {
+                    "description": "lihat AllowedAchievementTypes",
+                    "type": "string"
+                },
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Attachment"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "id": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementFunnel": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "submitted": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AchievementPublicVerification": {
+            "type": "object",
+            "properties": {
+                "achievement_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "program_study": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementReference": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "days_pending": {
+                    "description": "DaysPending \u0026 Overdue dihitung di service, hanya terisi untuk status submitted.",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mongo_achievement_id": {
+                    "type": "string"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "rejection_note": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "draft, submitted, verified, rejected, deleted",
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementWithReference": {
+            "type": "object",
+            "properties": {
+                "achievement": {
+                    "$ref": "#/definitions/model.Achievement"
+                },
+                "mongo_missing": {
+                    "description": "MongoMissing true jika dokumen Mongo reference ini tidak ada (id rusak atau dokumen terhapus)",
+                    "type": "boolean"
+                },
+                "reference": {
+                    "$ref": "#/definitions/model.AchievementReference"
+                },
+                "student": {
+                    "description": "Student hanya diisi jika diminta dengan ?expand=student",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.StudentSummary"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.AddStudentAdvisorRequest": {
+            "type": "object",
+            "required": [
+                "lecturer_id"
+            ],
+            "properties": {
+                "lecturer_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Attachment": {
+            "type": "object",
+            "properties": {
+                "file_name": {
+                    "type": "string"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "uploaded_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BulkReviewAchievementRequest": {
+            "type": "object",
+            "required": [
+                "ids",
+                "status"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rejection_note": {
+                    "type": "string",
+                    "example": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "verified",
+                        "rejected"
+                    ],
+                    "example": "verified"
+                }
+            }
+        },
+        "model.CreateAchievementRequest": {
+            "type": "object",
+            "required": [
+                "achievement_type",
+                "description",
+                "details",
+                "title"
+            ],
+            "properties": {
+                "achievement_type": {
+                    "type": "string",
+                    "enum": [
+                        "academic",
+                        "competition",
                         "organization",
                         "publication",
-                        "certification",
-                        "other"
+                        "certification"
                     ]
                 },
                 "attachments": {
@@ -2692,7 +5497,6 @@ const docTemplate = `{
             "type": "object",
             "required": [
                 "action",
-                "name",
                 "resource"
             ],
             "properties": {
@@ -2776,6 +5580,11 @@ const docTemplate = `{
                 "password": {
                     "type": "string"
                 },
+                "role_name": {
+                    "description": "RoleName opsional; jika diisi, role dicari berdasarkan nama dan role_id diset saat user dibuat.",
+                    "type": "string",
+                    "example": "mahasiswa"
+                },
                 "username": {
                     "type": "string"
                 }
@@ -2798,6 +5607,43 @@ const docTemplate = `{
                 }
             }
         },
+        "model.LecturerSearchItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lecturer_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LecturerSearchSection": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LecturerSearchItem"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.LoginRequest": {
             "type": "object",
             "required": [
@@ -2808,45 +5654,187 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
-                "password": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "ExpiresAt waktu expired token (RFC3339), ExpiresIn sisa detik saat token diterbitkan",
+                    "type": "string",
+                    "example": "2025-01-02T15:04:05Z"
+                },
+                "expires_in": {
+                    "type": "integer",
+                    "example": 86400
+                },
+                "message": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "description": "RefreshToken sekali pakai; kirim ke /v1/auth/refresh untuk mendapat pasangan token baru",
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/model.UserResponse"
+                }
+            }
+        },
+        "model.LogoutRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.MaintenanceRequest": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.OnboardStudentRequest": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string",
+                    "example": "2022"
+                },
+                "advisor_id": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "budi@mail.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "Secret1"
+                },
+                "program_study": {
+                    "type": "string",
+                    "example": "Informatika"
+                },
+                "student_id": {
+                    "type": "string",
+                    "example": "2201001"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "budi_s"
+                }
+            }
+        },
+        "model.PatchUserRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PublicAchievement": {
+            "type": "object",
+            "properties": {
+                "achievement_type": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "verified_at": {
                     "type": "string"
                 }
             }
         },
-        "model.LoginResponse": {
+        "model.ReassignAchievementRequest": {
             "type": "object",
+            "required": [
+                "student_id"
+            ],
             "properties": {
-                "message": {
-                    "type": "string"
-                },
-                "success": {
-                    "type": "boolean"
-                },
-                "token": {
-                    "type": "string"
-                },
-                "user": {
-                    "$ref": "#/definitions/model.UserResponse"
+                "student_id": {
+                    "type": "string",
+                    "example": "uuid-student"
                 }
             }
         },
-        "model.LogoutRequest": {
+        "model.ReferenceStatusesRequest": {
             "type": "object",
             "required": [
-                "token"
+                "mongo_ids"
             ],
             "properties": {
-                "token": {
-                    "type": "string"
+                "mongo_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
         "model.RefreshTokenRequest": {
             "type": "object",
-            "required": [
-                "token"
-            ],
             "properties": {
+                "refresh_token": {
+                    "type": "string"
+                },
                 "token": {
                     "type": "string"
                 }
@@ -2936,6 +5924,197 @@ const docTemplate = `{
                 }
             }
         },
+        "model.RoleUserCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "role_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SearchResult": {
+            "type": "object",
+            "properties": {
+                "lecturers": {
+                    "$ref": "#/definitions/model.LecturerSearchSection"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "students": {
+                    "$ref": "#/definitions/model.StudentSearchSection"
+                },
+                "users": {
+                    "$ref": "#/definitions/model.UserSearchSection"
+                }
+            }
+        },
+        "model.SetStudentAdvisorRequest": {
+            "type": "object",
+            "properties": {
+                "advisor_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SetUserActiveRequest": {
+            "type": "object",
+            "required": [
+                "is_active"
+            ],
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.StudentAchievementSummary": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string"
+                },
+                "achievements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PublicAchievement"
+                    }
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "program_study": {
+                    "type": "string"
+                },
+                "student_number": {
+                    "type": "string"
+                },
+                "total_points": {
+                    "type": "number"
+                },
+                "total_verified": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.StudentImportRowResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StudentImportSummary": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StudentImportRowResult"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "would_create": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.StudentSearchItem": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string"
+                },
+                "advisor_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "program_study": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StudentSearchSection": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StudentSearchItem"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.StudentSummary": {
+            "type": "object",
+            "properties": {
+                "advisor_name": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "program_study": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                }
+            }
+        },
         "model.SuccessResponse": {
             "type": "object",
             "properties": {
@@ -2953,12 +6132,28 @@ const docTemplate = `{
                 }
             }
         },
+        "model.TagCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
+        },
         "model.UpdateAchievementStatusRequest": {
             "type": "object",
             "required": [
                 "status"
             ],
             "properties": {
+                "points": {
+                    "description": "Points opsional: saat status verified, points achievement diganti nilai ini (tidak boleh negatif).\nDiabaikan jika status rejected.",
+                    "type": "number",
+                    "example": 50
+                },
                 "rejection_note": {
                     "type": "string",
                     "example": "string"
@@ -3043,6 +6238,10 @@ const docTemplate = `{
         "model.UpdateUserRequest": {
             "type": "object",
             "properties": {
+                "clear_role": {
+                    "description": "ClearRole true mengosongkan role (role_id = NULL); RoleID kosong berarti role tidak diubah.\nTidak boleh dikombinasikan dengan RoleID.",
+                    "type": "boolean"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -3058,6 +6257,10 @@ const docTemplate = `{
                 "role_id": {
                     "type": "string"
                 },
+                "updated_at": {
+                    "description": "UpdatedAt opsional: jika diisi, update hanya berhasil bila updated_at di DB masih sama (optimistic lock)",
+                    "type": "string"
+                },
                 "username": {
                     "type": "string"
                 }
@@ -3146,6 +6349,64 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "model.UserSearchSection": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.VerifyTokenRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.VerifyTokenResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-02T15:04:05Z"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Token valid"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "role_id": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
         }
     },
     "securityDefinitions": {
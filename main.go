@@ -1,14 +1,17 @@
 package main
 
 import (
+	"flag"
 	"log"
 
 	"github.com/joho/godotenv"
-	fiberSwagger "github.com/swaggo/fiber-swagger"
 
+	"hello-fiber/app/repository"
+	"hello-fiber/app/service"
 	"hello-fiber/config"
 	"hello-fiber/database"
 	_ "hello-fiber/docs" // Import generated docs package
+	"hello-fiber/utils"
 )
 
 // @title Alumni Management API
@@ -26,15 +29,38 @@ import (
 // @description Type "Bearer" followed by a space and JWT token.
 
 func main() {
+	seed := flag.Bool("seed", false, "buat role, permission, dan admin default lalu keluar")
+	flag.Parse()
+
 	// load .env
 	if err := godotenv.Load(); err != nil {
 		log.Println("Warning: .env not loaded:", err)
 	}
 
-	// NewApp will call ConnectMongoDB internally
+	// cek env wajib sebelum mencoba koneksi apa pun
+	if err := config.ValidateConfig(); err != nil {
+		log.Fatal(err)
+	}
+
+	if *seed {
+		runSeed()
+		return
+	}
+
+	// NewApp will call ConnectMongoDB internally (termasuk route /swagger/*)
 	app := config.NewApp()
 
-	app.Get("/swagger/*", fiberSwagger.WrapHandler)
+	// job hard delete achievement berstatus deleted (aktif jika ENABLE_CLEANUP=true)
+	if cfg, ok := service.CleanupConfigFromEnv(); ok {
+		stopCleanup := service.StartAchievementCleanup(cfg)
+		defer stopCleanup()
+	}
+
+	// job soft delete draft achievement lama (aktif jika ENABLE_DRAFT_EXPIRY=true)
+	if cfg, ok := service.DraftExpiryConfigFromEnv(); ok {
+		stopDraftExpiry := service.StartDraftExpiry(cfg)
+		defer stopDraftExpiry()
+	}
 
 	// disconnect saat program keluar (DisconnectMongoDB harus aman dipanggil jika belum terhubung)
 	defer func() {
@@ -45,3 +71,22 @@ func main() {
 
 	log.Fatal(app.Listen(":3000"))
 }
+
+// runSeed menjalankan repository.Seed; admin bootstrap diambil dari SEED_ADMIN_EMAIL,
+// SEED_ADMIN_PASSWORD, SEED_ADMIN_USERNAME (default admin), dan SEED_ADMIN_FULL_NAME.
+func runSeed() {
+	db := database.ConnectDB()
+	defer db.Close()
+
+	result, err := repository.Seed(db, &repository.SeedAdmin{
+		Username: utils.GetEnv("SEED_ADMIN_USERNAME", "admin"),
+		Email:    utils.GetEnv("SEED_ADMIN_EMAIL", ""),
+		Password: utils.GetEnv("SEED_ADMIN_PASSWORD", ""),
+		FullName: utils.GetEnv("SEED_ADMIN_FULL_NAME", "Administrator"),
+	})
+	if err != nil {
+		log.Fatal("Seed gagal: ", err)
+	}
+	log.Printf("Seed selesai: %d role, %d permission, %d role_permission baru; admin dibuat: %t",
+		result.RolesCreated, result.PermissionsCreated, result.MappingsCreated, result.AdminCreated)
+}
@@ -9,12 +9,11 @@ import (
 	"hello-fiber/utils"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 // JWTAuthMiddleware
 // Membaca header Authorization: Bearer <token>
-// Verifikasi JWT menggunakan utils.Claims dan utils.GetJWTSecret()
+// Verifikasi JWT menggunakan utils.ParseJWT (secret primary + JWT_PREVIOUS_SECRETS)
 // Simpan user_id, email, role_id ke Locals untuk dipakai handler / middleware lain
 func JWTAuthMiddleware(db *sql.DB) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -40,12 +39,7 @@ func JWTAuthMiddleware(db *sql.DB) fiber.Handler {
 		}
 
 		// Parse dan verifikasi token JWT
-		token, err := jwt.ParseWithClaims(tokenString, &utils.Claims{}, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrTokenUnverifiable
-			}
-			return utils.GetJWTSecret(), nil
-		})
+		token, err := utils.ParseJWT(tokenString)
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"error":  "Invalid atau expired token",
@@ -61,7 +55,7 @@ func JWTAuthMiddleware(db *sql.DB) fiber.Handler {
 		}
 
 		userRepo := repository.NewUserRepositoryPostgres(db)
-		user, err := userRepo.GetUserByID(claims.UserID)
+		user, err := userRepo.GetUserByID(c.UserContext(), claims.UserID)
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"error": "user tidak ditemukan",
@@ -106,7 +100,7 @@ func AdminOnlyMiddleware(db *sql.DB) fiber.Handler {
 		}
 
 		roleRepo := repository.NewRoleRepositoryPostgres(db)
-		adminRole, err := roleRepo.GetRoleByName("Admin")
+		adminRole, err := roleRepo.GetRoleByName(c.UserContext(), "Admin")
 		if err != nil {
 			fmt.Printf("[DEBUG] Admin role not found: %v\n", err)
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
@@ -146,7 +140,7 @@ func StudentOnlyMiddleware(db *sql.DB) fiber.Handler {
 		}
 
 		studentRepo := repository.NewStudentRepositoryPostgres(db)
-		st, err := studentRepo.GetStudentByUserID(userIDStr)
+		st, err := studentRepo.GetStudentByUserID(c.UserContext(), userIDStr)
 		if err != nil || st == nil {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 				"error": "Hanya mahasiswa yang dapat mengakses",
@@ -187,7 +181,7 @@ func RequirePermission(db *sql.DB, permName string) fiber.Handler {
 		}
 
 		rpRepo := repository.NewRolePermissionRepositoryPostgres(db)
-		perms, err := rpRepo.GetPermissionsByRoleID(roleID)
+		perms, err := rpRepo.GetPermissionsByRoleID(c.UserContext(), roleID)
 		if err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": "Failed to load permissions",
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit menolak request dengan body > defaultLimit byte (413).
+// overrides berisi limit khusus per route dengan key "METHOD /path" (mis. endpoint upload).
+// fiber.Config.BodyLimit tetap harus >= limit terbesar karena body dibaca fasthttp lebih dulu.
+func BodyLimit(defaultLimit int, overrides map[string]int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		limit := defaultLimit
+		if l, ok := overrides[routeKey(c)]; ok {
+			limit = l
+		}
+
+		size := c.Request().Header.ContentLength()
+		if n := len(c.Request().Body()); n > size {
+			size = n
+		}
+		if size > limit {
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+				"success": false,
+				"message": fmt.Sprintf("Ukuran request melebihi batas %d MB", limit/(1024*1024)),
+			})
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newBodyLimitTestApp() *fiber.App {
+	app := fiber.New(fiber.Config{BodyLimit: 8 * 1024})
+	app.Use(BodyLimit(1024, map[string]int{
+		fiber.MethodPost + " /upload": 4 * 1024,
+	}))
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Post("/json", ok)
+	app.Post("/upload", ok)
+	return app
+}
+
+func TestBodyLimit_OversizedJSONRejected(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/json", bytes.NewReader(bytes.Repeat([]byte("a"), 2048)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := newBodyLimitTestApp().Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestBodyLimit_UploadOverrideAllowsLargerBody(t *testing.T) {
+	app := newBodyLimitTestApp()
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", bytes.NewReader(bytes.Repeat([]byte("a"), 2048)))
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/upload", bytes.NewReader(bytes.Repeat([]byte("a"), 6*1024)))
+	resp, err = app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusRequestEntityTooLarge)
+	}
+}
@@ -1,11 +1,49 @@
 package middleware
 
 import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"hello-fiber/utils"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
-// LoggerMiddleware logs requests
+// HeaderRequestID header yang membawa request id dari client/proxy dan dikembalikan di response.
+const HeaderRequestID = "X-Request-ID"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// requestID memakai X-Request-ID dari client jika formatnya aman untuk log; selain itu dibuat baru.
+func requestID(c *fiber.Ctx) string {
+	if id := strings.TrimSpace(c.Get(HeaderRequestID)); requestIDPattern.MatchString(id) {
+		return id
+	}
+	return uuid.NewString()
+}
+
+// LoggerMiddleware logs requests dan mengisi metrik Prometheus (lihat metrics.go). Request id disimpan
+// di c.UserContext() (utils.RequestIDFromContext) agar log repository bisa dikaitkan ke request.
 func LoggerMiddleware(c *fiber.Ctx) error {
-    // Log request details (example)
-    return c.Next()
-}
\ No newline at end of file
+	start := time.Now()
+	id := requestID(c)
+	c.Locals("request_id", id)
+	c.SetUserContext(utils.WithRequestID(c.UserContext(), id))
+	c.Set(HeaderRequestID, id)
+	err := c.Next()
+
+	// error handler belum jalan di titik ini, jadi status diambil dari error yang dikembalikan
+	status := c.Response().StatusCode()
+	if err != nil {
+		status = fiber.StatusInternalServerError
+		var fe *fiber.Error
+		if errors.As(err, &fe) {
+			status = fe.Code
+		}
+	}
+	observeRequest(c, status, time.Since(start))
+	return err
+}
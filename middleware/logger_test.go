@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hello-fiber/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestLoggerMiddleware_PropagatesRequestID(t *testing.T) {
+	app := fiber.New()
+	app.Use(LoggerMiddleware)
+	var seen string
+	app.Get("/ping", func(c *fiber.Ctx) error {
+		seen = utils.RequestIDFromContext(c.UserContext())
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	for _, tc := range []struct {
+		name, header string
+		keep         bool
+	}{
+		{"client id dipakai", "abc-123", true},
+		{"id tidak aman diganti", "x\ny", false},
+		{"tanpa header", "", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			if tc.header != "" {
+				req.Header.Set(HeaderRequestID, tc.header)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			got := resp.Header.Get(HeaderRequestID)
+			if got == "" || got != seen {
+				t.Fatalf("response id %q must match context id %q", got, seen)
+			}
+			if (got == tc.header) != tc.keep {
+				t.Fatalf("header %q: got id %q", tc.header, got)
+			}
+		})
+	}
+}
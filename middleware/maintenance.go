@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"strconv"
+	"sync/atomic"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DefaultMaintenanceRetryAfter nilai header Retry-After (detik) jika MAINTENANCE_RETRY_AFTER tidak diset.
+const DefaultMaintenanceRetryAfter = 300
+
+// maintenanceMode flag global mode maintenance; diinisialisasi dari MAINTENANCE_MODE dan bisa
+// di-toggle admin saat runtime (hanya di memori, kembali ke nilai env setelah restart).
+var maintenanceMode atomic.Bool
+
+// SetMaintenanceMode menyalakan/mematikan mode maintenance.
+func SetMaintenanceMode(on bool) {
+	maintenanceMode.Store(on)
+}
+
+// MaintenanceModeEnabled melaporkan apakah mode maintenance sedang aktif.
+func MaintenanceModeEnabled() bool {
+	return maintenanceMode.Load()
+}
+
+// Maintenance menolak request yang mengubah data (POST/PUT/PATCH/DELETE) dengan 503 selama mode
+// maintenance aktif; GET/HEAD/OPTIONS tetap dilayani. exempt berisi route dengan key "METHOD /path"
+// yang tetap diizinkan, mis. login dan endpoint toggle agar admin bisa mematikan maintenance.
+func Maintenance(retryAfterSeconds int, exempt ...string) fiber.Handler {
+	allowed := make(map[string]bool, len(exempt))
+	for _, key := range exempt {
+		allowed[key] = true
+	}
+	return func(c *fiber.Ctx) error {
+		if !maintenanceMode.Load() {
+			return c.Next()
+		}
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			return c.Next()
+		}
+		if allowed[routeKey(c)] {
+			return c.Next()
+		}
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfterSeconds))
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"success": false,
+			"message": "Sistem sedang dalam maintenance, perubahan data sementara tidak dapat dilakukan",
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newMaintenanceTestApp(t *testing.T, on bool) *fiber.App {
+	t.Helper()
+	SetMaintenanceMode(on)
+	t.Cleanup(func() { SetMaintenanceMode(false) })
+
+	app := fiber.New()
+	app.Use(Maintenance(120, fiber.MethodPut+" /admin/maintenance"))
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Get("/items", ok)
+	app.Post("/items", ok)
+	app.Delete("/items/:id", ok)
+	app.Put("/admin/maintenance", ok)
+	return app
+}
+
+func doMaintenanceRequest(t *testing.T, app *fiber.App, method, path string) *http.Response {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest(method, path, nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	return resp
+}
+
+func TestMaintenance_BlocksMutationsWhenOn(t *testing.T) {
+	app := newMaintenanceTestApp(t, true)
+
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodPost, "/items"},
+		{http.MethodDelete, "/items/1"},
+	} {
+		resp := doMaintenanceRequest(t, app, tc.method, tc.path)
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("%s %s: got %d want 503", tc.method, tc.path, resp.StatusCode)
+		}
+		if got := resp.Header.Get(fiber.HeaderRetryAfter); got != "120" {
+			t.Fatalf("%s %s: Retry-After got %q", tc.method, tc.path, got)
+		}
+	}
+}
+
+func TestMaintenance_AllowsReadsAndExemptRoutesWhenOn(t *testing.T) {
+	app := newMaintenanceTestApp(t, true)
+
+	if resp := doMaintenanceRequest(t, app, http.MethodGet, "/items"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET must pass during maintenance, got %d", resp.StatusCode)
+	}
+	if resp := doMaintenanceRequest(t, app, http.MethodPut, "/admin/maintenance"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("exempt toggle endpoint must pass, got %d", resp.StatusCode)
+	}
+}
+
+func TestMaintenance_OffPassesEverything(t *testing.T) {
+	app := newMaintenanceTestApp(t, false)
+
+	if resp := doMaintenanceRequest(t, app, http.MethodPost, "/items"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("POST must pass when maintenance is off, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsRegistry registry Prometheus milik aplikasi (terpisah dari default registry global).
+var MetricsRegistry = prometheus.NewRegistry()
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Jumlah request HTTP per method, path route, dan status.",
+	}, []string{"method", "path", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Durasi request HTTP dalam detik per method dan path route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "path"})
+)
+
+func init() {
+	MetricsRegistry.MustRegister(
+		httpRequestsTotal,
+		httpRequestDuration,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// MetricsHandler handler GET /metrics dalam format exposition Prometheus
+func MetricsHandler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(MetricsRegistry, promhttp.HandlerOpts{}))
+}
+
+// observeRequest mencatat satu request; path memakai pola route (mis. /api/v1/users/:id)
+// agar label tidak meledak karena ID.
+func observeRequest(c *fiber.Ctx, status int, elapsed time.Duration) {
+	method := c.Method()
+	path := c.Route().Path
+	httpRequestsTotal.WithLabelValues(method, path, strconv.Itoa(status)).Inc()
+	httpRequestDuration.WithLabelValues(method, path).Observe(elapsed.Seconds())
+}
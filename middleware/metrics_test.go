@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func scrapeMetrics(t *testing.T, app *fiber.App) string {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/metrics", nil), -1)
+	if err != nil {
+		t.Fatalf("scrape /metrics: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("/metrics status: got %d", resp.StatusCode)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func metricLine(body, prefix string) string {
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, prefix) {
+			return line
+		}
+	}
+	return ""
+}
+
+func TestMetrics_CounterIncrementsAfterRequest(t *testing.T) {
+	app := fiber.New()
+	app.Use(LoggerMiddleware)
+	app.Get("/metrics", MetricsHandler())
+	app.Get("/items/:id", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	app.Get("/missing", func(c *fiber.Ctx) error { return fiber.ErrNotFound })
+
+	counter := `http_requests_total{method="GET",path="/items/:id",status="200"}`
+	before := metricLine(scrapeMetrics(t, app), counter)
+
+	for i := 0; i < 2; i++ {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, fmt.Sprintf("/items/%d", i), nil), -1)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("request %d: status=%v err=%v", i, resp, err)
+		}
+	}
+	if _, err := app.Test(httptest.NewRequest(http.MethodGet, "/missing", nil), -1); err != nil {
+		t.Fatalf("request /missing: %v", err)
+	}
+
+	body := scrapeMetrics(t, app)
+	if before != "" {
+		t.Fatalf("counter should not exist before first request, got %q", before)
+	}
+	if got := metricLine(body, counter); got != counter+" 2" {
+		t.Fatalf("unexpected counter line: %q\n%s", got, body)
+	}
+	if got := metricLine(body, `http_requests_total{method="GET",path="/missing",status="404"}`); !strings.HasSuffix(got, " 1") {
+		t.Fatalf("error status not recorded: %q", got)
+	}
+	if !strings.Contains(body, `http_request_duration_seconds_count{method="GET",path="/items/:id"} 2`) {
+		t.Fatalf("histogram not populated:\n%s", body)
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireJSON menolak request POST/PUT/PATCH ber-body yang Content-Type-nya bukan application/json
+// dengan 415, agar BodyParser tidak diam-diam mem-parse form/teks. Request tanpa body (mis. PUT submit)
+// tetap diteruskan. exempt berisi route dengan key "METHOD /path" yang menerima multipart (upload).
+func RequireJSON(exempt ...string) fiber.Handler {
+	allowed := make(map[string]bool, len(exempt))
+	for _, key := range exempt {
+		allowed[key] = true
+	}
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch:
+		default:
+			return c.Next()
+		}
+		if len(c.Body()) == 0 && c.Request().Header.ContentLength() <= 0 {
+			return c.Next()
+		}
+		if allowed[routeKey(c)] {
+			return c.Next()
+		}
+		ctype := strings.ToLower(strings.TrimSpace(c.Get(fiber.HeaderContentType)))
+		if i := strings.IndexByte(ctype, ';'); i >= 0 {
+			ctype = strings.TrimSpace(ctype[:i])
+		}
+		if ctype != fiber.MIMEApplicationJSON {
+			return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{
+				"success": false,
+				"message": "Content-Type harus application/json",
+			})
+		}
+		return c.Next()
+	}
+}
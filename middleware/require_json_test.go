@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequireJSON(t *testing.T) {
+	app := fiber.New()
+	app.Use(RequireJSON(fiber.MethodPost + " /api/v1/achievements"))
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Post("/api/v1/auth/login", ok)
+	app.Post("/api/v1/achievements", ok)
+	app.Put("/api/v1/achievements/:id/submit", ok)
+
+	for _, tc := range []struct {
+		name, method, path, ctype, body string
+		want                            int
+	}{
+		{"text/plain login", http.MethodPost, "/api/v1/auth/login", "text/plain", `{"email":"a@b.c"}`, http.StatusUnsupportedMediaType},
+		{"form login", http.MethodPost, "/api/v1/auth/login", "application/x-www-form-urlencoded", "email=a@b.c", http.StatusUnsupportedMediaType},
+		{"json with charset", http.MethodPost, "/api/v1/auth/login", "application/json; charset=utf-8", `{"email":"a@b.c"}`, http.StatusOK},
+		{"multipart create exempt", http.MethodPost, "/api/v1/achievements", "multipart/form-data; boundary=x", "--x--", http.StatusOK},
+		{"multipart exempt mixed case", http.MethodPost, "/API/v1/Achievements/", "multipart/form-data; boundary=x", "--x--", http.StatusOK},
+		{"empty body put", http.MethodPut, "/api/v1/achievements/1/submit", "", "", http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.ctype != "" {
+				req.Header.Set("Content-Type", tc.ctype)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != tc.want {
+				t.Fatalf("got %d want %d", resp.StatusCode, tc.want)
+			}
+		})
+	}
+}
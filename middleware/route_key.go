@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// routeKey kunci "METHOD /path" untuk daftar route khusus di middleware. Trailing slash dibuang dan,
+// jika routing tidak case-sensitive, path di-lowercase agar /API/v1/Achievements/ tetap cocok dengan
+// key yang sama seperti route yang di-resolve router.
+func routeKey(c *fiber.Ctx) string {
+	path := strings.TrimRight(c.Path(), "/")
+	if !c.App().Config().CaseSensitive {
+		path = strings.ToLower(path)
+	}
+	return c.Method() + " " + path
+}
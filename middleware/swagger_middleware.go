@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"strings"
+
+	"hello-fiber/app/repository"
+	"hello-fiber/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SwaggerAuthMiddleware melindungi /swagger/* jika SWAGGER_PROTECTED=true.
+// Akses diberikan lewat basic auth (SWAGGER_USER/SWAGGER_PASSWORD) atau Bearer token milik admin.
+// SWAGGER_DOC_PUBLIC=true membiarkan doc.json tetap publik (mis. untuk generator client).
+func SwaggerAuthMiddleware(db *sql.DB) fiber.Handler {
+	protected := strings.EqualFold(utils.GetEnv("SWAGGER_PROTECTED", "false"), "true")
+	docPublic := strings.EqualFold(utils.GetEnv("SWAGGER_DOC_PUBLIC", "false"), "true")
+	basicUser := utils.GetEnv("SWAGGER_USER", "")
+	basicPass := utils.GetEnv("SWAGGER_PASSWORD", "")
+
+	return func(c *fiber.Ctx) error {
+		if !protected {
+			return c.Next()
+		}
+		if docPublic && strings.HasSuffix(c.Path(), "/doc.json") {
+			return c.Next()
+		}
+
+		parts := strings.SplitN(c.Get("Authorization"), " ", 2)
+		if len(parts) == 2 {
+			scheme, cred := parts[0], strings.TrimSpace(parts[1])
+			switch {
+			case strings.EqualFold(scheme, "Basic") && basicUser != "" && basicPass != "":
+				if swaggerBasicAuthValid(cred, basicUser, basicPass) {
+					return c.Next()
+				}
+			case strings.EqualFold(scheme, "Bearer"):
+				if swaggerAdminTokenValid(c.UserContext(), db, cred) {
+					return c.Next()
+				}
+			}
+		}
+
+		c.Set(fiber.HeaderWWWAuthenticate, `Basic realm="swagger"`)
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Swagger membutuhkan kredensial admin",
+		})
+	}
+}
+
+func swaggerBasicAuthValid(cred, user, pass string) bool {
+	raw, err := base64.StdEncoding.DecodeString(cred)
+	if err != nil {
+		return false
+	}
+	u, p, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return false
+	}
+	userOK := subtle.ConstantTimeCompare([]byte(u), []byte(user)) == 1
+	passOK := subtle.ConstantTimeCompare([]byte(p), []byte(pass)) == 1
+	return userOK && passOK
+}
+
+func swaggerAdminTokenValid(ctx context.Context, db *sql.DB, tokenString string) bool {
+	if tokenString == "" {
+		return false
+	}
+	token, err := utils.ParseJWT(tokenString)
+	if err != nil {
+		return false
+	}
+	claims, ok := token.Claims.(*utils.Claims)
+	if !ok || !token.Valid || claims.RoleID == "" {
+		return false
+	}
+
+	// seperti AuthMiddleware: admin yang sudah dihapus/dinonaktifkan tidak boleh memakai token lamanya
+	user, err := repository.NewUserRepositoryPostgres(db).GetUserByID(ctx, claims.UserID)
+	if err != nil || user == nil || !user.IsActive || user.RoleID != claims.RoleID {
+		return false
+	}
+
+	role, err := repository.NewRoleRepositoryPostgres(db).GetRoleByID(ctx, claims.RoleID)
+	if err != nil || role == nil {
+		return false
+	}
+	return strings.EqualFold(strings.TrimSpace(role.Name), "admin")
+}
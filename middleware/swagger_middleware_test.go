@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newSwaggerTestApp() *fiber.App {
+	app := fiber.New()
+	app.Get("/swagger/*", SwaggerAuthMiddleware(nil), func(c *fiber.Ctx) error {
+		return c.SendString("swagger ui")
+	})
+	return app
+}
+
+func TestSwaggerAuthMiddleware_ProtectedNoCredentials(t *testing.T) {
+	t.Setenv("SWAGGER_PROTECTED", "true")
+	t.Setenv("SWAGGER_USER", "docs")
+	t.Setenv("SWAGGER_PASSWORD", "secret")
+
+	resp, err := newSwaggerTestApp().Test(httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+	if resp.Header.Get(fiber.HeaderWWWAuthenticate) == "" {
+		t.Fatalf("expected WWW-Authenticate header")
+	}
+}
+
+func TestSwaggerAuthMiddleware_ProtectedBasicAuth(t *testing.T) {
+	t.Setenv("SWAGGER_PROTECTED", "true")
+	t.Setenv("SWAGGER_USER", "docs")
+	t.Setenv("SWAGGER_PASSWORD", "secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil)
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("docs:secret")))
+	resp, err := newSwaggerTestApp().Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestSwaggerAuthMiddleware_Unprotected(t *testing.T) {
+	t.Setenv("SWAGGER_PROTECTED", "false")
+
+	resp, err := newSwaggerTestApp().Test(httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want %d", resp.StatusCode, http.StatusOK)
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DefaultRequestTimeout batas waktu request jika REQUEST_TIMEOUT tidak diset.
+const DefaultRequestTimeout = 30 * time.Second
+
+// Timeout memasang deadline d pada c.UserContext() sehingga handler yang menurunkan context dari
+// request (dan QueryContext di repository) ikut dibatalkan saat deadline lewat. Response diganti 503
+// "request timeout" jika handler mengembalikan error context.DeadlineExceeded, atau jika deadline sudah
+// lewat dan handler menulis response 5xx (service menangkap error repository lalu menulis 500 sendiri).
+// Response sukses yang selesai setelah deadline dibiarkan; header yang sudah diset (mis. X-Request-ID,
+// CORS) tetap dipertahankan.
+func Timeout(d time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), d)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		timedOut := errors.Is(err, context.DeadlineExceeded) ||
+			(errors.Is(ctx.Err(), context.DeadlineExceeded) && c.Response().StatusCode() >= fiber.StatusInternalServerError)
+		if timedOut {
+			c.Response().ResetBody()
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"success": false,
+				"message": "request timeout",
+			})
+		}
+		return err
+	}
+}
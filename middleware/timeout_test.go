@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestTimeout_SlowHandlerGets503(t *testing.T) {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Set("X-Request-ID", "req-1")
+		return c.Next()
+	})
+	app.Use(Timeout(20 * time.Millisecond))
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		select {
+		case <-c.UserContext().Done():
+		case <-time.After(time.Second):
+			t.Error("request context was not cancelled at the deadline")
+		}
+		return c.UserContext().Err()
+	})
+	app.Get("/fast", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/slow", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("slow: got %d want 503", resp.StatusCode)
+	}
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["message"] != "request timeout" {
+		t.Fatalf("unexpected body: %#v", body)
+	}
+	if got := resp.Header.Get("X-Request-ID"); got != "req-1" {
+		t.Fatalf("X-Request-ID hilang setelah timeout: %q", got)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/fast", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("fast: got %d want 200", resp.StatusCode)
+	}
+}
+
+func TestTimeout_HandlerResponseKeptWhenNoDeadlineError(t *testing.T) {
+	app := fiber.New()
+	app.Use(Timeout(20 * time.Millisecond))
+	app.Get("/late-ok", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return c.Status(fiber.StatusOK).SendString("done")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/late-ok", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got %d want 200", resp.StatusCode)
+	}
+	b, _ := io.ReadAll(resp.Body)
+	if string(b) != "done" {
+		t.Fatalf("unexpected body: %q", b)
+	}
+}
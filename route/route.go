@@ -10,6 +10,7 @@ import (
 )
 
 func SetupRoutes(app *fiber.App, db *sql.DB) {
+	service.InitPagination()
 	service.InitUserService(db)
 	service.InitRepoService(db)
 	service.InitPermissionService(db)
@@ -17,7 +18,14 @@ func SetupRoutes(app *fiber.App, db *sql.DB) {
 	service.InitLecturerService(db)
 	service.InitStudentService(db)
 	service.InitAchievementService(db, database.MongoDB)
+	service.InitSearchService(db)
+	service.InitAuditService(db)
 	api := app.Group("/api")
+	// body JSON wajib application/json (415); pengecualian hanya endpoint upload multipart
+	api.Use(middleware.RequireJSON(
+		fiber.MethodPost+" /api/v1/achievements",
+		fiber.MethodPost+" /api/v1/students/import",
+	))
 
 	api.Post("/v1/auth/register", func(c *fiber.Ctx) error {
 		return service.Register(c, db)
@@ -28,30 +36,39 @@ func SetupRoutes(app *fiber.App, db *sql.DB) {
 	api.Post("/v1/auth/refresh", func(c *fiber.Ctx) error {
 		return service.Refresh(c, db)
 	})
+	api.Post("/v1/auth/verify", service.VerifyTokenService)
 	api.Post("/v1/auth/logout", func(c *fiber.Ctx) error {
 		return service.Logout(c, db)
 	})
 	api.Get("/v1/auth/profile", middleware.JWTAuthMiddleware(db), func(c *fiber.Ctx) error {
 		return service.GetProfileService(c)
 	})
+	// verifikasi publik untuk link/QR di sertifikat; didaftarkan sebelum group protected agar tanpa JWT
+	api.Get("/v1/achievements/:id/verify-public", service.VerifyAchievementPublicService)
 
 	protected := api.Group("/", middleware.JWTAuthMiddleware(db))
 
 	user := protected.Group("/v1/users", middleware.RequirePermission(db, "user:manage"))
 	user.Get("/", service.GetAllUsersService)
-	// user.Get("/byrole", service.GetUsersByRoleNameService)
+	user.Get("/byrole", service.GetUsersByRoleNameService)
 	// user.Get("/byemail", service.GetUserByEmailService)
 	// user.Get("/byusername", service.GetUserByUsernameService)
+	user.Get("/locked", service.GetLockedUsersService)
+	user.Get("/unassigned", service.GetUnassignedUsersService)
 	user.Get("/:id", service.GetUserByIDService)
 	user.Post("/", service.CreateUserAdmin)
 	user.Put("/:id", service.UpdateUserService)
+	user.Patch("/:id", service.PatchUserService)
 	user.Put("/:id/role", service.UpdateUserRoleByNameService)
+	user.Put("/:id/active", service.SetUserActiveService)
+	user.Post("/:id/unlock", service.UnlockUserService)
 	user.Delete("/:id", service.DeleteUserService)
 
 	role := protected.Group("/v1/roles", middleware.RequirePermission(db, "user:manage"))
 	role.Get("/", service.GetAllRolesService)
 	// role.Get("/byname", service.GetRoleByNameService)
 	role.Get("/:id", service.GetRoleByIDService)
+	role.Get("/:id/user-count", service.GetRoleUserCountService)
 	role.Post("/", service.CreateRoleService)
 	role.Put("/:id", service.UpdateRoleService)
 	role.Delete("/:id", service.DeleteRoleService)
@@ -71,6 +88,10 @@ func SetupRoutes(app *fiber.App, db *sql.DB) {
 	rolePermission.Put("/:role_id/:permission_id", service.UpdateRolePermissionService)
 	rolePermission.Delete("/:role_id/:permission_id", service.DeleteRolePermissionService)
 
+	// /me didaftarkan sebelum group agar tidak terkena middleware user:manage; role dicek di service.
+	protected.Get("/v1/lecturers/me", service.GetMyLecturerService)
+	protected.Get("/v1/lecturers/me/advisees-summary", middleware.RequirePermission(db, "achievement:read"), service.GetMyAdviseesSummaryService)
+
 	lecturer := protected.Group("/v1/lecturers", middleware.RequirePermission(db, "user:manage"))
 	lecturer.Get("/", service.GetAllLecturersService)
 	lecturer.Get("/:id", service.GetLecturerByIDService)
@@ -78,21 +99,55 @@ func SetupRoutes(app *fiber.App, db *sql.DB) {
 	lecturer.Put("/:id", service.UpdateLecturerService)
 	lecturer.Delete("/:id", service.DeleteLecturerService)
 
+	// didaftarkan sebelum group students agar tidak terkena middleware user:manage;
+	// scope admin/mahasiswa/dosen wali dicek di service.
+	protected.Get("/v1/students/me", service.GetMyStudentService)
+	protected.Get("/v1/students/me/summary", middleware.RequirePermission(db, "achievement:read"), service.GetMyAchievementSummaryService)
+	protected.Get("/v1/students/:id/advisor-history", middleware.RequirePermission(db, "achievement:read"), service.GetStudentAdvisorHistoryService)
+
 	student := protected.Group("/v1/students", middleware.RequirePermission(db, "user:manage"))
 	student.Get("/", service.GetAllStudentsService)
 	student.Get("/:id", service.GetStudentByIDService)
 	student.Post("/", service.CreateStudentService)
+	student.Post("/import", service.ImportStudentsService)
 	student.Put("/:id", service.UpdateStudentService)
+	student.Put("/:id/advisor", service.SetStudentAdvisorService)
 	student.Delete("/:id", service.DeleteStudentService)
+	student.Get("/:id/advisors", service.GetStudentAdvisorsService)
+	student.Post("/:id/advisors", service.AddStudentAdvisorService)
+	student.Delete("/:id/advisors/:lecturer_id", service.RemoveStudentAdvisorService)
+
+	protected.Post("/v1/onboarding/student", middleware.RequirePermission(db, "user:manage"), service.OnboardStudentService)
+
+	protected.Get("/v1/audit-logs", middleware.AdminOnlyMiddleware(db), service.GetAuditLogsService)
+
+	protected.Put("/v1/admin/maintenance", middleware.AdminOnlyMiddleware(db), service.SetMaintenanceModeService)
+
+	protected.Get("/v1/search", middleware.RequirePermission(db, "user:manage"), service.GlobalSearchService)
 
 	achievements := protected.Group("/v1/achievements")
 	achievements.Post("/", middleware.RequirePermission(db, "achievement:create"), service.CreateAchievementService)
 	achievements.Put("/:id/submit", middleware.RequirePermission(db, "achievement:update"), service.SubmitAchievementService)
+	achievements.Put("/:id/reopen", middleware.RequirePermission(db, "achievement:update"), service.ReopenAchievementService)
 	achievements.Put("/:id/soft-delete", middleware.RequirePermission(db, "achievement:delete"), service.SoftDeleteAchievementService)
+	achievements.Put("/bulk-review", middleware.RequirePermission(db, "achievement:verify"), service.BulkReviewAchievementService)
 	achievements.Put("/:id/review", middleware.RequirePermission(db, "achievement:verify"), service.ReviewAchievementService)
+	achievements.Put("/:id/admin-soft-delete", middleware.RequirePermission(db, "user:manage"), service.AdminSoftDeleteAchievementService)
 	achievements.Delete("/:id/delete", middleware.RequirePermission(db, "user:manage"), service.HardDeleteAchievementService)
+	achievements.Put("/:id/reassign", middleware.RequirePermission(db, "user:manage"), service.AdminReassignAchievementService)
 	achievements.Get("/", middleware.RequirePermission(db, "achievement:read"), service.GetAchievementsService)
+	achievements.Get("/count", middleware.RequirePermission(db, "achievement:read"), service.GetAchievementCountService)
+	achievements.Get("/tags", middleware.RequirePermission(db, "achievement:read"), service.GetAchievementTagsService)
+	achievements.Get("/timeline", middleware.RequirePermission(db, "achievement:read"), service.GetAchievementTimelineService)
+	achievements.Get("/review-queue", middleware.RequirePermission(db, "achievement:verify"), service.GetReviewQueueService)
+	achievements.Get("/overdue", middleware.RequirePermission(db, "achievement:verify"), service.GetOverdueAchievementsService)
+	achievements.Get("/funnel", middleware.RequirePermission(db, "user:manage"), service.GetAchievementFunnelService)
+	achievements.Get("/:id", middleware.RequirePermission(db, "achievement:read"), service.GetAchievementByIDService)
+	achievements.Get("/:id/actions", middleware.RequirePermission(db, "achievement:read"), service.GetAchievementActionsService)
+	achievements.Get("/:id/attachments/:index", middleware.RequirePermission(db, "achievement:read"), service.GetAchievementAttachmentService)
+	achievements.Get("/:id/pdf", middleware.RequirePermission(db, "achievement:read"), service.GetAchievementPDFService)
 
 	achievementRefs := protected.Group("/v1/achievement-references")
 	achievementRefs.Get("/", middleware.RequirePermission(db, "achievement:read"), service.GetAchievementReferencesService)
+	achievementRefs.Post("/statuses", middleware.RequirePermission(db, "achievement:read"), service.GetReferenceStatusesService)
 }
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultBodyLimitMB       = 10
+	DefaultUploadBodyLimitMB = 20
+)
+
+// BodyLimitBytes membaca limit body dalam MB dari env key; nilai kosong/tidak valid memakai defaultMB.
+func BodyLimitBytes(key string, defaultMB int) int {
+	mb, err := strconv.Atoi(strings.TrimSpace(GetEnv(key, "")))
+	if err != nil || mb <= 0 {
+		mb = defaultMB
+	}
+	return mb * 1024 * 1024
+}
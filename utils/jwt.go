@@ -2,9 +2,12 @@ package utils
 
 import (
 	// "encoding/json"
+	"errors"
 	"hello-fiber/app/model"
+	"log"
 	// "net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -12,6 +15,16 @@ import (
 
 var jwtSecret = []byte(getJWTSecret())
 
+// DefaultJWTKID kid untuk JWT_SECRET jika JWT_KID tidak diset.
+const DefaultJWTKID = "default"
+
+// jwtCurrentKID kid yang distempel di header token baru (JWT_KID).
+var jwtCurrentKID = GetEnv("JWT_KID", DefaultJWTKID)
+
+// jwtKeys peta kid -> secret: secret primary (JWT_KID) plus secret lama dari
+// JWT_PREVIOUS_SECRETS berformat "kid:secret" dipisah koma, agar rotasi tidak membatalkan token beredar.
+var jwtKeys = buildJWTKeys(jwtCurrentKID, jwtSecret, os.Getenv("JWT_PREVIOUS_SECRETS"))
+
 func getJWTSecret() string {
 	if s := os.Getenv("JWT_SECRET"); s != "" {
 		return s
@@ -23,6 +36,59 @@ func GetJWTSecret() []byte {
 	return jwtSecret
 }
 
+// buildJWTKeys menyusun peta kid -> secret; entri tanpa kid atau secret kosong diabaikan,
+// dan kid primary tidak bisa ditimpa oleh secret lama. Entri yang diabaikan dicatat sebagai warning
+// (tanpa menulis secret-nya) agar konfigurasi format lama (secret tanpa "kid:") tidak hilang diam-diam.
+func buildJWTKeys(currentKID string, current []byte, previous string) map[string][]byte {
+	keys := map[string][]byte{currentKID: current}
+	for i, part := range strings.Split(previous, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		kid, secret, ok := strings.Cut(strings.TrimSpace(part), ":")
+		kid, secret = strings.TrimSpace(kid), strings.TrimSpace(secret)
+		switch {
+		case !ok || kid == "" || secret == "":
+			log.Printf("[WARNING] JWT_PREVIOUS_SECRETS entri ke-%d diabaikan: format harus \"kid:secret\"", i+1)
+			continue
+		case kid == currentKID:
+			log.Printf("[WARNING] JWT_PREVIOUS_SECRETS entri ke-%d diabaikan: kid %q sama dengan kid primary", i+1, kid)
+			continue
+		}
+		keys[kid] = []byte(secret)
+	}
+	return keys
+}
+
+// ErrUnknownJWTKID token memakai kid yang tidak terdaftar.
+var ErrUnknownJWTKID = errors.New("kid token tidak dikenal")
+
+// jwtKeyFunc memilih secret berdasarkan header kid. Token tanpa kid (diterbitkan sebelum kid dipakai)
+// hanya diverifikasi dengan secret primary.
+func jwtKeyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, jwt.ErrTokenUnverifiable
+	}
+	kid, hasKID := token.Header["kid"]
+	if !hasKID {
+		return jwtSecret, nil
+	}
+	kidStr, ok := kid.(string)
+	if !ok {
+		return nil, ErrUnknownJWTKID
+	}
+	secret, ok := jwtKeys[kidStr]
+	if !ok {
+		return nil, ErrUnknownJWTKID
+	}
+	return secret, nil
+}
+
+// ParseJWT memverifikasi token HMAC memakai secret yang ditunjuk header kid.
+func ParseJWT(tokenString string) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, &Claims{}, jwtKeyFunc)
+}
+
 type Claims struct {
 	UserID      string   `json:"user_id"` // Using json tags (not bson) because JWT is JSON Web Token
 	Email       string   `json:"email"`
@@ -50,23 +116,48 @@ type Claims struct {
 // 	return token.SignedString(jwtSecret)
 // }
 
+// DefaultAccessTokenTTL masa berlaku access token jika JWT_ACCESS_TTL tidak diset/valid.
+const DefaultAccessTokenTTL = 24 * time.Hour
+
+// AccessTokenTTL membaca JWT_ACCESS_TTL (durasi Go, mis. "15m", "24h").
+func AccessTokenTTL() time.Duration {
+	if d, err := time.ParseDuration(strings.TrimSpace(os.Getenv("JWT_ACCESS_TTL"))); err == nil && d > 0 {
+		return d
+	}
+	return DefaultAccessTokenTTL
+}
+
 // GenerateJWTPostgres generates a JWT token for user from PostgreSQL.
 // permissions bersifat opsional; jika tidak diberikan, akan diset kosong.
 func GenerateJWTPostgres(user *model.User, permissions ...string) (string, error) {
+	token, _, err := IssueAccessToken(user, permissions...)
+	return token, err
+}
+
+// IssueAccessToken sama dengan GenerateJWTPostgres tetapi juga mengembalikan waktu expired
+// (sama persis dengan claim exp) agar client bisa menjadwalkan refresh.
+func IssueAccessToken(user *model.User, permissions ...string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(AccessTokenTTL()).Truncate(time.Second)
 	claims := Claims{
 		UserID:      user.ID,
 		Email:       user.Email,
 		RoleID:      user.RoleID,
 		Permissions: permissions,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
 			Subject:   user.ID,
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	token.Header["kid"] = jwtCurrentKID
+	signed, err := token.SignedString(jwtKeys[jwtCurrentKID])
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
 }
 
 func GetEnv(key, defaultValue string) string {
@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"hello-fiber/app/model"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// withJWTKeys mengganti konfigurasi key selama test; previous berformat "kid:secret".
+func withJWTKeys(t *testing.T, currentKID, primary, previous string) {
+	t.Helper()
+	oldSecret, oldKID, oldKeys := jwtSecret, jwtCurrentKID, jwtKeys
+	jwtSecret = []byte(primary)
+	jwtCurrentKID = currentKID
+	jwtKeys = buildJWTKeys(currentKID, jwtSecret, previous)
+	t.Cleanup(func() {
+		jwtSecret, jwtCurrentKID, jwtKeys = oldSecret, oldKID, oldKeys
+	})
+}
+
+// signWithKey menandatangani token; kid kosong berarti header kid tidak diset.
+func signWithKey(t *testing.T, kid, secret string, expiresAt time.Time) string {
+	t.Helper()
+	claims := Claims{
+		UserID: "user-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return signed
+}
+
+func TestParseJWT_KnownKIDVerifies(t *testing.T) {
+	withJWTKeys(t, "2025-02", "new-secret", "2025-01:old-secret")
+
+	token, err := ParseJWT(signWithKey(t, "2025-01", "old-secret", time.Now().Add(time.Hour)))
+	if err != nil {
+		t.Fatalf("token dengan kid lama harus tetap valid: %v", err)
+	}
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid || claims.UserID != "user-1" {
+		t.Fatalf("unexpected claims: %+v", token.Claims)
+	}
+}
+
+func TestParseJWT_UnknownKIDRejected(t *testing.T) {
+	withJWTKeys(t, "2025-02", "new-secret", "2025-01:old-secret")
+
+	_, err := ParseJWT(signWithKey(t, "2024-12", "old-secret", time.Now().Add(time.Hour)))
+	if !errors.Is(err, ErrUnknownJWTKID) {
+		t.Fatalf("expected ErrUnknownJWTKID, got %v", err)
+	}
+}
+
+func TestParseJWT_KIDMismatchedSecretRejected(t *testing.T) {
+	withJWTKeys(t, "2025-02", "new-secret", "2025-01:old-secret")
+
+	// kid primary tapi ditandatangani secret lama: harus gagal, tidak mencoba secret lain
+	_, err := ParseJWT(signWithKey(t, "2025-02", "old-secret", time.Now().Add(time.Hour)))
+	if !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		t.Fatalf("expected signature invalid, got %v", err)
+	}
+}
+
+func TestParseJWT_LegacyTokenWithoutKIDUsesPrimary(t *testing.T) {
+	withJWTKeys(t, "2025-02", "new-secret", "2025-01:old-secret")
+
+	if _, err := ParseJWT(signWithKey(t, "", "new-secret", time.Now().Add(time.Hour))); err != nil {
+		t.Fatalf("token tanpa kid dengan secret primary harus valid: %v", err)
+	}
+	if _, err := ParseJWT(signWithKey(t, "", "old-secret", time.Now().Add(time.Hour))); err == nil {
+		t.Fatalf("token tanpa kid dengan secret lama harus ditolak")
+	}
+}
+
+func TestParseJWT_ExpiredTokenRejected(t *testing.T) {
+	withJWTKeys(t, "2025-02", "new-secret", "2025-01:old-secret")
+
+	_, err := ParseJWT(signWithKey(t, "2025-01", "old-secret", time.Now().Add(-time.Hour)))
+	if !errors.Is(err, jwt.ErrTokenExpired) {
+		t.Fatalf("expected expired, got %v", err)
+	}
+}
+
+func TestGenerateJWTPostgres_StampsCurrentKID(t *testing.T) {
+	withJWTKeys(t, "2025-02", "new-secret", "2025-01:old-secret")
+
+	signed, err := GenerateJWTPostgres(&model.User{ID: "user-1", Email: "a@example.com"})
+	if err != nil {
+		t.Fatalf("GenerateJWTPostgres: %v", err)
+	}
+	token, err := ParseJWT(signed)
+	if err != nil {
+		t.Fatalf("ParseJWT: %v", err)
+	}
+	if kid := token.Header["kid"]; kid != "2025-02" {
+		t.Fatalf("kid: got %v want 2025-02", kid)
+	}
+	if _, err := jwt.ParseWithClaims(signed, &Claims{}, func(*jwt.Token) (interface{}, error) {
+		return []byte("new-secret"), nil
+	}); err != nil {
+		t.Fatalf("token baru harus ditandatangani dengan secret primary: %v", err)
+	}
+}
+
+func TestBuildJWTKeys(t *testing.T) {
+	keys := buildJWTKeys("cur", []byte("primary"), " a:one, ,b: two ,noKid,cur:override,:x")
+	if len(keys) != 3 || string(keys["cur"]) != "primary" || string(keys["a"]) != "one" || string(keys["b"]) != "two" {
+		t.Fatalf("unexpected keys: %q", keys)
+	}
+}
+
+func TestBuildJWTKeys_WarnsOnSkippedEntries(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	keys := buildJWTKeys("cur", []byte("primary"), "oldsecret1,a:one")
+	if len(keys) != 2 || string(keys["a"]) != "one" {
+		t.Fatalf("unexpected keys: %q", keys)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "JWT_PREVIOUS_SECRETS entri ke-1 diabaikan") {
+		t.Fatalf("expected warning for entry without kid, got %q", out)
+	}
+	if strings.Contains(out, "oldsecret1") {
+		t.Fatalf("warning must not leak the secret: %q", out)
+	}
+}
+
+func TestAccessTokenTTL(t *testing.T) {
+	t.Setenv("JWT_ACCESS_TTL", "")
+	if got := AccessTokenTTL(); got != DefaultAccessTokenTTL {
+		t.Fatalf("default TTL: got %s", got)
+	}
+	t.Setenv("JWT_ACCESS_TTL", "30m")
+	if got := AccessTokenTTL(); got != 30*time.Minute {
+		t.Fatalf("configured TTL: got %s", got)
+	}
+	t.Setenv("JWT_ACCESS_TTL", "abc")
+	if got := AccessTokenTTL(); got != DefaultAccessTokenTTL {
+		t.Fatalf("invalid TTL must fall back to default, got %s", got)
+	}
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// Notifier mengirim pemberitahuan ke user (saat ini hanya perubahan status achievement).
+type Notifier interface {
+	SendAchievementStatusChanged(to, title, status, note string) error
+}
+
+// NoopNotifier dipakai saat SMTP tidak dikonfigurasi dan di test.
+type NoopNotifier struct{}
+
+func (NoopNotifier) SendAchievementStatusChanged(to, title, status, note string) error {
+	return nil
+}
+
+type SMTPNotifier struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// NewNotifierFromEnv membaca SMTP_HOST, SMTP_PORT, SMTP_USER, SMTP_PASSWORD, SMTP_FROM.
+// Jika SMTP_HOST kosong, dikembalikan NoopNotifier.
+func NewNotifierFromEnv() Notifier {
+	host := strings.TrimSpace(GetEnv("SMTP_HOST", ""))
+	if host == "" {
+		return NoopNotifier{}
+	}
+	user := GetEnv("SMTP_USER", "")
+	return &SMTPNotifier{
+		Host:     host,
+		Port:     GetEnv("SMTP_PORT", "587"),
+		Username: user,
+		Password: GetEnv("SMTP_PASSWORD", ""),
+		From:     GetEnv("SMTP_FROM", user),
+	}
+}
+
+func (n *SMTPNotifier) SendAchievementStatusChanged(to, title, status, note string) error {
+	if strings.TrimSpace(to) == "" {
+		return fmt.Errorf("alamat email tujuan kosong")
+	}
+
+	msg := buildStatusChangedMessage(n.From, to, title, status, note)
+
+	var auth smtp.Auth
+	if n.Username != "" {
+		auth = smtp.PlainAuth("", n.Username, n.Password, n.Host)
+	}
+	if err := smtp.SendMail(net.JoinHostPort(n.Host, n.Port), auth, n.From, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("gagal mengirim email: %w", err)
+	}
+	return nil
+}
+
+// headerSafe membuang CR/LF agar nilai dari user (mis. judul prestasi) tidak bisa menyisipkan header baru.
+func headerSafe(v string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(v)
+}
+
+func buildStatusChangedMessage(from, to, title, status, note string) string {
+	title, status = headerSafe(title), headerSafe(status)
+	subject := mime.QEncoding.Encode("utf-8", fmt.Sprintf("Status prestasi \"%s\": %s", title, status))
+	body := fmt.Sprintf("Prestasi \"%s\" telah diperbarui menjadi %s.", title, status)
+	if strings.TrimSpace(note) != "" {
+		body += "\r\nCatatan: " + note
+	}
+	return "From: " + headerSafe(from) + "\r\n" +
+		"To: " + headerSafe(to) + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n\r\n" +
+		body + "\r\n"
+}
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildStatusChangedMessage_StripsCRLFFromTitle(t *testing.T) {
+	msg := buildStatusChangedMessage("noreply@kampus.ac.id", "budi@example.com", "Juara 1\r\nBcc: attacker@example.com", "verified", "")
+
+	header := msg[:strings.Index(msg, "\r\n\r\n")]
+	for _, line := range strings.Split(header, "\r\n") {
+		if strings.HasPrefix(strings.ToLower(line), "bcc:") {
+			t.Fatalf("header Bcc tersisip: %q", header)
+		}
+	}
+	if n := len(strings.Split(header, "\r\n")); n != 5 {
+		t.Fatalf("expected 5 header lines, got %d: %q", n, header)
+	}
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultRefreshTokenTTL masa berlaku refresh token jika JWT_REFRESH_TTL tidak diset/valid.
+const DefaultRefreshTokenTTL = 7 * 24 * time.Hour
+
+// RefreshTokenTTL membaca JWT_REFRESH_TTL (durasi Go, mis. "168h").
+func RefreshTokenTTL() time.Duration {
+	if d, err := time.ParseDuration(strings.TrimSpace(os.Getenv("JWT_REFRESH_TTL"))); err == nil && d > 0 {
+		return d
+	}
+	return DefaultRefreshTokenTTL
+}
+
+// GenerateRefreshToken membuat refresh token acak (opaque, bukan JWT) beserta hash-nya untuk disimpan.
+func GenerateRefreshToken() (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken hash SHA-256 (hex) dari refresh token; token mentah tidak pernah disimpan.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,19 @@
+package utils
+
+import "context"
+
+type requestIDKey struct{}
+
+// WithRequestID menyimpan request id di ctx agar lapisan bawah (repository) bisa menyertakannya di log.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext request id yang disimpan WithRequestID; "" jika tidak ada.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const WebhookSignatureHeader = "X-Signature"
+
+// Webhook mengirim event ke sistem eksternal (dashboard kampus) dengan signature HMAC-SHA256.
+type Webhook struct {
+	URL         string
+	Secret      string
+	MaxAttempts int
+	Backoff     time.Duration
+	Client      *http.Client
+}
+
+// NewWebhookFromEnv membaca WEBHOOK_URL dan WEBHOOK_SECRET. Mengembalikan nil jika WEBHOOK_URL kosong,
+// atau jika WEBHOOK_SECRET kosong karena signature HMAC dengan key kosong bisa dipalsukan siapa saja.
+func NewWebhookFromEnv() *Webhook {
+	url := strings.TrimSpace(GetEnv("WEBHOOK_URL", ""))
+	if url == "" {
+		return nil
+	}
+	secret := GetEnv("WEBHOOK_SECRET", "")
+	if strings.TrimSpace(secret) == "" {
+		log.Printf("[WARNING] WEBHOOK_URL diset tanpa WEBHOOK_SECRET, webhook dinonaktifkan")
+		return nil
+	}
+	return &Webhook{
+		URL:         url,
+		Secret:      secret,
+		MaxAttempts: 3,
+		Backoff:     500 * time.Millisecond,
+		Client:      &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// SignWebhookPayload menghasilkan "sha256=<hex hmac>" dari body.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send mengirim payload JSON; retry dengan backoff eksponensial sampai MaxAttempts.
+func (w *Webhook) Send(payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("gagal encode payload webhook: %w", err)
+	}
+	signature := SignWebhookPayload(w.Secret, body)
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	attempts := w.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(w.Backoff * time.Duration(1<<(i-1)))
+		}
+
+		req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("gagal membuat request webhook: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(WebhookSignatureHeader, signature)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("webhook merespon status %d", resp.StatusCode)
+	}
+	return fmt.Errorf("gagal mengirim webhook setelah %d percobaan: %w", attempts, lastErr)
+}
@@ -0,0 +1,17 @@
+package utils
+
+import "testing"
+
+func TestNewWebhookFromEnv_RequiresSecret(t *testing.T) {
+	t.Setenv("WEBHOOK_URL", "https://dashboard.example.com/hook")
+	t.Setenv("WEBHOOK_SECRET", "")
+	if w := NewWebhookFromEnv(); w != nil {
+		t.Fatalf("webhook tanpa secret harus nonaktif, got %+v", w)
+	}
+
+	t.Setenv("WEBHOOK_SECRET", "rahasia")
+	w := NewWebhookFromEnv()
+	if w == nil || w.Secret != "rahasia" {
+		t.Fatalf("webhook dengan secret harus aktif, got %+v", w)
+	}
+}